@@ -0,0 +1,185 @@
+package graph
+
+import "context"
+
+// AllPairsShortestPaths computes the shortest paths between all pairs
+// of vertices in a graph where edges may have negative costs.
+// The number parent[v][w] is the predecessor of w on a shortest path
+// from v to w, or -1 if none exists.
+// The number dist[v][w] equals the length of a shortest path from v to w,
+// or is Max if w cannot be reached from v.
+//
+// If the graph contains a cycle of negative cost, shortest paths
+// aren't well defined and ok is set to false.
+//
+// The implementation uses the Floyd–Warshall algorithm,
+// which is a good choice for dense graphs; see Johnson for sparse graphs.
+// The time complexity is O(|V|³), where |V| is the number of vertices in the graph.
+func AllPairsShortestPaths(g Iterator) (parent [][]int, dist [][]int64, ok bool) {
+	return floydWarshall(context.Background(), g, nil)
+}
+
+// AllPairsShortestPathsProgress is like AllPairsShortestPaths, but calls
+// progress(k, n) after the k:th of the n iterations of the algorithm,
+// where n is the number of vertices. The call is made from the goroutine
+// that runs the computation.
+func AllPairsShortestPathsProgress(g Iterator, progress func(done, total int)) (parent [][]int, dist [][]int64, ok bool) {
+	return floydWarshall(context.Background(), g, progress)
+}
+
+// floydWarshall implements AllPairsShortestPaths. It gives up,
+// with arbitrary results, if ctx is done before an iteration.
+// If progress isn't nil, it's called after each iteration.
+func floydWarshall(ctx context.Context, g Iterator, progress func(done, total int)) (parent [][]int, dist [][]int64, ok bool) {
+	n := g.Order()
+	parent, dist = makeMatrices(n)
+	for v := 0; v < n; v++ {
+		dist[v][v] = 0
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if c < dist[v][w] {
+				dist[v][w], parent[v][w] = c, v
+			}
+			return
+		})
+	}
+
+	// Floyd–Warshall's algorithm
+	for k := 0; k < n; k++ {
+		if ctx.Err() != nil {
+			return
+		}
+		distK, parentK := dist[k], parent[k]
+		for v := 0; v < n; v++ {
+			dvk := dist[v][k]
+			if dvk == Max {
+				continue
+			}
+			distV, parentV := dist[v], parent[v]
+			for w, dkw := range distK {
+				if dkw == Max {
+					continue
+				}
+				if alt := dvk + dkw; alt < distV[w] {
+					distV[w], parentV[w] = alt, parentK[w]
+				}
+			}
+		}
+		if progress != nil {
+			progress(k+1, n)
+		}
+	}
+	for v := 0; v < n; v++ {
+		if dist[v][v] < 0 {
+			return
+		}
+	}
+	ok = true
+	return
+}
+
+// Johnson computes the shortest paths between all pairs of vertices
+// in a graph where edges may have negative costs. It returns the same
+// values as AllPairsShortestPaths.
+//
+// Johnson's algorithm uses the Bellman-Ford algorithm to reweight the edges
+// so that they have nonnegative costs, and then runs Dijkstra's algorithm
+// from each vertex. This is a good choice for sparse graphs.
+// The time complexity is O(|V|⋅(|E| + |V|)⋅log|V|), where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func Johnson(g Iterator) (parent [][]int, dist [][]int64, ok bool) {
+	return johnsonAllPairs(context.Background(), g, nil)
+}
+
+// JohnsonProgress is like Johnson, but calls progress(k, n) after
+// the shortest paths from k of the n vertices have been computed.
+// The call is made from the goroutine that runs the computation.
+func JohnsonProgress(g Iterator, progress func(done, total int)) (parent [][]int, dist [][]int64, ok bool) {
+	return johnsonAllPairs(context.Background(), g, progress)
+}
+
+// johnsonAllPairs implements Johnson. It gives up, with arbitrary results,
+// if ctx is done before a search. If progress isn't nil, it's called
+// after each search.
+func johnsonAllPairs(ctx context.Context, g Iterator, progress func(done, total int)) (parent [][]int, dist [][]int64, ok bool) {
+	n := g.Order()
+	_, h, ok := BellmanFord(superSource{g}, n)
+	if !ok {
+		return
+	}
+	h = h[:n]
+	parent, dist = makeMatrices(n)
+	r := reweighted{g, h}
+	for v := 0; v < n; v++ {
+		if ctx.Err() != nil {
+			return
+		}
+		p, d := ShortestPaths(r, v)
+		for w, dw := range d {
+			if dw != -1 {
+				dist[v][w] = dw - h[v] + h[w]
+				parent[v][w] = p[w]
+			}
+		}
+		if progress != nil {
+			progress(v+1, n)
+		}
+	}
+	return
+}
+
+// makeMatrices returns an n×n parent matrix filled with -1,
+// and an n×n distance matrix filled with Max.
+func makeMatrices(n int) (parent [][]int, dist [][]int64) {
+	parent = make([][]int, n)
+	dist = make([][]int64, n)
+	p := make([]int, n*n)
+	d := make([]int64, n*n)
+	for i := range p {
+		p[i], d[i] = -1, Max
+	}
+	for v := 0; v < n; v++ {
+		parent[v] = p[v*n : (v+1)*n : (v+1)*n]
+		dist[v] = d[v*n : (v+1)*n : (v+1)*n]
+	}
+	return
+}
+
+// superSource extends a graph with a new vertex, numbered g.Order(),
+// that has an edge of zero cost to every other vertex.
+type superSource struct {
+	g Iterator
+}
+
+func (s superSource) Order() int {
+	return s.g.Order() + 1
+}
+
+func (s superSource) Visit(v int, do func(w int, c int64) bool) bool {
+	n := s.g.Order()
+	if v < n {
+		return s.g.Visit(v, do)
+	}
+	for w := 0; w < n; w++ {
+		if do(w, 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// reweighted changes the cost of each edge (v, w) in g from c
+// to c + h[v] - h[w].
+type reweighted struct {
+	g Iterator
+	h []int64
+}
+
+func (r reweighted) Order() int {
+	return r.g.Order()
+}
+
+func (r reweighted) Visit(v int, do func(w int, c int64) bool) bool {
+	return r.g.Visit(v, func(w int, c int64) bool {
+		return do(w, c+r.h[v]-r.h[w])
+	})
+}
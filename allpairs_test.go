@@ -0,0 +1,170 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestAllPairsShortestPaths(t *testing.T) {
+	for _, apsp := range []struct {
+		name string
+		f    func(Iterator) ([][]int, [][]int64, bool)
+	}{
+		{"AllPairsShortestPaths", AllPairsShortestPaths},
+		{"Johnson", Johnson},
+	} {
+		g := New(0)
+		parent, dist, ok := apsp.f(g)
+		if mess, diff := diff(len(parent), 0); diff {
+			t.Errorf("%s->parent %s", apsp.name, mess)
+		}
+		if mess, diff := diff(len(dist), 0); diff {
+			t.Errorf("%s->dist %s", apsp.name, mess)
+		}
+		if mess, diff := diff(ok, true); diff {
+			t.Errorf("%s->ok %s", apsp.name, mess)
+		}
+
+		g = New(4)
+		g.AddCost(0, 1, 3)
+		g.AddCost(0, 2, 8)
+		g.AddCost(1, 2, -2)
+		g.AddCost(2, 0, 4)
+		g.AddCost(3, 3, 1)
+		parent, dist, ok = apsp.f(g)
+		expParent := [][]int{
+			{-1, 0, 1, -1},
+			{2, -1, 1, -1},
+			{2, 0, -1, -1},
+			{-1, -1, -1, -1},
+		}
+		expDist := [][]int64{
+			{0, 3, 1, Max},
+			{2, 0, -2, Max},
+			{4, 7, 0, Max},
+			{Max, Max, Max, 0},
+		}
+		if mess, diff := diff(parent, expParent); diff {
+			t.Errorf("%s->parent %s", apsp.name, mess)
+		}
+		if mess, diff := diff(dist, expDist); diff {
+			t.Errorf("%s->dist %s", apsp.name, mess)
+		}
+		if mess, diff := diff(ok, true); diff {
+			t.Errorf("%s->ok %s", apsp.name, mess)
+		}
+
+		g.AddCost(2, 0, -2) // 0 -> 1 -> 2 -> 0 has cost -1.
+		_, _, ok = apsp.f(g)
+		if mess, diff := diff(ok, false); diff {
+			t.Errorf("%s->ok %s", apsp.name, mess)
+		}
+	}
+}
+
+func TestAllPairsRandom(t *testing.T) {
+	n := 50
+	g := New(n)
+	// Costs of the form c + p[v] - p[w], with c ≥ 0, give no negative cycles.
+	p := make([]int64, n)
+	for v := range p {
+		p[v] = int64(rand.Intn(20))
+	}
+	for i := 0; i < 4*n; i++ {
+		v, w := rand.Intn(n), rand.Intn(n)
+		g.AddCost(v, w, int64(rand.Intn(10))+p[v]-p[w])
+	}
+	parent1, dist1, ok1 := AllPairsShortestPaths(g)
+	parent2, dist2, ok2 := Johnson(g)
+	if !ok1 || !ok2 {
+		t.Fatalf("ok: %v, %v; want true, true", ok1, ok2)
+	}
+	if mess, diff := diff(dist1, dist2); diff {
+		t.Errorf("Johnson->dist %s", mess)
+	}
+	for v := 0; v < n; v++ {
+		_, exp, _ := BellmanFord(g, v)
+		if mess, diff := diff(dist1[v], exp); diff {
+			t.Errorf("AllPairsShortestPaths->dist[%d] %s", v, mess)
+		}
+		// Follow parent pointers and check the path lengths.
+		for _, parent := range [][][]int{parent1, parent2} {
+			for w := 0; w < n; w++ {
+				if dist1[v][w] == Max || v == w {
+					continue
+				}
+				length := int64(0)
+				for x := w; x != v; x = parent[v][x] {
+					length += g.Cost(parent[v][x], x)
+				}
+				if length != dist1[v][w] {
+					t.Errorf("path length from %d to %d: %d; want %d", v, w, length, dist1[v][w])
+				}
+			}
+		}
+	}
+}
+
+func TestAllPairsProgress(t *testing.T) {
+	n := 20
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), int64(rand.Intn(10)))
+	}
+	for _, apsp := range []struct {
+		name string
+		f    func(Iterator, func(int, int)) ([][]int, [][]int64, bool)
+		exp  func(Iterator) ([][]int, [][]int64, bool)
+	}{
+		{"AllPairsShortestPathsProgress", AllPairsShortestPathsProgress, AllPairsShortestPaths},
+		{"JohnsonProgress", JohnsonProgress, Johnson},
+	} {
+		var res []int
+		_, dist, ok := apsp.f(g, func(done, total int) {
+			if total != n {
+				t.Errorf("%s: total %d; want %d", apsp.name, total, n)
+			}
+			res = append(res, done)
+		})
+		_, expDist, expOk := apsp.exp(g)
+		if mess, diff := diff(dist, expDist); diff {
+			t.Errorf("%s->dist %s", apsp.name, mess)
+		}
+		if mess, diff := diff(ok, expOk); diff {
+			t.Errorf("%s->ok %s", apsp.name, mess)
+		}
+		exp := make([]int, n)
+		for i := range exp {
+			exp[i] = i + 1
+		}
+		if mess, diff := diff(res, exp); diff {
+			t.Errorf("%s progress %s", apsp.name, mess)
+		}
+	}
+}
+
+func BenchmarkAllPairsShortestPaths(b *testing.B) {
+	n := 100
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), int64(rand.Intn(10)))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = AllPairsShortestPaths(g)
+	}
+}
+
+func BenchmarkJohnson(b *testing.B) {
+	n := 100
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), int64(rand.Intn(10)))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = Johnson(g)
+	}
+}
@@ -0,0 +1,142 @@
+package graph
+
+import "strconv"
+
+// MinArborescence computes a minimum spanning arborescence of the
+// directed graph g rooted at root: a set of edges of minimum total cost
+// that contains a directed path from root to every other vertex.
+// The arborescence is returned as parent pointers, like in MST,
+// with parent[root] = -1, and total is the sum of its edge costs.
+// Costs may be negative. If some vertex can't be reached from root,
+// it returns nil and sets ok to false.
+//
+// The implementation uses the Chu–Liu/Edmonds algorithm, which repeatedly
+// picks the cheapest edge into each vertex and contracts the cycles
+// this creates. The time complexity is O(|E|⋅|V|), where |E| is the
+// number of edges and |V| the number of vertices in the graph.
+func MinArborescence(g Iterator, root int) (parent []int, total int64, ok bool) {
+	n := g.Order()
+	if root < 0 || root >= n {
+		panic("vertex out of range: " + strconv.Itoa(root))
+	}
+	reached := 0
+	BFS(g, root, func(v, w int, c int64) { reached++ })
+	if reached < n-1 {
+		return nil, 0, false
+	}
+
+	type arc struct {
+		v, w int
+		cost int64
+		prev int // the index of the arc in the previous level
+	}
+	var first []arc
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if w != v && w != root {
+				first = append(first, arc{v, w, c, -1})
+			}
+			return
+		})
+	}
+
+	// Each level holds a graph, the cheapest arc into each vertex,
+	// and the cycles that are contracted to form the next level.
+	type level struct {
+		arcs  []arc
+		in    []int // in[v] is the index of the cheapest arc into v
+		cycle []int // cycle[v] is the cycle of v, or -1
+	}
+	var levels []level
+	arcs := first
+	for {
+		lv := level{arcs: arcs, in: make([]int, n), cycle: make([]int, n)}
+		for v := range lv.in {
+			lv.in[v], lv.cycle[v] = -1, -1
+		}
+		for i, a := range arcs {
+			if j := lv.in[a.w]; j == -1 || a.cost < arcs[j].cost {
+				lv.in[a.w] = i
+			}
+		}
+
+		// Find the cycles formed by the cheapest arcs.
+		cycles := 0
+		mark := make([]int, n) // the start of the walk that reached v, plus one
+		for s := 0; s < n; s++ {
+			v := s
+			for v != root && mark[v] == 0 && lv.in[v] != -1 {
+				mark[v] = s + 1
+				v = arcs[lv.in[v]].v
+			}
+			if v == root || mark[v] != s+1 || lv.cycle[v] != -1 {
+				continue
+			}
+			for u := v; lv.cycle[u] == -1; u = arcs[lv.in[u]].v {
+				lv.cycle[u] = cycles
+			}
+			cycles++
+		}
+		levels = append(levels, lv)
+		if cycles == 0 {
+			break
+		}
+
+		// Contract each cycle to a vertex, and make the cost of an arc
+		// into the cycle relative to the cheapest arc into its head.
+		comp := make([]int, n)
+		m := cycles
+		for v := range comp {
+			if lv.cycle[v] != -1 {
+				comp[v] = lv.cycle[v]
+			} else {
+				comp[v] = m
+				m++
+			}
+		}
+		var next []arc
+		for i, a := range arcs {
+			v, w := comp[a.v], comp[a.w]
+			if v == w {
+				continue
+			}
+			c := a.cost
+			if lv.cycle[a.w] != -1 {
+				c -= arcs[lv.in[a.w]].cost
+			}
+			next = append(next, arc{v, w, c, i})
+		}
+		n, root, arcs = m, comp[root], next
+	}
+
+	// Expand the cycles, starting with the last level. In each cycle,
+	// the arc into the vertex entered from outside the cycle is dropped.
+	var chosen []int
+	for i := len(levels) - 1; i >= 0; i-- {
+		lv := levels[i]
+		entered := make([]bool, len(lv.in))
+		var expanded []int
+		for _, j := range chosen {
+			a := lv.arcs[levels[i+1].arcs[j].prev]
+			entered[a.w] = true
+			expanded = append(expanded, levels[i+1].arcs[j].prev)
+		}
+		for v, j := range lv.in {
+			if j != -1 && (i == len(levels)-1 || lv.cycle[v] != -1 && !entered[v]) {
+				expanded = append(expanded, j)
+			}
+		}
+		chosen = expanded
+	}
+
+	parent = make([]int, len(levels[0].in))
+	for v := range parent {
+		parent[v] = -1
+	}
+	for _, j := range chosen {
+		a := first[j]
+		parent[a.w] = a.v
+		total += a.cost
+	}
+	return parent, total, true
+}
@@ -0,0 +1,132 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// bruteArborescence returns the cost of a minimum arborescence rooted
+// at root, found by trying all choices of parents, or false if
+// there is none.
+func bruteArborescence(g *Mutable, root int) (best int64, ok bool) {
+	n := g.Order()
+	parent := make([]int, n)
+	var try func(v int, cost int64)
+	try = func(v int, cost int64) {
+		if v == n {
+			// Check that every vertex reaches the root.
+			for u := 0; u < n; u++ {
+				x, steps := u, 0
+				for x != root && steps <= n {
+					x, steps = parent[x], steps+1
+				}
+				if x != root {
+					return
+				}
+			}
+			if !ok || cost < best {
+				best, ok = cost, true
+			}
+			return
+		}
+		if v == root {
+			parent[v] = -1
+			try(v+1, cost)
+			return
+		}
+		for u := 0; u < n; u++ {
+			if u != v && g.Edge(u, v) {
+				parent[v] = u
+				try(v+1, cost+g.Cost(u, v))
+			}
+		}
+	}
+	try(0, 0)
+	return
+}
+
+func TestMinArborescence(t *testing.T) {
+	// The cheapest arcs into 1 and 2 form a cycle.
+	g := New(4)
+	g.AddCost(0, 1, 10)
+	g.AddCost(0, 2, 12)
+	g.AddCost(1, 2, 1)
+	g.AddCost(2, 1, 2)
+	g.AddCost(2, 3, 5)
+	g.AddCost(3, 1, 1)
+	parent, total, ok := MinArborescence(g, 0)
+	if mess, diff := diff(parent, []int{-1, 0, 1, 2}); diff {
+		t.Errorf("MinArborescence->parent %s", mess)
+	}
+	if mess, diff := diff(total, int64(16)); diff {
+		t.Errorf("MinArborescence->total %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("MinArborescence->ok %s", mess)
+	}
+
+	parent, total, ok = MinArborescence(g, 3)
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("MinArborescence->ok %s", mess)
+	}
+	if parent != nil || total != 0 {
+		t.Errorf("MinArborescence = %v, %d; want nil, 0", parent, total)
+	}
+
+	parent, total, ok = MinArborescence(New(1), 0)
+	if mess, diff := diff(parent, []int{-1}); diff || total != 0 || !ok {
+		t.Errorf("MinArborescence(singleton)->parent %s, total %d, ok %v", mess, total, ok)
+	}
+}
+
+func TestMinArborescenceRandom(t *testing.T) {
+	for i := 0; i < 300; i++ {
+		n := 1 + rand.Intn(6)
+		g := New(n)
+		for m := rand.Intn(n*n + 1); m > 0; m-- {
+			g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(21)-5)
+		}
+		root := rand.Intn(n)
+		parent, total, ok := MinArborescence(g, root)
+		best, exp := bruteArborescence(g, root)
+		if mess, diff := diff(ok, exp); diff {
+			t.Errorf("MinArborescence(%v, %d)->ok %s", g, root, mess)
+		}
+		if !ok {
+			continue
+		}
+		if mess, diff := diff(total, best); diff {
+			t.Errorf("MinArborescence(%v, %d)->total %s", g, root, mess)
+		}
+		var sum int64
+		for v, p := range parent {
+			switch {
+			case v == root && p != -1:
+				t.Errorf("MinArborescence(%v, %d): root has parent %d", g, root, p)
+			case v != root && (p == -1 || !g.Edge(p, v)):
+				t.Errorf("MinArborescence(%v, %d): bad parent %d of %d", g, root, p, v)
+			case v != root:
+				sum += g.Cost(p, v)
+			}
+		}
+		if mess, diff := diff(sum, total); diff {
+			t.Errorf("MinArborescence(%v, %d)->parent cost %s", g, root, mess)
+		}
+	}
+}
+
+func BenchmarkMinArborescence(b *testing.B) {
+	n := 300
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 10*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(1000))
+	}
+	for v := 1; v < n; v++ {
+		g.AddCost(v-1, v, 1000)
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = MinArborescence(g, 0)
+	}
+}
@@ -0,0 +1,63 @@
+package graph
+
+// AStar computes a shortest path from v to w using the A* algorithm.
+// Only edges with non-negative costs are included.
+// The number dist is the length of the path, or -1 if w cannot be reached.
+//
+// The heuristic h(u) should estimate the length of a shortest path
+// from u to w. If h never overestimates this length, the path found is
+// a shortest path. A consistent heuristic, with h(u) ≤ c + h(x) for every
+// edge (u, x) of cost c, guarantees that no vertex is expanded twice.
+// With h(u) = 0 for all u the algorithm is equivalent to Dijkstra's.
+//
+// In the worst case, the time complexity is the same as for ShortestPath,
+// but a good heuristic can reduce the number of expanded vertices considerably.
+func AStar(g Iterator, v, w int, h func(v int) int64) (path []int, dist int64) {
+	return AStarWithQueue(g, v, w, h, NewQuadHeapQueue(g.Order()))
+}
+
+// AStarWithQueue is like AStar, but uses q as the priority queue,
+// ordered by the estimates dist(v, u) + h(u). The queue must be empty.
+// The estimates may grow by more than the cost of an edge, and with
+// an inconsistent heuristic they may decrease, so the queue must accept
+// pushes of any distance, like the heaps of NewHeapQueue and
+// NewQuadHeapQueue; the zero-one and bucket queues only work for
+// special heuristics. The time complexity depends on the implementation
+// of the queue.
+func AStarWithQueue(g Iterator, v, w int, h func(v int) int64, q DistQueue) (path []int, dist int64) {
+	n := g.Order()
+	distances := make([]int64, n)
+	parent := make([]int, n)
+	estimate := make([]int64, n) // distances[u] + h(u)
+	for i := range distances {
+		distances[i], parent[i] = -1, -1
+	}
+	distances[v], estimate[v] = 0, h(v)
+
+	q.Push(v, estimate[v])
+	for q.Len() > 0 {
+		u, e := q.Pop()
+		if e != estimate[u] {
+			continue // An outdated entry.
+		}
+		if u == w {
+			break
+		}
+		g.Visit(u, func(x int, d int64) (skip bool) {
+			if d < 0 {
+				return
+			}
+			alt := distances[u] + d
+			if distances[x] == -1 || alt < distances[x] {
+				// An inconsistent heuristic may reopen x.
+				distances[x], parent[x] = alt, u
+				estimate[x] = alt + h(x)
+				q.Push(x, estimate[x])
+			}
+			return
+		})
+	}
+
+	t := NewPathTree(parent, distances)
+	return t.PathTo(w), t.DistTo(w)
+}
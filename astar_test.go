@@ -0,0 +1,133 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// visitCounter counts the calls to Visit, the number of expanded vertices.
+type visitCounter struct {
+	Iterator
+	visits int
+}
+
+func (g *visitCounter) Visit(v int, do func(w int, c int64) (skip bool)) (aborted bool) {
+	g.visits++
+	return g.Iterator.Visit(v, do)
+}
+
+func TestAStar(t *testing.T) {
+	zero := func(int) int64 { return 0 }
+
+	g := New(6)
+	g.AddCost(0, 1, 1)
+	g.AddCost(0, 2, 1)
+	g.AddCost(0, 3, 3)
+	g.AddCost(1, 3, 0)
+	g.AddCost(2, 3, 1)
+	g.AddCost(2, 5, 8)
+	g.AddCost(3, 5, 7)
+	g.AddCost(1, 5, -1)
+	path, dist := AStar(g, 0, 5, zero)
+	if mess, diff := diff(path, []int{0, 1, 3, 5}); diff {
+		t.Errorf("AStar->path %s", mess)
+	}
+	if mess, diff := diff(dist, int64(8)); diff {
+		t.Errorf("AStar->dist %s", mess)
+	}
+
+	path, dist = AStar(g, 0, 0, zero)
+	if mess, diff := diff(path, []int{0}); diff {
+		t.Errorf("AStar->path %s", mess)
+	}
+	if mess, diff := diff(dist, int64(0)); diff {
+		t.Errorf("AStar->dist %s", mess)
+	}
+
+	path, dist = AStar(g, 0, 4, zero)
+	if mess, diff := diff(path, []int{}); diff {
+		t.Errorf("AStar->path %s", mess)
+	}
+	if mess, diff := diff(dist, int64(-1)); diff {
+		t.Errorf("AStar->dist %s", mess)
+	}
+
+	// A 10×10 grid with unit costs and the Manhattan distance heuristic.
+	const m = 10
+	g = New(m * m)
+	for r := 0; r < m; r++ {
+		for c := 0; c < m; c++ {
+			if c+1 < m {
+				g.AddBothCost(r*m+c, r*m+c+1, 1)
+			}
+			if r+1 < m {
+				g.AddBothCost(r*m+c, (r+1)*m+c, 1)
+			}
+		}
+	}
+	goal := m*m - 1
+	manhattan := func(v int) int64 {
+		return int64(goal/m - v/m + goal%m - v%m)
+	}
+	astar := &visitCounter{Iterator: g}
+	path, dist = AStar(astar, 0, goal, manhattan)
+	if mess, diff := diff(dist, int64(2*(m-1))); diff {
+		t.Errorf("AStar->dist %s", mess)
+	}
+	if mess, diff := diff(len(path), 2*m-1); diff {
+		t.Errorf("AStar->len(path) %s", mess)
+	}
+	dijkstra := &visitCounter{Iterator: g}
+	AStar(dijkstra, 0, goal, func(int) int64 { return 0 })
+	if astar.visits >= dijkstra.visits {
+		t.Errorf("AStar: expanded %d vertices, Dijkstra %d", astar.visits, dijkstra.visits)
+	}
+
+	// An admissible but inconsistent heuristic.
+	g = New(4)
+	g.AddCost(0, 1, 1)
+	g.AddCost(0, 2, 4)
+	g.AddCost(1, 2, 1)
+	g.AddCost(2, 3, 4)
+	h := []int64{0, 5, 0, 0}
+	path, dist = AStar(g, 0, 3, func(v int) int64 { return h[v] })
+	if mess, diff := diff(path, []int{0, 1, 2, 3}); diff {
+		t.Errorf("AStar->path %s", mess)
+	}
+	if mess, diff := diff(dist, int64(6)); diff {
+		t.Errorf("AStar->dist %s", mess)
+	}
+}
+
+func TestAStarRandom(t *testing.T) {
+	n := 100
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), int64(rand.Intn(10)))
+	}
+	zero := func(int) int64 { return 0 }
+	_, distances := ShortestPaths(g, 0)
+	for w := 0; w < n; w++ {
+		if _, dist := AStar(g, 0, w, zero); dist != distances[w] {
+			t.Errorf("AStar(0, %d) dist: %d; want %d", w, dist, distances[w])
+		}
+		if _, dist := AStarWithQueue(g, 0, w, zero, NewHeapQueue(n)); dist != distances[w] {
+			t.Errorf("AStarWithQueue(0, %d) dist: %d; want %d", w, dist, distances[w])
+		}
+	}
+}
+
+func BenchmarkAStar(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < n; i++ {
+		g.Add(0, rand.Intn(n))
+		g.Add(rand.Intn(n), rand.Intn(n))
+	}
+	zero := func(int) int64 { return 0 }
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = AStar(g, 0, n-1, zero)
+	}
+}
@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ShortestPathsBatch computes ShortestPaths from each of the sources,
+// using all available CPUs. The numbers parents[i] and dists[i] are
+// the results of ShortestPaths(g, sources[i]).
+// The Visit method of g must be safe for concurrent use; this is true
+// for Mutable and Immutable graphs that aren't modified during the call.
+//
+// The time complexity is O(k⋅(|E| + |V|)⋅log|V|), where k is the number
+// of sources, |E| the number of edges and |V| the number of vertices
+// in the graph, divided by the number of CPUs.
+func ShortestPathsBatch(g Iterator, sources []int) (parents [][]int, dists [][]int64) {
+	n := g.Order()
+	parents, dists = make([][]int, len(sources)), make([][]int64, len(sources))
+	forEachSource(len(sources), 0, func() func(i int) {
+		ws := new(Workspace)
+		return func(i int) {
+			parent, dist := make([]int, n), make([]int64, n)
+			ShortestPathsInto(g, sources[i], parent, dist, ws)
+			parents[i], dists[i] = parent, dist
+		}
+	})
+	return
+}
+
+// ShortestPathsEach computes ShortestPaths from each of the sources,
+// using the given number of worker goroutines, or all available CPUs
+// if workers ≤ 0. For each source, it calls do(i, parent, dist) with
+// the results of ShortestPaths(g, sources[i]). Each worker reuses its
+// own parent and dist slices, so they must not be retained after do
+// returns. The function do is called concurrently from several goroutines,
+// in no particular order.
+// The Visit method of g must be safe for concurrent use; this is true
+// for Mutable and Immutable graphs that aren't modified during the call.
+//
+// The time complexity is the same as for ShortestPathsBatch, but only
+// O(|V|) space is allocated for each worker.
+func ShortestPathsEach(g Iterator, sources []int, workers int, do func(i int, parent []int, dist []int64)) {
+	n := g.Order()
+	forEachSource(len(sources), workers, func() func(i int) {
+		parent, dist := make([]int, n), make([]int64, n)
+		ws := new(Workspace)
+		return func(i int) {
+			ShortestPathsInto(g, sources[i], parent, dist, ws)
+			do(i, parent, dist)
+		}
+	})
+}
+
+// forEachSource starts the given number of workers, or one for each CPU
+// if workers ≤ 0, and calls a function made by newWorker for each
+// index 0..k-1. Each worker calls newWorker once, in its own goroutine.
+func forEachSource(k, workers int, newWorker func() func(i int)) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > k {
+		workers = k
+	}
+	var next int64 = -1
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f := newWorker()
+			for i := int(atomic.AddInt64(&next, 1)); i < k; i = int(atomic.AddInt64(&next, 1)) {
+				f(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
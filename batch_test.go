@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestShortestPathsBatch(t *testing.T) {
+	parents, dists := ShortestPathsBatch(New(0), nil)
+	if len(parents) != 0 || len(dists) != 0 {
+		t.Errorf("ShortestPathsBatch(empty) %v %v", parents, dists)
+	}
+
+	for n := 1; n < 40; n++ {
+		g := New(n)
+		for m := rand.Intn(4*n + 1); m > 0; m-- {
+			g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(10)-1)
+		}
+		h := Sort(g)
+		var sources []int
+		for k := rand.Intn(2*n + 1); k > 0; k-- {
+			sources = append(sources, rand.Intn(n))
+		}
+
+		parents, dists := ShortestPathsBatch(h, sources)
+		var mu sync.Mutex
+		calls := make([]int, len(sources))
+		ShortestPathsEach(h, sources, 1+rand.Intn(4), func(i int, parent []int, dist []int64) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls[i]++
+			expParent, expDist := ShortestPaths(h, sources[i])
+			if mess, diff := diff(parent, expParent); diff {
+				t.Errorf("ShortestPathsEach->parent %s", mess)
+			}
+			if mess, diff := diff(dist, expDist); diff {
+				t.Errorf("ShortestPathsEach->dist %s", mess)
+			}
+		})
+		for i, v := range sources {
+			expParent, expDist := ShortestPaths(h, v)
+			if mess, diff := diff(parents[i], expParent); diff {
+				t.Errorf("ShortestPathsBatch->parent %s", mess)
+			}
+			if mess, diff := diff(dists[i], expDist); diff {
+				t.Errorf("ShortestPathsBatch->dist %s", mess)
+			}
+			if calls[i] != 1 {
+				t.Errorf("ShortestPathsEach called do %d times for %d", calls[i], i)
+			}
+		}
+	}
+}
+
+func BenchmarkShortestPathsBatch(b *testing.B) {
+	b.StopTimer()
+	n := 1000
+	g := New(n)
+	for i := 0; i < 10*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(100))
+	}
+	h := Sort(g)
+	sources := make([]int, 100)
+	for i := range sources {
+		sources[i] = i
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ShortestPathsBatch(h, sources)
+	}
+}
@@ -0,0 +1,87 @@
+package graph
+
+// BellmanFord computes the shortest paths from v to all other vertices
+// in a graph where edges may have negative costs.
+// The number parent[w] is the predecessor of w on a shortest path from v to w,
+// or -1 if none exists.
+// The number dist[w] equals the length of a shortest path from v to w,
+// or is Max if w cannot be reached.
+//
+// If there is a cycle of negative cost reachable from v, shortest paths
+// aren't well defined and ok is set to false.
+//
+// The time complexity is O(|E|⋅|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func BellmanFord(g Iterator, v int) (parent []int, dist []int64, ok bool) {
+	n := g.Order()
+	dist = make([]int64, n)
+	parent = make([]int, n)
+	for i := range dist {
+		dist[i], parent[i] = Max, -1
+	}
+	dist[v] = 0
+	ok = bellmanFord(g, parent, dist) == -1
+	return
+}
+
+// FindNegativeCycle returns a cycle of negative cost in g, if there is one.
+// Otherwise, it returns an empty slice and sets ok to false.
+// The cycle is given as a list of vertices, with an edge from each vertex
+// to the next and from the last vertex back to the first.
+//
+// The time complexity is O(|E|⋅|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func FindNegativeCycle(g Iterator) (cycle []int, ok bool) {
+	n := g.Order()
+	// Start at distance 0 from a virtual vertex with edges to all vertices.
+	dist := make([]int64, n)
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = -1
+	}
+	v := bellmanFord(g, parent, dist)
+	if v == -1 {
+		return []int{}, false
+	}
+	// After n steps back, v must be on the cycle.
+	for i := 0; i < n; i++ {
+		v = parent[v]
+	}
+	cycle = []int{v}
+	for w := parent[v]; w != v; w = parent[w] {
+		cycle = append(cycle, w)
+	}
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
+	}
+	return cycle, true
+}
+
+// bellmanFord relaxes the edges of g for up to |V| rounds, starting with
+// the given parent pointers and distances, where Max means unreachable.
+// It returns a vertex whose distance changed in the last round,
+// or -1 if the distances converged.
+func bellmanFord(g Iterator, parent []int, dist []int64) (last int) {
+	n := g.Order()
+	last = -1
+	// Without negative cycles, all distances are final after n-1 rounds.
+	for round := 0; round < n; round++ {
+		last = -1
+		for u := 0; u < n; u++ {
+			if dist[u] == Max {
+				continue
+			}
+			g.Visit(u, func(w int, c int64) (skip bool) {
+				if alt := dist[u] + c; alt < dist[w] {
+					dist[w], parent[w] = alt, u
+					last = w
+				}
+				return
+			})
+		}
+		if last == -1 {
+			return
+		}
+	}
+	return
+}
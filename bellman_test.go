@@ -0,0 +1,184 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBellmanFord(t *testing.T) {
+	g := New(1)
+	parent, dist, ok := BellmanFord(g, 0)
+	if mess, diff := diff(parent, []int{-1}); diff {
+		t.Errorf("BellmanFord->parent %s", mess)
+	}
+	if mess, diff := diff(dist, []int64{0}); diff {
+		t.Errorf("BellmanFord->dist %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("BellmanFord->ok %s", mess)
+	}
+
+	g = New(6)
+	g.AddCost(0, 1, 4)
+	g.AddCost(0, 2, 2)
+	g.AddCost(1, 3, -3)
+	g.AddCost(2, 1, 1)
+	g.AddCost(2, 3, 5)
+	g.AddCost(3, 5, 2)
+	g.AddCost(5, 4, -1)
+	parent, dist, ok = BellmanFord(g, 0)
+	if mess, diff := diff(parent, []int{-1, 2, 0, 1, 5, 3}); diff {
+		t.Errorf("BellmanFord->parent %s", mess)
+	}
+	if mess, diff := diff(dist, []int64{0, 3, 2, 0, 1, 2}); diff {
+		t.Errorf("BellmanFord->dist %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("BellmanFord->ok %s", mess)
+	}
+
+	parent, dist, ok = BellmanFord(g, 4)
+	if mess, diff := diff(parent, []int{-1, -1, -1, -1, -1, -1}); diff {
+		t.Errorf("BellmanFord->parent %s", mess)
+	}
+	if mess, diff := diff(dist, []int64{Max, Max, Max, Max, 0, Max}); diff {
+		t.Errorf("BellmanFord->dist %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("BellmanFord->ok %s", mess)
+	}
+
+	// A negative cycle 1 -> 3 -> 2 -> 1.
+	g.AddCost(3, 2, -1)
+	_, _, ok = BellmanFord(g, 0)
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("BellmanFord->ok %s", mess)
+	}
+	// The cycle can't be reached from 4.
+	_, _, ok = BellmanFord(g, 4)
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("BellmanFord->ok %s", mess)
+	}
+
+	g = New(2)
+	g.AddCost(1, 1, -1)
+	_, _, ok = BellmanFord(g, 1)
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("BellmanFord->ok %s", mess)
+	}
+}
+
+func TestBellmanFordRandom(t *testing.T) {
+	n := 100
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), int64(rand.Intn(10)))
+	}
+	_, exp := ShortestPaths(g, 0)
+	_, dist, ok := BellmanFord(g, 0)
+	if !ok {
+		t.Errorf("BellmanFord->ok false; want true")
+	}
+	for w := range dist {
+		if dist[w] == Max {
+			dist[w] = -1
+		}
+	}
+	if mess, diff := diff(dist, exp); diff {
+		t.Errorf("BellmanFord->dist %s", mess)
+	}
+}
+
+func TestFindNegativeCycle(t *testing.T) {
+	g := New(0)
+	cycle, ok := FindNegativeCycle(g)
+	if mess, diff := diff(cycle, []int{}); diff {
+		t.Errorf("FindNegativeCycle->cycle %s", mess)
+	}
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("FindNegativeCycle->ok %s", mess)
+	}
+
+	g = New(5)
+	g.AddCost(0, 1, 1)
+	g.AddCost(1, 2, 1)
+	g.AddCost(2, 3, 1)
+	g.AddCost(3, 1, -1)
+	g.AddCost(3, 4, -5)
+	cycle, ok = FindNegativeCycle(g)
+	if mess, diff := diff(cycle, []int{}); diff {
+		t.Errorf("FindNegativeCycle->cycle %s", mess)
+	}
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("FindNegativeCycle->ok %s", mess)
+	}
+
+	// The cycle 1 -> 2 -> 3 -> 1 can't be reached from 0.
+	g.Delete(0, 1)
+	g.AddCost(3, 1, -3)
+	cycle, ok = FindNegativeCycle(g)
+	if mess, diff := diff(len(cycle), 3); diff {
+		t.Errorf("FindNegativeCycle->cycle %s", mess)
+	}
+	if mess, diff := diff(cycleCost(g, cycle), int64(-1)); diff {
+		t.Errorf("FindNegativeCycle: cost of %v %s", cycle, mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("FindNegativeCycle->ok %s", mess)
+	}
+
+	g = New(2)
+	g.AddCost(1, 1, -1)
+	cycle, ok = FindNegativeCycle(g)
+	if mess, diff := diff(cycle, []int{1}); diff {
+		t.Errorf("FindNegativeCycle->cycle %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("FindNegativeCycle->ok %s", mess)
+	}
+}
+
+func TestFindNegativeCycleRandom(t *testing.T) {
+	n := 50
+	for i := 0; i < 20; i++ {
+		g := New(n)
+		for j := 0; j < 2*n; j++ {
+			g.AddCost(rand.Intn(n), rand.Intn(n), int64(rand.Intn(20)-3))
+		}
+		cycle, ok := FindNegativeCycle(g)
+		_, _, noCycle := AllPairsShortestPaths(g)
+		if ok == noCycle {
+			t.Errorf("FindNegativeCycle->ok %t; want %t", ok, !noCycle)
+		}
+		if ok && cycleCost(g, cycle) >= 0 {
+			t.Errorf("FindNegativeCycle: cost of %v is %d", cycle, cycleCost(g, cycle))
+		}
+	}
+}
+
+// cycleCost returns the cost of a cycle, or Max if it isn't a cycle in g.
+func cycleCost(g *Mutable, cycle []int) int64 {
+	cost := int64(0)
+	for i, v := range cycle {
+		w := cycle[(i+1)%len(cycle)]
+		if !g.Edge(v, w) {
+			return Max
+		}
+		cost += g.Cost(v, w)
+	}
+	return cost
+}
+
+func BenchmarkBellmanFord(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < n; i++ {
+		g.AddCost(0, rand.Intn(n), int64(rand.Intn(10)))
+		g.AddCost(rand.Intn(n), rand.Intn(n), int64(rand.Intn(10)))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = BellmanFord(g, 0)
+	}
+}
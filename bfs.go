@@ -21,3 +21,49 @@ func BFS(g Iterator, v int, do func(v, w int, c int64)) {
 		})
 	}
 }
+
+// BFSTree computes the shortest unweighted paths from v to all other
+// vertices, where the length of a path is its number of edges.
+// The number parent[w] is the predecessor of w on a shortest path from v to w,
+// or -1 if none exists.
+// The number dist[w] equals the number of edges on a shortest path from v to w,
+// or is -1 if w cannot be reached.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func BFSTree(g Iterator, v int) (parent []int, dist []int) {
+	n := g.Order()
+	parent = make([]int, n)
+	dist = make([]int, n)
+	BFSTreeInto(g, v, parent, dist, nil)
+	return
+}
+
+// BFSLayers traverses g in breadth-first order starting at v and calls
+// do for each layer, the set of vertices at the same distance from v,
+// in order of increasing distance. The first layer holds only v.
+// The traversal is aborted if do returns true.
+// The slice passed to do must not be retained.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func BFSLayers(g Iterator, v int, do func(layer []int) (skip bool)) (aborted bool) {
+	visited := make([]bool, g.Order())
+	visited[v] = true
+	var next []int
+	for layer := []int{v}; len(layer) > 0; layer, next = next, layer[:0] {
+		if do(layer) {
+			return true
+		}
+		for _, v := range layer {
+			g.Visit(v, func(w int, _ int64) (skip bool) {
+				if !visited[w] {
+					visited[w] = true
+					next = append(next, w)
+				}
+				return
+			})
+		}
+	}
+	return
+}
@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"math/rand"
 	"strconv"
 	"testing"
 )
@@ -26,3 +27,88 @@ func TestBFS(t *testing.T) {
 		t.Errorf("BFS: %s", mess)
 	}
 }
+
+func TestBFSTree(t *testing.T) {
+	g := New(6)
+	g.AddBoth(0, 1)
+	g.AddBoth(1, 2)
+	g.AddBoth(0, 3)
+	g.AddBoth(3, 2)
+	g.Add(2, 4)
+	parent, dist := BFSTree(Sort(g), 0)
+	if mess, diff := diff(parent, []int{-1, 0, 1, 0, 2, -1}); diff {
+		t.Errorf("BFSTree->parent %s", mess)
+	}
+	if mess, diff := diff(dist, []int{0, 1, 2, 1, 3, -1}); diff {
+		t.Errorf("BFSTree->dist %s", mess)
+	}
+
+	parent, dist = BFSTree(g, 4)
+	if mess, diff := diff(parent, []int{-1, -1, -1, -1, -1, -1}); diff {
+		t.Errorf("BFSTree->parent %s", mess)
+	}
+	if mess, diff := diff(dist, []int{-1, -1, -1, -1, 0, -1}); diff {
+		t.Errorf("BFSTree->dist %s", mess)
+	}
+}
+
+func TestBFSTreeRandom(t *testing.T) {
+	n := 100
+	g := New(n)
+	for i := 0; i < 2*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), 1)
+	}
+	_, exp := ShortestPaths(g, 0)
+	_, dist := BFSTree(g, 0)
+	for w := range dist {
+		if int64(dist[w]) != exp[w] {
+			t.Errorf("BFSTree->dist[%d] %d; want %d", w, dist[w], exp[w])
+		}
+	}
+}
+
+func TestBFSLayers(t *testing.T) {
+	g := New(6)
+	g.AddBoth(0, 1)
+	g.AddBoth(1, 2)
+	g.AddBoth(0, 3)
+	g.AddBoth(3, 2)
+	g.Add(2, 4)
+	var res [][]int
+	aborted := BFSLayers(Sort(g), 0, func(layer []int) (skip bool) {
+		res = append(res, append([]int{}, layer...))
+		return
+	})
+	if mess, diff := diff(res, [][]int{{0}, {1, 3}, {2}, {4}}); diff {
+		t.Errorf("BFSLayers %s", mess)
+	}
+	if mess, diff := diff(aborted, false); diff {
+		t.Errorf("BFSLayers->aborted %s", mess)
+	}
+
+	res = nil
+	aborted = BFSLayers(Sort(g), 0, func(layer []int) (skip bool) {
+		res = append(res, append([]int{}, layer...))
+		return len(res) == 2
+	})
+	if mess, diff := diff(res, [][]int{{0}, {1, 3}}); diff {
+		t.Errorf("BFSLayers %s", mess)
+	}
+	if mess, diff := diff(aborted, true); diff {
+		t.Errorf("BFSLayers->aborted %s", mess)
+	}
+}
+
+func BenchmarkBFSTree(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < n; i++ {
+		g.Add(0, rand.Intn(n))
+		g.Add(rand.Intn(n), rand.Intn(n))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = BFSTree(g, 0)
+	}
+}
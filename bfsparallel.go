@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// Parameters for switching between top-down and bottom-up steps,
+// as suggested by Beamer, Asanović and Patterson.
+const (
+	bfsAlpha = 14 // go bottom-up when the frontier has more than 1/alpha of the unexplored edges
+	bfsBeta  = 24 // go top-down when the frontier has fewer than 1/beta of the vertices
+)
+
+// BFSParallel computes the shortest unweighted paths from v to all other
+// vertices, like BFSTree, using the given number of worker goroutines,
+// or all available CPUs if workers ≤ 0. The number dist[w] is the same
+// as for BFSTree, and parent[w] is the predecessor of w on some shortest
+// path from v to w, or -1 if none exists.
+//
+// The implementation uses direction-optimizing BFS: while the frontier is
+// small, each step visits the edges out of the frontier (top-down), and
+// when it is large, each unvisited vertex instead looks for a parent among
+// its incoming edges (bottom-up), which skips most of the edges.
+// The incoming edges are read from transpose, which must be Transpose(g),
+// or g itself if g is undirected. If transpose is nil, it is computed
+// the first time it's needed.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph, split evenly among the workers.
+func BFSParallel(g, transpose *Immutable, v int, workers int) (parent []int, dist []int) {
+	n := g.Order()
+	if v < 0 || v >= n {
+		panic("vertex out of range: " + strconv.Itoa(v))
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	parent = make([]int, n)
+	dist = make([]int, n)
+	for i := range dist {
+		parent[i], dist[i] = -1, -1
+	}
+	dist[v] = 0
+	visited := make([]int32, n) // set to 1 atomically when a vertex is reached
+	visited[v] = 1
+
+	var mu sync.Mutex
+	frontier := []int{v}
+	unexplored := len(g.edges) - g.Degree(v)
+	inFrontier := make([]bool, n) // the frontier during bottom-up steps
+	bottomUp := false
+	for d := 1; len(frontier) > 0; d++ {
+		edges := 0
+		for _, v := range frontier {
+			edges += g.Degree(v)
+		}
+		switch {
+		case !bottomUp && edges > unexplored/bfsAlpha:
+			bottomUp = true
+			if transpose == nil {
+				transpose = Transpose(g)
+			}
+		case bottomUp && len(frontier) < n/bfsBeta:
+			bottomUp = false
+		}
+
+		var next []int
+		if bottomUp {
+			for _, v := range frontier {
+				inFrontier[v] = true
+			}
+			parallel(n, workers, func(lo, hi int) int {
+				var found []int
+				for w := lo; w < hi; w++ {
+					if visited[w] != 0 {
+						continue
+					}
+					in := transpose.edges[transpose.offset[w]:transpose.offset[w+1]]
+					for _, e := range in {
+						if inFrontier[e.vertex] {
+							visited[w] = 1
+							parent[w], dist[w] = e.vertex, d
+							found = append(found, w)
+							break
+						}
+					}
+				}
+				mu.Lock()
+				next = append(next, found...)
+				mu.Unlock()
+				return -1
+			})
+			for _, v := range frontier {
+				inFrontier[v] = false
+			}
+		} else {
+			parallel(len(frontier), workers, func(lo, hi int) int {
+				var found []int
+				for _, v := range frontier[lo:hi] {
+					for _, e := range g.edges[g.offset[v]:g.offset[v+1]] {
+						w := e.vertex
+						if atomic.LoadInt32(&visited[w]) == 0 && atomic.CompareAndSwapInt32(&visited[w], 0, 1) {
+							parent[w], dist[w] = v, d
+							found = append(found, w)
+						}
+					}
+				}
+				mu.Lock()
+				next = append(next, found...)
+				mu.Unlock()
+				return -1
+			})
+		}
+		for _, w := range next {
+			unexplored -= g.Degree(w)
+		}
+		frontier = next
+	}
+	return
+}
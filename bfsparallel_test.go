@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBFSParallel(t *testing.T) {
+	g := New(6)
+	g.AddBoth(0, 1)
+	g.AddBoth(0, 2)
+	g.Add(1, 3)
+	g.Add(2, 3)
+	g.Add(3, 4)
+	h := Sort(g)
+	parent, dist := BFSParallel(h, nil, 0, 2)
+	if mess, diff := diff(dist, []int{0, 1, 1, 2, 3, -1}); diff {
+		t.Errorf("BFSParallel->dist %s", mess)
+	}
+	if parent[3] != 1 && parent[3] != 2 {
+		t.Errorf("BFSParallel->parent[3] = %d", parent[3])
+	}
+
+	for n := 1; n < 300; n += 1 + n/10 {
+		g := New(n)
+		for m := rand.Intn(8*n + 1); m > 0; m-- {
+			g.Add(rand.Intn(n), rand.Intn(n))
+		}
+		h := Sort(g)
+		var transpose *Immutable
+		if rand.Intn(2) == 0 {
+			transpose = Transpose(h)
+		}
+		v := rand.Intn(n)
+		_, expDist := BFSTree(h, v)
+		parent, dist := BFSParallel(h, transpose, v, 1+rand.Intn(4))
+		if mess, diff := diff(dist, expDist); diff {
+			t.Errorf("BFSParallel->dist %s", mess)
+		}
+		for w, p := range parent {
+			if p == -1 {
+				if w != v && dist[w] != -1 {
+					t.Errorf("BFSParallel->parent[%d] = -1", w)
+				}
+				continue
+			}
+			if !h.Edge(p, w) || dist[p]+1 != dist[w] {
+				t.Errorf("BFSParallel->parent[%d] = %d is not on a shortest path", w, p)
+			}
+		}
+	}
+}
+
+func BenchmarkBFSParallel(b *testing.B) {
+	b.StopTimer()
+	n := 1 << 16
+	g := New(n)
+	for i := 0; i < 8*n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	h := Sort(g)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = BFSParallel(h, h, 0, 0)
+	}
+}
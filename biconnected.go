@@ -0,0 +1,182 @@
+package graph
+
+import "sort"
+
+// ArticulationPoints returns the articulation points of an undirected
+// graph, in increasing order. An articulation point, or cut vertex,
+// is a vertex whose removal increases the number of connected components.
+//
+// The graph is undirected if each edge from v to w is matched by
+// an edge from w to v.
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func ArticulationPoints(g Iterator) []int {
+	cut, _, _ := biconnected(g, false)
+	points := []int{}
+	for v, ok := range cut {
+		if ok {
+			points = append(points, v)
+		}
+	}
+	return points
+}
+
+// Bridges returns the bridges of an undirected graph. A bridge is an edge
+// whose removal increases the number of connected components.
+// Each bridge is given once, with V < W, and the bridges are sorted.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func Bridges(g Iterator) []Edge {
+	_, bridges, _ := biconnected(g, false)
+	sort.Slice(bridges, func(i, j int) bool {
+		a, b := bridges[i], bridges[j]
+		return a.V < b.V || a.V == b.V && a.W < b.W
+	})
+	return bridges
+}
+
+// BiconnectedComponents returns the biconnected components of an
+// undirected graph. A biconnected component is a maximal subgraph that
+// stays connected if any one vertex is removed; a bridge with its two
+// endpoints is a biconnected component by itself.
+// Each component is given as a sorted list of vertices. An articulation
+// point belongs to several components, while isolated vertices,
+// with no edges other than self-loops, don't belong to any component.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func BiconnectedComponents(g Iterator) [][]int {
+	_, _, components := biconnected(g, true)
+	return components
+}
+
+// biconnected makes an iterative depth-first search of g, marking
+// articulation points and collecting bridges and, if requested,
+// biconnected components.
+func biconnected(g Iterator, collect bool) (cut []bool, bridges []Edge, components [][]int) {
+	n := g.Order()
+	cut = make([]bool, n)
+	bridges = []Edge{}
+	components = [][]int{}
+	// disc[v] is the discovery time of v, starting at 1, and low[v] is
+	// the smallest discovery time reachable from the subtree of v using
+	// at most one back edge.
+	disc := make([]int, n)
+	low := make([]int, n)
+	time := 0
+
+	type frame struct {
+		v, parent   int
+		start, next int  // neighbors[start:] are the neighbors of v
+		skipped     bool // the edge back to the parent has been skipped
+		children    int
+	}
+	type arc struct {
+		w int
+		c int64
+	}
+	var path []frame
+	var neighbors []arc
+	var edges [][2]int // edges of the current components, if collect
+	visit := func(v, parent int) {
+		time++
+		disc[v], low[v] = time, time
+		start := len(neighbors)
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			neighbors = append(neighbors, arc{w, c})
+			return
+		})
+		path = append(path, frame{v: v, parent: parent, start: start, next: start})
+	}
+	for root := 0; root < n; root++ {
+		if disc[root] != 0 {
+			continue
+		}
+		visit(root, -1)
+		for len(path) > 0 {
+			f := &path[len(path)-1]
+			v := f.v
+			if f.next < len(neighbors) {
+				w := neighbors[f.next].w
+				switch {
+				case w == v:
+					// Ignore self-loops.
+				case w == f.parent && !f.skipped:
+					f.skipped = true
+				case disc[w] == 0:
+					if collect {
+						edges = append(edges, [2]int{v, w})
+					}
+					f.children++
+					visit(w, v)
+					continue
+				case disc[w] < disc[v]:
+					// A back edge to an ancestor of v.
+					if collect {
+						edges = append(edges, [2]int{v, w})
+					}
+					if disc[w] < low[v] {
+						low[v] = disc[w]
+					}
+				}
+				f.next++
+				continue
+			}
+			neighbors = neighbors[:f.start]
+			parent, children := f.parent, f.children
+			path = path[:len(path)-1]
+			if parent == -1 {
+				if children > 1 {
+					cut[v] = true
+				}
+				continue
+			}
+			p := &path[len(path)-1]
+			if low[v] < low[parent] {
+				low[parent] = low[v]
+			}
+			if low[v] > disc[parent] {
+				c := neighbors[p.next].c
+				a, b := parent, v
+				if a > b {
+					a, b = b, a
+				}
+				bridges = append(bridges, Edge{a, b, c})
+			}
+			if low[v] >= disc[parent] {
+				if p.parent != -1 {
+					cut[parent] = true
+				}
+				if collect {
+					components = append(components, popComponent(&edges, parent, v))
+				}
+			}
+			p.next++
+		}
+	}
+	return
+}
+
+// popComponent removes the edges up to and including the tree edge
+// (v, w) from the stack, and returns their endpoints as a sorted list.
+func popComponent(edges *[][2]int, v, w int) []int {
+	seen := make(map[int]bool)
+	comp := []int{}
+	for {
+		n := len(*edges) - 1
+		e := (*edges)[n]
+		*edges = (*edges)[:n]
+		for _, x := range e {
+			if !seen[x] {
+				seen[x] = true
+				comp = append(comp, x)
+			}
+		}
+		if e == [2]int{v, w} {
+			break
+		}
+	}
+	sort.Ints(comp)
+	return comp
+}
@@ -0,0 +1,120 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func setupBiconnected() *Mutable {
+	//  0 - 1 - 2 - 3     7
+	//   \ /    |   |
+	//    4     5 - 6 - 8
+	g := New(9)
+	g.AddBoth(0, 1)
+	g.AddBoth(0, 4)
+	g.AddBoth(1, 4)
+	g.AddBothCost(1, 2, 5)
+	g.AddBoth(2, 3)
+	g.AddBoth(2, 5)
+	g.AddBoth(3, 6)
+	g.AddBoth(5, 6)
+	g.AddBothCost(6, 8, 2)
+	g.Add(7, 7)
+	return g
+}
+
+func TestArticulationPoints(t *testing.T) {
+	if mess, diff := diff(ArticulationPoints(New(0)), []int{}); diff {
+		t.Errorf("ArticulationPoints %s", mess)
+	}
+	g := setupBiconnected()
+	if mess, diff := diff(ArticulationPoints(g), []int{1, 2, 6}); diff {
+		t.Errorf("ArticulationPoints %s", mess)
+	}
+	// The root of a path is not a cut vertex, but the middle is.
+	h := New(3)
+	h.AddBoth(0, 1)
+	h.AddBoth(1, 2)
+	if mess, diff := diff(ArticulationPoints(h), []int{1}); diff {
+		t.Errorf("ArticulationPoints %s", mess)
+	}
+}
+
+func TestBridges(t *testing.T) {
+	g := setupBiconnected()
+	if mess, diff := diff(Bridges(g), []Edge{{1, 2, 5}, {6, 8, 2}}); diff {
+		t.Errorf("Bridges %s", mess)
+	}
+	// Parallel edges are not bridges.
+	h := FromEdges(3, []Edge{{0, 1, 0}, {1, 0, 0}, {0, 1, 0}, {1, 0, 0}, {1, 2, 0}, {2, 1, 0}})
+	if mess, diff := diff(Bridges(h), []Edge{{1, 2, 0}}); diff {
+		t.Errorf("Bridges multigraph %s", mess)
+	}
+}
+
+func TestBiconnectedComponents(t *testing.T) {
+	g := setupBiconnected()
+	res := BiconnectedComponents(g)
+	exp := map[string]bool{"[0 1 4]": true, "[1 2]": true, "[2 3 5 6]": true, "[6 8]": true}
+	if len(res) != len(exp) {
+		t.Errorf("BiconnectedComponents %v; want %d components", res, len(exp))
+	}
+	for _, comp := range res {
+		if s := fmt.Sprint(comp); !exp[s] {
+			t.Errorf("BiconnectedComponents: unexpected component %s", s)
+		}
+	}
+}
+
+func TestBiconnectedRandom(t *testing.T) {
+	n := 30
+	g := New(n)
+	for i := 0; i < n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	count := len(Components(g))
+	points := make(map[int]bool)
+	for _, v := range ArticulationPoints(g) {
+		points[v] = true
+	}
+	// Compare with removing each vertex in turn.
+	for v := 0; v < n; v++ {
+		h := Copy(g)
+		for w := 0; w < n; w++ {
+			h.DeleteBoth(v, w)
+		}
+		if cut := len(Components(h)) > count+1; cut != points[v] {
+			t.Errorf("ArticulationPoints: %d is cut vertex %t; want %t", v, points[v], cut)
+		}
+	}
+	bridges := make(map[[2]int]bool)
+	for _, e := range Bridges(g) {
+		bridges[[2]int{e.V, e.W}] = true
+	}
+	for v := 0; v < n; v++ {
+		for w := v + 1; w < n; w++ {
+			if !g.Edge(v, w) {
+				continue
+			}
+			h := Copy(g)
+			h.DeleteBoth(v, w)
+			if bridge := len(Components(h)) > count; bridge != bridges[[2]int{v, w}] {
+				t.Errorf("Bridges: (%d, %d) is bridge %t; want %t", v, w, !bridge, bridge)
+			}
+		}
+	}
+}
+
+func BenchmarkBiconnectedComponents(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 2*n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = BiconnectedComponents(g)
+	}
+}
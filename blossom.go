@@ -0,0 +1,166 @@
+package graph
+
+// MaxMatching computes a maximum matching in an undirected graph:
+// a largest set of edges without common vertices. The edges are
+// returned with V < W, sorted by V. For bipartite graphs,
+// BipartiteMatching is faster.
+//
+// The implementation uses Edmonds' blossom algorithm.
+// The time complexity is O(|V|³), where |V| is the number of vertices
+// in the graph.
+func MaxMatching(g Iterator) (matching []Edge) {
+	n := g.Order()
+	adj := make([][]int, n)
+	for v := range adj {
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			if v != w {
+				adj[v] = append(adj[v], w)
+			}
+			return
+		})
+	}
+	e := &edmonds{
+		adj:     adj,
+		mate:    make([]int, n),
+		parent:  make([]int, n),
+		base:    make([]int, n),
+		used:    make([]bool, n),
+		blossom: make([]bool, n),
+		mark:    make([]bool, n),
+	}
+	for v := range e.mate {
+		e.mate[v] = -1
+	}
+	// Start with a greedy matching.
+	for v := range adj {
+		if e.mate[v] != -1 {
+			continue
+		}
+		for _, w := range adj[v] {
+			if e.mate[w] == -1 {
+				e.mate[v], e.mate[w] = w, v
+				break
+			}
+		}
+	}
+	for v := range adj {
+		if e.mate[v] != -1 {
+			continue
+		}
+		// Flip the augmenting path, if any.
+		for w := e.findPath(v); w != -1; {
+			pw := e.parent[w]
+			next := e.mate[pw]
+			e.mate[w], e.mate[pw] = pw, w
+			w = next
+		}
+	}
+
+	matching = []Edge{}
+	for v, w := range e.mate {
+		if v < w {
+			c := int64(0)
+			g.Visit(v, func(u int, cost int64) (skip bool) {
+				if u == w {
+					c, skip = cost, true
+				}
+				return
+			})
+			matching = append(matching, Edge{v, w, c})
+		}
+	}
+	return
+}
+
+// edmonds holds the state of Edmonds' blossom algorithm.
+type edmonds struct {
+	adj     [][]int
+	mate    []int  // The vertex matched to each vertex, or -1.
+	parent  []int  // The predecessor of each vertex in the search tree.
+	base    []int  // The base of the blossom containing each vertex.
+	used    []bool // Vertices at even distance from the root.
+	blossom []bool
+	mark    []bool
+	queue   []int
+}
+
+// findPath searches for an augmenting path starting at root and returns
+// its last vertex, or -1 if there is no such path.
+// The path can be followed back to root using parent and mate.
+func (e *edmonds) findPath(root int) int {
+	for v := range e.base {
+		e.used[v], e.parent[v], e.base[v] = false, -1, v
+	}
+	e.used[root] = true
+	e.queue = append(e.queue[:0], root)
+	for i := 0; i < len(e.queue); i++ {
+		v := e.queue[i]
+		for _, w := range e.adj[v] {
+			if e.base[v] == e.base[w] || e.mate[v] == w {
+				continue
+			}
+			if w == root || e.mate[w] != -1 && e.parent[e.mate[w]] != -1 {
+				// An odd cycle: contract the blossom.
+				b := e.lca(v, w)
+				for u := range e.blossom {
+					e.blossom[u] = false
+				}
+				e.markPath(v, b, w)
+				e.markPath(w, b, v)
+				for u := range e.base {
+					if e.blossom[e.base[u]] {
+						e.base[u] = b
+						if !e.used[u] {
+							e.used[u] = true
+							e.queue = append(e.queue, u)
+						}
+					}
+				}
+			} else if e.parent[w] == -1 {
+				e.parent[w] = v
+				if e.mate[w] == -1 {
+					return w
+				}
+				u := e.mate[w]
+				e.used[u] = true
+				e.queue = append(e.queue, u)
+			}
+		}
+	}
+	return -1
+}
+
+// lca returns the base of the blossom formed by the edge (v, w),
+// the lowest common ancestor of v and w in the search tree.
+func (e *edmonds) lca(v, w int) int {
+	for u := range e.mark {
+		e.mark[u] = false
+	}
+	for {
+		v = e.base[v]
+		e.mark[v] = true
+		if e.mate[v] == -1 {
+			break
+		}
+		v = e.parent[e.mate[v]]
+	}
+	for {
+		w = e.base[w]
+		if e.mark[w] {
+			return w
+		}
+		w = e.parent[e.mate[w]]
+	}
+}
+
+// markPath marks the blossoms on the path from v to the base b,
+// and redirects the parent pointers along the path through child.
+func (e *edmonds) markPath(v, b, child int) {
+	for e.base[v] != b {
+		e.blossom[e.base[v]] = true
+		e.blossom[e.base[e.mate[v]]] = true
+		e.parent[v] = child
+		child = e.mate[v]
+		v = e.parent[e.mate[v]]
+	}
+}
@@ -0,0 +1,162 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMaxMatching(t *testing.T) {
+	g := New(0)
+	if mess, diff := diff(MaxMatching(g), []Edge{}); diff {
+		t.Errorf("MaxMatching %s", mess)
+	}
+
+	// A 5-cycle with a pendant edge at each end of a path:
+	// the greedy matching must be augmented through a blossom.
+	//
+	//   5 - 0 - 1
+	//       |   |
+	//       4   2 - 6
+	//        \ /
+	//         3
+	g = New(7)
+	g.AddBoth(0, 1)
+	g.AddBoth(1, 2)
+	g.AddBoth(2, 3)
+	g.AddBothCost(3, 4, 2)
+	g.AddBoth(4, 0)
+	g.AddBoth(0, 5)
+	g.AddBoth(2, 6)
+	g.AddBoth(1, 1)
+	matching := MaxMatching(Sort(g))
+	if mess, diff := diff(len(matching), 3); diff {
+		t.Errorf("MaxMatching %s", mess)
+	}
+	checkMatching(t, g, matching)
+}
+
+func TestMaxMatchingRandom(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		n := 1 + rand.Intn(10)
+		g := New(n)
+		for j := 0; j < n+rand.Intn(2*n); j++ {
+			g.AddBoth(rand.Intn(n), rand.Intn(n))
+		}
+		matching := MaxMatching(g)
+		checkMatching(t, g, matching)
+		if exp := bruteMatching(g, 0, make([]bool, n), func(_ int64) int64 { return 1 }); int64(len(matching)) != exp {
+			t.Errorf("MaxMatching(%v): %d edges; want %d", g, len(matching), exp)
+		}
+	}
+}
+
+func TestMaxWeightMatching(t *testing.T) {
+	g := New(0)
+	matching, weight := MaxWeightMatching(g)
+	if mess, diff := diff(matching, []Edge{}); diff {
+		t.Errorf("MaxWeightMatching %s", mess)
+	}
+	if mess, diff := diff(weight, int64(0)); diff {
+		t.Errorf("MaxWeightMatching->weight %s", mess)
+	}
+
+	// A path where the heavy middle edge beats the two light ones.
+	g = New(4)
+	g.AddBothCost(0, 1, 3)
+	g.AddBothCost(1, 2, 7)
+	g.AddBothCost(2, 3, 3)
+	matching, weight = MaxWeightMatching(g)
+	if mess, diff := diff(matching, []Edge{{1, 2, 7}}); diff {
+		t.Errorf("MaxWeightMatching %s", mess)
+	}
+	if mess, diff := diff(weight, int64(7)); diff {
+		t.Errorf("MaxWeightMatching->weight %s", mess)
+	}
+
+	g.AddBothCost(1, 2, 5)
+	matching, weight = MaxWeightMatching(g)
+	if mess, diff := diff(matching, []Edge{{0, 1, 3}, {2, 3, 3}}); diff {
+		t.Errorf("MaxWeightMatching %s", mess)
+	}
+	if mess, diff := diff(weight, int64(6)); diff {
+		t.Errorf("MaxWeightMatching->weight %s", mess)
+	}
+
+	g = New(2)
+	g.AddBothCost(0, 1, -1)
+	matching, _ = MaxWeightMatching(g)
+	if mess, diff := diff(matching, []Edge{}); diff {
+		t.Errorf("MaxWeightMatching %s", mess)
+	}
+}
+
+func TestMaxWeightMatchingRandom(t *testing.T) {
+	for i := 0; i < 500; i++ {
+		n := 1 + rand.Intn(10)
+		g := New(n)
+		for j := 0; j < n+rand.Intn(3*n); j++ {
+			g.AddBothCost(rand.Intn(n), rand.Intn(n), rand.Int63n(20)-2)
+		}
+		matching, weight := MaxWeightMatching(g)
+		checkMatching(t, g, matching)
+		sum := int64(0)
+		for _, e := range matching {
+			sum += e.Cost
+		}
+		if sum != weight {
+			t.Errorf("MaxWeightMatching->weight %d; want %d", weight, sum)
+		}
+		if exp := bruteMatching(g, 0, make([]bool, n), func(c int64) int64 { return c }); weight != exp {
+			t.Errorf("MaxWeightMatching(%v)->weight %d; want %d", g, weight, exp)
+		}
+	}
+}
+
+// bruteMatching returns the maximum total value of a matching among
+// the vertices v..n-1 that aren't used.
+func bruteMatching(g *Mutable, v int, used []bool, value func(c int64) int64) int64 {
+	for v < g.Order() && used[v] {
+		v++
+	}
+	if v == g.Order() {
+		return 0
+	}
+	used[v] = true
+	best := bruteMatching(g, v+1, used, value)
+	g.Visit(v, func(w int, c int64) (skip bool) {
+		if !used[w] {
+			used[w] = true
+			best = max(best, value(c)+bruteMatching(g, v+1, used, value))
+			used[w] = false
+		}
+		return
+	})
+	used[v] = false
+	return best
+}
+
+func BenchmarkMaxMatching(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 2*n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = MaxMatching(g)
+	}
+}
+
+func BenchmarkMaxWeightMatching(b *testing.B) {
+	n := 200
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddBothCost(rand.Intn(n), rand.Intn(n), rand.Int63n(100))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = MaxWeightMatching(g)
+	}
+}
@@ -0,0 +1,139 @@
+package build
+
+import "strconv"
+
+// Grid3 returns a virtual graph whose vertices correspond to integer
+// points in space: x-coordinates being in the range 0..l-1,
+// y-coordinates in the range 0..m-1, and z-coordinates in the range 0..n-1.
+// Two vertices of a grid are adjacent whenever the corresponding points
+// are at distance 1.
+//
+// Point (x, y, z) gets index (xm + y)n + z.
+func Grid3(l, m, n int) *Virtual {
+	switch {
+	case l < 0 || m < 0 || n < 0:
+		return nil
+	case l == 0 || m == 0 || n == 0:
+		return null
+	case m*n/m != n || l*m*n/l != m*n:
+		panic("too large l=" + strconv.Itoa(l) + " m=" + strconv.Itoa(m) + " n=" + strconv.Itoa(n))
+	case l == 1:
+		return Grid(m, n)
+	}
+	mn := m * n
+
+	g := generic0(l*mn, func(v, w int) (edge bool) {
+		d := v - w
+		switch {
+		case d == mn || d == -mn:
+			return true
+		case v/mn != w/mn:
+			return
+		case d == n || d == -n:
+			return true
+		case v/n != w/n:
+			return
+		}
+		return d == 1 || d == -1
+	})
+
+	g.degree = func(v int) (deg int) {
+		g.visit(v, 0, func(int, int64) (skip bool) {
+			deg++
+			return
+		})
+		return
+	}
+
+	g.visit = func(v int, a int, do func(w int, c int64) bool) (aborted bool) {
+		x, y, z := v/mn, v%mn/n, v%n
+		var w [6]int
+		k := 0
+		if x > 0 {
+			w[k], k = v-mn, k+1
+		}
+		if y > 0 {
+			w[k], k = v-n, k+1
+		}
+		if z > 0 {
+			w[k], k = v-1, k+1
+		}
+		if z < n-1 {
+			w[k], k = v+1, k+1
+		}
+		if y < m-1 {
+			w[k], k = v+n, k+1
+		}
+		if x < l-1 {
+			w[k], k = v+mn, k+1
+		}
+		for _, w := range w[:k] {
+			if w >= a && do(w, 0) {
+				return true
+			}
+		}
+		return
+	}
+	return g
+}
+
+// DiagonalGrid returns a virtual graph with the same vertices as Grid(m, n),
+// in which two vertices are adjacent whenever the corresponding points
+// are at distance 1 or √2. Hence each vertex is adjacent to its
+// horizontal, vertical and diagonal neighbors.
+//
+// Point (x, y) gets index nx + y, and index i corresponds to the point (i/n, i%n).
+func DiagonalGrid(m, n int) *Virtual {
+	switch {
+	case m < 0 || n < 0:
+		return nil
+	case m == 0 || n == 0:
+		return null
+	case m == 1 || n == 1:
+		return Grid(m, n)
+	case m*n/m != n:
+		panic("too large m=" + strconv.Itoa(m) + " n=" + strconv.Itoa(n))
+	}
+
+	g := generic0(m*n, func(v, w int) (edge bool) {
+		xdiff, ydiff := v/n-w/n, v%n-w%n
+		return xdiff >= -1 && xdiff <= 1 && ydiff >= -1 && ydiff <= 1
+	})
+
+	g.degree = func(v int) int {
+		x, y := v/n, v%n
+		rows, cols := 3, 3
+		if x == 0 {
+			rows--
+		}
+		if x == m-1 {
+			rows--
+		}
+		if y == 0 {
+			cols--
+		}
+		if y == n-1 {
+			cols--
+		}
+		return rows*cols - 1
+	}
+
+	g.visit = func(v int, a int, do func(w int, c int64) bool) (aborted bool) {
+		x, y := v/n, v%n
+		for dx := -1; dx <= 1; dx++ {
+			if x+dx < 0 || x+dx >= m {
+				continue
+			}
+			for dy := -1; dy <= 1; dy++ {
+				if dx == 0 && dy == 0 || y+dy < 0 || y+dy >= n {
+					continue
+				}
+				if w := v + dx*n + dy; w >= a && do(w, 0) {
+					return true
+				}
+			}
+		}
+		return
+	}
+	return g
+}
@@ -0,0 +1,71 @@
+package build
+
+import "testing"
+
+func TestGrid3(t *testing.T) {
+	if mess, diff := diff(Grid3(-1, 1, 1), (*Virtual)(nil)); diff {
+		t.Errorf("Grid3 %s", mess)
+	}
+
+	if mess, diff := diff(Grid3(2, 0, 3).String(), "0 []"); diff {
+		t.Errorf("Grid3 %s", mess)
+	}
+
+	if mess, diff := diff(Grid3(1, 1, 1).String(), "1 []"); diff {
+		t.Errorf("Grid3 %s", mess)
+	}
+
+	if mess, diff := diff(Grid3(2, 1, 1).String(), "2 [{0 1}]"); diff {
+		t.Errorf("Grid3 %s", mess)
+	}
+
+	if mess, diff := diff(Grid3(1, 2, 2).String(), Grid(2, 2).String()); diff {
+		t.Errorf("Grid3 %s", mess)
+	}
+
+	exp := "8 [{0 1} {0 2} {0 4} {1 3} {1 5} {2 3} {2 6} {3 7} {4 5} {4 6} {5 7} {6 7}]"
+	if mess, diff := diff(Grid3(2, 2, 2).String(), exp); diff {
+		t.Errorf("Grid3 %s", mess)
+	}
+
+	if mess, diff := diff(Grid3(2, 2, 2).String(), Hyper(3).String()); diff {
+		t.Errorf("Grid3 %s", mess)
+	}
+
+	for l := 0; l < 4; l++ {
+		for m := 0; m < 4; m++ {
+			for n := 0; n < 4; n++ {
+				Consistent("Grid3", t, Grid3(l, m, n))
+			}
+		}
+	}
+}
+
+func TestDiagonalGrid(t *testing.T) {
+	if mess, diff := diff(DiagonalGrid(-1, 1), (*Virtual)(nil)); diff {
+		t.Errorf("DiagonalGrid %s", mess)
+	}
+
+	if mess, diff := diff(DiagonalGrid(0, 2).String(), "0 []"); diff {
+		t.Errorf("DiagonalGrid %s", mess)
+	}
+
+	if mess, diff := diff(DiagonalGrid(1, 3).String(), Grid(1, 3).String()); diff {
+		t.Errorf("DiagonalGrid %s", mess)
+	}
+
+	if mess, diff := diff(DiagonalGrid(2, 2).String(), Kn(4).String()); diff {
+		t.Errorf("DiagonalGrid %s", mess)
+	}
+
+	exp := "6 [{0 1} {0 3} {0 4} {1 2} {1 3} {1 4} {1 5} {2 4} {2 5} {3 4} {4 5}]"
+	if mess, diff := diff(DiagonalGrid(2, 3).String(), exp); diff {
+		t.Errorf("DiagonalGrid %s", mess)
+	}
+
+	for m := 0; m < 5; m++ {
+		for n := 0; n < 5; n++ {
+			Consistent("DiagonalGrid", t, DiagonalGrid(m, n))
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package build
+
+// Path returns a virtual path graph with n vertices and
+// the edges {0, 1}, {1, 2}, {2, 3},... , {n-2, n-1}.
+func Path(n int) *Virtual {
+	return line(n)
+}
+
+// Star returns a virtual star graph with n vertices, in which
+// vertex 0 is adjacent to each of the vertices 1, 2,… , n-1.
+// It's the same graph as Kmn(1, n-1).
+func Star(n int) *Virtual {
+	switch {
+	case n < 0:
+		return nil
+	case n == 0:
+		return null
+	}
+	return Kmn(1, n-1)
+}
@@ -0,0 +1,55 @@
+package build
+
+import "testing"
+
+func TestPath(t *testing.T) {
+	if mess, diff := diff(Path(-1), (*Virtual)(nil)); diff {
+		t.Errorf("Path %s", mess)
+	}
+
+	if mess, diff := diff(Path(0).String(), "0 []"); diff {
+		t.Errorf("Path %s", mess)
+	}
+
+	if mess, diff := diff(Path(1).String(), "1 []"); diff {
+		t.Errorf("Path %s", mess)
+	}
+
+	if mess, diff := diff(Path(2).String(), "2 [{0 1}]"); diff {
+		t.Errorf("Path %s", mess)
+	}
+
+	if mess, diff := diff(Path(4).String(), "4 [{0 1} {1 2} {2 3}]"); diff {
+		t.Errorf("Path %s", mess)
+	}
+
+	for n := 0; n < 5; n++ {
+		Consistent("Path", t, Path(n))
+	}
+}
+
+func TestStar(t *testing.T) {
+	if mess, diff := diff(Star(-1), (*Virtual)(nil)); diff {
+		t.Errorf("Star %s", mess)
+	}
+
+	if mess, diff := diff(Star(0).String(), "0 []"); diff {
+		t.Errorf("Star %s", mess)
+	}
+
+	if mess, diff := diff(Star(1).String(), "1 []"); diff {
+		t.Errorf("Star %s", mess)
+	}
+
+	if mess, diff := diff(Star(2).String(), "2 [{0 1}]"); diff {
+		t.Errorf("Star %s", mess)
+	}
+
+	if mess, diff := diff(Star(4).String(), "4 [{0 1} {0 2} {0 3}]"); diff {
+		t.Errorf("Star %s", mess)
+	}
+
+	for n := 0; n < 5; n++ {
+		Consistent("Star", t, Star(n))
+	}
+}
@@ -0,0 +1,129 @@
+package graph
+
+import "strconv"
+
+// Builder builds a graph whose vertices are identified by keys of type K,
+// such as strings, instead of by numbers. Each new key is assigned
+// the next free vertex number, starting at 0, in the order in which
+// the keys are first seen; the results of algorithms run on the graph
+// can be mapped back to keys with Key, Keys and KeyGroups.
+// The zero value is an empty builder ready to use.
+type Builder[K comparable] struct {
+	ids   map[K]int
+	keys  []K
+	edges []Edge
+}
+
+// NewBuilder returns an empty builder.
+func NewBuilder[K comparable]() *Builder[K] {
+	return &Builder[K]{ids: make(map[K]int)}
+}
+
+// Order returns the number of keys, which is the number of vertices
+// in the graph.
+func (b *Builder[K]) Order() int {
+	return len(b.keys)
+}
+
+// Size returns the number of edges added to the builder.
+func (b *Builder[K]) Size() int {
+	return len(b.edges)
+}
+
+// ID returns the vertex of key, adding a new vertex if the key
+// hasn't been seen before.
+func (b *Builder[K]) ID(key K) int {
+	if v, ok := b.ids[key]; ok {
+		return v
+	}
+	if b.ids == nil {
+		b.ids = make(map[K]int)
+	}
+	v := len(b.keys)
+	b.ids[key] = v
+	b.keys = append(b.keys, key)
+	return v
+}
+
+// Lookup returns the vertex of key. If there is no such key,
+// it returns -1 and ok is false.
+func (b *Builder[K]) Lookup(key K) (v int, ok bool) {
+	if v, ok = b.ids[key]; !ok {
+		v = -1
+	}
+	return
+}
+
+// Key returns the key of vertex v.
+func (b *Builder[K]) Key(v int) K {
+	if v < 0 || v >= len(b.keys) {
+		panic("vertex out of range: " + strconv.Itoa(v))
+	}
+	return b.keys[v]
+}
+
+// Keys returns the keys of the given vertices, for instance a path.
+func (b *Builder[K]) Keys(vertices []int) []K {
+	keys := make([]K, len(vertices))
+	for i, v := range vertices {
+		keys[i] = b.Key(v)
+	}
+	return keys
+}
+
+// KeyGroups returns the keys of each group of vertices,
+// for instance the components found by StrongComponents.
+func (b *Builder[K]) KeyGroups(groups [][]int) [][]K {
+	res := make([][]K, len(groups))
+	for i, vertices := range groups {
+		res[i] = b.Keys(vertices)
+	}
+	return res
+}
+
+// AddEdge adds a directed edge with the given cost between the vertices
+// of from and to, adding new vertices for keys that haven't been seen.
+// Duplicate edges are kept.
+func (b *Builder[K]) AddEdge(from, to K, cost int64) {
+	b.edges = append(b.edges, Edge{b.ID(from), b.ID(to), cost})
+}
+
+// AddBoth adds edges with the given cost in both directions
+// between the vertices of from and to.
+func (b *Builder[K]) AddBoth(from, to K, cost int64) {
+	v, w := b.ID(from), b.ID(to)
+	b.edges = append(b.edges, Edge{v, w, cost})
+	if v != w {
+		b.edges = append(b.edges, Edge{w, v, cost})
+	}
+}
+
+// Graph returns an immutable graph with the vertices and edges added
+// so far. Duplicate edges are kept, so the graph may be a multigraph.
+func (b *Builder[K]) Graph() *Immutable {
+	return FromEdges(len(b.keys), b.edges)
+}
+
+// Mutable returns a mutable graph with the vertices and edges added
+// so far. For duplicate edges, the cost of the last one is used.
+func (b *Builder[K]) Mutable() *Mutable {
+	g := New(len(b.keys))
+	for _, e := range b.edges {
+		g.AddCost(e.V, e.W, e.Cost)
+	}
+	return g
+}
+
+// ByKey returns a map from the key of each vertex v to values[v],
+// for instance the distances computed by ShortestPaths.
+// The slice must have one value for each vertex of b.
+func ByKey[K comparable, T any](b *Builder[K], values []T) map[K]T {
+	if len(values) != len(b.keys) {
+		panic("slice length " + strconv.Itoa(len(values)) + " doesn't match graph order " + strconv.Itoa(len(b.keys)))
+	}
+	m := make(map[K]T, len(values))
+	for v, x := range values {
+		m[b.keys[v]] = x
+	}
+	return m
+}
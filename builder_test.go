@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	var b Builder[string] // The zero value is ready to use.
+	b.AddEdge("a", "b", 1)
+	b.AddEdge("b", "c", 2)
+	b.AddEdge("a", "c", 5)
+	b.AddBoth("c", "d", 1)
+	b.ID("e")
+	if mess, diff := diff(b.Order(), 5); diff {
+		t.Errorf("Order %s", mess)
+	}
+	if mess, diff := diff(b.Size(), 5); diff {
+		t.Errorf("Size %s", mess)
+	}
+	if mess, diff := diff(b.ID("c"), 2); diff {
+		t.Errorf("ID %s", mess)
+	}
+	v, ok := b.Lookup("d")
+	if mess, diff := diff([]interface{}{v, ok}, []interface{}{3, true}); diff {
+		t.Errorf("Lookup %s", mess)
+	}
+	v, ok = b.Lookup("x")
+	if mess, diff := diff([]interface{}{v, ok}, []interface{}{-1, false}); diff {
+		t.Errorf("Lookup %s", mess)
+	}
+
+	g := b.Graph()
+	if mess, diff := diff(g.String(), "5 [(0 1):1 (0 2):5 (1 2):2 {2 3}:1]"); diff {
+		t.Errorf("Graph %s", mess)
+	}
+	if mess, diff := diff(b.Mutable().String(), g.String()); diff {
+		t.Errorf("Mutable %s", mess)
+	}
+
+	path, dist := ShortestPath(g, b.ID("a"), b.ID("d"))
+	if mess, diff := diff(b.Keys(path), []string{"a", "b", "c", "d"}); diff {
+		t.Errorf("Keys %s", mess)
+	}
+	if mess, diff := diff(dist, int64(4)); diff {
+		t.Errorf("ShortestPath %s", mess)
+	}
+	if mess, diff := diff(b.KeyGroups(Components(g)), [][]string{{"a", "b", "c", "d"}, {"e"}}); diff {
+		t.Errorf("KeyGroups %s", mess)
+	}
+	_, dists := ShortestPaths(g, b.ID("a"))
+	exp := map[string]int64{"a": 0, "b": 1, "c": 3, "d": 4, "e": -1}
+	if mess, diff := diff(ByKey(&b, dists), exp); diff {
+		t.Errorf("ByKey %s", mess)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Key(5): no panic")
+		}
+	}()
+	b.Key(5)
+}
+
+func ExampleBuilder() {
+	b := NewBuilder[string]()
+	b.AddEdge("Stockholm", "Uppsala", 70)
+	b.AddEdge("Uppsala", "Gävle", 110)
+	b.AddEdge("Stockholm", "Gävle", 200)
+	g := b.Graph()
+
+	path, dist := ShortestPath(g, b.ID("Stockholm"), b.ID("Gävle"))
+	fmt.Println(b.Keys(path), dist)
+	// Output: [Stockholm Uppsala Gävle] 180
+}
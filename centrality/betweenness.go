@@ -0,0 +1,70 @@
+package centrality
+
+import (
+	"context"
+	"github.com/yourbasic/graph"
+	"math/rand"
+)
+
+// Betweenness computes the betweenness centrality of each vertex in g:
+// the sum, over all ordered pairs of distinct vertices s and t different
+// from v, of the fraction of shortest paths from s to t that pass
+// through v. In an undirected graph each pair is counted twice;
+// divide by 2 for the more common definition.
+//
+// If opts.Samples is k > 0, only k randomly chosen sources are used,
+// and the result is scaled by n/k to estimate the exact value.
+// If opts is nil, the default options are used.
+//
+// The implementation uses Brandes' algorithm. The time complexity is
+// O(|V|⋅|E|) for unweighted and O(|V|⋅|E|⋅log|V|) for weighted graphs,
+// where |E| is the number of edges and |V| the number of vertices
+// in the graph. Sampling reduces the |V| factor to k.
+func Betweenness(g graph.Iterator, opts *Options) []float64 {
+	res, _ := BetweennessCtx(context.Background(), g, opts)
+	return res
+}
+
+// BetweennessCtx is like Betweenness, but stops early and returns ctx.Err()
+// if the context is cancelled or its deadline expires before all sources
+// have been searched. In that case, the result is nil.
+func BetweennessCtx(ctx context.Context, g graph.Iterator, opts *Options) ([]float64, error) {
+	if opts == nil {
+		opts = new(Options)
+	}
+	n := g.Order()
+	sources := allVertices(n)
+	scale := 1.0
+	if k := opts.Samples; k > 0 && k < n {
+		r := rand.New(rand.NewSource(opts.Seed))
+		r.Shuffle(n, func(i, j int) { sources[i], sources[j] = sources[j], sources[i] })
+		sources = sources[:k]
+		scale = float64(n) / float64(k)
+	}
+
+	res, err := parallelCtx(ctx, g, sources, opts, true, func(s *search, v int, res []float64) {
+		delta := s.delta
+		// Accumulate dependencies in order of non-increasing distance.
+		for i := len(s.order) - 1; i >= 0; i-- {
+			w := s.order[i]
+			for _, u := range s.pred[w] {
+				delta[u] += s.sigma[u] / s.sigma[w] * (1 + delta[w])
+			}
+			if w != v {
+				res[w] += delta[w]
+			}
+		}
+		for _, w := range s.order {
+			delta[w] = 0
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if scale != 1 {
+		for v := range res {
+			res[v] *= scale
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,201 @@
+package centrality
+
+import (
+	"context"
+	"fmt"
+	"github.com/yourbasic/graph"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func diff(res, exp interface{}) (message string, diff bool) {
+	if !reflect.DeepEqual(res, exp) {
+		message = fmt.Sprintf("%v; want %v", res, exp)
+		diff = true
+	}
+	return
+}
+
+// near tells if the values of a and b differ by at most 1e-9.
+func near(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBetweenness(t *testing.T) {
+	if mess, diff := diff(Betweenness(graph.New(0), nil), []float64{}); diff {
+		t.Errorf("Betweenness %s", mess)
+	}
+
+	// 0 - 1 - 2 - 4
+	//     \     /
+	//       3 -
+	g := graph.New(5)
+	g.AddBoth(0, 1)
+	g.AddBoth(1, 2)
+	g.AddBoth(1, 3)
+	g.AddBoth(2, 4)
+	g.AddBoth(3, 4)
+	res := Betweenness(g, nil)
+	if exp := []float64{0, 7, 2, 2, 1}; !near(res, exp) {
+		t.Errorf("Betweenness %v; want %v", res, exp)
+	}
+
+	g.AddBothCost(1, 2, 1)
+	g.AddBothCost(2, 4, 1)
+	g.AddBothCost(0, 1, 1)
+	g.AddBothCost(1, 3, 2)
+	g.AddBothCost(3, 4, 2)
+	res = Betweenness(g, &Options{Weighted: true})
+	if exp := []float64{0, 7, 4, 0, 1}; !near(res, exp) {
+		t.Errorf("Betweenness %v; want %v", res, exp)
+	}
+}
+
+func TestBetweennessRandom(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		n := 1 + rand.Intn(20)
+		g := graph.New(n)
+		for j := 0; j < 2*n; j++ {
+			g.AddCost(rand.Intn(n), rand.Intn(n), 1+rand.Int63n(3))
+		}
+		for _, weighted := range []bool{false, true} {
+			exp := bruteBetweenness(g, weighted)
+			res := Betweenness(g, &Options{Weighted: weighted})
+			if !near(res, exp) {
+				t.Errorf("Betweenness %v; want %v", res, exp)
+			}
+			res = Betweenness(g, &Options{Weighted: weighted, Workers: 3})
+			if !near(res, exp) {
+				t.Errorf("Betweenness %v; want %v", res, exp)
+			}
+			res = Betweenness(g, &Options{Weighted: weighted, Samples: n})
+			if !near(res, exp) {
+				t.Errorf("Betweenness %v; want %v", res, exp)
+			}
+		}
+	}
+}
+
+func TestBetweennessSampled(t *testing.T) {
+	n := 200
+	g := graph.New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	exp := Betweenness(g, nil)
+	opts := &Options{Samples: n / 2, Seed: 1}
+	res := Betweenness(g, opts)
+	if again := Betweenness(g, opts); !near(again, res) {
+		t.Errorf("Betweenness %v; want %v", again, res)
+	}
+	var sumRes, sumExp float64
+	for v := range res {
+		sumRes += res[v]
+		sumExp += exp[v]
+	}
+	if math.Abs(sumRes-sumExp) > 0.2*sumExp {
+		t.Errorf("Betweenness: total %g; want about %g", sumRes, sumExp)
+	}
+}
+
+func TestBetweennessCtx(t *testing.T) {
+	n := 100
+	g := graph.New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	for _, workers := range []int{1, 4} {
+		opts := &Options{Workers: workers}
+		res, err := BetweennessCtx(context.Background(), g, opts)
+		if mess, diff := diff(err, nil); diff {
+			t.Errorf("BetweennessCtx->err %s", mess)
+		}
+		if exp := Betweenness(g, opts); !near(res, exp) {
+			t.Errorf("BetweennessCtx %v; want %v", res, exp)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		res, err = BetweennessCtx(ctx, g, opts)
+		if mess, diff := diff(err, context.Canceled); diff {
+			t.Errorf("BetweennessCtx cancelled->err %s", mess)
+		}
+		if res != nil {
+			t.Errorf("BetweennessCtx cancelled %v; want nil", res)
+		}
+	}
+}
+
+func TestProgress(t *testing.T) {
+	n := 50
+	g := graph.New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	for _, workers := range []int{1, 4} {
+		last, calls := 0, 0
+		opts := &Options{Workers: workers, Samples: 20, Progress: func(done, total int) {
+			if done != last+1 || total != 20 {
+				t.Errorf("Progress(%d, %d) after %d; want (%d, 20)", done, total, last, last+1)
+			}
+			last = done
+			calls++
+		}}
+		Betweenness(g, opts)
+		if calls != 20 {
+			t.Errorf("Betweenness: %d calls to Progress; want 20", calls)
+		}
+	}
+}
+
+// bruteBetweenness computes betweenness from all-pairs
+// distances and path counts.
+func bruteBetweenness(g graph.Iterator, weighted bool) []float64 {
+	n := g.Order()
+	dist := make([][]int64, n)
+	count := make([][]float64, n)
+	for v := range dist {
+		s := newSearch(g, weighted)
+		s.run(v, true)
+		dist[v] = append([]int64{}, s.dist...)
+		count[v] = append([]float64{}, s.sigma...)
+	}
+	res := make([]float64, n)
+	for s := 0; s < n; s++ {
+		for t := 0; t < n; t++ {
+			if s == t || dist[s][t] == -1 {
+				continue
+			}
+			for v := 0; v < n; v++ {
+				if v != s && v != t && dist[s][v] != -1 && dist[v][t] != -1 &&
+					dist[s][v]+dist[v][t] == dist[s][t] {
+					res[v] += count[s][v] * count[v][t] / count[s][t]
+				}
+			}
+		}
+	}
+	return res
+}
+
+func BenchmarkBetweenness(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := graph.New(n)
+	for i := 0; i < 2*n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Betweenness(g, nil)
+	}
+}
@@ -0,0 +1,224 @@
+// Package centrality implements measures of the importance of vertices.
+//
+// Betweenness counts the shortest paths that pass through a vertex,
+// while Closeness and Harmonic measure how close a vertex is to all
+// other vertices. The lengths of the paths are either the number
+// of edges, or, if the Weighted option is set, the sum of the edge costs.
+//
+// PageRank, Eigenvector, Katz and HITS are spectral measures, computed
+// by power iteration, where a vertex is important if it's linked to
+// by other important vertices.
+//
+// All measures can be computed in parallel using the Workers option.
+package centrality
+
+import (
+	"context"
+	"github.com/yourbasic/graph"
+	"sync"
+)
+
+// Options configure the centrality computations.
+// A nil *Options is the same as the zero value.
+type Options struct {
+	// Weighted tells if the length of an edge is its cost;
+	// otherwise each edge has length 1. The costs must be positive.
+	// For the spectral measures, the cost is used as the edge weight.
+	Weighted bool
+
+	// Workers is the number of goroutines that process source vertices
+	// in parallel. If Workers ≤ 1, a single goroutine is used.
+	// With several workers, the Visit method of g must be safe for
+	// concurrent use; this is true for Mutable and Immutable graphs
+	// that aren't modified during the call.
+	Workers int
+
+	// Samples is the number of randomly chosen source vertices used by
+	// Betweenness to approximate the result. If Samples is 0 or at least
+	// the number of vertices, the exact result is computed.
+	Samples int
+
+	// Seed initializes the random choice of sources.
+	Seed int64
+
+	// Tol is the tolerance of the spectral measures: the iteration
+	// stops when the sum of the absolute changes of the values in
+	// an iteration is less than Tol. If Tol is 0, 1e-9 is used.
+	Tol float64
+
+	// MaxIter is the maximum number of iterations of the spectral
+	// measures. If MaxIter is 0, 1000 is used.
+	MaxIter int
+
+	// Progress, if not nil, is called by Betweenness, Closeness and
+	// Harmonic after each search from a source vertex, with the number
+	// of sources done so far and the total number of sources.
+	// The calls are never concurrent, even with several workers.
+	Progress func(done, total int)
+}
+
+// search holds the result of a single-source shortest path search.
+type search struct {
+	g        graph.Iterator
+	weighted bool
+	// The reached vertices in order of non-decreasing distance.
+	order []int
+	dist  []int64   // The distance from the source, or -1.
+	sigma []float64 // The number of shortest paths from the source.
+	pred  [][]int   // The predecessors on shortest paths.
+	delta []float64 // Scratch space for Betweenness.
+	queue graph.DistQueue
+}
+
+func newSearch(g graph.Iterator, weighted bool) *search {
+	n := g.Order()
+	s := &search{
+		g:        g,
+		weighted: weighted,
+		dist:     make([]int64, n),
+		sigma:    make([]float64, n),
+		pred:     make([][]int, n),
+		delta:    make([]float64, n),
+	}
+	for v := range s.dist {
+		s.dist[v] = -1
+	}
+	if weighted {
+		s.queue = graph.NewHeapQueue(n)
+	}
+	return s
+}
+
+// run computes shortest paths from v. If paths is false,
+// only the distances are computed.
+func (s *search) run(v int, paths bool) {
+	for _, w := range s.order {
+		s.dist[w], s.sigma[w] = -1, 0
+		s.pred[w] = s.pred[w][:0]
+	}
+	s.order = s.order[:0]
+	s.dist[v], s.sigma[v] = 0, 1
+	relax := func(v, w int, d int64) (improved bool) {
+		switch {
+		case s.dist[w] == -1 || d < s.dist[w]:
+			s.dist[w] = d
+			if paths {
+				s.sigma[w] = s.sigma[v]
+				s.pred[w] = append(s.pred[w][:0], v)
+			}
+			return true
+		case d == s.dist[w] && paths:
+			s.sigma[w] += s.sigma[v]
+			s.pred[w] = append(s.pred[w], v)
+		}
+		return false
+	}
+
+	if !s.weighted {
+		s.order = append(s.order, v)
+		for i := 0; i < len(s.order); i++ {
+			v := s.order[i]
+			d := s.dist[v] + 1
+			s.g.Visit(v, func(w int, _ int64) (skip bool) {
+				if relax(v, w, d) {
+					s.order = append(s.order, w)
+				}
+				return
+			})
+		}
+		return
+	}
+
+	s.queue.Push(v, 0)
+	for s.queue.Len() > 0 {
+		v, d := s.queue.Pop()
+		if d > s.dist[v] {
+			continue // An outdated entry.
+		}
+		s.order = append(s.order, v)
+		s.g.Visit(v, func(w int, c int64) (skip bool) {
+			if relax(v, w, d+c) {
+				s.queue.Push(w, d+c)
+			}
+			return
+		})
+	}
+}
+
+// parallel calls f for each source vertex, using the given number
+// of workers. Each worker gets its own search and result slice;
+// the result slices are added up and returned.
+func parallel(g graph.Iterator, sources []int, opts *Options, paths bool,
+	f func(s *search, v int, res []float64)) []float64 {
+	res, _ := parallelCtx(context.Background(), g, sources, opts, paths, f)
+	return res
+}
+
+// parallelCtx is like parallel, but stops before the next source
+// and returns ctx.Err() if ctx is done.
+func parallelCtx(ctx context.Context, g graph.Iterator, sources []int, opts *Options, paths bool,
+	f func(s *search, v int, res []float64)) ([]float64, error) {
+	n := g.Order()
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(sources) {
+		workers = len(sources)
+	}
+	res := make([]float64, n)
+	var mu sync.Mutex
+	done := 0
+	report := func() {
+		if opts.Progress == nil {
+			return
+		}
+		mu.Lock()
+		done++
+		opts.Progress(done, len(sources))
+		mu.Unlock()
+	}
+	if workers <= 1 {
+		s := newSearch(g, opts.Weighted)
+		for _, v := range sources {
+			if ctx.Err() != nil {
+				break
+			}
+			s.run(v, paths)
+			f(s, v, res)
+			report()
+		}
+		return res, ctx.Err()
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s := newSearch(g, opts.Weighted)
+			local := make([]float64, n)
+			for j := i; j < len(sources) && ctx.Err() == nil; j += workers {
+				v := sources[j]
+				s.run(v, paths)
+				f(s, v, local)
+				report()
+			}
+			mu.Lock()
+			for v, x := range local {
+				res[v] += x
+			}
+			mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+	return res, ctx.Err()
+}
+
+// allVertices returns the vertices 0..n-1.
+func allVertices(n int) []int {
+	vertices := make([]int, n)
+	for v := range vertices {
+		vertices[v] = v
+	}
+	return vertices
+}
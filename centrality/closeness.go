@@ -0,0 +1,52 @@
+package centrality
+
+import "github.com/yourbasic/graph"
+
+// Closeness computes the closeness centrality of each vertex in g.
+// For a vertex v that can reach r-1 other vertices, at a total distance
+// of d, the closeness is (r-1)/d scaled by (r-1)/(n-1), as proposed by
+// Wasserman and Faust, so that vertices in small components don't get
+// high values. A vertex that can't reach any other vertex has closeness 0.
+// The distances are measured from v; use graph.Transpose for incoming
+// distances. If opts is nil, the default options are used.
+//
+// The time complexity is O(|V|⋅|E|) for unweighted and O(|V|⋅|E|⋅log|V|)
+// for weighted graphs, where |E| is the number of edges and |V| the number
+// of vertices in the graph.
+func Closeness(g graph.Iterator, opts *Options) []float64 {
+	if opts == nil {
+		opts = new(Options)
+	}
+	n := g.Order()
+	return parallel(g, allVertices(n), opts, false, func(s *search, v int, res []float64) {
+		var sum int64
+		for _, w := range s.order {
+			sum += s.dist[w]
+		}
+		if sum > 0 {
+			r := float64(len(s.order) - 1)
+			res[v] = r / float64(sum) * r / float64(n-1)
+		}
+	})
+}
+
+// Harmonic computes the harmonic centrality of each vertex v in g:
+// the sum of 1/d(v, w) over all vertices w ≠ v that can be reached from v,
+// where d(v, w) is the distance from v to w.
+// If opts is nil, the default options are used.
+//
+// The time complexity is O(|V|⋅|E|) for unweighted and O(|V|⋅|E|⋅log|V|)
+// for weighted graphs, where |E| is the number of edges and |V| the number
+// of vertices in the graph.
+func Harmonic(g graph.Iterator, opts *Options) []float64 {
+	if opts == nil {
+		opts = new(Options)
+	}
+	return parallel(g, allVertices(g.Order()), opts, false, func(s *search, v int, res []float64) {
+		for _, w := range s.order {
+			if d := s.dist[w]; d > 0 {
+				res[v] += 1 / float64(d)
+			}
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package centrality
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"testing"
+)
+
+func TestCloseness(t *testing.T) {
+	// 0 - 1 - 2   3
+	g := graph.New(4)
+	g.AddBoth(0, 1)
+	g.AddBoth(1, 2)
+	res := Closeness(g, nil)
+	if exp := []float64{4.0 / 9, 2.0 / 3, 4.0 / 9, 0}; !near(res, exp) {
+		t.Errorf("Closeness %v; want %v", res, exp)
+	}
+	res = Harmonic(g, nil)
+	if exp := []float64{1.5, 2, 1.5, 0}; !near(res, exp) {
+		t.Errorf("Harmonic %v; want %v", res, exp)
+	}
+
+	g.AddBothCost(0, 1, 2)
+	g.AddBothCost(1, 2, 3)
+	res = Harmonic(g, &Options{Weighted: true})
+	if exp := []float64{0.5 + 0.2, 0.5 + 1.0/3, 0.2 + 1.0/3, 0}; !near(res, exp) {
+		t.Errorf("Harmonic %v; want %v", res, exp)
+	}
+	res = Closeness(g, &Options{Weighted: true})
+	if exp := []float64{2.0 / 7 * 2 / 3, 2.0 / 5 * 2 / 3, 2.0 / 8 * 2 / 3, 0}; !near(res, exp) {
+		t.Errorf("Closeness %v; want %v", res, exp)
+	}
+}
+
+func TestClosenessParallel(t *testing.T) {
+	n := 100
+	g := graph.New(n)
+	for i := 0; i < 2*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), 1+rand.Int63n(5))
+	}
+	for _, weighted := range []bool{false, true} {
+		opts := &Options{Weighted: weighted, Workers: 4}
+		seq := &Options{Weighted: weighted}
+		if res, exp := Closeness(g, opts), Closeness(g, seq); !near(res, exp) {
+			t.Errorf("Closeness %v; want %v", res, exp)
+		}
+		if res, exp := Harmonic(g, opts), Harmonic(g, seq); !near(res, exp) {
+			t.Errorf("Harmonic %v; want %v", res, exp)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package centrality
+
+import (
+	"github.com/yourbasic/graph"
+	"math"
+	"sync"
+)
+
+// matrix is the adjacency matrix of a graph, or its transpose,
+// stored in compressed sparse row format.
+type matrix struct {
+	rows     *graph.Immutable
+	weighted bool
+	workers  int
+}
+
+// newMatrix returns the adjacency matrix of g, or its transpose.
+func newMatrix(g graph.Iterator, transpose bool, opts *Options) *matrix {
+	m := &matrix{weighted: opts.Weighted, workers: opts.Workers}
+	if transpose {
+		m.rows = graph.Transpose(g)
+	} else {
+		m.rows = graph.Sort(g)
+	}
+	if m.workers < 1 {
+		m.workers = 1
+	}
+	return m
+}
+
+// mul computes the matrix-vector product y = Mx.
+func (m *matrix) mul(x, y []float64) {
+	n := len(y)
+	row := func(lo, hi int) {
+		for v := lo; v < hi; v++ {
+			sum := 0.0
+			m.rows.Visit(v, func(w int, c int64) (skip bool) {
+				if m.weighted {
+					sum += float64(c) * x[w]
+				} else {
+					sum += x[w]
+				}
+				return
+			})
+			y[v] = sum
+		}
+	}
+	if m.workers == 1 || n < 2*m.workers {
+		row(0, n)
+		return
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < m.workers; i++ {
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			row(lo, hi)
+		}(i*n/m.workers, (i+1)*n/m.workers)
+	}
+	wg.Wait()
+}
+
+// iterate computes next from x using step, and then swaps the two,
+// until the sum of the absolute changes is less than tol, or for at most
+// maxIter iterations. It returns the final values and tells if they
+// converged.
+func iterate(x []float64, tol float64, maxIter int, step func(x, next []float64)) (res []float64, ok bool) {
+	next := make([]float64, len(x))
+	for iter := 0; iter < maxIter; iter++ {
+		step(x, next)
+		x, next = next, x
+		err := 0.0
+		for v := range x {
+			err += math.Abs(x[v] - next[v])
+		}
+		if err < tol {
+			return x, true
+		}
+	}
+	return x, false
+}
+
+// convergence returns the tolerance and the maximum number of iterations.
+func (opts *Options) convergence() (tol float64, maxIter int) {
+	tol, maxIter = opts.Tol, opts.MaxIter
+	if tol <= 0 {
+		tol = 1e-9
+	}
+	if maxIter <= 0 {
+		maxIter = 1000
+	}
+	return
+}
+
+// normalize scales x to unit length in the given norm, 1 or 2.
+// A zero vector is left as is.
+func normalize(x []float64, norm int) {
+	sum := 0.0
+	for _, a := range x {
+		if norm == 1 {
+			sum += math.Abs(a)
+		} else {
+			sum += a * a
+		}
+	}
+	if norm == 2 {
+		sum = math.Sqrt(sum)
+	}
+	if sum == 0 {
+		return
+	}
+	for v := range x {
+		x[v] /= sum
+	}
+}
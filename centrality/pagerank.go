@@ -0,0 +1,108 @@
+package centrality
+
+import (
+	"github.com/yourbasic/graph"
+	"math"
+	"strconv"
+)
+
+// PageRank computes the PageRank of each vertex in g: the probability
+// that a random surfer, who follows a random outgoing edge with
+// probability damping and jumps to a random vertex otherwise,
+// is at the vertex. A surfer at a vertex without outgoing edges
+// always jumps. The values add up to 1.
+//
+// The ranks are computed by power iteration, which stops when the sum
+// of the absolute changes in an iteration is less than tol.
+// The damping factor must be in the range [0, 1), and tol must be positive;
+// the values 0.85 and 1e-9 are common choices.
+//
+// Each iteration takes O(|E| + |V|) time, and the number of iterations is
+// at most log(tol/2)/log(damping), where |E| is the number of edges and |V|
+// the number of vertices in the graph.
+func PageRank(g graph.Iterator, damping, tol float64) []float64 {
+	return PageRankParallel(g, damping, tol, nil, 1)
+}
+
+// PersonalizedPageRank is like PageRank, but the surfer always jumps
+// to one of the seed vertices, chosen uniformly at random.
+// The result measures the proximity of each vertex to the seeds.
+func PersonalizedPageRank(g graph.Iterator, damping, tol float64, seeds []int) []float64 {
+	return PageRankParallel(g, damping, tol, seeds, 1)
+}
+
+// PageRankParallel computes the personalized PageRank, or the ordinary
+// PageRank if seeds is empty, using the given number of goroutines
+// for each iteration.
+func PageRankParallel(g graph.Iterator, damping, tol float64, seeds []int, workers int) []float64 {
+	if damping < 0 || damping >= 1 {
+		panic("damping factor out of range: " + strconv.FormatFloat(damping, 'g', -1, 64))
+	}
+	if tol <= 0 {
+		panic("non-positive tolerance: " + strconv.FormatFloat(tol, 'g', -1, 64))
+	}
+	n := g.Order()
+	if n == 0 {
+		return []float64{}
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	// The jump distribution.
+	jump := make([]float64, n)
+	if len(seeds) == 0 {
+		for v := range jump {
+			jump[v] = 1 / float64(n)
+		}
+	} else {
+		for _, v := range seeds {
+			if v < 0 || v >= n {
+				panic("vertex out of range: " + strconv.Itoa(v))
+			}
+			jump[v] = 1
+		}
+		count := 0.0
+		for _, x := range jump {
+			count += x
+		}
+		for v := range jump {
+			jump[v] /= count
+		}
+	}
+
+	// The incoming edges, in compressed sparse row format,
+	// and the out-degree of each vertex.
+	in := graph.Transpose(g)
+	outdeg := make([]float64, n)
+	for v := 0; v < n; v++ {
+		in.Visit(v, func(w int, _ int64) (skip bool) {
+			outdeg[w]++
+			return
+		})
+	}
+
+	share := make([]float64, n) // The rank passed along each outgoing edge.
+	maxIter := 1
+	if damping > 0 {
+		maxIter = int(math.Ceil(math.Log(tol/2)/math.Log(damping))) + 1
+	}
+	m := &matrix{rows: in, workers: workers}
+	rank, _ := iterate(append([]float64{}, jump...), tol, maxIter, func(rank, next []float64) {
+		dangling := 0.0
+		for v, d := range outdeg {
+			if d == 0 {
+				dangling += rank[v]
+				share[v] = 0
+			} else {
+				share[v] = rank[v] / d
+			}
+		}
+		m.mul(share, next)
+		base := 1 - damping + damping*dangling
+		for w := range next {
+			next[w] = base*jump[w] + damping*next[w]
+		}
+	})
+	return rank
+}
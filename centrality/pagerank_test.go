@@ -0,0 +1,97 @@
+package centrality
+
+import (
+	"github.com/yourbasic/graph"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPageRank(t *testing.T) {
+	if mess, diff := diff(PageRank(graph.New(0), 0.85, 1e-9), []float64{}); diff {
+		t.Errorf("PageRank %s", mess)
+	}
+
+	// A directed cycle: all vertices have the same rank.
+	g := graph.New(4)
+	for v := 0; v < 4; v++ {
+		g.Add(v, (v+1)%4)
+	}
+	res := PageRank(g, 0.85, 1e-12)
+	if exp := []float64{0.25, 0.25, 0.25, 0.25}; !near(res, exp) {
+		t.Errorf("PageRank %v; want %v", res, exp)
+	}
+
+	// 0 -> 1 <- 2, where 1 has no outgoing edges.
+	g = graph.New(3)
+	g.Add(0, 1)
+	g.Add(2, 1)
+	res = PageRank(g, 0.5, 1e-12)
+	// x0 = x2 = a and x1 = b, with a = (1-d)/3 + d*b/3
+	// and b = (1-d)/3 + d*(2a + b/3), where d = 1/2.
+	a, b := 0.25, 0.5
+	if exp := []float64{a, b, a}; !near(res, exp) {
+		t.Errorf("PageRank %v; want %v", res, exp)
+	}
+
+	res = PageRank(g, 0, 1e-12)
+	if exp := []float64{1.0 / 3, 1.0 / 3, 1.0 / 3}; !near(res, exp) {
+		t.Errorf("PageRank %v; want %v", res, exp)
+	}
+}
+
+func TestPersonalizedPageRank(t *testing.T) {
+	// 0 -> 1 -> 2 -> 3
+	g := graph.New(4)
+	g.Add(0, 1)
+	g.Add(1, 2)
+	g.Add(2, 3)
+	res := PersonalizedPageRank(g, 0.5, 1e-12, []int{0})
+	// The surfer always jumps back to 0, also from 3.
+	x0 := 1 / (1 + 0.5 + 0.25 + 0.125)
+	if exp := []float64{x0, x0 / 2, x0 / 4, x0 / 8}; !near(res, exp) {
+		t.Errorf("PersonalizedPageRank %v; want %v", res, exp)
+	}
+
+	res = PersonalizedPageRank(g, 0.5, 1e-12, []int{3, 3})
+	if exp := []float64{0, 0, 0, 1}; !near(res, exp) {
+		t.Errorf("PersonalizedPageRank %v; want %v", res, exp)
+	}
+}
+
+func TestPageRankRandom(t *testing.T) {
+	n := 500
+	g := graph.New(n)
+	for i := 0; i < 5*n; i++ {
+		g.Add(rand.Intn(n), rand.Intn(n))
+	}
+	res := PageRank(g, 0.85, 1e-10)
+	sum := 0.0
+	for _, x := range res {
+		sum += x
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("PageRank: sum %g; want 1", sum)
+	}
+	if par := PageRankParallel(g, 0.85, 1e-10, nil, 4); !near(par, res) {
+		t.Errorf("PageRankParallel %v; want %v", par, res)
+	}
+	seeds := []int{1, 2, 3}
+	if par, exp := PageRankParallel(g, 0.85, 1e-10, seeds, 3), PersonalizedPageRank(g, 0.85, 1e-10, seeds); !near(par, exp) {
+		t.Errorf("PageRankParallel %v; want %v", par, exp)
+	}
+}
+
+func BenchmarkPageRank(b *testing.B) {
+	n := 10000
+	b.StopTimer()
+	g := graph.New(n)
+	for i := 0; i < 10*n; i++ {
+		g.Add(rand.Intn(n), rand.Intn(n))
+	}
+	h := graph.Sort(g)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = PageRank(h, 0.85, 1e-9)
+	}
+}
@@ -0,0 +1,98 @@
+package centrality
+
+import "github.com/yourbasic/graph"
+
+// Eigenvector computes the eigenvector centrality of each vertex in g:
+// the entries of the eigenvector of the transposed adjacency matrix
+// that belongs to its largest eigenvalue, scaled to unit length.
+// The centrality of a vertex is hence proportional to the sum of
+// the centralities of the vertices with edges to it.
+// If the iteration doesn't converge within opts.MaxIter iterations,
+// ok is set to false. If opts is nil, the default options are used.
+//
+// Each iteration takes O(|E| + |V|) time, where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func Eigenvector(g graph.Iterator, opts *Options) (x []float64, ok bool) {
+	if opts == nil {
+		opts = new(Options)
+	}
+	n := g.Order()
+	if n == 0 {
+		return []float64{}, true
+	}
+	m := newMatrix(g, true, opts)
+	x = make([]float64, n)
+	for v := range x {
+		x[v] = 1 / float64(n)
+	}
+	tol, maxIter := opts.convergence()
+	return iterate(x, tol, maxIter, func(x, next []float64) {
+		// Iterating with A+I instead of A gives the same eigenvector,
+		// but also converges for bipartite graphs.
+		m.mul(x, next)
+		for v := range next {
+			next[v] += x[v]
+		}
+		normalize(next, 2)
+	})
+}
+
+// Katz computes the Katz centrality of each vertex in g: the solution x
+// of x = alpha⋅Aᵀx + beta, where A is the adjacency matrix of g,
+// scaled to unit length. The centrality of a vertex counts the walks
+// ending at the vertex, where a walk of length k has weight alphaᵏ.
+// The attenuation factor alpha must be smaller than the reciprocal of
+// the largest eigenvalue of A; otherwise the iteration doesn't converge
+// and ok is set to false. If opts is nil, the default options are used.
+//
+// Each iteration takes O(|E| + |V|) time, where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func Katz(g graph.Iterator, alpha, beta float64, opts *Options) (x []float64, ok bool) {
+	if opts == nil {
+		opts = new(Options)
+	}
+	n := g.Order()
+	m := newMatrix(g, true, opts)
+	tol, maxIter := opts.convergence()
+	x, ok = iterate(make([]float64, n), tol, maxIter, func(x, next []float64) {
+		m.mul(x, next)
+		for v := range next {
+			next[v] = alpha*next[v] + beta
+		}
+	})
+	normalize(x, 2)
+	return
+}
+
+// HITS computes the hub and authority scores of each vertex in g,
+// using Kleinberg's hyperlink-induced topic search. A good hub has
+// edges to many good authorities, and a good authority has edges from
+// many good hubs. Both score vectors add up to 1, unless g has no edges.
+// If the iteration doesn't converge within opts.MaxIter iterations,
+// ok is set to false. If opts is nil, the default options are used.
+//
+// Each iteration takes O(|E| + |V|) time, where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func HITS(g graph.Iterator, opts *Options) (hubs, authorities []float64, ok bool) {
+	if opts == nil {
+		opts = new(Options)
+	}
+	n := g.Order()
+	if n == 0 {
+		return []float64{}, []float64{}, true
+	}
+	a, at := newMatrix(g, false, opts), newMatrix(g, true, opts)
+	hubs = make([]float64, n)
+	for v := range hubs {
+		hubs[v] = 1 / float64(n)
+	}
+	authorities = make([]float64, n)
+	tol, maxIter := opts.convergence()
+	hubs, ok = iterate(hubs, tol, maxIter, func(h, next []float64) {
+		at.mul(h, authorities)
+		normalize(authorities, 1)
+		a.mul(authorities, next)
+		normalize(next, 1)
+	})
+	return
+}
@@ -0,0 +1,134 @@
+package centrality
+
+import (
+	"github.com/yourbasic/graph"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestEigenvector(t *testing.T) {
+	x, ok := Eigenvector(graph.New(0), nil)
+	if mess, diff := diff(x, []float64{}); diff {
+		t.Errorf("Eigenvector %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("Eigenvector->ok %s", mess)
+	}
+
+	// A star with center 0 and leaves 1, 2, 3 has the eigenvector
+	// (√3, 1, 1, 1) for the eigenvalue √3.
+	g := graph.New(4)
+	for v := 1; v < 4; v++ {
+		g.AddBoth(0, v)
+	}
+	x, ok = Eigenvector(g, &Options{Tol: 1e-12})
+	c := 1 / math.Sqrt(6)
+	if exp := []float64{math.Sqrt(3) * c, c, c, c}; !near(x, exp) {
+		t.Errorf("Eigenvector %v; want %v", x, exp)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("Eigenvector->ok %s", mess)
+	}
+
+	// With weights 4 on the edge {0, 1}, the eigenvalue is √18
+	// and the eigenvector (√18, 4, 1, 1).
+	g.AddBothCost(0, 1, 4)
+	g.AddBothCost(0, 2, 1)
+	g.AddBothCost(0, 3, 1)
+	x, _ = Eigenvector(g, &Options{Weighted: true, Tol: 1e-12})
+	c = 1 / 6.0
+	if exp := []float64{math.Sqrt(18) * c, 4 * c, c, c}; !near(x, exp) {
+		t.Errorf("Eigenvector %v; want %v", x, exp)
+	}
+
+	_, ok = Eigenvector(g, &Options{MaxIter: 2})
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("Eigenvector->ok %s", mess)
+	}
+}
+
+func TestKatz(t *testing.T) {
+	// 0 -> 1 -> 2: x = (β, β + αβ, β + αβ + α²β) before scaling.
+	g := graph.New(3)
+	g.Add(0, 1)
+	g.Add(1, 2)
+	x, ok := Katz(g, 0.5, 1, nil)
+	exp := []float64{1, 1.5, 1.75}
+	normalize(exp, 2)
+	if !near(x, exp) {
+		t.Errorf("Katz %v; want %v", x, exp)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("Katz->ok %s", mess)
+	}
+
+	// The cycle 0 -> 1 -> 2 -> 0 has largest eigenvalue 1.
+	g.Add(2, 0)
+	x, ok = Katz(g, 0.5, 1, nil)
+	if exp := []float64{1 / math.Sqrt(3), 1 / math.Sqrt(3), 1 / math.Sqrt(3)}; !near(x, exp) {
+		t.Errorf("Katz %v; want %v", x, exp)
+	}
+	_, ok = Katz(g, 1.5, 1, nil)
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("Katz->ok %s", mess)
+	}
+}
+
+func TestHITS(t *testing.T) {
+	// Hubs 0 and 1 both link to 2 and 3; 1 also links to 4.
+	g := graph.New(5)
+	g.Add(0, 2)
+	g.Add(0, 3)
+	g.Add(1, 2)
+	g.Add(1, 3)
+	g.Add(1, 4)
+	hubs, auth, ok := HITS(g, &Options{Tol: 1e-12})
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("HITS->ok %s", mess)
+	}
+	if hubs[1] <= hubs[0] || hubs[2] != 0 || hubs[0] <= 0 {
+		t.Errorf("HITS->hubs %v", hubs)
+	}
+	if auth[2] != auth[3] || auth[4] >= auth[2] || auth[0] != 0 {
+		t.Errorf("HITS->authorities %v", auth)
+	}
+	sumH, sumA := 0.0, 0.0
+	for v := range hubs {
+		sumH += hubs[v]
+		sumA += auth[v]
+	}
+	if math.Abs(sumH-1) > 1e-9 || math.Abs(sumA-1) > 1e-9 {
+		t.Errorf("HITS: sums %g and %g; want 1", sumH, sumA)
+	}
+
+	hubs, auth, _ = HITS(graph.New(2), nil)
+	if mess, diff := diff(hubs, []float64{0, 0}); diff {
+		t.Errorf("HITS->hubs %s", mess)
+	}
+	if mess, diff := diff(auth, []float64{0, 0}); diff {
+		t.Errorf("HITS->authorities %s", mess)
+	}
+}
+
+func TestSpectralParallel(t *testing.T) {
+	n := 300
+	g := graph.New(n)
+	for i := 0; i < 5*n; i++ {
+		g.AddBothCost(rand.Intn(n), rand.Intn(n), 1+rand.Int63n(3))
+	}
+	seq := &Options{Weighted: true}
+	par := &Options{Weighted: true, Workers: 4}
+	x, _ := Eigenvector(g, seq)
+	if y, _ := Eigenvector(g, par); !near(x, y) {
+		t.Errorf("Eigenvector %v; want %v", y, x)
+	}
+	x, _ = Katz(g, 0.01, 1, seq)
+	if y, _ := Katz(g, 0.01, 1, par); !near(x, y) {
+		t.Errorf("Katz %v; want %v", y, x)
+	}
+	x, _, _ = HITS(g, seq)
+	if y, _, _ := HITS(g, par); !near(x, y) {
+		t.Errorf("HITS %v; want %v", y, x)
+	}
+}
@@ -0,0 +1,262 @@
+// Package ch implements contraction hierarchies, a technique
+// for answering many shortest path queries on a static graph.
+//
+// The preprocessing step contracts the vertices of a graph one at a time,
+// in order of increasing importance, and adds shortcut edges that
+// preserve all shortest path distances among the remaining vertices.
+// A query is then answered by two small Dijkstra searches, one from
+// each endpoint, which only follow edges to more important vertices.
+// For road networks and similar graphs, a query typically settles
+// only a few hundred vertices, even when the graph has millions of them.
+//
+// The preprocessed hierarchy can be saved with WriteTo and
+// loaded with ReadFrom, so that preprocessing is only done once.
+package ch
+
+import (
+	"container/heap"
+	"github.com/yourbasic/graph"
+	"sort"
+)
+
+// Hierarchy is a contraction hierarchy of a directed graph
+// with non-negative edge costs.
+type Hierarchy struct {
+	// rank[v] is the position of v in the contraction order.
+	rank []int
+
+	// up[v] lists the edges (v, w) with rank[v] < rank[w].
+	// down[v] lists the edges (w, v) with rank[v] < rank[w],
+	// with arc.vertex equal to w.
+	up, down [][]arc
+}
+
+// An arc is an edge of a hierarchy. A shortcut replaces the path
+// from an endpoint via a contracted vertex to the other endpoint.
+type arc struct {
+	vertex int
+	cost   int64
+	via    int // the contracted vertex, or -1 if this isn't a shortcut
+}
+
+// Order returns the number of vertices in the hierarchy.
+func (h *Hierarchy) Order() int {
+	return len(h.rank)
+}
+
+// Shortcuts returns the number of shortcut edges added by the preprocessing.
+func (h *Hierarchy) Shortcuts() int {
+	count := 0
+	for _, arcs := range [][][]arc{h.up, h.down} {
+		for _, list := range arcs {
+			for _, a := range list {
+				if a.via != -1 {
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
+
+// The maximum number of vertices settled by a witness search.
+// A search that gives up early only adds an unneeded shortcut.
+const witnessLimit = 500
+
+// New preprocesses g into a contraction hierarchy.
+// Only edges with non-negative costs are included;
+// self-loops and, for multiple edges, all but the cheapest edge are ignored.
+//
+// The time complexity depends heavily on the structure of the graph.
+// Sparse graphs with a natural hierarchy, such as road networks,
+// are preprocessed in close to linear time.
+func New(g graph.Iterator) *Hierarchy {
+	n := g.Order()
+	c := &contractor{
+		out:     make([]map[int]arc, n),
+		in:      make([]map[int]arc, n),
+		deleted: make([]int, n),
+		dist:    make([]int64, n),
+	}
+	for v := 0; v < n; v++ {
+		c.out[v] = make(map[int]arc)
+		c.in[v] = make(map[int]arc)
+		c.dist[v] = -1
+	}
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, cost int64) (skip bool) {
+			if v == w || cost < 0 {
+				return
+			}
+			if a, ok := c.out[v][w]; !ok || cost < a.cost {
+				c.out[v][w] = arc{w, cost, -1}
+				c.in[w][v] = arc{v, cost, -1}
+			}
+			return
+		})
+	}
+
+	h := &Hierarchy{
+		rank: make([]int, n),
+		up:   make([][]arc, n),
+		down: make([][]arc, n),
+	}
+	Q := make(priorityQueue, n)
+	for v := range Q {
+		Q[v] = item{v, c.priority(v)}
+	}
+	heap.Init(&Q)
+	for rank := 0; Q.Len() > 0; {
+		// Lazy update: the priority of v may be outdated.
+		it := heap.Pop(&Q).(item)
+		v := it.vertex
+		if p := c.priority(v); Q.Len() > 0 && p > Q[0].priority {
+			heap.Push(&Q, item{v, p})
+			continue
+		}
+		h.rank[v] = rank
+		rank++
+		h.up[v] = sortedArcs(c.out[v])
+		h.down[v] = sortedArcs(c.in[v])
+		c.contract(v, true)
+	}
+	return h
+}
+
+// sortedArcs returns the arcs of m ordered by vertex.
+func sortedArcs(m map[int]arc) []arc {
+	var arcs []arc
+	for _, a := range m {
+		arcs = append(arcs, a)
+	}
+	sort.Slice(arcs, func(i, j int) bool { return arcs[i].vertex < arcs[j].vertex })
+	return arcs
+}
+
+// contractor holds the remaining graph during preprocessing.
+type contractor struct {
+	out, in []map[int]arc // edges between uncontracted vertices
+	deleted []int         // number of contracted neighbors
+
+	// Workspace for witness searches.
+	dist    []int64
+	touched []int
+}
+
+// priority returns the edge difference of v, the number of shortcuts
+// needed to contract v minus the number of removed edges, adjusted by the
+// number of contracted neighbors to spread contractions evenly.
+func (c *contractor) priority(v int) int64 {
+	shortcuts := c.contract(v, false)
+	return int64(shortcuts - len(c.in[v]) - len(c.out[v]) + c.deleted[v])
+}
+
+// contract removes v from the remaining graph and adds the necessary
+// shortcuts. If apply is false, it only counts the shortcuts.
+func (c *contractor) contract(v int, apply bool) (shortcuts int) {
+	type shortcut struct {
+		from int
+		a    arc
+	}
+	var added []shortcut
+	for u, in := range c.in[v] {
+		maxCost := int64(0)
+		for w, out := range c.out[v] {
+			if w != u && in.cost+out.cost > maxCost {
+				maxCost = in.cost + out.cost
+			}
+		}
+		c.witnessSearch(u, v, maxCost)
+		for w, out := range c.out[v] {
+			if w == u {
+				continue
+			}
+			cost := in.cost + out.cost
+			if d := c.dist[w]; d != -1 && d <= cost {
+				continue // A witness path.
+			}
+			shortcuts++
+			if apply {
+				added = append(added, shortcut{u, arc{w, cost, v}})
+			}
+		}
+		c.clearSearch()
+	}
+	if !apply {
+		return
+	}
+	for u := range c.in[v] {
+		delete(c.out[u], v)
+		c.deleted[u]++
+	}
+	for w := range c.out[v] {
+		delete(c.in[w], v)
+		c.deleted[w]++
+	}
+	for _, s := range added {
+		u, w := s.from, s.a.vertex
+		if a, ok := c.out[u][w]; !ok || s.a.cost < a.cost {
+			c.out[u][w] = s.a
+			c.in[w][u] = arc{u, s.a.cost, v}
+		}
+	}
+	return
+}
+
+// witnessSearch runs Dijkstra's algorithm from u in the remaining
+// graph without v, stopping at distance maxCost.
+func (c *contractor) witnessSearch(u, v int, maxCost int64) {
+	c.dist[u] = 0
+	c.touched = append(c.touched, u)
+	Q := priorityQueue{{u, 0}}
+	for settled := 0; Q.Len() > 0 && settled < witnessLimit; settled++ {
+		it := heap.Pop(&Q).(item)
+		x, d := it.vertex, it.priority
+		if d > c.dist[x] {
+			settled--
+			continue // An outdated entry.
+		}
+		for y, a := range c.out[x] {
+			if y == v {
+				continue
+			}
+			alt := d + a.cost
+			if alt > maxCost {
+				continue
+			}
+			if c.dist[y] == -1 || alt < c.dist[y] {
+				if c.dist[y] == -1 {
+					c.touched = append(c.touched, y)
+				}
+				c.dist[y] = alt
+				heap.Push(&Q, item{y, alt})
+			}
+		}
+	}
+}
+
+func (c *contractor) clearSearch() {
+	for _, x := range c.touched {
+		c.dist[x] = -1
+	}
+	c.touched = c.touched[:0]
+}
+
+type item struct {
+	vertex   int
+	priority int64
+}
+
+type priorityQueue []item
+
+func (q priorityQueue) Len() int            { return len(q) }
+func (q priorityQueue) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q priorityQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *priorityQueue) Push(x interface{}) { *q = append(*q, x.(item)) }
+func (q *priorityQueue) Pop() interface{} {
+	old := *q
+	n := len(old) - 1
+	x := old[n]
+	*q = old[:n]
+	return x
+}
@@ -0,0 +1,237 @@
+package ch
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func diff(res, exp interface{}) (message string, diff bool) {
+	if !reflect.DeepEqual(res, exp) {
+		message = fmt.Sprintf("%v; want %v", res, exp)
+		diff = true
+	}
+	return
+}
+
+// Check that the path from v to w has length dist in g.
+func checkPath(t *testing.T, g *graph.Mutable, v, w int, path []int, dist int64) {
+	if dist == -1 {
+		if len(path) != 0 {
+			t.Errorf("ShortestPath(%d, %d): path %v with dist -1", v, w, path)
+		}
+		return
+	}
+	if len(path) == 0 || path[0] != v || path[len(path)-1] != w {
+		t.Errorf("ShortestPath(%d, %d): path %v", v, w, path)
+		return
+	}
+	length := int64(0)
+	for i := 1; i < len(path); i++ {
+		if !g.Edge(path[i-1], path[i]) {
+			t.Errorf("ShortestPath(%d, %d): no edge (%d, %d) in path %v", v, w, path[i-1], path[i], path)
+			return
+		}
+		length += g.Cost(path[i-1], path[i])
+	}
+	if length != dist {
+		t.Errorf("ShortestPath(%d, %d): length of %v is %d; want %d", v, w, path, length, dist)
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	g := graph.New(6)
+	g.AddBothCost(0, 1, 8) //  0==1--2
+	g.AddBothCost(0, 3, 2) //  |  |  |
+	g.AddBothCost(1, 2, 2) //  3--4==5
+	g.AddBothCost(1, 4, 2) //
+	g.AddBothCost(2, 5, 2) //  -- cost 2
+	g.AddBothCost(3, 4, 2) //  == cost 8
+	g.AddBothCost(4, 5, 8)
+	h := New(g)
+	if mess, diff := diff(h.Order(), 6); diff {
+		t.Errorf("Order %s", mess)
+	}
+	path, dist := h.ShortestPath(0, 5)
+	if mess, diff := diff(path, []int{0, 3, 4, 1, 2, 5}); diff {
+		t.Errorf("ShortestPath->path %s", mess)
+	}
+	if mess, diff := diff(dist, int64(10)); diff {
+		t.Errorf("ShortestPath->dist %s", mess)
+	}
+	path, dist = h.ShortestPath(2, 2)
+	if mess, diff := diff(path, []int{2}); diff {
+		t.Errorf("ShortestPath->path %s", mess)
+	}
+	if mess, diff := diff(dist, int64(0)); diff {
+		t.Errorf("ShortestPath->dist %s", mess)
+	}
+
+	g = graph.New(3)
+	g.AddCost(0, 1, 1)
+	g.AddCost(2, 1, 1)
+	h = New(g)
+	path, dist = h.ShortestPath(0, 2)
+	if mess, diff := diff(path, []int{}); diff {
+		t.Errorf("ShortestPath->path %s", mess)
+	}
+	if mess, diff := diff(dist, int64(-1)); diff {
+		t.Errorf("ShortestPath->dist %s", mess)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	for _, n := range []int{1, 10, 100} {
+		g := graph.New(n)
+		for i := 0; i < 3*n; i++ {
+			g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(10)-1)
+		}
+		h := New(g)
+		for v := 0; v < n; v++ {
+			_, exp := graph.ShortestPaths(g, v)
+			for w := 0; w < n; w++ {
+				path, dist := h.ShortestPath(v, w)
+				if dist != exp[w] {
+					t.Errorf("ShortestPath(%d, %d)->dist %d; want %d", v, w, dist, exp[w])
+				}
+				checkPath(t, g, v, w, path, dist)
+				if d := h.Dist(v, w); d != dist {
+					t.Errorf("Dist(%d, %d) %d; want %d", v, w, d, dist)
+				}
+			}
+		}
+	}
+}
+
+// A grid graph has a natural hierarchy.
+func TestGrid(t *testing.T) {
+	g := grid(30)
+	h := New(g)
+	n := g.Order()
+	for i := 0; i < 100; i++ {
+		v, w := rand.Intn(n), rand.Intn(n)
+		_, exp := graph.ShortestPath(g, v, w)
+		path, dist := h.ShortestPath(v, w)
+		if dist != exp {
+			t.Errorf("ShortestPath(%d, %d)->dist %d; want %d", v, w, dist, exp)
+		}
+		checkPath(t, g, v, w, path, dist)
+	}
+}
+
+func TestReadWrite(t *testing.T) {
+	n := 50
+	g := graph.New(n)
+	for i := 0; i < 3*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(1000))
+	}
+	h := New(g)
+	var buf bytes.Buffer
+	m, err := h.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if mess, diff := diff(m, int64(buf.Len())); diff {
+		t.Errorf("WriteTo %s", mess)
+	}
+	data := buf.Bytes()
+
+	h2 := new(Hierarchy)
+	m, err = h2.ReadFrom(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+	if mess, diff := diff(m, int64(len(data))); diff {
+		t.Errorf("ReadFrom %s", mess)
+	}
+	if mess, diff := diff(h2, h); diff {
+		t.Errorf("ReadFrom %s", mess)
+	}
+
+	for _, bad := range [][]byte{
+		nil,
+		[]byte("not a hierarchy"),
+		data[:len(data)-1],
+		append([]byte(magic), 2),
+	} {
+		if _, err := new(Hierarchy).ReadFrom(bytes.NewReader(bad)); err != ErrFormat {
+			t.Errorf("ReadFrom(%q): %v; want %v", bad, err, ErrFormat)
+		}
+	}
+
+	// The path 0 → 1 → 2, where 1 is contracted first, with a shortcut.
+	h = &Hierarchy{
+		rank: []int{1, 0, 2},
+		up:   [][]arc{{{2, 2, 1}}, {{2, 1, -1}}, nil},
+		down: [][]arc{nil, {{0, 1, -1}}, nil},
+	}
+	for _, test := range []struct {
+		name string
+		h    *Hierarchy
+		err  error
+	}{
+		{"valid", h, nil},
+		{"rank", &Hierarchy{rank: []int{0, 0, 2}, up: h.up, down: h.down}, ErrFormat},
+		{"order", &Hierarchy{rank: []int{2, 0, 1}, up: h.up, down: h.down}, ErrFormat},
+		{"unsorted", &Hierarchy{
+			rank: []int{0, 1, 2},
+			up:   [][]arc{{{2, 1, -1}, {1, 1, -1}}, nil, nil},
+			down: [][]arc{nil, nil, nil},
+		}, ErrFormat},
+		{"via", &Hierarchy{
+			rank: h.rank,
+			up:   [][]arc{{{2, 2, 1}}, nil, nil},
+			down: h.down,
+		}, ErrFormat},
+	} {
+		buf.Reset()
+		test.h.WriteTo(&buf)
+		h2 := new(Hierarchy)
+		if _, err := h2.ReadFrom(&buf); err != test.err {
+			t.Errorf("ReadFrom(%s): %v; want %v", test.name, err, test.err)
+		}
+		if test.err == nil {
+			if path, dist := h2.ShortestPath(0, 2); dist != 2 || len(path) != 3 {
+				t.Errorf("ReadFrom(%s): ShortestPath %v %d", test.name, path, dist)
+			}
+		}
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	b.StopTimer()
+	g := grid(100)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = New(g)
+	}
+}
+
+func BenchmarkShortestPath(b *testing.B) {
+	b.StopTimer()
+	g := grid(100)
+	h := New(g)
+	n := g.Order()
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = h.ShortestPath(rand.Intn(n), rand.Intn(n))
+	}
+}
+
+func grid(m int) *graph.Mutable {
+	g := graph.New(m * m)
+	for r := 0; r < m; r++ {
+		for c := 0; c < m; c++ {
+			if c+1 < m {
+				g.AddBothCost(r*m+c, r*m+c+1, rand.Int63n(100)+1)
+			}
+			if r+1 < m {
+				g.AddBothCost(r*m+c, (r+1)*m+c, rand.Int63n(100)+1)
+			}
+		}
+	}
+	return g
+}
@@ -0,0 +1,133 @@
+package ch
+
+import (
+	"errors"
+	"github.com/yourbasic/graph/internal/binio"
+	"io"
+)
+
+// The binary format starts with a magic string and a version number.
+// All integers that follow are varint encoded:
+//
+//	n
+//	rank[0] … rank[n-1]
+//	for each vertex v: len(up[v]) followed by its arcs
+//	for each vertex v: len(down[v]) followed by its arcs
+//
+// where each arc is encoded as its vertex, cost and via+1.
+const (
+	magic   = "yourbasic/graph/ch"
+	version = 1
+)
+
+// ErrFormat is returned by ReadFrom when the input isn't a hierarchy.
+var ErrFormat = errors.New("ch: invalid format")
+
+// WriteTo writes a binary representation of h to w.
+// It returns the number of bytes written.
+func (h *Hierarchy) WriteTo(w io.Writer) (int64, error) {
+	bw := binio.NewWriter(w)
+	bw.WriteString(magic)
+	bw.WriteUvarint(version)
+	bw.WriteUvarint(uint64(len(h.rank)))
+	for _, r := range h.rank {
+		bw.WriteUvarint(uint64(r))
+	}
+	for _, arcs := range [][][]arc{h.up, h.down} {
+		for _, list := range arcs {
+			bw.WriteUvarint(uint64(len(list)))
+			for _, a := range list {
+				bw.WriteUvarint(uint64(a.vertex))
+				bw.WriteUvarint(uint64(a.cost))
+				bw.WriteUvarint(uint64(a.via + 1))
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadFrom reads a hierarchy written by WriteTo from r, replacing
+// the contents of h. It returns the number of bytes read.
+// If the input is malformed, ReadFrom returns ErrFormat.
+func (h *Hierarchy) ReadFrom(r io.Reader) (int64, error) {
+	br := binio.NewReader(r, ErrFormat)
+	br.ReadHeader(magic, version)
+	n := br.ReadInt(-1)
+	rank := make([]int, 0)
+	for v := 0; v < n && br.Err() == nil; v++ {
+		rank = append(rank, br.ReadInt(n))
+	}
+	var lists [2][][]arc
+	for i := range lists {
+		for v := 0; v < n && br.Err() == nil; v++ {
+			m := br.ReadInt(-1)
+			var list []arc
+			for j := 0; j < m && br.Err() == nil; j++ {
+				w := br.ReadInt(n)
+				cost := int64(br.ReadUvarint())
+				via := br.ReadInt(n+1) - 1
+				if cost < 0 {
+					br.Fail()
+				}
+				list = append(list, arc{w, cost, via})
+			}
+			lists[i] = append(lists[i], list)
+		}
+	}
+	if err := br.Err(); err != nil {
+		return br.Count(), err
+	}
+	if !valid(rank, lists[0], lists[1]) {
+		return br.Count(), ErrFormat
+	}
+	h.rank, h.up, h.down = rank, lists[0], lists[1]
+	return br.Count(), nil
+}
+
+// valid tells if rank is a permutation, if the arc lists are sorted
+// by vertex and lead to vertices of higher rank, and if the two halves
+// of each shortcut exist, as arcs via a vertex of lower rank.
+func valid(rank []int, up, down [][]arc) bool {
+	seen := make([]bool, len(rank))
+	for _, r := range rank {
+		if seen[r] {
+			return false
+		}
+		seen[r] = true
+	}
+	for _, arcs := range [][][]arc{up, down} {
+		for v, list := range arcs {
+			for i, a := range list {
+				if i > 0 && list[i-1].vertex >= a.vertex || rank[a.vertex] <= rank[v] {
+					return false
+				}
+			}
+		}
+	}
+	// A shortcut x → y via u consists of the edges x → u,
+	// stored in down[u], and u → y, stored in up[u].
+	shortcut := func(x, y, u int) bool {
+		if u == -1 {
+			return true
+		}
+		if rank[u] >= rank[x] || rank[u] >= rank[y] {
+			return false
+		}
+		_, ok1 := findArc(down[u], x)
+		_, ok2 := findArc(up[u], y)
+		return ok1 && ok2
+	}
+	for v := range rank {
+		for _, a := range up[v] {
+			if !shortcut(v, a.vertex, a.via) {
+				return false
+			}
+		}
+		for _, a := range down[v] {
+			if !shortcut(a.vertex, v, a.via) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+package ch
+
+import "container/heap"
+
+// ShortestPath computes a shortest path from v to w.
+// The number dist is the length of the path, or -1 if w cannot be reached.
+//
+// The query performs a bidirectional Dijkstra search that only follows
+// edges leading to vertices later in the contraction order.
+func (h *Hierarchy) ShortestPath(v, w int) (path []int, dist int64) {
+	dist, mid, fwd, bwd := h.search(v, w)
+	if dist == -1 {
+		return []int{}, -1
+	}
+
+	// The upward path from v to mid, and the downward path from mid to w.
+	var up, down []int
+	for x := mid; x != v; x = fwd.parent[x] {
+		up = append(up, x)
+	}
+	up = append(up, v)
+	for x := mid; x != w; x = bwd.parent[x] {
+		down = append(down, x)
+	}
+	down = append(down, w)
+
+	path = []int{v}
+	for i := len(up) - 1; i > 0; i-- {
+		path = h.unpack(path, up[i], up[i-1])
+	}
+	for i := 0; i < len(down)-1; i++ {
+		path = h.unpack(path, down[i], down[i+1])
+	}
+	return path, dist
+}
+
+// Dist returns the length of a shortest path from v to w,
+// or -1 if w cannot be reached.
+func (h *Hierarchy) Dist(v, w int) int64 {
+	dist, _, _, _ := h.search(v, w)
+	return dist
+}
+
+// search returns the distance from v to w and a vertex mid on a shortest
+// path with the highest rank, together with the two search spaces.
+func (h *Hierarchy) search(v, w int) (dist int64, mid int, fwd, bwd *searchSpace) {
+	fwd = newSearchSpace(v)
+	bwd = newSearchSpace(w)
+	dist, mid = -1, -1
+	update := func(x int) {
+		df, okf := fwd.dist[x]
+		db, okb := bwd.dist[x]
+		if okf && okb && (dist == -1 || df+db < dist) {
+			dist, mid = df+db, x
+		}
+	}
+	update(v)
+	// Alternate between the two searches. A search stops when
+	// its smallest tentative distance is no better than dist.
+	for fwd.active(dist) || bwd.active(dist) {
+		if fwd.active(dist) {
+			update(fwd.step(h.up))
+		}
+		if bwd.active(dist) {
+			update(bwd.step(h.down))
+		}
+	}
+	return
+}
+
+// unpack appends the path represented by the edge (v, w) to path,
+// not including v.
+func (h *Hierarchy) unpack(path []int, v, w int) []int {
+	type edge struct{ v, w int }
+	for stack := []edge{{v, w}}; len(stack) > 0; {
+		n := len(stack) - 1
+		e := stack[n]
+		stack = stack[:n]
+		via := h.via(e.v, e.w)
+		if via == -1 {
+			path = append(path, e.w)
+			continue
+		}
+		// Process (e.v, via) before (via, e.w).
+		stack = append(stack, edge{via, e.w}, edge{e.v, via})
+	}
+	return path
+}
+
+// via returns the contracted vertex of the edge (v, w),
+// or -1 if it's an original edge.
+func (h *Hierarchy) via(v, w int) int {
+	var a arc
+	if h.rank[v] < h.rank[w] {
+		a, _ = findArc(h.up[v], w)
+	} else {
+		a, _ = findArc(h.down[w], v)
+	}
+	return a.via
+}
+
+// findArc returns the arc to v in a list sorted by vertex.
+// If there is no such arc, ok is false and a.via is -1.
+func findArc(arcs []arc, v int) (a arc, ok bool) {
+	i, j := 0, len(arcs)
+	for i < j {
+		m := int(uint(i+j) >> 1)
+		if arcs[m].vertex < v {
+			i = m + 1
+		} else {
+			j = m
+		}
+	}
+	if i == len(arcs) || arcs[i].vertex != v {
+		return arc{vertex: v, via: -1}, false
+	}
+	return arcs[i], true
+}
+
+// searchSpace holds the state of one direction of a query.
+// Maps are used since a search only visits a small part of the graph.
+type searchSpace struct {
+	dist   map[int]int64
+	parent map[int]int
+	queue  priorityQueue
+}
+
+func newSearchSpace(v int) *searchSpace {
+	return &searchSpace{
+		dist:   map[int]int64{v: 0},
+		parent: map[int]int{v: -1},
+		queue:  priorityQueue{{v, 0}},
+	}
+}
+
+// active tells if the search may still find a path shorter than dist.
+func (s *searchSpace) active(dist int64) bool {
+	return s.queue.Len() > 0 && (dist == -1 || s.queue[0].priority < dist)
+}
+
+// step settles a vertex, relaxes its edges in arcs, and returns the vertex.
+func (s *searchSpace) step(arcs [][]arc) int {
+	it := heap.Pop(&s.queue).(item)
+	v, d := it.vertex, it.priority
+	if d > s.dist[v] {
+		return v // An outdated entry.
+	}
+	for _, a := range arcs[v] {
+		alt := d + a.cost
+		if dw, ok := s.dist[a.vertex]; !ok || alt < dw {
+			s.dist[a.vertex], s.parent[a.vertex] = alt, v
+			heap.Push(&s.queue, item{a.vertex, alt})
+		}
+	}
+	return v
+}
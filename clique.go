@@ -0,0 +1,160 @@
+package graph
+
+import (
+	"iter"
+	"sort"
+)
+
+// Cliques returns an iterator over the maximal cliques of g: the sets of
+// pairwise adjacent vertices that aren't contained in a larger such set.
+// The graph is treated as undirected, as in the coloring functions.
+// Each clique is a new slice, sorted in increasing order.
+//
+// The implementation uses the Bron–Kerbosch algorithm with pivoting,
+// with the outer level in degeneracy order. The time complexity is
+// O(d⋅|V|⋅3^(d/3)), where d is the degeneracy of g and |V| the number
+// of vertices in the graph.
+func Cliques(g Iterator) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		adj := undirected(g)
+		b := &bronKerbosch{adj: adj, yield: yield}
+		pos := make([]int, len(adj))
+		order, _ := degeneracyOrder(adj)
+		for i, v := range order {
+			pos[v] = i
+		}
+		for _, v := range order {
+			var p, x []int
+			for _, w := range adj[v] {
+				if pos[w] > pos[v] {
+					p = append(p, w)
+				} else {
+					x = append(x, w)
+				}
+			}
+			if !b.search([]int{v}, p, x) {
+				return
+			}
+		}
+	}
+}
+
+// degeneracyOrder returns the vertices in an order where each vertex
+// has the fewest possible neighbors later in the order, by repeatedly
+// removing a vertex of smallest degree, and the core number of each
+// vertex: the largest degree of a removed vertex up to and including it.
+func degeneracyOrder(adj [][]int) (order, core []int) {
+	n := len(adj)
+	degree := make([]int, n)
+	core = make([]int, n)
+	// bucket[d] holds the vertices with degree d, and removed
+	// vertices or outdated entries are skipped.
+	var bucket [][]int
+	for v, list := range adj {
+		d := len(list)
+		degree[v] = d
+		for len(bucket) <= d {
+			bucket = append(bucket, nil)
+		}
+		bucket[d] = append(bucket[d], v)
+	}
+	removed := make([]bool, n)
+	order = make([]int, 0, n)
+	k := 0
+	for d := 0; len(order) < n; {
+		if len(bucket[d]) == 0 {
+			d++
+			continue
+		}
+		m := len(bucket[d]) - 1
+		v := bucket[d][m]
+		bucket[d] = bucket[d][:m]
+		if removed[v] || degree[v] != d {
+			continue
+		}
+		removed[v] = true
+		order = append(order, v)
+		if d > k {
+			k = d
+		}
+		core[v] = k
+		for _, w := range adj[v] {
+			if !removed[w] {
+				degree[w]--
+				bucket[degree[w]] = append(bucket[degree[w]], w)
+				if degree[w] < d {
+					d = degree[w]
+				}
+			}
+		}
+	}
+	return
+}
+
+type bronKerbosch struct {
+	adj   [][]int
+	yield func([]int) bool
+}
+
+// search reports all maximal cliques that contain r, some vertices of p,
+// and no vertices of x. It returns false if the iteration was stopped.
+func (b *bronKerbosch) search(r, p, x []int) bool {
+	if len(p) == 0 {
+		if len(x) > 0 {
+			return true
+		}
+		clique := append([]int{}, r...)
+		sort.Ints(clique)
+		return b.yield(clique)
+	}
+	// Choose a pivot u that maximizes |p ∩ N(u)|;
+	// only vertices in p \ N(u) need to be tried.
+	u, most := -1, -1
+	for _, list := range [][]int{p, x} {
+		for _, w := range list {
+			if k := len(intersect(p, b.adj[w])); k > most {
+				u, most = w, k
+			}
+		}
+	}
+	for _, v := range append([]int{}, p...) {
+		if inSorted(b.adj[u], v) {
+			continue
+		}
+		nv := b.adj[v]
+		if !b.search(append(r, v), intersect(p, nv), intersect(x, nv)) {
+			return false
+		}
+		p = without(p, v)
+		x = append(x, v)
+	}
+	return true
+}
+
+// intersect returns the elements of a that are in the sorted slice s.
+func intersect(a, s []int) []int {
+	res := []int{}
+	for _, v := range a {
+		if inSorted(s, v) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// inSorted tells if the sorted slice s contains v.
+func inSorted(s []int, v int) bool {
+	i := sort.SearchInts(s, v)
+	return i < len(s) && s[i] == v
+}
+
+// without returns a copy of a with the first occurrence of v removed.
+func without(a []int, v int) []int {
+	for i, w := range a {
+		if w == v {
+			res := append([]int{}, a[:i]...)
+			return append(res, a[i+1:]...)
+		}
+	}
+	return a
+}
@@ -0,0 +1,130 @@
+package graph
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestCliques(t *testing.T) {
+	var res [][]int
+	for c := range Cliques(New(0)) {
+		res = append(res, c)
+	}
+	if mess, diff := diff(len(res), 0); diff {
+		t.Errorf("Cliques %s", mess)
+	}
+
+	// 0 — 1 — 2 — 4, a triangle 1, 2, 3 and an isolated vertex 5,
+	// with one edge in a single direction.
+	g := New(6)
+	g.AddBoth(0, 1)
+	g.AddBoth(1, 2)
+	g.Add(3, 1)
+	g.AddBoth(2, 3)
+	g.AddBoth(2, 4)
+	g.Add(3, 3)
+	res = nil
+	for c := range Cliques(g) {
+		res = append(res, c)
+	}
+	sortCliques(res)
+	exp := [][]int{{0, 1}, {1, 2, 3}, {2, 4}, {5}}
+	if mess, diff := diff(res, exp); diff {
+		t.Errorf("Cliques %s", mess)
+	}
+
+	// Stop early.
+	count := 0
+	for range Cliques(g) {
+		count++
+		break
+	}
+	if mess, diff := diff(count, 1); diff {
+		t.Errorf("Cliques %s", mess)
+	}
+}
+
+func TestCliquesRandom(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		n := 1 + rand.Intn(12)
+		g := New(n)
+		for m := rand.Intn(n*n/2 + 1); m > 0; m-- {
+			g.AddBoth(rand.Intn(n), rand.Intn(n))
+		}
+		var res [][]int
+		for c := range Cliques(g) {
+			res = append(res, c)
+		}
+		sortCliques(res)
+		if mess, diff := diff(res, bruteCliques(g)); diff {
+			t.Errorf("Cliques %s", mess)
+		}
+	}
+}
+
+// bruteCliques returns the maximal cliques of g by trying all subsets.
+func bruteCliques(g Iterator) [][]int {
+	n := g.Order()
+	adj := undirected(g)
+	isClique := func(set int) bool {
+		for v := 0; v < n; v++ {
+			for w := v + 1; w < n; w++ {
+				if set&(1<<v) != 0 && set&(1<<w) != 0 && !inSorted(adj[v], w) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	res := [][]int{}
+	for set := 1; set < 1<<n; set++ {
+		if !isClique(set) {
+			continue
+		}
+		maximal := true
+		for v := 0; v < n; v++ {
+			if set&(1<<v) == 0 && isClique(set|1<<v) {
+				maximal = false
+			}
+		}
+		if !maximal {
+			continue
+		}
+		var c []int
+		for v := 0; v < n; v++ {
+			if set&(1<<v) != 0 {
+				c = append(c, v)
+			}
+		}
+		res = append(res, c)
+	}
+	sortCliques(res)
+	return res
+}
+
+func sortCliques(cliques [][]int) {
+	sort.Slice(cliques, func(i, j int) bool {
+		a, b := cliques[i], cliques[j]
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+}
+
+func BenchmarkCliques(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 10*n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		for range Cliques(g) {
+		}
+	}
+}
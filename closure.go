@@ -0,0 +1,193 @@
+package graph
+
+import "sort"
+
+// Reachability is the transitive closure of a graph,
+// which answers reachability queries in logarithmic or constant time.
+type Reachability struct {
+	comp []int // comp[v] is the strongly connected component of v
+
+	// The closure uses one of two representations of the
+	// components reachable from a component c:
+	//
+	//  • labels[c] is a sorted list of disjoint intervals that contain
+	//    the postorder numbers post[d] of the components d reachable from c;
+	//
+	//  • rows[c] is a bitset of the components reachable from c.
+	//
+	// The intervals are compact when the condensation of the graph
+	// is close to a tree, while the bitsets are better for dense graphs.
+	post   []int
+	labels [][]interval
+	rows   [][]uint64
+}
+
+type interval struct{ lo, hi int }
+
+// TransitiveClosure computes the reachability relation of g.
+//
+// The closure is computed on the condensation of g, the acyclic graph of
+// its strongly connected components. Each component is labeled by a list
+// of intervals of postorder numbers from a depth-first search; if these
+// lists become larger than a bit matrix, a bit matrix is used instead.
+// The time complexity is O(|E|⋅|V|/64) in the worst case, where |E| is
+// the number of edges and |V| the number of vertices in the graph,
+// and close to linear for graphs whose condensation resembles a tree.
+func TransitiveClosure(g Iterator) *Reachability {
+	n := g.Order()
+	components := StrongComponents(g)
+	m := len(components)
+	r := &Reachability{comp: make([]int, n)}
+	for c, vertices := range components {
+		for _, v := range vertices {
+			r.comp[v] = c
+		}
+	}
+	succ := make([][]int, m)
+	for c, vertices := range components {
+		seen := map[int]bool{c: true}
+		for _, v := range vertices {
+			g.Visit(v, func(w int, _ int64) (skip bool) {
+				if d := r.comp[w]; !seen[d] {
+					seen[d] = true
+					succ[c] = append(succ[c], d)
+				}
+				return
+			})
+		}
+	}
+
+	// Number the components in postorder. The components of a DFS subtree
+	// rooted at c get the numbers low[c] to post[c].
+	r.post = make([]int, m)
+	low := make([]int, m)
+	visited := make([]bool, m)
+	order := make([]int, 0, m) // components in postorder
+	type frame struct{ c, next int }
+	for root := 0; root < m; root++ {
+		if visited[root] {
+			continue
+		}
+		visited[root] = true
+		low[root] = len(order)
+		stack := []frame{{root, 0}}
+		for len(stack) > 0 {
+			f := &stack[len(stack)-1]
+			if f.next < len(succ[f.c]) {
+				d := succ[f.c][f.next]
+				f.next++
+				if !visited[d] {
+					visited[d] = true
+					low[d] = len(order)
+					stack = append(stack, frame{d, 0})
+				}
+				continue
+			}
+			r.post[f.c] = len(order)
+			order = append(order, f.c)
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	// In an acyclic graph, all successors of c come before c in postorder.
+	budget := m * ((m + 63) / 64) // words in a bit matrix
+	r.labels = make([][]interval, m)
+	for _, c := range order {
+		list := []interval{{low[c], r.post[c]}}
+		for _, d := range succ[c] {
+			list = append(list, r.labels[d]...)
+		}
+		r.labels[c] = mergeIntervals(list)
+		if budget -= 2 * len(r.labels[c]); budget < 0 {
+			r.buildRows(order, succ)
+			break
+		}
+	}
+	return r
+}
+
+func (r *Reachability) buildRows(order []int, succ [][]int) {
+	m := len(order)
+	r.labels, r.post = nil, nil
+	r.rows = make([][]uint64, m)
+	for _, c := range order {
+		row := make([]uint64, (m+63)/64)
+		row[c/64] |= 1 << uint(c%64)
+		for _, d := range succ[c] {
+			for i, x := range r.rows[d] {
+				row[i] |= x
+			}
+		}
+		r.rows[c] = row
+	}
+}
+
+// mergeIntervals sorts a list of intervals and merges
+// overlapping and adjacent intervals.
+func mergeIntervals(list []interval) []interval {
+	sort.Slice(list, func(i, j int) bool { return list[i].lo < list[j].lo })
+	res := list[:1]
+	for _, in := range list[1:] {
+		last := &res[len(res)-1]
+		if in.lo <= last.hi+1 {
+			if in.hi > last.hi {
+				last.hi = in.hi
+			}
+			continue
+		}
+		res = append(res, in)
+	}
+	return append([]interval(nil), res...)
+}
+
+// Reachable tells if there is a path from v to w.
+// A vertex is always reachable from itself.
+func (r *Reachability) Reachable(v, w int) bool {
+	c, d := r.comp[v], r.comp[w]
+	if r.rows != nil {
+		return r.rows[c][d/64]&(1<<uint(d%64)) != 0
+	}
+	p := r.post[d]
+	list := r.labels[c]
+	i := sort.Search(len(list), func(i int) bool { return p <= list[i].hi })
+	return i < len(list) && list[i].lo <= p
+}
+
+// TransitiveReduction returns the transitive reduction of a directed
+// acyclic graph: the graph with the fewest edges that has the same
+// reachability relation as g. An edge from v to w is kept, with its cost,
+// unless there is another path from v to w.
+// If g isn't acyclic, it returns nil and sets ok to false.
+// If g is a multigraph, any duplicate edges will be lost.
+func TransitiveReduction(g Iterator) (reduction *Mutable, ok bool) {
+	if !Acyclic(g) {
+		return
+	}
+	r := TransitiveClosure(g)
+	n := g.Order()
+	reduction = New(n)
+	for v := 0; v < n; v++ {
+		var succ []int
+		cost := make(map[int]int64)
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if _, ok := cost[w]; !ok {
+				succ = append(succ, w)
+			}
+			cost[w] = c
+			return
+		})
+		for _, w := range succ {
+			redundant := false
+			for _, x := range succ {
+				if x != w && r.Reachable(x, w) {
+					redundant = true
+					break
+				}
+			}
+			if !redundant {
+				reduction.AddCost(v, w, cost[w])
+			}
+		}
+	}
+	return reduction, true
+}
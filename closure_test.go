@@ -0,0 +1,112 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestTransitiveClosure(t *testing.T) {
+	g := New(6)
+	g.Add(0, 1) // 0 -> 1 <-> 2 -> 3
+	g.Add(1, 2) //           |
+	g.Add(2, 1) //           v
+	g.Add(2, 3) //      4 -> 5
+	g.Add(2, 5)
+	g.Add(4, 5)
+	r := TransitiveClosure(g)
+	exp := [][]bool{
+		{true, true, true, true, false, true},
+		{false, true, true, true, false, true},
+		{false, true, true, true, false, true},
+		{false, false, false, true, false, false},
+		{false, false, false, false, true, true},
+		{false, false, false, false, false, true},
+	}
+	for v := range exp {
+		for w, reach := range exp[v] {
+			if r.Reachable(v, w) != reach {
+				t.Errorf("Reachable(%d, %d) %t; want %t", v, w, !reach, reach)
+			}
+		}
+	}
+}
+
+func TestTransitiveClosureRandom(t *testing.T) {
+	// A tree with a few extra edges is labeled by intervals.
+	n := 1000
+	g := New(n)
+	for w := 1; w < n; w++ {
+		g.Add(rand.Intn(w), w)
+	}
+	for i := 0; i < 10; i++ {
+		g.Add(rand.Intn(n), rand.Intn(n))
+	}
+	r := TransitiveClosure(g)
+	if r.labels == nil {
+		t.Errorf("TransitiveClosure: no interval labels for a tree")
+	}
+	checkClosure(t, g, r)
+
+	for _, density := range []int{1, 3, 20} {
+		n := 100
+		g := New(n)
+		for i := 0; i < density*n; i++ {
+			v, w := rand.Intn(n), rand.Intn(n)
+			if density < 20 || v < w {
+				g.Add(v, w)
+			}
+		}
+		checkClosure(t, g, TransitiveClosure(g))
+	}
+}
+
+func checkClosure(t *testing.T, g Iterator, r *Reachability) {
+	n := g.Order()
+	for v := 0; v < n; v++ {
+		reach := make([]bool, n)
+		BFS(g, v, func(_, w int, _ int64) { reach[w] = true })
+		reach[v] = true
+		for w := 0; w < n; w++ {
+			if r.Reachable(v, w) != reach[w] {
+				t.Errorf("Reachable(%d, %d) %t; want %t", v, w, !reach[w], reach[w])
+			}
+		}
+	}
+}
+
+func TestTransitiveReduction(t *testing.T) {
+	g := New(5)
+	g.AddCost(0, 1, 1)
+	g.AddCost(0, 2, 2)
+	g.AddCost(0, 3, 3)
+	g.AddCost(1, 3, 4)
+	g.AddCost(2, 3, 5)
+	g.AddCost(3, 4, 6)
+	g.AddCost(0, 4, 7)
+	res, ok := TransitiveReduction(g)
+	if mess, diff := diff(res.String(), "5 [(0 1):1 (0 2):2 (1 3):4 (2 3):5 (3 4):6]"); diff {
+		t.Errorf("TransitiveReduction %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("TransitiveReduction->ok %s", mess)
+	}
+
+	g.Add(4, 0)
+	res, ok = TransitiveReduction(g)
+	if res != nil || ok {
+		t.Errorf("TransitiveReduction: (%v, %t); want (<nil>, false)", res, ok)
+	}
+}
+
+func BenchmarkTransitiveClosure(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 3*n; i++ {
+		g.Add(rand.Intn(n), rand.Intn(n))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = TransitiveClosure(g)
+	}
+}
@@ -0,0 +1,261 @@
+package graph
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// The coloring functions treat g as an undirected graph: two vertices
+// joined by an edge in either direction get different colors.
+// Self-loops are ignored. The colors are numbered from 0,
+// and the number k is the number of colors used,
+// an upper bound on the chromatic number of g.
+
+// GreedyColoring computes a vertex coloring of g with the Welsh–Powell
+// heuristic: it visits the vertices in order of decreasing degree,
+// and gives each vertex the smallest color not used by its neighbors.
+// The number color[v] is the color of v.
+//
+// The time complexity is O(|E| + |V|⋅log|V|), where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func GreedyColoring(g Iterator) (color []int, k int) {
+	adj := undirected(g)
+	n := len(adj)
+	order := make([]int, n)
+	for v := range order {
+		order[v] = v
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(adj[order[i]]) > len(adj[order[j]])
+	})
+	color = make([]int, n)
+	for v := range color {
+		color[v] = -1
+	}
+	// used[c] == v+1 if color c is used by a neighbor of v.
+	used := make([]int, n+1)
+	for _, v := range order {
+		for _, w := range adj[v] {
+			if c := color[w]; c != -1 {
+				used[c] = v + 1
+			}
+		}
+		c := 0
+		for used[c] == v+1 {
+			c++
+		}
+		color[v] = c
+		if c == k {
+			k++
+		}
+	}
+	return
+}
+
+// DSaturColoring computes a vertex coloring of g with the DSATUR
+// heuristic of Brélaz: it repeatedly colors the vertex with the most
+// differently colored neighbors, breaking ties by degree, using the
+// smallest color not used by its neighbors. It usually needs fewer
+// colors than GreedyColoring. The number color[v] is the color of v.
+//
+// The time complexity is O((|E| + |V|)⋅log|V|), where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func DSaturColoring(g Iterator) (color []int, k int) {
+	return dsatur(undirected(g))
+}
+
+// ExactColoring computes a vertex coloring of g with the smallest
+// possible number of colors, the chromatic number of g, by branch and
+// bound. The number color[v] is the color of v.
+//
+// The search takes exponential time in the worst case. If g has more
+// than cutoff vertices, ExactColoring returns the coloring computed by
+// DSaturColoring instead and sets exact to false.
+func ExactColoring(g Iterator, cutoff int) (color []int, k int, exact bool) {
+	adj := undirected(g)
+	color, k = dsatur(adj)
+	if len(adj) > cutoff {
+		return color, k, false
+	}
+	n := len(adj)
+	s := &colorSearch{
+		adj:   adj,
+		color: make([]int, n),
+		count: make([][]int, n),
+		sat:   make([]int, n),
+		best:  color,
+		k:     k,
+	}
+	for v := range s.color {
+		s.color[v] = -1
+		s.count[v] = make([]int, k)
+	}
+	s.search(0, 0)
+	return s.best, s.k, true
+}
+
+// undirected returns the neighbors of each vertex in g, in both
+// directions and without self-loops, sorted and without duplicates.
+// The lists are sorted in linear time: since the neighbor relation
+// is symmetric, appending v to the list of each neighbor w,
+// for v in increasing order, gives sorted lists.
+func undirected(g Iterator) [][]int {
+	n := g.Order()
+	both := make([][]int, n)
+	for v := range both {
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			if v != w {
+				both[v] = append(both[v], w)
+				both[w] = append(both[w], v)
+			}
+			return
+		})
+	}
+	adj := make([][]int, n)
+	for v, list := range both {
+		for _, w := range list {
+			if l := len(adj[w]); l == 0 || adj[w][l-1] != v {
+				adj[w] = append(adj[w], v)
+			}
+		}
+	}
+	return adj
+}
+
+func dsatur(adj [][]int) (color []int, k int) {
+	n := len(adj)
+	color = make([]int, n)
+	// seen[v] holds the colors of the neighbors of v.
+	seen := make([]map[int]bool, n)
+	q := make(satHeap, n)
+	for v := range color {
+		color[v] = -1
+		q[v] = satItem{v, 0, len(adj[v])}
+	}
+	heap.Init(&q)
+	used := make([]int, n+1)
+	for q.Len() > 0 {
+		it := heap.Pop(&q).(satItem)
+		v := it.v
+		if color[v] != -1 || it.sat != len(seen[v]) {
+			continue // An outdated entry.
+		}
+		for _, w := range adj[v] {
+			if c := color[w]; c != -1 {
+				used[c] = v + 1
+			}
+		}
+		c := 0
+		for used[c] == v+1 {
+			c++
+		}
+		color[v] = c
+		if c == k {
+			k++
+		}
+		for _, w := range adj[v] {
+			if color[w] != -1 || seen[w][c] {
+				continue
+			}
+			if seen[w] == nil {
+				seen[w] = make(map[int]bool)
+			}
+			seen[w][c] = true
+			heap.Push(&q, satItem{w, len(seen[w]), len(adj[w])})
+		}
+	}
+	return
+}
+
+// satItem is a vertex with its saturation and degree.
+type satItem struct {
+	v, sat, degree int
+}
+
+// satHeap is a max-heap of vertices ordered by saturation,
+// then by degree, then by smallest vertex.
+type satHeap []satItem
+
+func (h satHeap) Len() int { return len(h) }
+func (h satHeap) Less(i, j int) bool {
+	a, b := h[i], h[j]
+	if a.sat != b.sat {
+		return a.sat > b.sat
+	}
+	if a.degree != b.degree {
+		return a.degree > b.degree
+	}
+	return a.v < b.v
+}
+func (h satHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *satHeap) Push(x interface{}) { *h = append(*h, x.(satItem)) }
+func (h *satHeap) Pop() interface{} {
+	n := len(*h) - 1
+	x := (*h)[n]
+	*h = (*h)[:n]
+	return x
+}
+
+// colorSearch holds the state of the branch and bound search
+// in ExactColoring.
+type colorSearch struct {
+	adj   [][]int
+	color []int
+	count [][]int // count[v][c] is the number of neighbors of v with color c.
+	sat   []int   // The number of distinct colors of the neighbors of each vertex.
+	best  []int   // The best coloring found so far, with k colors.
+	k     int
+}
+
+// search extends the partial coloring, where colored vertices
+// use the colors 0 to used-1, to colorings with fewer than k colors.
+func (s *colorSearch) search(colored, used int) {
+	if used >= s.k {
+		return
+	}
+	if colored == len(s.adj) {
+		s.best = append([]int{}, s.color...)
+		s.k = used
+		return
+	}
+	// Branch on the uncolored vertex with the largest saturation.
+	v := -1
+	for w, c := range s.color {
+		if c != -1 {
+			continue
+		}
+		if v == -1 || s.sat[w] > s.sat[v] || s.sat[w] == s.sat[v] && len(s.adj[w]) > len(s.adj[v]) {
+			v = w
+		}
+	}
+	for c := 0; c <= used && c < s.k-1; c++ {
+		if c < used && s.count[v][c] > 0 {
+			continue
+		}
+		s.set(v, c, 1)
+		if c == used {
+			s.search(colored+1, used+1)
+		} else {
+			s.search(colored+1, used)
+		}
+		s.set(v, c, -1)
+	}
+}
+
+// set colors v with c if delta is 1, and removes the color if delta is -1.
+func (s *colorSearch) set(v, c, delta int) {
+	if delta == 1 {
+		s.color[v] = c
+	} else {
+		s.color[v] = -1
+	}
+	for _, w := range s.adj[v] {
+		if delta == 1 && s.count[w][c] == 0 {
+			s.sat[w]++
+		}
+		s.count[w][c] += delta
+		if delta == -1 && s.count[w][c] == 0 {
+			s.sat[w]--
+		}
+	}
+}
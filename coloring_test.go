@@ -0,0 +1,192 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkColoring checks that color is a proper coloring of g with k colors.
+func checkColoring(t *testing.T, name string, g Iterator, color []int, k int) {
+	if len(color) != g.Order() {
+		t.Errorf("%s: %d colors for %d vertices", name, len(color), g.Order())
+		return
+	}
+	used := make(map[int]bool)
+	for v, c := range color {
+		if c < 0 || c >= k {
+			t.Errorf("%s: color[%d] = %d with k = %d", name, v, c, k)
+		}
+		used[c] = true
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			if v != w && color[w] == c {
+				t.Errorf("%s: %d and %d both have color %d", name, v, w, c)
+			}
+			return
+		})
+	}
+	if len(used) != k {
+		t.Errorf("%s: %d colors used; k = %d", name, len(used), k)
+	}
+}
+
+var colorings = []struct {
+	name string
+	f    func(Iterator) ([]int, int)
+}{
+	{"GreedyColoring", GreedyColoring},
+	{"DSaturColoring", DSaturColoring},
+	{"ExactColoring", func(g Iterator) ([]int, int) {
+		color, k, _ := ExactColoring(g, 20)
+		return color, k
+	}},
+}
+
+func TestColoring(t *testing.T) {
+	for _, alg := range colorings {
+		color, k := alg.f(New(0))
+		if mess, diff := diff(color, []int{}); diff {
+			t.Errorf("%s->color %s", alg.name, mess)
+		}
+		if mess, diff := diff(k, 0); diff {
+			t.Errorf("%s->k %s", alg.name, mess)
+		}
+
+		g := New(3)
+		g.Add(0, 0)
+		color, k = alg.f(g)
+		if mess, diff := diff(color, []int{0, 0, 0}); diff {
+			t.Errorf("%s->color %s", alg.name, mess)
+		}
+		if mess, diff := diff(k, 1); diff {
+			t.Errorf("%s->k %s", alg.name, mess)
+		}
+
+		// A directed odd cycle needs three colors.
+		g = New(5)
+		for v := 0; v < 5; v++ {
+			g.Add(v, (v+1)%5)
+		}
+		color, k = alg.f(g)
+		checkColoring(t, alg.name, g, color, k)
+		if mess, diff := diff(k, 3); diff {
+			t.Errorf("%s->k %s", alg.name, mess)
+		}
+
+		// A bipartite graph.
+		g = New(6)
+		g.AddBoth(0, 3)
+		g.AddBoth(0, 4)
+		g.AddBoth(1, 4)
+		g.AddBoth(1, 5)
+		g.AddBoth(2, 5)
+		color, k = alg.f(g)
+		checkColoring(t, alg.name, g, color, k)
+		if mess, diff := diff(k, 2); diff {
+			t.Errorf("%s->k %s", alg.name, mess)
+		}
+	}
+
+	// A crown graph: greedy coloring in the order 0, 1, 2, ...
+	// would need n colors, but Welsh–Powell ties among equal degrees.
+	g := New(8)
+	for v := 0; v < 4; v++ {
+		for w := 0; w < 4; w++ {
+			if v != w {
+				g.AddBoth(2*v, 2*w+1)
+			}
+		}
+	}
+	color, k, exact := ExactColoring(g, 20)
+	checkColoring(t, "ExactColoring", g, color, k)
+	if mess, diff := diff(k, 2); diff {
+		t.Errorf("ExactColoring->k %s", mess)
+	}
+	if mess, diff := diff(exact, true); diff {
+		t.Errorf("ExactColoring->exact %s", mess)
+	}
+	_, _, exact = ExactColoring(g, 7)
+	if mess, diff := diff(exact, false); diff {
+		t.Errorf("ExactColoring->exact %s", mess)
+	}
+}
+
+func TestColoringRandom(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		n := 1 + rand.Intn(9)
+		g := New(n)
+		for m := rand.Intn(2*n*n/3 + 1); m > 0; m-- {
+			g.Add(rand.Intn(n), rand.Intn(n))
+		}
+		chi := bruteChromatic(g)
+		for _, alg := range colorings {
+			color, k := alg.f(g)
+			checkColoring(t, alg.name, g, color, k)
+			if k < chi {
+				t.Errorf("%s->k %d; chromatic number %d", alg.name, k, chi)
+			}
+			if alg.name == "ExactColoring" && k != chi {
+				t.Errorf("%s->k %d; want %d", alg.name, k, chi)
+			}
+		}
+	}
+}
+
+// bruteChromatic returns the chromatic number of g
+// by trying all colorings.
+func bruteChromatic(g Iterator) int {
+	n := g.Order()
+	adj := undirected(g)
+	color := make([]int, n)
+	for k := 1; ; k++ {
+		var try func(v int) bool
+		try = func(v int) bool {
+			if v == n {
+				return true
+			}
+			for c := 0; c < k; c++ {
+				ok := true
+				for _, w := range adj[v] {
+					if w < v && color[w] == c {
+						ok = false
+					}
+				}
+				if ok {
+					color[v] = c
+					if try(v + 1) {
+						return true
+					}
+				}
+			}
+			return false
+		}
+		if n == 0 || try(0) {
+			return k
+		}
+	}
+}
+
+func BenchmarkDSaturColoring(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 5*n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = DSaturColoring(g)
+	}
+}
+
+func BenchmarkExactColoring(b *testing.B) {
+	n := 40
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 3*n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = ExactColoring(g, n)
+	}
+}
@@ -0,0 +1,239 @@
+// Package community implements community detection: algorithms that
+// partition the vertices of a graph into groups that are more densely
+// connected internally than with the rest of the graph.
+//
+// Louvain and Leiden find a partition with high modularity.
+// The Leiden algorithm is somewhat slower, but it guarantees that
+// each community is connected, which the Louvain algorithm doesn't.
+// LabelPropagation is a fast alternative when a lower quality is acceptable.
+//
+// The graphs are undirected: an undirected edge {v, w} is represented by
+// the two directed edges (v, w) and (w, v), as in the graph package.
+package community
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+)
+
+// Options configure the community detection algorithms.
+// A nil *Options is the same as the zero value.
+type Options struct {
+	// Resolution is the resolution parameter γ of the modularity.
+	// Higher values give more and smaller communities.
+	// If Resolution is 0, the value 1 is used.
+	Resolution float64
+
+	// Weighted tells if the cost of an edge is its weight;
+	// otherwise each edge has weight 1. The costs must be non-negative.
+	Weighted bool
+
+	// Seed initializes the random order in which the vertices are visited.
+	Seed int64
+}
+
+func (opts *Options) resolution() float64 {
+	if opts.Resolution == 0 {
+		return 1
+	}
+	return opts.Resolution
+}
+
+// Modularity returns the modularity of the partition of g's vertices
+// given by comm, where comm[v] is the community of v:
+//
+//	Q = 1/2m ⋅ Σ (A[v][w] - γ⋅k[v]⋅k[w]/2m),
+//
+// where the sum is over all pairs v, w in the same community,
+// A[v][w] is the weight of the edge (v, w), k[v] the total weight of
+// the edges leaving v, 2m the total weight of all edges, and γ the
+// resolution. A self-loop counts once. If opts is nil, the default
+// options are used.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func Modularity(g graph.Iterator, comm []int, opts *Options) float64 {
+	if opts == nil {
+		opts = new(Options)
+	}
+	nw := newNetwork(g, opts.Weighted)
+	return nw.modularity(comm, opts.resolution())
+}
+
+// network is an undirected graph with float64 edge weights,
+// where each vertex may stand for a set of aggregated vertices.
+type network struct {
+	adj [][]arc
+	k   []float64 // The total weight of the arcs leaving each vertex.
+	m2  float64   // The total weight of all arcs.
+}
+
+type arc struct {
+	to int
+	w  float64
+}
+
+func newNetwork(g graph.Iterator, weighted bool) *network {
+	n := g.Order()
+	nw := &network{adj: make([][]arc, n), k: make([]float64, n)}
+	for v := range nw.adj {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			x := 1.0
+			if weighted {
+				x = float64(c)
+			}
+			nw.adj[v] = append(nw.adj[v], arc{w, x})
+			nw.k[v] += x
+			nw.m2 += x
+			return
+		})
+	}
+	return nw
+}
+
+// aggregate returns the network whose vertices are the count parts
+// of the partition part of nw's vertices.
+func (nw *network) aggregate(part []int, count int) *network {
+	agg := &network{adj: make([][]arc, count), k: make([]float64, count), m2: nw.m2}
+	index := make([]int, count) // The position of each arc in agg.adj[p], or -1.
+	for p := range index {
+		index[p] = -1
+	}
+	members := make([][]int, count)
+	for v, p := range part {
+		members[p] = append(members[p], v)
+	}
+	for p, vs := range members {
+		for _, v := range vs {
+			agg.k[p] += nw.k[v]
+			for _, a := range nw.adj[v] {
+				q := part[a.to]
+				if index[q] == -1 {
+					index[q] = len(agg.adj[p])
+					agg.adj[p] = append(agg.adj[p], arc{q, 0})
+				}
+				agg.adj[p][index[q]].w += a.w
+			}
+		}
+		for _, a := range agg.adj[p] {
+			index[a.to] = -1
+		}
+	}
+	return agg
+}
+
+func (nw *network) modularity(comm []int, gamma float64) float64 {
+	if nw.m2 == 0 {
+		return 0
+	}
+	in := make(map[int]float64)
+	tot := make(map[int]float64)
+	for v, arcs := range nw.adj {
+		tot[comm[v]] += nw.k[v]
+		for _, a := range arcs {
+			if comm[a.to] == comm[v] {
+				in[comm[v]] += a.w
+			}
+		}
+	}
+	q := 0.0
+	for c, t := range tot {
+		q += in[c]/nw.m2 - gamma*(t/nw.m2)*(t/nw.m2)
+	}
+	return q
+}
+
+// mover holds the state of the local moving phase.
+type mover struct {
+	nw    *network
+	gamma float64
+	comm  []int
+	tot   []float64 // The total weight of each community.
+	wt    []float64 // The weight from the current vertex to each community.
+	seen  []bool
+	near  []int // The communities with seen set.
+}
+
+func newMover(nw *network, comm []int, gamma float64) *mover {
+	n := len(nw.adj)
+	m := &mover{
+		nw:    nw,
+		gamma: gamma,
+		comm:  comm,
+		tot:   make([]float64, n),
+		wt:    make([]float64, n),
+		seen:  make([]bool, n),
+	}
+	for v, c := range comm {
+		m.tot[c] += nw.k[v]
+	}
+	return m
+}
+
+// neighbors computes the weight from v to each neighboring community,
+// using the community of each vertex given by comm.
+func (m *mover) neighbors(v int, comm []int) {
+	for _, c := range m.near {
+		m.wt[c], m.seen[c] = 0, false
+	}
+	m.near = m.near[:0]
+	for _, a := range m.nw.adj[v] {
+		if a.to == v {
+			continue
+		}
+		c := comm[a.to]
+		if !m.seen[c] {
+			m.seen[c] = true
+			m.near = append(m.near, c)
+		}
+		m.wt[c] += a.w
+	}
+}
+
+// move moves v to the neighboring community that increases
+// the modularity the most, and tells if v changed community.
+func (m *mover) move(v int) bool {
+	nw, k := m.nw, m.nw.k[v]
+	m.neighbors(v, m.comm)
+	c := m.comm[v]
+	m.tot[c] -= k
+	best := c
+	bestGain := m.wt[c] - m.gamma*m.tot[c]*k/nw.m2
+	for _, d := range m.near {
+		if gain := m.wt[d] - m.gamma*m.tot[d]*k/nw.m2; gain > bestGain {
+			best, bestGain = d, gain
+		}
+	}
+	m.tot[best] += k
+	m.comm[v] = best
+	return best != c
+}
+
+// renumber renumbers the values in a to 0..count-1,
+// in order of first appearance.
+func renumber(a []int) (count int) {
+	index := make(map[int]int)
+	for i, x := range a {
+		j, ok := index[x]
+		if !ok {
+			j = len(index)
+			index[x] = j
+		}
+		a[i] = j
+	}
+	return len(index)
+}
+
+func identity(n int) []int {
+	a := make([]int, n)
+	for i := range a {
+		a[i] = i
+	}
+	return a
+}
+
+func shuffled(r *rand.Rand, n int) []int {
+	a := identity(n)
+	r.Shuffle(n, func(i, j int) { a[i], a[j] = a[j], a[i] })
+	return a
+}
@@ -0,0 +1,264 @@
+package community
+
+import (
+	"fmt"
+	"github.com/yourbasic/graph"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func diff(res, exp interface{}) (message string, diff bool) {
+	if !reflect.DeepEqual(res, exp) {
+		message = fmt.Sprintf("%v; want %v", res, exp)
+		diff = true
+	}
+	return
+}
+
+// cliques returns k cliques of size m with edges of cost 1, connected in a ring
+// by a single edge between consecutive cliques.
+func cliques(k, m int) *graph.Mutable {
+	g := graph.New(k * m)
+	for c := 0; c < k; c++ {
+		for i := 0; i < m; i++ {
+			for j := i + 1; j < m; j++ {
+				g.AddBothCost(c*m+i, c*m+j, 1)
+			}
+		}
+		if k > 1 {
+			g.AddBothCost(c*m, (c+1)%k*m+m-1, 1)
+		}
+	}
+	return g
+}
+
+func TestModularity(t *testing.T) {
+	g := graph.New(4)
+	if mess, diff := diff(Modularity(g, []int{0, 1, 2, 3}, nil), 0.0); diff {
+		t.Errorf("Modularity %s", mess)
+	}
+
+	// Two triangles joined by the edge {2, 3}.
+	g = graph.New(6)
+	for _, e := range [][2]int{{0, 1}, {0, 2}, {1, 2}, {3, 4}, {3, 5}, {4, 5}, {2, 3}} {
+		g.AddBothCost(e[0], e[1], 1)
+	}
+	q := Modularity(g, []int{0, 0, 0, 1, 1, 1}, nil)
+	if exp := 12.0/14 - 2*(7.0/14)*(7.0/14); math.Abs(q-exp) > 1e-12 {
+		t.Errorf("Modularity %v; want %v", q, exp)
+	}
+	q = Modularity(g, []int{0, 0, 0, 0, 0, 0}, nil)
+	if math.Abs(q) > 1e-12 {
+		t.Errorf("Modularity %v; want 0", q)
+	}
+	q = Modularity(g, []int{0, 0, 0, 0, 0, 0}, &Options{Resolution: 0.5})
+	if math.Abs(q-0.5) > 1e-12 {
+		t.Errorf("Modularity %v; want 0.5", q)
+	}
+
+	// A heavy bridge makes the split worse.
+	g.AddBothCost(2, 3, 10)
+	q = Modularity(g, []int{0, 0, 0, 1, 1, 1}, &Options{Weighted: true})
+	if exp := 12.0/32 - 2*(16.0/32)*(16.0/32); math.Abs(q-exp) > 1e-12 {
+		t.Errorf("Modularity %v; want %v", q, exp)
+	}
+}
+
+var algorithms = []struct {
+	name string
+	f    func(graph.Iterator, *Options) ([]int, float64)
+}{
+	{"Louvain", Louvain},
+	{"Leiden", Leiden},
+}
+
+func TestCommunities(t *testing.T) {
+	for _, alg := range algorithms {
+		comm, q := alg.f(graph.New(0), nil)
+		if mess, diff := diff(comm, []int{}); diff {
+			t.Errorf("%s->comm %s", alg.name, mess)
+		}
+		if mess, diff := diff(q, 0.0); diff {
+			t.Errorf("%s->modularity %s", alg.name, mess)
+		}
+
+		comm, _ = alg.f(graph.New(3), nil)
+		if mess, diff := diff(comm, []int{0, 1, 2}); diff {
+			t.Errorf("%s->comm %s", alg.name, mess)
+		}
+
+		g := cliques(4, 5)
+		exp := []int{0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 3, 3, 3, 3, 3}
+		for seed := int64(0); seed < 10; seed++ {
+			comm, q = alg.f(g, &Options{Seed: seed})
+			if mess, diff := diff(comm, exp); diff {
+				t.Errorf("%s->comm %s", alg.name, mess)
+			}
+			if exp := Modularity(g, comm, nil); math.Abs(q-exp) > 1e-12 {
+				t.Errorf("%s->modularity %v; want %v", alg.name, q, exp)
+			}
+		}
+
+		// With a low resolution, everything is one community.
+		comm, _ = alg.f(g, &Options{Resolution: 0.01})
+		if mess, diff := diff(comm, make([]int, 20)); diff {
+			t.Errorf("%s->comm %s", alg.name, mess)
+		}
+
+		// Edges of cost 0 between two cliques only count if unweighted.
+		g = cliques(2, 5)
+		for v := 0; v < 5; v++ {
+			for w := 5; w < 10; w++ {
+				if !g.Edge(v, w) {
+					g.AddBoth(v, w)
+				}
+			}
+		}
+		comm, _ = alg.f(g, nil)
+		if mess, diff := diff(comm, make([]int, 10)); diff {
+			t.Errorf("%s->comm %s", alg.name, mess)
+		}
+		comm, _ = alg.f(g, &Options{Weighted: true})
+		if mess, diff := diff(comm, []int{0, 0, 0, 0, 0, 1, 1, 1, 1, 1}); diff {
+			t.Errorf("%s->comm %s", alg.name, mess)
+		}
+	}
+}
+
+func TestCommunitiesRandom(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		n := 100
+		g := graph.New(n)
+		for j := 0; j < 3*n; j++ {
+			g.AddBothCost(rand.Intn(n), rand.Intn(n), rand.Int63n(10))
+		}
+		for _, alg := range algorithms {
+			for _, opts := range []*Options{nil, {Weighted: true}, {Resolution: 2}} {
+				comm, q := alg.f(g, opts)
+				if exp := Modularity(g, comm, opts); math.Abs(q-exp) > 1e-9 {
+					t.Errorf("%s->modularity %v; want %v", alg.name, q, exp)
+				}
+				if single := Modularity(g, identity(n), opts); q < single {
+					t.Errorf("%s->modularity %v; singletons have %v", alg.name, q, single)
+				}
+				if alg.name == "Leiden" {
+					checkConnected(t, g, comm)
+				}
+			}
+		}
+	}
+}
+
+// checkConnected checks that each community induces a connected subgraph.
+func checkConnected(t *testing.T, g graph.Iterator, comm []int) {
+	n := g.Order()
+	sub := graph.New(n)
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			if comm[v] == comm[w] {
+				sub.AddBoth(v, w)
+			}
+			return
+		})
+	}
+	root := make(map[int]int)
+	for _, c := range graph.Components(sub) {
+		if r, ok := root[comm[c[0]]]; ok {
+			t.Errorf("Leiden: community %d isn't connected: %d, %d", comm[c[0]], r, c[0])
+		}
+		root[comm[c[0]]] = c[0]
+	}
+}
+
+func BenchmarkLouvain(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := graph.New(n)
+	for i := 0; i < 5*n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Louvain(g, nil)
+	}
+}
+
+func BenchmarkLeiden(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := graph.New(n)
+	for i := 0; i < 5*n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Leiden(g, nil)
+	}
+}
+
+func TestLabelPropagation(t *testing.T) {
+	if mess, diff := diff(LabelPropagation(graph.New(0), nil), []int{}); diff {
+		t.Errorf("LabelPropagation %s", mess)
+	}
+	if mess, diff := diff(LabelPropagation(graph.New(3), nil), []int{0, 1, 2}); diff {
+		t.Errorf("LabelPropagation %s", mess)
+	}
+
+	// Two cliques and an isolated vertex.
+	g := graph.New(11)
+	for v := 0; v < 5; v++ {
+		for w := v + 1; w < 5; w++ {
+			g.AddBoth(v, w)
+			g.AddBoth(v+6, w+6)
+		}
+	}
+	exp := []int{0, 0, 0, 0, 0, 1, 2, 2, 2, 2, 2}
+	for seed := int64(0); seed < 10; seed++ {
+		if mess, diff := diff(LabelPropagation(g, &Options{Seed: seed}), exp); diff {
+			t.Errorf("LabelPropagation %s", mess)
+		}
+	}
+
+	// Edges of cost 0 have no weight.
+	g = graph.New(4)
+	g.AddBothCost(0, 1, 1)
+	g.AddBoth(1, 2)
+	g.AddBothCost(2, 3, 1)
+	exp = []int{0, 0, 1, 1}
+	if mess, diff := diff(LabelPropagation(g, &Options{Weighted: true}), exp); diff {
+		t.Errorf("LabelPropagation %s", mess)
+	}
+}
+
+func TestLabelPropagationRandom(t *testing.T) {
+	n := 200
+	g := graph.New(n)
+	for i := 0; i < 2*n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	// Each community lies within a connected component.
+	label, _ := graph.ComponentLabels(g)
+	comm := LabelPropagation(g, nil)
+	first := make(map[int]int)
+	for v, c := range comm {
+		if w, ok := first[c]; ok && label[v] != label[w] {
+			t.Errorf("LabelPropagation: %d and %d in different components", v, w)
+		}
+		first[c] = v
+	}
+}
+
+func BenchmarkLabelPropagation(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := graph.New(n)
+	for i := 0; i < 5*n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = LabelPropagation(g, nil)
+	}
+}
@@ -0,0 +1,129 @@
+package community
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+)
+
+// Leiden partitions the vertices of an undirected graph into connected
+// communities using the Leiden algorithm of Traag, Waltman and van Eck.
+// It returns the community of each vertex, numbered from 0 in order of
+// their smallest vertex, and the modularity of the partition.
+// If opts is nil, the default options are used.
+//
+// Compared to Louvain, the algorithm adds a refinement phase that splits
+// each community into well-connected parts before merging them.
+// In this implementation, a vertex in the refinement phase joins
+// the part that increases the modularity the most.
+func Leiden(g graph.Iterator, opts *Options) (comm []int, modularity float64) {
+	if opts == nil {
+		opts = new(Options)
+	}
+	gamma := opts.resolution()
+	r := rand.New(rand.NewSource(opts.Seed))
+	nw := newNetwork(g, opts.Weighted)
+	top := nw
+	node := identity(g.Order()) // The vertex of nw that contains each vertex.
+	part := identity(len(nw.adj))
+	for {
+		m := newMover(nw, part, gamma)
+		m.moveFast(shuffled(r, len(nw.adj)))
+		count := renumber(part)
+		if count == len(nw.adj) {
+			break // Each vertex is a community of its own.
+		}
+		refined := m.refine(shuffled(r, len(nw.adj)))
+		rcount := renumber(refined)
+		if rcount == len(nw.adj) {
+			break // Nothing to merge.
+		}
+		next := make([]int, rcount)
+		for v, x := range refined {
+			next[x] = part[v]
+		}
+		for v, x := range node {
+			node[v] = refined[x]
+		}
+		nw = nw.aggregate(refined, rcount)
+		part = next
+	}
+	comm = make([]int, len(node))
+	for v, x := range node {
+		comm[v] = part[x]
+	}
+	renumber(comm)
+	return comm, top.modularity(comm, gamma)
+}
+
+// moveFast moves vertices until no move increases the modularity,
+// only revisiting the neighbors of vertices that have moved.
+func (m *mover) moveFast(order []int) {
+	queued := make([]bool, len(order))
+	for _, v := range order {
+		queued[v] = true
+	}
+	queue := append([]int{}, order...)
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		queued[v] = false
+		if !m.move(v) {
+			continue
+		}
+		for _, a := range m.nw.adj[v] {
+			if w := a.to; !queued[w] && m.comm[w] != m.comm[v] {
+				queued[w] = true
+				queue = append(queue, w)
+			}
+		}
+	}
+}
+
+// refine splits each community into well-connected parts, starting
+// from singletons, and returns the part of each vertex.
+func (m *mover) refine(order []int) []int {
+	nw, n := m.nw, len(m.nw.adj)
+	part := identity(n)
+	size := make([]int, n)
+	ptot := make([]float64, n) // The total weight of each part.
+	cut := make([]float64, n)  // The weight between each part and the rest of its community.
+	for v := range part {
+		size[v] = 1
+		ptot[v] = nw.k[v]
+		for _, a := range nw.adj[v] {
+			if a.to != v && m.comm[a.to] == m.comm[v] {
+				cut[v] += a.w
+			}
+		}
+	}
+	// wellConnected tells if a set with total weight x and weight y to the
+	// rest of its community C has enough edges to the rest of C.
+	wellConnected := func(x, y float64, c int) bool {
+		return y >= m.gamma*x*(m.tot[c]-x)/nw.m2
+	}
+	for _, v := range order {
+		c, k := m.comm[v], nw.k[v]
+		if size[part[v]] != 1 || !wellConnected(k, cut[v], c) {
+			continue
+		}
+		m.neighbors(v, part)
+		best, bestGain := part[v], 0.0
+		for _, p := range m.near {
+			if p == part[v] || m.comm[p] != c || !wellConnected(ptot[p], cut[p], c) {
+				continue
+			}
+			if gain := m.wt[p] - m.gamma*ptot[p]*k/nw.m2; gain > bestGain {
+				best, bestGain = p, gain
+			}
+		}
+		if best == part[v] {
+			continue
+		}
+		size[part[v]], ptot[part[v]] = 0, 0
+		part[v] = best
+		size[best]++
+		ptot[best] += k
+		cut[best] += cut[v] - 2*m.wt[best]
+	}
+	return part
+}
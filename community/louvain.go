@@ -0,0 +1,51 @@
+package community
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+)
+
+// Louvain partitions the vertices of an undirected graph into communities
+// using the Louvain method of Blondel et al. It returns the community of
+// each vertex, numbered from 0 in order of their smallest vertex,
+// and the modularity of the partition. If opts is nil,
+// the default options are used.
+//
+// The algorithm repeatedly moves single vertices to the neighboring
+// community that increases the modularity the most, and then merges
+// each community into a single vertex. It typically runs in time
+// O(|E|⋅log|V|), where |E| is the number of edges and |V| the number
+// of vertices in the graph.
+func Louvain(g graph.Iterator, opts *Options) (comm []int, modularity float64) {
+	if opts == nil {
+		opts = new(Options)
+	}
+	gamma := opts.resolution()
+	r := rand.New(rand.NewSource(opts.Seed))
+	nw := newNetwork(g, opts.Weighted)
+	top := nw
+	comm = identity(g.Order()) // The vertex of nw that contains each vertex.
+	for {
+		m := newMover(nw, identity(len(nw.adj)), gamma)
+		order := shuffled(r, len(nw.adj))
+		moved := false
+		for improved := true; improved; {
+			improved = false
+			for _, v := range order {
+				if m.move(v) {
+					improved, moved = true, true
+				}
+			}
+		}
+		if !moved {
+			break
+		}
+		count := renumber(m.comm)
+		for v, x := range comm {
+			comm[v] = m.comm[x]
+		}
+		nw = nw.aggregate(m.comm, count)
+	}
+	renumber(comm)
+	return comm, top.modularity(comm, gamma)
+}
@@ -0,0 +1,66 @@
+package community
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+)
+
+// maxRounds bounds the number of rounds of label propagation,
+// which may oscillate on some graphs.
+const maxRounds = 100
+
+// LabelPropagation partitions the vertices of an undirected graph into
+// communities using the label propagation algorithm of Raghavan, Albert
+// and Kumara. It returns the community of each vertex, numbered from 0
+// in order of their smallest vertex. If opts is nil, the default options
+// are used; the resolution isn't used.
+//
+// Each vertex starts with a label of its own. The vertices are then
+// visited in random order, and each vertex adopts the label with the
+// largest total weight among its neighbors, until every vertex has such
+// a label. Ties are broken at random, preferring the current label.
+//
+// The algorithm is much faster than Louvain, but the communities are
+// often of lower quality. Each round takes O(|E| + |V|) time, where |E|
+// is the number of edges and |V| the number of vertices in the graph,
+// and usually only a few rounds are needed.
+func LabelPropagation(g graph.Iterator, opts *Options) []int {
+	if opts == nil {
+		opts = new(Options)
+	}
+	r := rand.New(rand.NewSource(opts.Seed))
+	nw := newNetwork(g, opts.Weighted)
+	n := len(nw.adj)
+	m := newMover(nw, identity(n), 1)
+	label := m.comm
+	var best []int
+	for round := 0; round < maxRounds; round++ {
+		changed := false
+		for _, v := range shuffled(r, n) {
+			m.neighbors(v, label)
+			if len(m.near) == 0 {
+				continue
+			}
+			max := 0.0
+			best = best[:0]
+			for _, c := range m.near {
+				switch w := m.wt[c]; {
+				case w > max:
+					max, best = w, append(best[:0], c)
+				case w == max && w > 0:
+					best = append(best, c)
+				}
+			}
+			if len(best) == 0 || m.seen[label[v]] && m.wt[label[v]] == max {
+				continue
+			}
+			label[v] = best[r.Intn(len(best))]
+			changed = true
+		}
+		if !changed {
+			break
+		}
+	}
+	renumber(label)
+	return label
+}
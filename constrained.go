@@ -0,0 +1,105 @@
+package graph
+
+import "container/heap"
+
+// ConstrainedShortestPath computes a shortest path from v to w
+// whose total resource consumption is at most limit.
+// The function resource(x, y, c) gives the resource consumed by the
+// edge (x, y) of cost c; it could, for instance, return the toll of a road
+// with travel time c. Only edges with non-negative costs and
+// non-negative resource consumption are included.
+// The number dist is the length of the path, or -1 if no such path exists.
+//
+// The implementation is a labeling algorithm: it keeps, for each vertex,
+// the set of paths that aren't dominated by a path that is both shorter and
+// consumes less. The problem is NP-hard, and in the worst case the number
+// of labels grows exponentially, but the dominance pruning makes it fast
+// for many practical instances.
+func ConstrainedShortestPath(g Iterator, v, w int, resource func(v, w int, c int64) int64, limit int64) (path []int, dist int64) {
+	if limit < 0 {
+		return []int{}, -1
+	}
+	// labels[i] is a path ending at labels[i].vertex.
+	labels := []label{{vertex: v, pred: -1}}
+	front := make([][]int, g.Order()) // non-dominated labels at each vertex
+	Q := &labelQueue{labels: &labels, heap: []int{0}}
+	for Q.Len() > 0 {
+		i := heap.Pop(Q).(int)
+		l := labels[i]
+		if dominated(labels, front[l.vertex], l) {
+			continue
+		}
+		front[l.vertex] = append(front[l.vertex], i)
+		if l.vertex == w {
+			// The first label to reach w is the shortest feasible path.
+			length := 0
+			for j := i; j != -1; j = labels[j].pred {
+				length++
+			}
+			path = make([]int, length)
+			for j := i; j != -1; j = labels[j].pred {
+				length--
+				path[length] = labels[j].vertex
+			}
+			return path, l.cost
+		}
+		g.Visit(l.vertex, func(x int, c int64) (skip bool) {
+			if c < 0 {
+				return
+			}
+			r := resource(l.vertex, x, c)
+			if r < 0 || l.used+r > limit {
+				return
+			}
+			next := label{vertex: x, cost: l.cost + c, used: l.used + r, pred: i}
+			if !dominated(labels, front[x], next) {
+				labels = append(labels, next)
+				heap.Push(Q, len(labels)-1)
+			}
+			return
+		})
+	}
+	return []int{}, -1
+}
+
+type label struct {
+	vertex int
+	cost   int64
+	used   int64 // resource consumption
+	pred   int   // index of the previous label, or -1
+}
+
+// dominated tells if one of the labels in front is at least as good as l.
+func dominated(labels []label, front []int, l label) bool {
+	for _, i := range front {
+		if m := labels[i]; m.cost <= l.cost && m.used <= l.used {
+			return true
+		}
+	}
+	return false
+}
+
+// labelQueue is a priority queue of label indices, ordered by cost
+// and then by resource consumption.
+type labelQueue struct {
+	labels *[]label
+	heap   []int
+}
+
+func (q *labelQueue) Len() int { return len(q.heap) }
+
+func (q *labelQueue) Less(i, j int) bool {
+	a, b := (*q.labels)[q.heap[i]], (*q.labels)[q.heap[j]]
+	return a.cost < b.cost || a.cost == b.cost && a.used < b.used
+}
+
+func (q *labelQueue) Swap(i, j int) { q.heap[i], q.heap[j] = q.heap[j], q.heap[i] }
+
+func (q *labelQueue) Push(x interface{}) { q.heap = append(q.heap, x.(int)) }
+
+func (q *labelQueue) Pop() interface{} {
+	n := len(q.heap) - 1
+	x := q.heap[n]
+	q.heap = q.heap[:n]
+	return x
+}
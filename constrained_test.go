@@ -0,0 +1,87 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestConstrainedShortestPath(t *testing.T) {
+	// Travel times as costs, and tolls on the side.
+	g := New(5)
+	g.AddCost(0, 1, 1)  // 0 -> 1 -> 4: fast and expensive
+	g.AddCost(1, 4, 1)  //
+	g.AddCost(0, 2, 2)  // 0 -> 2 -> 3 -> 4: medium
+	g.AddCost(2, 3, 2)  //
+	g.AddCost(3, 4, 2)  // 0 -> 3 -> 4: slow and cheap
+	g.AddCost(0, 3, 10) //
+	toll := map[[2]int]int64{{0, 1}: 5, {1, 4}: 5, {0, 2}: 1, {2, 3}: 1, {3, 4}: 1}
+	resource := func(v, w int, _ int64) int64 { return toll[[2]int{v, w}] }
+
+	for _, e := range []struct {
+		limit int64
+		path  []int
+		dist  int64
+	}{
+		{100, []int{0, 1, 4}, 2},
+		{10, []int{0, 1, 4}, 2},
+		{9, []int{0, 2, 3, 4}, 6},
+		{3, []int{0, 2, 3, 4}, 6},
+		{2, []int{0, 3, 4}, 12},
+		{0, []int{}, -1},
+		{-1, []int{}, -1},
+	} {
+		path, dist := ConstrainedShortestPath(g, 0, 4, resource, e.limit)
+		if mess, diff := diff(path, e.path); diff {
+			t.Errorf("ConstrainedShortestPath(%d)->path %s", e.limit, mess)
+		}
+		if mess, diff := diff(dist, e.dist); diff {
+			t.Errorf("ConstrainedShortestPath(%d)->dist %s", e.limit, mess)
+		}
+	}
+
+	path, dist := ConstrainedShortestPath(g, 2, 2, resource, 0)
+	if mess, diff := diff(path, []int{2}); diff {
+		t.Errorf("ConstrainedShortestPath->path %s", mess)
+	}
+	if mess, diff := diff(dist, int64(0)); diff {
+		t.Errorf("ConstrainedShortestPath->dist %s", mess)
+	}
+}
+
+func TestConstrainedShortestPathRandom(t *testing.T) {
+	n := 50
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), int64(rand.Intn(10)))
+	}
+	// With an unlimited budget, the constraint is irrelevant.
+	one := func(int, int, int64) int64 { return 1 }
+	_, exp := ShortestPaths(g, 0)
+	for w := 0; w < n; w++ {
+		if _, dist := ConstrainedShortestPath(g, 0, w, one, Max); dist != exp[w] {
+			t.Errorf("ConstrainedShortestPath(0, %d)->dist %d; want %d", w, dist, exp[w])
+		}
+	}
+	// Consuming only costs, the constraint is a distance budget.
+	cost := func(_, _ int, c int64) int64 { return c }
+	for w := 0; w < n; w++ {
+		_, dist := ConstrainedShortestPath(g, 0, w, cost, 15)
+		if exp[w] > 15 && dist != -1 || exp[w] <= 15 && dist != exp[w] {
+			t.Errorf("ConstrainedShortestPath(0, %d)->dist %d; shortest %d", w, dist, exp[w])
+		}
+	}
+}
+
+func BenchmarkConstrainedShortestPath(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), int64(rand.Intn(10)))
+	}
+	resource := func(v, w int, c int64) int64 { return 10 - c }
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ConstrainedShortestPath(g, 0, 1, resource, 50)
+	}
+}
@@ -0,0 +1,66 @@
+package graph
+
+import "strconv"
+
+// EdgeAdder describes a graph that edges can be added to,
+// such as Mutable, Undirected and Graph.
+type EdgeAdder interface {
+	// Order returns the number of vertices in a graph.
+	Order() int
+
+	// AddCost inserts an edge from v to w with cost c.
+	AddCost(v, w int, c int64)
+}
+
+// CopyInto adds the edges of src to dst, which must have at least
+// as many vertices as src. Existing edges of dst are kept, but may get
+// new costs. If dst is Undirected, an edge and its reverse in src
+// give a single edge, with the cost of the one that is added last.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in src.
+func CopyInto(dst EdgeAdder, src Iterator) {
+	if n, m := src.Order(), dst.Order(); m < n {
+		panic("order mismatch: " + strconv.Itoa(m) + " < " + strconv.Itoa(n))
+	}
+	VisitAll(src, func(v, w int, c int64) (skip bool) {
+		dst.AddCost(v, w, c)
+		return
+	})
+}
+
+// CopyUndirected returns an undirected copy of g. An edge and its reverse
+// in g give a single edge; if their costs differ, one of them is used.
+func CopyUndirected(g Iterator) *Undirected {
+	h := NewUndirected(g.Order())
+	CopyInto(h, g)
+	return h
+}
+
+// CopyMultigraph returns a copy of g as a Multigraph.
+// Unlike Copy, it keeps any duplicate edges in g.
+func CopyMultigraph(g Iterator) *Multigraph {
+	h := NewMultigraph(g.Order())
+	VisitAll(g, func(v, w int, c int64) (skip bool) {
+		h.AddEdge(v, w, c)
+		return
+	})
+	return h
+}
+
+// Clone returns a copy of g, including any removed vertices
+// and the sorted mode, but not the observers.
+func (g *Mutable) Clone() *Mutable {
+	return copyMutable(g)
+}
+
+// Immutable returns an immutable copy of g; it's the same as Sort(g).
+func (g *Mutable) Immutable() *Immutable {
+	return Sort(g)
+}
+
+// Mutable returns a mutable copy of g; it's the same as Copy(g).
+// If g is a multigraph, any duplicate edges in g will be lost.
+func (g *Immutable) Mutable() *Mutable {
+	return copyImmutable(g)
+}
@@ -0,0 +1,61 @@
+package graph
+
+import "testing"
+
+func TestConvert(t *testing.T) {
+	g := New(4)
+	g.AddCost(0, 1, 2)
+	g.AddBothCost(1, 2, 3)
+	g.Add(3, 3)
+	exp := "4 [(0 1):2 {1 2}:3 (3 3)]"
+
+	h := New(5)
+	h.Add(4, 0)
+	CopyInto(h, g)
+	if mess, diff := diff(h.String(), "5 [(0 1):2 {1 2}:3 (3 3) (4 0)]"); diff {
+		t.Errorf("CopyInto %s", mess)
+	}
+	if mess, diff := diff(CopyUndirected(g).String(), "4 [{0 1}:2 {1 2}:3 (3 3)]"); diff {
+		t.Errorf("CopyUndirected %s", mess)
+	}
+
+	mg := NewMultigraph(2)
+	mg.AddEdge(0, 1, 1)
+	mg.AddEdge(0, 1, 2)
+	if mess, diff := diff(CopyMultigraph(Sort(mg)).Size(), 2); diff {
+		t.Errorf("CopyMultigraph %s", mess)
+	}
+
+	g.SetSorted(true)
+	g.RemoveVertex(2)
+	c := g.Clone()
+	if mess, diff := diff(c.String(), g.String()); diff {
+		t.Errorf("Clone %s", mess)
+	}
+	if !c.Sorted() || !c.Removed(2) {
+		t.Errorf("Clone: sorted %t, removed %t; want true, true", c.Sorted(), c.Removed(2))
+	}
+	c.Add(0, 3)
+	if g.Edge(0, 3) {
+		t.Errorf("Clone: shares edges with original")
+	}
+
+	g = New(4)
+	g.AddCost(0, 1, 2)
+	g.AddBothCost(1, 2, 3)
+	g.Add(3, 3)
+	im := g.Immutable()
+	if mess, diff := diff(im.String(), exp); diff {
+		t.Errorf("Immutable %s", mess)
+	}
+	if mess, diff := diff(im.Mutable().String(), exp); diff {
+		t.Errorf("Mutable %s", mess)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("CopyInto to smaller graph: no panic")
+		}
+	}()
+	CopyInto(New(3), g)
+}
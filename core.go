@@ -0,0 +1,50 @@
+package graph
+
+// CoreNumbers returns the core number of each vertex in g:
+// the largest k such that v belongs to the k-core of g, the largest
+// subgraph in which every vertex has at least k neighbors.
+// The graph is treated as undirected, as in DegeneracyOrdering;
+// self-loops and duplicate edges are ignored.
+//
+// The implementation uses the bucket algorithm by Batagelj and Zaversnik,
+// which repeatedly removes a vertex of smallest degree.
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func CoreNumbers(g Iterator) []int {
+	_, core := degeneracyOrder(undirected(g))
+	return core
+}
+
+// Degeneracy returns the degeneracy of g: the largest k for which
+// g has a non-empty k-core, or 0 if g has no vertices.
+// The graph is treated as undirected.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func Degeneracy(g Iterator) int {
+	k := 0
+	for _, c := range CoreNumbers(g) {
+		if c > k {
+			k = c
+		}
+	}
+	return k
+}
+
+// KCore returns a view of the k-core of g, the subgraph induced by
+// the vertices with core number at least k, and the vertices of g
+// that belong to it, in increasing order. Vertex i of the view
+// corresponds to vertex vertices[i] of g, as in Subgraph.
+// The view has the same, possibly directed, edges as g.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func KCore(g Iterator, k int) (core Iterator, vertices []int) {
+	vertices = []int{}
+	for v, c := range CoreNumbers(g) {
+		if c >= k {
+			vertices = append(vertices, v)
+		}
+	}
+	return Subgraph(g, vertices), vertices
+}
@@ -0,0 +1,84 @@
+package graph
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestCoreNumbers(t *testing.T) {
+	// A clique 0-1-2-3, a path 3-4-5, and a vertex 6 with a self-loop.
+	g := New(7)
+	for v := 0; v < 4; v++ {
+		for w := v + 1; w < 4; w++ {
+			g.AddBoth(v, w)
+		}
+	}
+	g.AddBoth(3, 4)
+	g.Add(4, 5)
+	g.Add(6, 6)
+	if mess, diff := diff(CoreNumbers(g), []int{3, 3, 3, 3, 1, 1, 0}); diff {
+		t.Errorf("CoreNumbers %s", mess)
+	}
+	if mess, diff := diff(Degeneracy(g), 3); diff {
+		t.Errorf("Degeneracy %s", mess)
+	}
+	if mess, diff := diff(Degeneracy(New(0)), 0); diff {
+		t.Errorf("Degeneracy %s", mess)
+	}
+	core, vertices := KCore(g, 2)
+	if mess, diff := diff(vertices, []int{0, 1, 2, 3}); diff {
+		t.Errorf("KCore %s", mess)
+	}
+	if mess, diff := diff(Sort(core).String(), "4 [{0 1} {0 2} {0 3} {1 2} {1 3} {2 3}]"); diff {
+		t.Errorf("KCore %s", mess)
+	}
+	if _, vertices := KCore(g, 4); len(vertices) != 0 {
+		t.Errorf("KCore(4) %v; want []", vertices)
+	}
+}
+
+func TestCoreNumbersRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		n := 1 + r.Intn(30)
+		g := New(n)
+		for j := r.Intn(4 * n); j > 0; j-- {
+			g.Add(r.Intn(n), r.Intn(n))
+		}
+		core := CoreNumbers(g)
+		adj := undirected(g)
+		for v, list := range adj {
+			if !sort.IntsAreSorted(list) {
+				t.Fatalf("undirected: neighbors of %d not sorted: %v", v, list)
+			}
+		}
+		// The k-core is what remains after repeatedly removing
+		// the vertices with fewer than k neighbors.
+		for k := 0; k <= n; k++ {
+			in := make([]bool, n)
+			for v := range in {
+				in[v] = true
+			}
+			for changed := true; changed; {
+				changed = false
+				for v := range adj {
+					d := 0
+					for _, w := range adj[v] {
+						if in[w] {
+							d++
+						}
+					}
+					if in[v] && d < k {
+						in[v], changed = false, true
+					}
+				}
+			}
+			for v := range in {
+				if in[v] != (core[v] >= k) {
+					t.Fatalf("CoreNumbers(%v)[%d] = %d; wrong for k = %d", g, v, core[v], k)
+				}
+			}
+		}
+	}
+}
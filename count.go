@@ -0,0 +1,162 @@
+package graph
+
+import (
+	"container/heap"
+	"math/big"
+)
+
+// CountShortestPaths computes the number of distinct shortest paths
+// from v to every other vertex. Only edges with non-negative costs are
+// included, and self-loops are ignored. The number dist[w] is the same
+// as for ShortestPaths, and count[w] is the number of shortest paths
+// from v to w, or 0 if w cannot be reached.
+//
+// Ties between paths of the same cost are broken by the number of
+// zero-cost edges: only the paths of minimum cost with the fewest
+// zero-cost edges are counted. These paths are simple, and their number
+// is finite even if there are cycles of zero cost. In particular, for
+// a graph where all edges have cost 0, as with Add and AddBoth, the paths
+// counted are the paths with the fewest edges, as in a breadth-first search.
+// If the graph has no zero-cost edges, all paths of minimum cost are counted.
+//
+// The graph dag contains the edges of g that belong to some counted path
+// from v; the paths from v in dag are exactly the counted paths.
+//
+// The time complexity is O((|E| + |V|)⋅log|V|) arithmetic operations,
+// where |E| is the number of edges and |V| the number of vertices in the graph.
+func CountShortestPaths(g Iterator, v int) (dist []int64, count []*big.Int, dag *Immutable) {
+	dist, dag, order := shortestPathDAG(g, v)
+	count = make([]*big.Int, len(dist))
+	for w := range count {
+		count[w] = new(big.Int)
+	}
+	count[v].SetInt64(1)
+	for _, u := range order {
+		dag.Visit(u, func(w int, _ int64) (skip bool) {
+			count[w].Add(count[w], count[u])
+			return
+		})
+	}
+	return
+}
+
+// CountShortestPathsMod computes the number of distinct shortest paths
+// from v to every other vertex modulo m, with m > 0.
+// It returns the same values as CountShortestPaths.
+func CountShortestPathsMod(g Iterator, v int, m int64) (dist []int64, count []int64, dag *Immutable) {
+	if m <= 0 {
+		panic("nonpositive modulus")
+	}
+	dist, dag, order := shortestPathDAG(g, v)
+	count = make([]int64, len(dist))
+	count[v] = 1 % m
+	for _, u := range order {
+		dag.Visit(u, func(w int, _ int64) (skip bool) {
+			// The sum may overflow an int64.
+			count[w] = int64((uint64(count[w]) + uint64(count[u])) % uint64(m))
+			return
+		})
+	}
+	return
+}
+
+// shortestPathDAG returns the distances from v, the DAG of the paths
+// counted by CountShortestPaths, and a topological order of the
+// vertices that can be reached from v.
+//
+// The paths are found by Dijkstra's algorithm with the pairs
+// (dist, zeros) as distances, ordered lexicographically, where zeros
+// is the number of zero-cost edges. Each edge of the DAG increases
+// either dist or zeros, and the DAG has no cycles.
+func shortestPathDAG(g Iterator, v int) (dist []int64, dag *Immutable, order []int) {
+	n := g.Order()
+	dist = make([]int64, n)
+	zeros := make([]int, n)
+	for w := range dist {
+		dist[w] = -1
+	}
+	dist[v] = 0
+	q := &pathHeap{{v, 0, 0}}
+	done := make([]bool, n)
+	for q.Len() > 0 {
+		e := heap.Pop(q).(pathEntry)
+		u := e.v
+		if done[u] {
+			continue // An outdated entry.
+		}
+		done[u] = true
+		order = append(order, u)
+		g.Visit(u, func(w int, c int64) (skip bool) {
+			if c < 0 || w == u {
+				return
+			}
+			d, z := dist[u]+c, zeros[u]
+			if c == 0 {
+				z++
+			}
+			if dist[w] == -1 || d < dist[w] || d == dist[w] && z < zeros[w] {
+				dist[w], zeros[w] = d, z
+				heap.Push(q, pathEntry{w, d, z})
+			}
+			return
+		})
+	}
+
+	edges := make(edgeLists, n)
+	for _, u := range order {
+		g.Visit(u, func(w int, c int64) (skip bool) {
+			if c < 0 || w == u || dist[u]+c != dist[w] {
+				return
+			}
+			if z := zeros[u]; c == 0 && z+1 == zeros[w] || c > 0 && z == zeros[w] {
+				edges[u] = append(edges[u], neighbor{w, c})
+			}
+			return
+		})
+	}
+	dag = Sort(edges)
+	return
+}
+
+// pathEntry is a vertex with its distance and number of zero-cost edges.
+type pathEntry struct {
+	v     int
+	dist  int64
+	zeros int
+}
+
+// pathHeap is a min-heap of entries ordered by distance,
+// then by number of zero-cost edges.
+type pathHeap []pathEntry
+
+func (h pathHeap) Len() int { return len(h) }
+func (h pathHeap) Less(i, j int) bool {
+	if h[i].dist != h[j].dist {
+		return h[i].dist < h[j].dist
+	}
+	return h[i].zeros < h[j].zeros
+}
+func (h pathHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *pathHeap) Push(x interface{}) { *h = append(*h, x.(pathEntry)) }
+func (h *pathHeap) Pop() interface{} {
+	n := len(*h) - 1
+	x := (*h)[n]
+	*h = (*h)[:n]
+	return x
+}
+
+// edgeLists is an Iterator backed by adjacency lists.
+type edgeLists [][]neighbor
+
+func (e edgeLists) Order() int {
+	return len(e)
+}
+
+func (e edgeLists) Visit(v int, do func(w int, c int64) bool) bool {
+	for _, x := range e[v] {
+		if do(x.vertex, x.cost) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package graph
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestCountShortestPaths(t *testing.T) {
+	g := New(7)
+	g.AddBothCost(0, 1, 1) //     1
+	g.AddBothCost(0, 2, 1) //   /   \
+	g.AddBothCost(1, 3, 1) //  0     3 -- 4    5 ==> 6
+	g.AddBothCost(2, 3, 1) //   \   /
+	g.AddBothCost(3, 4, 2) //     2
+	g.AddCost(0, 4, 4)
+	g.AddCost(5, 6, 0)
+	g.AddCost(6, 5, 0)
+
+	dist, count, dag := CountShortestPaths(g, 0)
+	if mess, diff := diff(dist, []int64{0, 1, 1, 2, 4, -1, -1}); diff {
+		t.Errorf("CountShortestPaths->dist %s", mess)
+	}
+	var res []int64
+	for _, c := range count {
+		res = append(res, c.Int64())
+	}
+	if mess, diff := diff(res, []int64{1, 1, 1, 2, 3, 0, 0}); diff {
+		t.Errorf("CountShortestPaths->count %s", mess)
+	}
+	exp := "7 [(0 1):1 (0 2):1 (0 4):4 (1 3):1 (2 3):1 (3 4):2]"
+	if mess, diff := diff(dag.String(), exp); diff {
+		t.Errorf("CountShortestPaths->dag %s", mess)
+	}
+
+	dist, modCount, _ := CountShortestPathsMod(g, 0, 2)
+	if mess, diff := diff(modCount, []int64{1, 1, 1, 0, 1, 0, 0}); diff {
+		t.Errorf("CountShortestPathsMod->count %s", mess)
+	}
+
+	// Zero-cost cycles don't give infinitely many paths.
+	g.AddCost(4, 5, 0)
+	_, count, _ = CountShortestPaths(g, 0)
+	res = res[:0]
+	for _, c := range count {
+		res = append(res, c.Int64())
+	}
+	if mess, diff := diff(res, []int64{1, 1, 1, 2, 3, 3, 3}); diff {
+		t.Errorf("CountShortestPaths->count %s", mess)
+	}
+	_, modCount, _ = CountShortestPathsMod(g, 5, 7)
+	if mess, diff := diff(modCount, []int64{0, 0, 0, 0, 0, 1, 1}); diff {
+		t.Errorf("CountShortestPathsMod->count %s", mess)
+	}
+}
+
+// In an unweighted graph, the paths with the fewest edges are counted.
+func TestCountShortestPathsUnweighted(t *testing.T) {
+	g := New(3)
+	g.AddBoth(0, 1)
+	g.AddBoth(1, 2)
+	dist, count, dag := CountShortestPaths(g, 0)
+	if mess, diff := diff(dist, []int64{0, 0, 0}); diff {
+		t.Errorf("CountShortestPaths->dist %s", mess)
+	}
+	if mess, diff := diff(count, []*big.Int{big.NewInt(1), big.NewInt(1), big.NewInt(1)}); diff {
+		t.Errorf("CountShortestPaths->count %s", mess)
+	}
+	if mess, diff := diff(dag.String(), "3 [(0 1) (1 2)]"); diff {
+		t.Errorf("CountShortestPaths->dag %s", mess)
+	}
+
+	// A cycle of four vertices with a self-loop and an extra vertex.
+	g = New(5)
+	for v := 0; v < 4; v++ {
+		g.AddBoth(v, (v+1)%4)
+	}
+	g.Add(0, 0)
+	g.AddBoth(2, 4)
+	_, modCount, _ := CountShortestPathsMod(g, 0, 10)
+	if mess, diff := diff(modCount, []int64{1, 1, 2, 1, 2}); diff {
+		t.Errorf("CountShortestPathsMod->count %s", mess)
+	}
+
+	// The counts match those of a breadth-first search.
+	r := rand.New(rand.NewSource(1))
+	n := 50
+	g = New(n)
+	for i := 0; i < 2*n; i++ {
+		g.AddBoth(r.Intn(n), r.Intn(n))
+	}
+	_, count, _ = CountShortestPaths(g, 0)
+	_, layers := BFSTree(g, 0)
+	exp := make([]*big.Int, n)
+	for v := range exp {
+		exp[v] = new(big.Int)
+	}
+	exp[0].SetInt64(1)
+	for d := 0; d < n; d++ {
+		for v := 0; v < n; v++ {
+			if layers[v] != d {
+				continue
+			}
+			g.Visit(v, func(w int, _ int64) (skip bool) {
+				if layers[w] == d+1 {
+					exp[w].Add(exp[w], exp[v])
+				}
+				return
+			})
+		}
+	}
+	if mess, diff := diff(count, exp); diff {
+		t.Errorf("CountShortestPaths->count %s", mess)
+	}
+}
+
+// A grid where every monotone path is a shortest path:
+// the count at (r, c) is the binomial coefficient C(r+c, r).
+func TestCountShortestPathsGrid(t *testing.T) {
+	const m = 40
+	g := New(m * m)
+	for r := 0; r < m; r++ {
+		for c := 0; c < m; c++ {
+			if c+1 < m {
+				g.AddBothCost(r*m+c, r*m+c+1, 1)
+			}
+			if r+1 < m {
+				g.AddBothCost(r*m+c, (r+1)*m+c, 1)
+			}
+		}
+	}
+	_, count, _ := CountShortestPaths(g, 0)
+	exp := new(big.Int).Binomial(2*m-2, m-1)
+	if count[m*m-1].Cmp(exp) != 0 {
+		t.Errorf("CountShortestPaths: %v; want %v", count[m*m-1], exp)
+	}
+	const mod = 1000000007
+	_, modCount, _ := CountShortestPathsMod(g, 0, mod)
+	if mess, diff := diff(modCount[m*m-1], new(big.Int).Mod(exp, big.NewInt(mod)).Int64()); diff {
+		t.Errorf("CountShortestPathsMod %s", mess)
+	}
+}
+
+func BenchmarkCountShortestPaths(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < n; i++ {
+		g.AddCost(0, rand.Intn(n), int64(rand.Intn(3)))
+		g.AddCost(rand.Intn(n), rand.Intn(n), int64(rand.Intn(3)))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = CountShortestPaths(g, 0)
+	}
+}
@@ -0,0 +1,364 @@
+package graph
+
+// The vertex cover and independent set functions treat g as an
+// undirected graph: a vertex cover is a set of vertices such that each
+// edge, in either direction, has at least one endpoint in the set, and
+// an independent set is a set of vertices with no edges between them.
+// Self-loops are ignored. The complement of a vertex cover is an
+// independent set, and the other way around. The vertices of the sets
+// are sorted in increasing order.
+
+// ApproxVertexCover computes a vertex cover of g that is at most twice
+// as large as a minimum vertex cover. It consists of the endpoints of
+// the edges in a maximal matching, which is found greedily.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func ApproxVertexCover(g Iterator) (cover []int) {
+	adj := undirected(g)
+	in := make([]bool, len(adj))
+	for v, list := range adj {
+		if in[v] {
+			continue
+		}
+		for _, w := range list {
+			if !in[w] {
+				in[v], in[w] = true, true
+				break
+			}
+		}
+	}
+	return members(in, true)
+}
+
+// ExactVertexCover computes a minimum vertex cover of g by branch and
+// bound. It's the complement of the set computed by ExactIndependentSet.
+//
+// The search takes exponential time in the worst case. If g has more
+// than cutoff vertices, ExactVertexCover returns the complement of the
+// set computed by LocalIndependentSet instead and sets exact to false.
+func ExactVertexCover(g Iterator, cutoff int) (cover []int, exact bool) {
+	in, exact := exactCover(undirected(g), cutoff)
+	return members(in, true), exact
+}
+
+// GreedyIndependentSet computes a maximal independent set of g:
+// it repeatedly picks a vertex of minimum degree, and removes the vertex
+// and its neighbors from the graph, until the graph is empty.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func GreedyIndependentSet(g Iterator) (set []int) {
+	return members(greedyIndependent(undirected(g)), true)
+}
+
+// LocalIndependentSet computes a maximal independent set of g by local
+// search: it starts with the set computed by GreedyIndependentSet and
+// applies (1,2)-swaps, which remove one vertex and insert two, as long
+// as possible. The set is usually larger than the greedy one.
+//
+// Each pass of the search takes O(|E| + |V|) time, where |E| is the
+// number of edges and |V| the number of vertices in the graph,
+// and there are at most |V| passes.
+func LocalIndependentSet(g Iterator) (set []int) {
+	adj := undirected(g)
+	return members(localSearch(adj, greedyIndependent(adj)), true)
+}
+
+// ExactIndependentSet computes a maximum independent set of g by branch
+// and bound. It's the complement of the cover computed by ExactVertexCover.
+//
+// The search takes exponential time in the worst case. If g has more
+// than cutoff vertices, ExactIndependentSet returns the set computed by
+// LocalIndependentSet instead and sets exact to false.
+func ExactIndependentSet(g Iterator, cutoff int) (set []int, exact bool) {
+	in, exact := exactCover(undirected(g), cutoff)
+	return members(in, false), exact
+}
+
+// members returns the vertices v, in increasing order, for which in[v] == b.
+func members(in []bool, b bool) []int {
+	res := []int{}
+	for v, x := range in {
+		if x == b {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// greedyIndependent returns a maximal independent set, picking vertices
+// of minimum degree first. The vertices are kept in buckets by degree,
+// with lazy deletion: an entry is skipped if the vertex has been removed
+// or its degree has changed.
+func greedyIndependent(adj [][]int) (in []bool) {
+	n := len(adj)
+	in = make([]bool, n)
+	removed := make([]bool, n)
+	deg := make([]int, n)
+	bucket := make([][]int, n)
+	for v := n - 1; v >= 0; v-- {
+		deg[v] = len(adj[v])
+		bucket[deg[v]] = append(bucket[deg[v]], v)
+	}
+	for d := 0; d < n; {
+		l := len(bucket[d])
+		if l == 0 {
+			d++
+			continue
+		}
+		v := bucket[d][l-1]
+		bucket[d] = bucket[d][:l-1]
+		if removed[v] || deg[v] != d {
+			continue
+		}
+		in[v], removed[v] = true, true
+		for _, w := range adj[v] {
+			if removed[w] {
+				continue
+			}
+			removed[w] = true
+			for _, x := range adj[w] {
+				if removed[x] {
+					continue
+				}
+				deg[x]--
+				bucket[deg[x]] = append(bucket[deg[x]], x)
+				if deg[x] < d {
+					d = deg[x]
+				}
+			}
+		}
+	}
+	return
+}
+
+// localSearch improves the maximal independent set in with (1,2)-swaps,
+// as in the iterated local search of Andrade, Resende and Werneck.
+// A swap removes a vertex x from the set and inserts two non-adjacent
+// neighbors of x that have no other neighbors in the set; any vertices
+// that become free are then also inserted.
+func localSearch(adj [][]int, in []bool) []bool {
+	n := len(adj)
+	// tight[v] is the number of neighbors of v in the set.
+	tight := make([]int, n)
+	add := func(v int, delta int) {
+		in[v] = delta == 1
+		for _, w := range adj[v] {
+			tight[w] += delta
+		}
+	}
+	for v := range in {
+		if in[v] {
+			in[v] = false
+			add(v, 1)
+		}
+	}
+	// list[u] == x+1 if u is a candidate for a swap with x,
+	// and near[u] == stamp if u is a neighbor of the candidate c.
+	list, near := make([]int, n), make([]int, n)
+	stamp := 0
+	var cand []int
+	for improved := true; improved; {
+		improved = false
+		for x := 0; x < n; x++ {
+			if !in[x] {
+				continue
+			}
+			cand = cand[:0]
+			for _, u := range adj[x] {
+				if tight[u] == 1 {
+					cand = append(cand, u)
+					list[u] = x + 1
+				}
+			}
+			// Find a candidate c with fewer than len(cand)-1
+			// candidate neighbors, and a candidate not next to c.
+			u, w := -1, -1
+			for _, c := range cand {
+				stamp++
+				k := 0
+				for _, y := range adj[c] {
+					if list[y] == x+1 && tight[y] == 1 {
+						near[y] = stamp
+						k++
+					}
+				}
+				if k == len(cand)-1 {
+					continue
+				}
+				for _, d := range cand {
+					if d != c && near[d] != stamp {
+						u, w = c, d
+						break
+					}
+				}
+				break
+			}
+			if u == -1 {
+				continue
+			}
+			add(x, -1)
+			add(u, 1)
+			add(w, 1)
+			for _, y := range adj[x] {
+				if !in[y] && tight[y] == 0 {
+					add(y, 1)
+				}
+			}
+			improved = true
+		}
+	}
+	return in
+}
+
+// exactCover returns a minimum vertex cover of the graph, or the
+// complement of the set found by localSearch if the graph has more
+// than cutoff vertices.
+func exactCover(adj [][]int, cutoff int) (in []bool, exact bool) {
+	n := len(adj)
+	in = localSearch(adj, greedyIndependent(adj))
+	for v := range in {
+		in[v] = !in[v]
+	}
+	if n > cutoff {
+		return in, false
+	}
+	s := &coverSearch{
+		adj:   adj,
+		alive: make([]bool, n),
+		deg:   make([]int, n),
+		in:    make([]bool, n),
+		mark:  make([]int, n),
+		best:  in,
+	}
+	for v := range adj {
+		s.alive[v] = true
+		s.deg[v] = len(adj[v])
+		if in[v] {
+			s.k++
+		}
+	}
+	s.search()
+	return s.best, true
+}
+
+// coverSearch holds the state of the branch and bound search
+// in exactCover.
+type coverSearch struct {
+	adj   [][]int
+	alive []bool // The vertices that remain in the graph.
+	deg   []int  // The number of neighbors of each vertex that remain.
+	in    []bool // The partial cover.
+	size  int    // The size of the partial cover.
+	trail []int  // The removed vertices, in order of removal.
+	mark  []int  // Scratch space for the matching bound.
+	stamp int
+	best  []bool // The best cover found so far, with k vertices.
+	k     int
+}
+
+// search extends the partial cover to covers of the remaining graph
+// with fewer than k vertices in total.
+func (s *coverSearch) search() {
+	top := len(s.trail)
+	defer s.undo(top)
+
+	// A vertex of degree 0 isn't needed, and the neighbor
+	// of a vertex of degree 1 can be taken into the cover.
+	for reduced := true; reduced; {
+		reduced = false
+		for v, ok := range s.alive {
+			switch {
+			case !ok || s.deg[v] > 1:
+				continue
+			case s.deg[v] == 0:
+				s.remove(v, false)
+			default:
+				for _, w := range s.adj[v] {
+					if s.alive[w] {
+						s.remove(w, true)
+						break
+					}
+				}
+			}
+			reduced = true
+		}
+	}
+
+	// Each edge in a matching needs its own vertex in the cover.
+	s.stamp++
+	bound := s.size
+	v := -1
+	for u, ok := range s.alive {
+		if !ok {
+			continue
+		}
+		if v == -1 || s.deg[u] > s.deg[v] {
+			v = u
+		}
+		if s.mark[u] == s.stamp {
+			continue
+		}
+		for _, w := range s.adj[u] {
+			if s.alive[w] && s.mark[w] != s.stamp {
+				s.mark[u], s.mark[w] = s.stamp, s.stamp
+				bound++
+				break
+			}
+		}
+	}
+	if bound >= s.k {
+		return
+	}
+	if v == -1 {
+		s.best = append([]bool{}, s.in...)
+		s.k = s.size
+		return
+	}
+
+	// Branch on a vertex v of maximum degree: either v
+	// or all of its neighbors belong to the cover.
+	mid := len(s.trail)
+	s.remove(v, true)
+	s.search()
+	s.undo(mid)
+	for _, w := range s.adj[v] {
+		if s.alive[w] {
+			s.remove(w, true)
+		}
+	}
+	s.remove(v, false)
+	s.search()
+}
+
+// remove removes v from the graph, and adds it to the cover if in is true.
+func (s *coverSearch) remove(v int, in bool) {
+	s.alive[v], s.in[v] = false, in
+	if in {
+		s.size++
+	}
+	for _, w := range s.adj[v] {
+		if s.alive[w] {
+			s.deg[w]--
+		}
+	}
+	s.trail = append(s.trail, v)
+}
+
+// undo restores the vertices removed after the first top removals.
+func (s *coverSearch) undo(top int) {
+	for len(s.trail) > top {
+		v := s.trail[len(s.trail)-1]
+		s.trail = s.trail[:len(s.trail)-1]
+		for _, w := range s.adj[v] {
+			if s.alive[w] {
+				s.deg[w]++
+			}
+		}
+		if s.in[v] {
+			s.size--
+		}
+		s.alive[v], s.in[v] = true, false
+	}
+}
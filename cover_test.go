@@ -0,0 +1,191 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkCover checks that cover is a sorted vertex cover of g.
+func checkCover(t *testing.T, name string, g Iterator, cover []int) {
+	in := make([]bool, g.Order())
+	for i, v := range cover {
+		if i > 0 && cover[i-1] >= v {
+			t.Errorf("%s: %v not sorted", name, cover)
+		}
+		in[v] = true
+	}
+	VisitAll(g, func(v, w int, _ int64) (skip bool) {
+		if v != w && !in[v] && !in[w] {
+			t.Errorf("%s: edge (%d, %d) not covered", name, v, w)
+		}
+		return
+	})
+}
+
+// checkIndependent checks that set is a sorted maximal independent set of g.
+func checkIndependent(t *testing.T, name string, g Iterator, set []int) {
+	n := g.Order()
+	in := make([]bool, n)
+	for i, v := range set {
+		if i > 0 && set[i-1] >= v {
+			t.Errorf("%s: %v not sorted", name, set)
+		}
+		in[v] = true
+	}
+	dominated := append([]bool{}, in...)
+	VisitAll(g, func(v, w int, _ int64) (skip bool) {
+		if v != w && in[v] && in[w] {
+			t.Errorf("%s: edge (%d, %d) in set", name, v, w)
+		}
+		if v != w && in[v] {
+			dominated[w] = true
+		}
+		if v != w && in[w] {
+			dominated[v] = true
+		}
+		return
+	})
+	for v, ok := range dominated {
+		if !ok {
+			t.Errorf("%s: %v not maximal, %d can be added", name, set, v)
+		}
+	}
+}
+
+// maxIndependent returns the size of a maximum independent set by brute force.
+func maxIndependent(g Iterator) int {
+	n := g.Order()
+	nbrs := make([]int, n)
+	VisitAll(g, func(v, w int, _ int64) (skip bool) {
+		if v != w {
+			nbrs[v] |= 1 << uint(w)
+			nbrs[w] |= 1 << uint(v)
+		}
+		return
+	})
+	best := 0
+	for s := 0; s < 1<<uint(n); s++ {
+		size, ok := 0, true
+		for v := 0; v < n && ok; v++ {
+			if s&(1<<uint(v)) != 0 {
+				size++
+				ok = s&nbrs[v] == 0
+			}
+		}
+		if ok && size > best {
+			best = size
+		}
+	}
+	return best
+}
+
+func TestVertexCover(t *testing.T) {
+	g := New(0)
+	if mess, diff := diff(ApproxVertexCover(g), []int{}); diff {
+		t.Errorf("ApproxVertexCover %s", mess)
+	}
+	set, exact := ExactIndependentSet(g, 10)
+	if mess, diff := diff(set, []int{}); diff || !exact {
+		t.Errorf("ExactIndependentSet %s", mess)
+	}
+
+	// A star with a self-loop at its center.
+	g = New(5)
+	g.Add(0, 0)
+	for v := 1; v < 5; v++ {
+		g.Add(0, v)
+	}
+	if mess, diff := diff(ApproxVertexCover(g), []int{0, 1}); diff {
+		t.Errorf("ApproxVertexCover %s", mess)
+	}
+	cover, exact := ExactVertexCover(g, 10)
+	if mess, diff := diff(cover, []int{0}); diff || !exact {
+		t.Errorf("ExactVertexCover %s", mess)
+	}
+	if mess, diff := diff(GreedyIndependentSet(g), []int{1, 2, 3, 4}); diff {
+		t.Errorf("GreedyIndependentSet %s", mess)
+	}
+
+	// A path, where the vertices of degree 1 are picked first.
+	g = New(5)
+	for v := 0; v < 4; v++ {
+		g.AddBoth(v, v+1)
+	}
+	if mess, diff := diff(LocalIndependentSet(g), []int{0, 2, 4}); diff {
+		t.Errorf("LocalIndependentSet %s", mess)
+	}
+	cover, exact = ExactVertexCover(g, 2)
+	if mess, diff := diff(cover, []int{1, 3}); diff || exact {
+		t.Errorf("ExactVertexCover(cutoff) %s", mess)
+	}
+}
+
+func TestVertexCoverRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := r.Intn(13)
+		g := New(n)
+		p := r.Float64()
+		for v := 0; v < n; v++ {
+			for w := 0; w < n; w++ {
+				if r.Float64() < p/2 {
+					g.Add(v, w)
+				}
+			}
+		}
+		opt := maxIndependent(g)
+
+		cover := ApproxVertexCover(g)
+		checkCover(t, "ApproxVertexCover", g, cover)
+		if len(cover) > 2*(n-opt) {
+			t.Errorf("ApproxVertexCover: %d vertices, optimum %d", len(cover), n-opt)
+		}
+		cover, exact := ExactVertexCover(g, n)
+		checkCover(t, "ExactVertexCover", g, cover)
+		if len(cover) != n-opt || !exact {
+			t.Errorf("ExactVertexCover: %d vertices, optimum %d", len(cover), n-opt)
+		}
+
+		greedy := GreedyIndependentSet(g)
+		checkIndependent(t, "GreedyIndependentSet", g, greedy)
+		local := LocalIndependentSet(g)
+		checkIndependent(t, "LocalIndependentSet", g, local)
+		if len(local) < len(greedy) {
+			t.Errorf("LocalIndependentSet: %d < %d vertices", len(local), len(greedy))
+		}
+		set, exact := ExactIndependentSet(g, n)
+		checkIndependent(t, "ExactIndependentSet", g, set)
+		if len(set) != opt || !exact {
+			t.Errorf("ExactIndependentSet: %d vertices, optimum %d", len(set), opt)
+		}
+		if len(cover)+len(set) != n {
+			t.Errorf("ExactVertexCover and ExactIndependentSet don't match")
+		}
+	}
+}
+
+func BenchmarkLocalIndependentSet(b *testing.B) {
+	n := 10000
+	g := New(n)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 5*n; i++ {
+		g.AddBoth(r.Intn(n), r.Intn(n))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = LocalIndependentSet(g)
+	}
+}
+
+func BenchmarkExactIndependentSet(b *testing.B) {
+	n := 60
+	g := New(n)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 3*n; i++ {
+		g.AddBoth(r.Intn(n), r.Intn(n))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ExactIndependentSet(g, n)
+	}
+}
@@ -0,0 +1,71 @@
+package graph
+
+import "context"
+
+// ctxCheckInterval is the number of steps between two checks
+// of the context in the Ctx variants of long-running functions.
+const ctxCheckInterval = 1 << 10
+
+// A ctxChecker tells if a context is done, but only looks at
+// the context once every ctxCheckInterval calls.
+type ctxChecker struct {
+	ctx  context.Context
+	n    int
+	done bool
+}
+
+// stop returns true if the context is done.
+func (c *ctxChecker) stop() bool {
+	if c.done {
+		return true
+	}
+	if c.n++; c.n == ctxCheckInterval {
+		c.n = 0
+		c.done = c.ctx.Err() != nil
+	}
+	return c.done
+}
+
+// ShortestPathsCtx is like ShortestPaths, but stops early and returns
+// ctx.Err() if the context is cancelled or its deadline expires
+// during the search. In that case, parent and dist are nil.
+func ShortestPathsCtx(ctx context.Context, g Iterator, v int) (parent []int, dist []int64, err error) {
+	if err = ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	c := &ctxChecker{ctx: ctx}
+	// Once the context is done, all edges are skipped and the search ends.
+	parent, dist = shortestPaths(g, v, func(int, int, int64) bool { return !c.stop() }, Max)
+	if err = ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	return
+}
+
+// AllPairsShortestPathsCtx is like AllPairsShortestPaths, but stops early
+// and returns ctx.Err() if the context is cancelled or its deadline expires
+// during the computation. In that case, parent and dist are nil.
+func AllPairsShortestPathsCtx(ctx context.Context, g Iterator) (parent [][]int, dist [][]int64, ok bool, err error) {
+	if err = ctx.Err(); err != nil {
+		return nil, nil, false, err
+	}
+	parent, dist, ok = floydWarshall(ctx, g, nil)
+	if err = ctx.Err(); err != nil {
+		return nil, nil, false, err
+	}
+	return
+}
+
+// JohnsonCtx is like Johnson, but stops early and returns ctx.Err()
+// if the context is cancelled or its deadline expires during
+// the computation. In that case, parent and dist are nil.
+func JohnsonCtx(ctx context.Context, g Iterator) (parent [][]int, dist [][]int64, ok bool, err error) {
+	if err = ctx.Err(); err != nil {
+		return nil, nil, false, err
+	}
+	parent, dist, ok = johnsonAllPairs(ctx, g, nil)
+	if err = ctx.Err(); err != nil {
+		return nil, nil, false, err
+	}
+	return
+}
@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+// countCtx is a context that is cancelled after its Err method
+// has been called n times.
+type countCtx struct {
+	context.Context
+	n int
+}
+
+func (c *countCtx) Err() error {
+	if c.n <= 0 {
+		return context.Canceled
+	}
+	c.n--
+	return nil
+}
+
+func TestShortestPathsCtx(t *testing.T) {
+	n := 2000
+	g := New(n)
+	for v := 0; v < n; v++ {
+		for m := 5; m > 0; m-- {
+			g.AddCost(v, rand.Intn(n), rand.Int63n(100))
+		}
+	}
+
+	parent, dist, err := ShortestPathsCtx(context.Background(), g, 0)
+	expParent, expDist := ShortestPaths(g, 0)
+	if mess, diff := diff(err, nil); diff {
+		t.Errorf("ShortestPathsCtx->err %s", mess)
+	}
+	if mess, diff := diff(dist, expDist); diff {
+		t.Errorf("ShortestPathsCtx->dist %s", mess)
+	}
+	if mess, diff := diff(len(parent), len(expParent)); diff {
+		t.Errorf("ShortestPathsCtx->parent %s", mess)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	parent, dist, err = ShortestPathsCtx(ctx, g, 0)
+	if mess, diff := diff(err, context.Canceled); diff {
+		t.Errorf("ShortestPathsCtx cancelled->err %s", mess)
+	}
+	if parent != nil || dist != nil {
+		t.Errorf("ShortestPathsCtx cancelled: non-nil result")
+	}
+
+	_, _, err = ShortestPathsCtx(&countCtx{context.Background(), 2}, g, 0)
+	if mess, diff := diff(err, context.Canceled); diff {
+		t.Errorf("ShortestPathsCtx midway->err %s", mess)
+	}
+}
+
+func TestAllPairsCtx(t *testing.T) {
+	g := New(50)
+	for m := 200; m > 0; m-- {
+		g.AddCost(rand.Intn(50), rand.Intn(50), rand.Int63n(10))
+	}
+	for _, apsp := range []struct {
+		name string
+		f    func(Iterator) ([][]int, [][]int64, bool)
+		ctx  func(context.Context, Iterator) ([][]int, [][]int64, bool, error)
+	}{
+		{"AllPairsShortestPathsCtx", AllPairsShortestPaths, AllPairsShortestPathsCtx},
+		{"JohnsonCtx", Johnson, JohnsonCtx},
+	} {
+		parent, dist, ok, err := apsp.ctx(context.Background(), g)
+		expParent, expDist, expOk := apsp.f(g)
+		if mess, diff := diff(err, nil); diff {
+			t.Errorf("%s->err %s", apsp.name, mess)
+		}
+		// Shortest paths with equal costs may be chosen differently.
+		if mess, diff := diff(len(parent), len(expParent)); diff {
+			t.Errorf("%s->parent %s", apsp.name, mess)
+		}
+		if mess, diff := diff(dist, expDist); diff {
+			t.Errorf("%s->dist %s", apsp.name, mess)
+		}
+		if mess, diff := diff(ok, expOk); diff {
+			t.Errorf("%s->ok %s", apsp.name, mess)
+		}
+
+		parent, dist, ok, err = apsp.ctx(&countCtx{context.Background(), 10}, g)
+		if mess, diff := diff(err, context.Canceled); diff {
+			t.Errorf("%s midway->err %s", apsp.name, mess)
+		}
+		if parent != nil || dist != nil || ok {
+			t.Errorf("%s midway: non-empty result", apsp.name)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package graph
+
+// AllCycles calls do for each elementary cycle of the directed graph g,
+// a closed path that visits no vertex twice. The cycle is given as a new
+// slice of vertices, starting with its smallest vertex, with an edge
+// from each vertex to the next and from the last vertex back to the first.
+// A self-loop is a cycle with one vertex, and multiple edges
+// between the same pair of vertices are treated as one edge.
+// If maxLen > 0, only cycles with at most maxLen vertices are produced.
+// The enumeration is aborted if do returns true.
+//
+// The implementation uses Johnson's algorithm.
+// Without a length bound, the time complexity is O((|E| + |V|)⋅(c + |V|)),
+// where c is the number of cycles, |E| the number of edges and |V|
+// the number of vertices in the graph. With a bound, the search
+// is pruned by the distance back to the first vertex of the cycle,
+// but the running time may still be exponential in maxLen.
+func AllCycles(g Iterator, maxLen int, do func(cycle []int) (skip bool)) (aborted bool) {
+	h := Sort(g)
+	n := h.Order()
+	j := &johnson{
+		adj:     make([][]int, n),
+		radj:    make([][]int, n),
+		inComp:  make([]bool, n),
+		blocked: make([]bool, n),
+		b:       make([]map[int]bool, n),
+		dist:    make([]int, n),
+		maxLen:  maxLen,
+		do:      do,
+	}
+	loop := make([]bool, n)
+	for v := 0; v < n; v++ {
+		h.Visit(v, func(w int, _ int64) (skip bool) {
+			switch {
+			case w == v:
+				loop[v] = true
+			case len(j.adj[v]) == 0 || j.adj[v][len(j.adj[v])-1] != w:
+				j.adj[v] = append(j.adj[v], w)
+				j.radj[w] = append(j.radj[w], v)
+			}
+			return
+		})
+	}
+	for s := 0; s < n; s++ {
+		if loop[s] && do([]int{s}) {
+			return true
+		}
+		if maxLen == 1 {
+			continue
+		}
+		// The cycles through s in the subgraph induced by s, s+1,… , n-1
+		// are found in the strongly connected component of s.
+		label, _ := StrongComponentLabels(FilterEdges(h, func(v, w int, _ int64) bool {
+			return v >= s && w >= s
+		}))
+		size := 0
+		for v := 0; v < n; v++ {
+			j.inComp[v] = v >= s && label[v] == label[s]
+			if j.inComp[v] {
+				size++
+			}
+		}
+		if size > 1 && j.search(s) {
+			return true
+		}
+	}
+	return false
+}
+
+type johnson struct {
+	adj, radj [][]int // the graph and its transpose, without self-loops
+	inComp    []bool  // the strongly connected component being searched
+	blocked   []bool
+	b         []map[int]bool // b[w] holds the vertices to unblock with w
+	dist      []int          // the distance back to s, with a length bound
+	maxLen    int
+	do        func(cycle []int) (skip bool)
+}
+
+// search finds the cycles through s in the current component.
+func (j *johnson) search(s int) (aborted bool) {
+	bounded := j.maxLen > 0
+	for v, ok := range j.inComp {
+		if ok {
+			j.blocked[v], j.b[v], j.dist[v] = false, nil, -1
+		}
+	}
+	if bounded {
+		j.dist[s] = 0
+		for queue := []int{s}; len(queue) > 0; queue = queue[1:] {
+			v := queue[0]
+			for _, w := range j.radj[v] {
+				if j.inComp[w] && j.dist[w] == -1 {
+					j.dist[w] = j.dist[v] + 1
+					queue = append(queue, w)
+				}
+			}
+		}
+	}
+
+	type frame struct {
+		v, next int
+		found   bool // a cycle through v has been found
+	}
+	path := []int{s}
+	stack := []frame{{v: s}}
+	j.blocked[s] = true
+	for len(stack) > 0 {
+		f := &stack[len(stack)-1]
+		v := f.v
+		if f.next < len(j.adj[v]) {
+			w := j.adj[v][f.next]
+			f.next++
+			switch {
+			case !j.inComp[w]:
+			case w == s:
+				cycle := make([]int, len(path))
+				copy(cycle, path)
+				if j.do(cycle) {
+					return true
+				}
+				f.found = true
+			case j.blocked[w]:
+			case bounded && len(path)+j.dist[w] > j.maxLen:
+			default:
+				j.blocked[w] = true
+				path = append(path, w)
+				stack = append(stack, frame{v: w})
+			}
+			continue
+		}
+		found := f.found
+		switch {
+		case bounded:
+			// Johnson's blocking doesn't work with a length bound,
+			// so only the vertices on the path are blocked.
+			j.blocked[v] = false
+		case found:
+			j.unblock(v)
+		default:
+			for _, w := range j.adj[v] {
+				if j.inComp[w] {
+					if j.b[w] == nil {
+						j.b[w] = make(map[int]bool)
+					}
+					j.b[w][v] = true
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		path = path[:len(path)-1]
+		if found && len(stack) > 0 {
+			stack[len(stack)-1].found = true
+		}
+	}
+	return false
+}
+
+// unblock unblocks u, and recursively the vertices in b[u].
+func (j *johnson) unblock(u int) {
+	for stack := []int{u}; len(stack) > 0; {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !j.blocked[v] {
+			continue
+		}
+		j.blocked[v] = false
+		for w := range j.b[v] {
+			stack = append(stack, w)
+		}
+		j.b[v] = nil
+	}
+}
@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// collectCycles returns the cycles produced by AllCycles.
+func collectCycles(g Iterator, maxLen int) [][]int {
+	res := [][]int{}
+	AllCycles(g, maxLen, func(cycle []int) (skip bool) {
+		res = append(res, cycle)
+		return
+	})
+	return res
+}
+
+// bruteCycles finds all elementary cycles of g with at most maxLen
+// vertices, if maxLen > 0, by trying all paths from each vertex s
+// through larger vertices.
+func bruteCycles(g Iterator, maxLen int) map[string]bool {
+	n := g.Order()
+	res := make(map[string]bool)
+	onPath := make([]bool, n)
+	var path []int
+	var extend func(s, v int)
+	extend = func(s, v int) {
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			switch {
+			case w == s:
+				res[fmt.Sprint(path)] = true
+			case w > s && !onPath[w] && (maxLen <= 0 || len(path) < maxLen):
+				onPath[w] = true
+				path = append(path, w)
+				extend(s, w)
+				path = path[:len(path)-1]
+				onPath[w] = false
+			}
+			return
+		})
+	}
+	for s := 0; s < n; s++ {
+		path = []int{s}
+		onPath[s] = true
+		extend(s, s)
+		onPath[s] = false
+	}
+	return res
+}
+
+func TestAllCycles(t *testing.T) {
+	g := New(5)
+	g.Add(0, 1)
+	g.Add(1, 2)
+	g.Add(2, 0)
+	g.Add(1, 0)
+	g.Add(2, 3)
+	g.Add(3, 3)
+	g.Add(3, 4)
+	exp := [][]int{{0, 1}, {0, 1, 2}, {3}}
+	if mess, diff := diff(collectCycles(g, 0), exp); diff {
+		t.Errorf("AllCycles %s", mess)
+	}
+	if mess, diff := diff(collectCycles(g, 2), [][]int{{0, 1}, {3}}); diff {
+		t.Errorf("AllCycles(maxLen=2) %s", mess)
+	}
+	if mess, diff := diff(collectCycles(g, 1), [][]int{{3}}); diff {
+		t.Errorf("AllCycles(maxLen=1) %s", mess)
+	}
+	if mess, diff := diff(collectCycles(New(3), 0), [][]int{}); diff {
+		t.Errorf("AllCycles(empty) %s", mess)
+	}
+
+	// Multiple edges are treated as one.
+	h := FromEdges(2, []Edge{{0, 1, 0}, {0, 1, 1}, {1, 0, 0}})
+	if mess, diff := diff(collectCycles(h, 0), [][]int{{0, 1}}); diff {
+		t.Errorf("AllCycles(multigraph) %s", mess)
+	}
+
+	count := 0
+	aborted := AllCycles(g, 0, func(cycle []int) (skip bool) {
+		count++
+		return count == 2
+	})
+	if mess, diff := diff(aborted, true); diff {
+		t.Errorf("AllCycles->aborted %s", mess)
+	}
+	if mess, diff := diff(count, 2); diff {
+		t.Errorf("AllCycles->count %s", mess)
+	}
+}
+
+func TestAllCyclesRandom(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		n := 1 + rand.Intn(8)
+		g := New(n)
+		for m := rand.Intn(n*n/2 + 1); m > 0; m-- {
+			g.Add(rand.Intn(n), rand.Intn(n))
+		}
+		maxLen := rand.Intn(n + 1)
+		exp := bruteCycles(g, maxLen)
+		res := make(map[string]bool)
+		for _, cycle := range collectCycles(Sort(g), maxLen) {
+			key := fmt.Sprint(cycle)
+			if res[key] {
+				t.Errorf("AllCycles(%v, %d): duplicate cycle %v", g, maxLen, cycle)
+			}
+			res[key] = true
+		}
+		if mess, diff := diff(sortedKeys(res), sortedKeys(exp)); diff {
+			t.Errorf("AllCycles(%v, %d) %s", g, maxLen, mess)
+		}
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func BenchmarkAllCycles(b *testing.B) {
+	n := 30
+	b.StopTimer()
+	g := New(n)
+	for m := 3 * n; m > 0; m-- {
+		g.Add(rand.Intn(n), rand.Intn(n))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		AllCycles(g, 0, func([]int) (skip bool) { return })
+	}
+}
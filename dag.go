@@ -0,0 +1,138 @@
+package graph
+
+// ShortestPathDAG computes a shortest path from v to w in a directed
+// acyclic graph, where edges may have negative costs.
+// The number dist is the length of the path, or Max if w cannot be reached.
+// If g isn't acyclic, it returns an empty path and sets ok to false.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func ShortestPathDAG(g Iterator, v, w int) (path []int, dist int64, ok bool) {
+	parent, distances, ok := ShortestPathsDAG(g, v)
+	return dagPath(parent, distances, w, Max, ok)
+}
+
+// ShortestPathsDAG computes the shortest paths from v to all other vertices
+// in a directed acyclic graph, where edges may have negative costs.
+// The number parent[w] is the predecessor of w on a shortest path from v to w,
+// or -1 if none exists.
+// The number dist[w] equals the length of a shortest path from v to w,
+// or is Max if w cannot be reached.
+// If g isn't acyclic, it returns nil slices and sets ok to false.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func ShortestPathsDAG(g Iterator, v int) (parent []int, dist []int64, ok bool) {
+	return dagPaths(g, v, Max, func(alt, d int64) bool { return alt < d })
+}
+
+// LongestPathDAG computes a longest path from v to w in a directed
+// acyclic graph, where edges may have negative costs.
+// The number dist is the length of the path, or Min if w cannot be reached.
+// If g isn't acyclic, it returns an empty path and sets ok to false.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func LongestPathDAG(g Iterator, v, w int) (path []int, dist int64, ok bool) {
+	parent, distances, ok := LongestPathsDAG(g, v)
+	return dagPath(parent, distances, w, Min, ok)
+}
+
+// LongestPathsDAG computes the longest paths from v to all other vertices
+// in a directed acyclic graph, where edges may have negative costs.
+// The number parent[w] is the predecessor of w on a longest path from v to w,
+// or -1 if none exists.
+// The number dist[w] equals the length of a longest path from v to w,
+// or is Min if w cannot be reached.
+// If g isn't acyclic, it returns nil slices and sets ok to false.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func LongestPathsDAG(g Iterator, v int) (parent []int, dist []int64, ok bool) {
+	return dagPaths(g, v, Min, func(alt, d int64) bool { return alt > d })
+}
+
+// CriticalPath computes a longest path in a directed acyclic graph,
+// where edges may have negative costs. In a project schedule, where each edge
+// is a task with a duration, this is a sequence of tasks that determines
+// the minimum time needed to complete the project.
+// The number dist is the length of the path.
+// If g isn't acyclic, it returns an empty path and sets ok to false.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func CriticalPath(g Iterator) (path []int, dist int64, ok bool) {
+	order, ok := TopSort(g)
+	if !ok {
+		return []int{}, 0, false
+	}
+	n := g.Order()
+	parent := make([]int, n)
+	distances := make([]int64, n) // All paths may start anywhere.
+	for i := range parent {
+		parent[i] = -1
+	}
+	for _, v := range order {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if alt := distances[v] + c; alt > distances[w] {
+				distances[w], parent[w] = alt, v
+			}
+			return
+		})
+	}
+	if n == 0 {
+		return []int{}, 0, true
+	}
+	end := 0
+	for v, d := range distances {
+		if d > distances[end] {
+			end = v
+		}
+	}
+	return followParents(parent, end), distances[end], true
+}
+
+// dagPaths relaxes the edges of g in topological order starting at v.
+// The number none represents unreachable vertices,
+// and better(alt, d) tells if alt is better than d.
+func dagPaths(g Iterator, v int, none int64, better func(alt, d int64) bool) (parent []int, dist []int64, ok bool) {
+	order, ok := TopSort(g)
+	if !ok {
+		return
+	}
+	n := g.Order()
+	dist = make([]int64, n)
+	parent = make([]int, n)
+	for i := range dist {
+		dist[i], parent[i] = none, -1
+	}
+	dist[v] = 0
+
+	// Skip the vertices that come before v; they can't be reached.
+	i := 0
+	for order[i] != v {
+		i++
+	}
+	for _, v := range order[i:] {
+		if dist[v] == none {
+			continue
+		}
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if alt := dist[v] + c; dist[w] == none || better(alt, dist[w]) {
+				dist[w], parent[w] = alt, v
+			}
+			return
+		})
+	}
+	return
+}
+
+func dagPath(parent []int, dist []int64, w int, none int64, ok bool) ([]int, int64, bool) {
+	switch {
+	case !ok:
+		return []int{}, 0, false
+	case dist[w] == none:
+		return []int{}, none, true
+	}
+	return followParents(parent, w), dist[w], true
+}
@@ -0,0 +1,160 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestShortestPathDAG(t *testing.T) {
+	g := New(6)
+	g.AddCost(0, 1, 5)
+	g.AddCost(0, 2, 3)
+	g.AddCost(1, 2, 2)
+	g.AddCost(1, 3, 6)
+	g.AddCost(2, 3, 7)
+	g.AddCost(2, 4, 4)
+	g.AddCost(2, 5, 2)
+	g.AddCost(3, 4, -1)
+	g.AddCost(3, 5, 1)
+	g.AddCost(4, 5, -2)
+
+	parent, dist, ok := ShortestPathsDAG(g, 1)
+	if mess, diff := diff(parent, []int{-1, -1, 1, 1, 3, 4}); diff {
+		t.Errorf("ShortestPathsDAG->parent %s", mess)
+	}
+	if mess, diff := diff(dist, []int64{Max, 0, 2, 6, 5, 3}); diff {
+		t.Errorf("ShortestPathsDAG->dist %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("ShortestPathsDAG->ok %s", mess)
+	}
+
+	parent, dist, ok = LongestPathsDAG(g, 1)
+	if mess, diff := diff(parent, []int{-1, -1, 1, 2, 3, 3}); diff {
+		t.Errorf("LongestPathsDAG->parent %s", mess)
+	}
+	if mess, diff := diff(dist, []int64{Min, 0, 2, 9, 8, 10}); diff {
+		t.Errorf("LongestPathsDAG->dist %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("LongestPathsDAG->ok %s", mess)
+	}
+
+	path, d, ok := ShortestPathDAG(g, 0, 5)
+	if mess, diff := diff(path, []int{0, 2, 5}); diff {
+		t.Errorf("ShortestPathDAG->path %s", mess)
+	}
+	if mess, diff := diff(d, int64(5)); diff {
+		t.Errorf("ShortestPathDAG->dist %s", mess)
+	}
+	path, d, ok = LongestPathDAG(g, 0, 5)
+	if mess, diff := diff(path, []int{0, 1, 2, 3, 5}); diff {
+		t.Errorf("LongestPathDAG->path %s", mess)
+	}
+	if mess, diff := diff(d, int64(15)); diff {
+		t.Errorf("LongestPathDAG->dist %s", mess)
+	}
+	path, d, ok = ShortestPathDAG(g, 5, 0)
+	if mess, diff := diff(path, []int{}); diff {
+		t.Errorf("ShortestPathDAG->path %s", mess)
+	}
+	if mess, diff := diff(d, Max); diff {
+		t.Errorf("ShortestPathDAG->dist %s", mess)
+	}
+	path, d, ok = LongestPathDAG(g, 5, 0)
+	if mess, diff := diff(d, Min); diff {
+		t.Errorf("LongestPathDAG->dist %s", mess)
+	}
+
+	path, d, ok = CriticalPath(g)
+	if mess, diff := diff(path, []int{0, 1, 2, 3, 5}); diff {
+		t.Errorf("CriticalPath->path %s", mess)
+	}
+	if mess, diff := diff(d, int64(15)); diff {
+		t.Errorf("CriticalPath->dist %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("CriticalPath->ok %s", mess)
+	}
+
+	g.Add(5, 0)
+	_, _, ok = ShortestPathsDAG(g, 0)
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("ShortestPathsDAG->ok %s", mess)
+	}
+	path, _, ok = LongestPathDAG(g, 0, 5)
+	if mess, diff := diff(path, []int{}); diff {
+		t.Errorf("LongestPathDAG->path %s", mess)
+	}
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("LongestPathDAG->ok %s", mess)
+	}
+	_, _, ok = CriticalPath(g)
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("CriticalPath->ok %s", mess)
+	}
+
+	path, d, ok = CriticalPath(New(0))
+	if mess, diff := diff(path, []int{}); diff {
+		t.Errorf("CriticalPath->path %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("CriticalPath->ok %s", mess)
+	}
+}
+
+func TestShortestPathsDAGRandom(t *testing.T) {
+	n := 100
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		v, w := rand.Intn(n), rand.Intn(n)
+		if v < w {
+			g.AddCost(v, w, int64(rand.Intn(20)-10))
+		}
+	}
+	_, exp, _ := BellmanFord(g, 0)
+	_, dist, ok := ShortestPathsDAG(g, 0)
+	if !ok {
+		t.Errorf("ShortestPathsDAG->ok false; want true")
+	}
+	if mess, diff := diff(dist, exp); diff {
+		t.Errorf("ShortestPathsDAG->dist %s", mess)
+	}
+
+	// A longest path is a shortest path with negated costs.
+	h := New(n)
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			h.AddCost(v, w, -c)
+			return
+		})
+	}
+	_, exp, _ = BellmanFord(h, 0)
+	_, dist, _ = LongestPathsDAG(g, 0)
+	for v := range dist {
+		if exp[v] == Max {
+			exp[v] = Min
+		} else {
+			exp[v] = -exp[v]
+		}
+	}
+	if mess, diff := diff(dist, exp); diff {
+		t.Errorf("LongestPathsDAG->dist %s", mess)
+	}
+}
+
+func BenchmarkShortestPathsDAG(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		v, w := rand.Intn(n), rand.Intn(n)
+		if v < w {
+			g.AddCost(v, w, int64(rand.Intn(20)-10))
+		}
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = ShortestPathsDAG(g, 0)
+	}
+}
@@ -0,0 +1,129 @@
+package graph
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Degrees returns the indegree and outdegree of each vertex of g,
+// computed in a single pass over the edges.
+// A self-loop adds one to both the indegree and the outdegree.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func Degrees(g Iterator) (in, out []int) {
+	n := g.Order()
+	in, out = make([]int, n), make([]int, n)
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			if w < 0 || w >= n {
+				panic("vertex out of range: " + strconv.Itoa(w))
+			}
+			in[w]++
+			out[v]++
+			return
+		})
+	}
+	return
+}
+
+// DegreeHistogram returns the degree distribution of a list of degrees,
+// such as the indegrees or outdegrees returned by Degrees.
+// The number hist[d] is the number of vertices of degree d,
+// and the list ends with the largest degree.
+func DegreeHistogram(degree []int) (hist []int) {
+	hist = []int{}
+	for _, d := range degree {
+		if d < 0 {
+			panic("negative degree: " + strconv.Itoa(d))
+		}
+		for len(hist) <= d {
+			hist = append(hist, 0)
+		}
+		hist[d]++
+	}
+	return
+}
+
+// IsGraphical tells if the degree sequence seq is graphical, that is,
+// if there is a simple undirected graph, without self-loops or
+// multiple edges, in which vertex v has degree seq[v].
+//
+// The implementation uses the Erdős–Gallai theorem.
+// The time complexity is O(n log n), where n is the length of seq.
+func IsGraphical(seq []int) bool {
+	n := len(seq)
+	d := make([]int, n)
+	copy(d, seq)
+	sort.Sort(sort.Reverse(sort.IntSlice(d)))
+	sum := 0
+	for _, x := range d {
+		if x < 0 || x >= n {
+			return false
+		}
+		sum += x
+	}
+	if sum%2 != 0 {
+		return false
+	}
+	// suffix[i] is the sum of d[i:].
+	suffix := make([]int, n+1)
+	for i := n - 1; i >= 0; i-- {
+		suffix[i] = suffix[i+1] + d[i]
+	}
+	// The k largest degrees must add up to at most k(k-1) + Σ min(d[i], k)
+	// for i ≥ k. Since d is decreasing, d[i] ≥ k exactly when i < p.
+	left, p := 0, n
+	for k := 1; k <= n; k++ {
+		left += d[k-1]
+		for p > 0 && d[p-1] < k {
+			p--
+		}
+		j := max(p, k)
+		if left > k*(k-1)+k*(j-k)+suffix[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// HavelHakimi returns a simple undirected graph, without self-loops
+// or multiple edges, in which vertex v has degree seq[v].
+// If there is no such graph, it returns nil and sets ok to false.
+//
+// The graph is built by the Havel–Hakimi algorithm, which repeatedly
+// connects a vertex of largest remaining degree to the vertices
+// with the next largest degrees.
+// The time complexity is O(n² log n), where n is the length of seq.
+func HavelHakimi(seq []int) (g *Mutable, ok bool) {
+	n := len(seq)
+	left := make([]int, n) // left[v] is the remaining degree of v
+	order := make([]int, n)
+	for v, d := range seq {
+		if d < 0 || d >= n {
+			return nil, false
+		}
+		left[v], order[v] = d, v
+	}
+	g = New(n)
+	for n > 0 {
+		sort.Slice(order, func(i, j int) bool {
+			a, b := order[i], order[j]
+			return left[a] > left[b] || left[a] == left[b] && a < b
+		})
+		v := order[0]
+		d := left[v]
+		if d == 0 {
+			return g, true
+		}
+		if d >= n || left[order[d]] == 0 {
+			return nil, false
+		}
+		left[v] = 0
+		for _, w := range order[1 : d+1] {
+			g.AddBoth(v, w)
+			left[w]--
+		}
+	}
+	return g, true
+}
@@ -0,0 +1,167 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestDegrees(t *testing.T) {
+	g := New(4)
+	g.Add(0, 1)
+	g.Add(0, 2)
+	g.Add(2, 2)
+	g.AddBoth(1, 2)
+	in, out := Degrees(g)
+	if mess, diff := diff(in, []int{0, 2, 3, 0}); diff {
+		t.Errorf("Degrees->in %s", mess)
+	}
+	if mess, diff := diff(out, []int{2, 1, 2, 0}); diff {
+		t.Errorf("Degrees->out %s", mess)
+	}
+
+	in, out = Degrees(New(0))
+	if mess, diff := diff(in, []int{}); diff {
+		t.Errorf("Degrees->in %s", mess)
+	}
+	if mess, diff := diff(out, []int{}); diff {
+		t.Errorf("Degrees->out %s", mess)
+	}
+}
+
+func TestDegreeHistogram(t *testing.T) {
+	if mess, diff := diff(DegreeHistogram([]int{2, 1, 2, 0, 4}), []int{1, 1, 2, 0, 1}); diff {
+		t.Errorf("DegreeHistogram %s", mess)
+	}
+	if mess, diff := diff(DegreeHistogram(nil), []int{}); diff {
+		t.Errorf("DegreeHistogram %s", mess)
+	}
+	if mess, diff := diff(DegreeHistogram([]int{0, 0}), []int{2}); diff {
+		t.Errorf("DegreeHistogram %s", mess)
+	}
+}
+
+func TestIsGraphical(t *testing.T) {
+	for _, s := range []struct {
+		seq []int
+		exp bool
+	}{
+		{nil, true},
+		{[]int{0}, true},
+		{[]int{1}, false},
+		{[]int{1, 1}, true},
+		{[]int{2, 2, 2}, true},
+		{[]int{3, 3, 1, 1}, false},
+		{[]int{3, 3, 3, 3}, true},
+		{[]int{1, 1, 1}, false},
+		{[]int{-1, 1}, false},
+		{[]int{4, 1, 1, 1, 1}, true},
+		{[]int{3, 3, 3, 1}, false},
+	} {
+		if mess, diff := diff(IsGraphical(s.seq), s.exp); diff {
+			t.Errorf("IsGraphical(%v) %s", s.seq, mess)
+		}
+		g, ok := HavelHakimi(s.seq)
+		if mess, diff := diff(ok, s.exp); diff {
+			t.Errorf("HavelHakimi(%v)->ok %s", s.seq, mess)
+		}
+		if ok {
+			checkDegreeSequence(t, g, s.seq)
+		}
+	}
+}
+
+// checkDegreeSequence checks that g is a simple undirected graph
+// with the given degrees.
+func checkDegreeSequence(t *testing.T, g *Mutable, seq []int) {
+	t.Helper()
+	for v, d := range seq {
+		if g.Degree(v) != d {
+			t.Errorf("Degree(%d) = %d; want %d", v, g.Degree(v), d)
+		}
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			if v == w || !g.Edge(w, v) {
+				t.Errorf("bad edge (%d, %d) in %v", v, w, g)
+			}
+			return
+		})
+	}
+}
+
+// graphical returns the degree sequences of all simple graphs
+// with n vertices.
+func graphical(n int) map[string]bool {
+	type pair struct{ v, w int }
+	var pairs []pair
+	for v := 0; v < n; v++ {
+		for w := v + 1; w < n; w++ {
+			pairs = append(pairs, pair{v, w})
+		}
+	}
+	res := make(map[string]bool)
+	for set := 0; set < 1<<uint(len(pairs)); set++ {
+		seq := make([]int, n)
+		for i, p := range pairs {
+			if set&(1<<uint(i)) != 0 {
+				seq[p.v]++
+				seq[p.w]++
+			}
+		}
+		res[fmt.Sprint(seq)] = true
+	}
+	return res
+}
+
+func TestIsGraphicalRandom(t *testing.T) {
+	for n := 0; n <= 5; n++ {
+		exp := graphical(n)
+		for i := 0; i < 200; i++ {
+			seq := make([]int, n)
+			for v := range seq {
+				seq[v] = rand.Intn(n + 1)
+			}
+			ok := exp[fmt.Sprint(seq)]
+			if mess, diff := diff(IsGraphical(seq), ok); diff {
+				t.Errorf("IsGraphical(%v) %s", seq, mess)
+			}
+			g, res := HavelHakimi(seq)
+			if mess, diff := diff(res, ok); diff {
+				t.Errorf("HavelHakimi(%v)->ok %s", seq, mess)
+			}
+			if res {
+				checkDegreeSequence(t, g, seq)
+			}
+		}
+	}
+	for i := 0; i < 20; i++ {
+		n := 1 + rand.Intn(100)
+		g := New(n)
+		for m := rand.Intn(n*n/4 + 1); m > 0; m-- {
+			if v, w := rand.Intn(n), rand.Intn(n); v != w {
+				g.AddBoth(v, w)
+			}
+		}
+		_, seq := Degrees(g)
+		if !IsGraphical(seq) {
+			t.Errorf("IsGraphical(%v) false for graph %v", seq, g)
+		}
+		h, ok := HavelHakimi(seq)
+		if !ok {
+			t.Errorf("HavelHakimi(%v) failed for graph %v", seq, g)
+			continue
+		}
+		checkDegreeSequence(t, h, seq)
+	}
+}
+
+func BenchmarkHavelHakimi(b *testing.B) {
+	b.StopTimer()
+	seq := make([]int, 1000)
+	for v := range seq {
+		seq[v] = 4
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = HavelHakimi(seq)
+	}
+}
@@ -0,0 +1,221 @@
+package graph
+
+import (
+	"container/heap"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// parallelMinOrder is the smallest graph for which ShortestPathsParallel
+// doesn't fall back to Dijkstra's algorithm.
+const parallelMinOrder = 1 << 12
+
+// ShortestPathsParallel computes the shortest paths from v to all other
+// vertices, like ShortestPaths, using the given number of worker goroutines,
+// or all available CPUs if workers ≤ 0. Only edges with non-negative costs
+// are included. The numbers parent[w] and dist[w] are the same as for
+// ShortestPaths, except that another shortest path may be chosen when
+// there are several.
+// The Visit method of g must be safe for concurrent use; this is true
+// for Mutable and Immutable graphs that aren't modified during the call.
+//
+// The implementation uses the delta-stepping algorithm by Meyer and Sanders,
+// which keeps the tentative distances in buckets of width delta and relaxes
+// the edges of all vertices in the first nonempty bucket in parallel.
+// A small delta gives less wasted work, a large delta more parallelism;
+// if delta ≤ 0, the maximum edge cost divided by the average degree is used.
+// Small graphs, and calls with a single worker, fall back to ShortestPaths.
+//
+// The time complexity is O(|E| + |V| + L⋅(|E| + |V|)/delta) in the worst case,
+// where L is the length of the longest shortest path, |E| the number of edges
+// and |V| the number of vertices in the graph. For random costs, the work
+// is close to linear and is split evenly among the workers.
+func ShortestPathsParallel(g Iterator, v int, delta int64, workers int) (parent []int, dist []int64) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers == 1 || g.Order() < parallelMinOrder {
+		return ShortestPaths(g, v)
+	}
+	return deltaStepping(g, v, delta, workers)
+}
+
+func deltaStepping(g Iterator, v int, delta int64, workers int) (parent []int, dist []int64) {
+	n := g.Order()
+	if v < 0 || v >= n {
+		panic("vertex out of range: " + strconv.Itoa(v))
+	}
+	if delta <= 0 {
+		delta = defaultDelta(g, workers)
+	}
+	dist = make([]int64, n)
+	parent = make([]int, n)
+	for i := range dist {
+		dist[i], parent[i] = -1, -1
+	}
+	dist[v] = 0
+
+	// A request asks to relax the edge from v into w, giving distance d.
+	type request struct {
+		v, w int
+		d    int64
+	}
+	// out[i][j] holds the requests made by worker i for vertices w
+	// with w % workers == j, so that worker j can apply them alone.
+	out := make([][][]request, workers)
+	for i := range out {
+		out[i] = make([][]request, workers)
+	}
+	changed := make([][]int, workers)
+	stamp := make([]int, n) // the last round in which v was changed or taken
+	round := 0
+	run := func(f func(i int)) {
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				f(i)
+			}(i)
+		}
+		wg.Wait()
+	}
+
+	// relax relaxes the light or heavy edges of the vertices in frontier,
+	// and returns the vertices whose distance changed.
+	relax := func(frontier []int, light bool) []int {
+		round++
+		run(func(i int) {
+			req := out[i]
+			for j := range req {
+				req[j] = req[j][:0]
+			}
+			lo, hi := i*len(frontier)/workers, (i+1)*len(frontier)/workers
+			for _, v := range frontier[lo:hi] {
+				g.Visit(v, func(w int, c int64) (skip bool) {
+					if c < 0 || (c <= delta) != light {
+						return
+					}
+					if d := dist[v] + c; dist[w] == -1 || d < dist[w] {
+						req[w%workers] = append(req[w%workers], request{v, w, d})
+					}
+					return
+				})
+			}
+		})
+		run(func(j int) {
+			changed[j] = changed[j][:0]
+			for i := range out {
+				for _, r := range out[i][j] {
+					if dist[r.w] != -1 && r.d >= dist[r.w] {
+						continue
+					}
+					dist[r.w], parent[r.w] = r.d, r.v
+					if stamp[r.w] != round {
+						stamp[r.w] = round
+						changed[j] = append(changed[j], r.w)
+					}
+				}
+			}
+		})
+		var all []int
+		for _, c := range changed {
+			all = append(all, c...)
+		}
+		return all
+	}
+
+	buckets := make(map[int64][]int)
+	var order bucketHeap
+	add := func(vs []int) {
+		for _, w := range vs {
+			k := dist[w] / delta
+			if _, ok := buckets[k]; !ok {
+				heap.Push(&order, k)
+			}
+			buckets[k] = append(buckets[k], w)
+		}
+	}
+	add([]int{v})
+	for order.Len() > 0 {
+		k := heap.Pop(&order).(int64)
+		var settled []int
+		for len(buckets[k]) > 0 {
+			// Take the vertices that still belong to bucket k, once each.
+			round++
+			var frontier []int
+			for _, w := range buckets[k] {
+				if dist[w]/delta == k && stamp[w] != round {
+					stamp[w] = round
+					frontier = append(frontier, w)
+				}
+			}
+			buckets[k] = nil
+			settled = append(settled, frontier...)
+			add(relax(frontier, true))
+		}
+		delete(buckets, k)
+
+		// The distances in bucket k are now final.
+		round++
+		frontier := settled[:0]
+		for _, w := range settled {
+			if stamp[w] != round {
+				stamp[w] = round
+				frontier = append(frontier, w)
+			}
+		}
+		add(relax(frontier, false))
+	}
+	return
+}
+
+// defaultDelta returns the maximum edge cost of g divided by the average
+// degree, and at least 1.
+func defaultDelta(g Iterator, workers int) int64 {
+	var mu sync.Mutex
+	var maxCost, m int64
+	parallel(g.Order(), workers, func(lo, hi int) int {
+		var max, edges int64
+		for v := lo; v < hi; v++ {
+			g.Visit(v, func(w int, c int64) (skip bool) {
+				if c >= 0 {
+					edges++
+					if c > max {
+						max = c
+					}
+				}
+				return
+			})
+		}
+		mu.Lock()
+		m += edges
+		if max > maxCost {
+			maxCost = max
+		}
+		mu.Unlock()
+		return -1
+	})
+	if m == 0 {
+		return 1
+	}
+	if d := int64(float64(maxCost) * float64(g.Order()) / float64(m)); d > 1 {
+		return d
+	}
+	return 1
+}
+
+// bucketHeap is a min-heap of bucket indices.
+type bucketHeap []int64
+
+func (h bucketHeap) Len() int            { return len(h) }
+func (h bucketHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h bucketHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *bucketHeap) Push(x interface{}) { *h = append(*h, x.(int64)) }
+func (h *bucketHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,76 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestShortestPathsParallel(t *testing.T) {
+	g := New(6)
+	g.AddCost(0, 1, 1)
+	g.AddCost(0, 2, 1)
+	g.AddCost(0, 3, 3)
+	g.AddCost(1, 3, 0)
+	g.AddCost(2, 3, 1)
+	g.AddCost(2, 5, 8)
+	g.AddCost(3, 5, 7)
+	g.AddCost(1, 5, -1)
+	for _, delta := range []int64{0, 1, 2, 100} {
+		parent, dist := deltaStepping(g, 0, delta, 3)
+		if mess, diff := diff(parent, []int{-1, 0, 0, 1, -1, 3}); diff {
+			t.Errorf("deltaStepping(%d)->parent %s", delta, mess)
+		}
+		if mess, diff := diff(dist, []int64{0, 1, 1, 1, -1, 8}); diff {
+			t.Errorf("deltaStepping(%d)->dist %s", delta, mess)
+		}
+	}
+
+	for n := 1; n < 50; n++ {
+		g := New(n)
+		for m := rand.Intn(4*n + 1); m > 0; m-- {
+			g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(20)-1)
+		}
+		v := rand.Intn(n)
+		_, expDist := ShortestPaths(g, v)
+		parent, dist := deltaStepping(g, v, rand.Int63n(10), 1+rand.Intn(4))
+		if mess, diff := diff(dist, expDist); diff {
+			t.Errorf("deltaStepping->dist %s", mess)
+		}
+		for w, p := range parent {
+			if p == -1 {
+				if w != v && dist[w] != -1 {
+					t.Errorf("deltaStepping->parent[%d] = -1", w)
+				}
+				continue
+			}
+			if c := g.Cost(p, w); !g.Edge(p, w) || dist[p]+c != dist[w] {
+				t.Errorf("deltaStepping->parent[%d] = %d is not on a shortest path", w, p)
+			}
+		}
+	}
+
+	n := parallelMinOrder
+	h := New(n)
+	for m := 4 * n; m > 0; m-- {
+		h.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(1000))
+	}
+	_, expDist := ShortestPaths(h, 0)
+	_, dist := ShortestPathsParallel(h, 0, 0, 4)
+	if mess, diff := diff(dist, expDist); diff {
+		t.Errorf("ShortestPathsParallel->dist %s", mess)
+	}
+}
+
+func BenchmarkShortestPathsParallel(b *testing.B) {
+	b.StopTimer()
+	n := 1 << 16
+	g := New(n)
+	for i := 0; i < 10*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(1000))
+	}
+	h := Sort(g)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ShortestPathsParallel(h, 0, 0, 0)
+	}
+}
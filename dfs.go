@@ -0,0 +1,132 @@
+package graph
+
+// DFSEvents holds the functions called by a depth-first search.
+// Nil functions are ignored.
+//
+// In an undirected graph each edge is seen from both of its endpoints;
+// the edge back to the parent of a vertex is reported as a back edge.
+type DFSEvents struct {
+	// Discover is called when v is visited for the first time.
+	Discover func(v int)
+
+	// Finish is called when all neighbors of v have been explored.
+	Finish func(v int)
+
+	// TreeEdge is called for an edge (v, w) leading to
+	// a previously unvisited vertex w.
+	TreeEdge func(v, w int, c int64)
+
+	// BackEdge is called for an edge (v, w) leading to
+	// a vertex w that is an ancestor of v, or v itself.
+	BackEdge func(v, w int, c int64)
+
+	// ForwardCrossEdge is called for an edge (v, w) leading to
+	// a vertex w that is already finished: either a descendant of v
+	// or a vertex in another branch of the search.
+	ForwardCrossEdge func(v, w int, c int64)
+}
+
+// DFS traverses g in depth-first order, starting at each unvisited
+// vertex in numerical order, and calls the functions in ev as it goes.
+// The search is iterative and doesn't use the call stack,
+// so it can handle very deep graphs.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func DFS(g Iterator, ev DFSEvents) {
+	newDFS(g, ev).searchAll()
+}
+
+// DFSFrom traverses the part of g reachable from v in depth-first order,
+// calling the functions in ev as it goes.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func DFSFrom(g Iterator, v int, ev DFSEvents) {
+	newDFS(g, ev).search(v)
+}
+
+const (
+	white = iota // Not yet visited.
+	gray         // On the search path.
+	black        // Finished.
+)
+
+type dfs struct {
+	g     Iterator
+	ev    DFSEvents
+	color []byte
+	// The neighbors of the vertices on the search path,
+	// stored in a single buffer.
+	neighbors []neighbor
+	path      []dfsFrame
+	stop      bool // Set to abort the search.
+}
+
+type dfsFrame struct {
+	v, start, next int
+}
+
+func newDFS(g Iterator, ev DFSEvents) *dfs {
+	return &dfs{
+		g:     g,
+		ev:    ev,
+		color: make([]byte, g.Order()),
+	}
+}
+
+func (d *dfs) discover(v int) {
+	d.color[v] = gray
+	if d.ev.Discover != nil {
+		d.ev.Discover(v)
+	}
+	start := len(d.neighbors)
+	d.g.Visit(v, func(w int, c int64) (skip bool) {
+		d.neighbors = append(d.neighbors, neighbor{w, c})
+		return
+	})
+	d.path = append(d.path, dfsFrame{v, start, start})
+}
+
+// searchAll starts a search at each unvisited vertex.
+func (d *dfs) searchAll() {
+	for v := range d.color {
+		if d.color[v] == white && !d.stop {
+			d.search(v)
+		}
+	}
+}
+
+func (d *dfs) search(v int) {
+	d.discover(v)
+	for len(d.path) > 0 && !d.stop {
+		f := &d.path[len(d.path)-1]
+		v := f.v
+		if f.next == len(d.neighbors) {
+			d.color[v] = black
+			d.neighbors = d.neighbors[:f.start]
+			d.path = d.path[:len(d.path)-1]
+			if d.ev.Finish != nil {
+				d.ev.Finish(v)
+			}
+			continue
+		}
+		e := d.neighbors[f.next]
+		f.next++
+		switch d.color[e.vertex] {
+		case white:
+			if d.ev.TreeEdge != nil {
+				d.ev.TreeEdge(v, e.vertex, e.cost)
+			}
+			d.discover(e.vertex)
+		case gray:
+			if d.ev.BackEdge != nil {
+				d.ev.BackEdge(v, e.vertex, e.cost)
+			}
+		default:
+			if d.ev.ForwardCrossEdge != nil {
+				d.ev.ForwardCrossEdge(v, e.vertex, e.cost)
+			}
+		}
+	}
+}
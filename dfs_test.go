@@ -0,0 +1,83 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestDFS(t *testing.T) {
+	//  0 --> 1 --> 2
+	//  |  \  ^     |
+	//  v   \ |     v
+	//  3     4 <-- 5
+	g := New(7)
+	g.Add(0, 1)
+	g.Add(0, 3)
+	g.Add(0, 4)
+	g.Add(1, 2)
+	g.Add(2, 5)
+	g.Add(5, 4)
+	g.Add(4, 1)
+	g.Add(3, 3)
+	g.Add(6, 3)
+	var res []string
+	ev := DFSEvents{
+		Discover:         func(v int) { res = append(res, fmt.Sprint("d", v)) },
+		Finish:           func(v int) { res = append(res, fmt.Sprint("f", v)) },
+		TreeEdge:         func(v, w int, _ int64) { res = append(res, fmt.Sprint("t", v, w)) },
+		BackEdge:         func(v, w int, _ int64) { res = append(res, fmt.Sprint("b", v, w)) },
+		ForwardCrossEdge: func(v, w int, _ int64) { res = append(res, fmt.Sprint("c", v, w)) },
+	}
+	DFS(Sort(g), ev)
+	exp := []string{
+		"d0", "t0 1", "d1", "t1 2", "d2", "t2 5", "d5", "t5 4", "d4", "b4 1",
+		"f4", "f5", "f2", "f1", "t0 3", "d3", "b3 3", "f3", "c0 4", "f0",
+		"d6", "c6 3", "f6",
+	}
+	if mess, diff := diff(res, exp); diff {
+		t.Errorf("DFS %s", mess)
+	}
+
+	res = nil
+	DFSFrom(Sort(g), 5, ev)
+	exp = []string{"d5", "t5 4", "d4", "t4 1", "d1", "t1 2", "d2", "b2 5", "f2", "f1", "f4", "f5"}
+	if mess, diff := diff(res, exp); diff {
+		t.Errorf("DFSFrom %s", mess)
+	}
+
+	// Nil functions are ignored.
+	DFS(g, DFSEvents{})
+}
+
+func TestDFSDeep(t *testing.T) {
+	n := 100000
+	g := New(n)
+	for v := 0; v < n-1; v++ {
+		g.Add(v, v+1)
+	}
+	finished := 0
+	DFS(g, DFSEvents{Finish: func(v int) {
+		if v != n-1-finished {
+			t.Fatalf("Finish(%d); want %d", v, n-1-finished)
+		}
+		finished++
+	}})
+	if mess, diff := diff(finished, n); diff {
+		t.Errorf("DFS %s", mess)
+	}
+}
+
+func BenchmarkDFS(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < n; i++ {
+		g.Add(0, rand.Intn(n))
+		g.Add(rand.Intn(n), rand.Intn(n))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		DFS(g, DFSEvents{})
+	}
+}
@@ -0,0 +1,124 @@
+package graph
+
+import "strconv"
+
+// GreedyDominatingSet computes a small dominating set of g: a set of
+// vertices such that each vertex is in the set or has a neighbor in it.
+// The graph is treated as undirected, and self-loops are ignored.
+// The vertices of the set are sorted in increasing order.
+//
+// Finding a minimum dominating set is NP-hard. This implementation
+// uses the greedy heuristic: it repeatedly picks a vertex that dominates
+// the most vertices not yet dominated, breaking ties by vertex number.
+// The set is at most H(Δ+1) ≤ 1 + ln(Δ+1) times larger than a minimum
+// dominating set, where Δ is the maximum degree.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func GreedyDominatingSet(g Iterator) (set []int) {
+	adj := undirected(g)
+	n := len(adj)
+	// gain[v] is the number of vertices not yet dominated among v and
+	// its neighbors. The vertices are kept in buckets by gain, with lazy
+	// deletion: an entry is skipped if the gain of the vertex has changed.
+	gain := make([]int, n)
+	bucket := make([][]int, n+1)
+	for v := n - 1; v >= 0; v-- {
+		gain[v] = len(adj[v]) + 1
+		bucket[gain[v]] = append(bucket[gain[v]], v)
+	}
+	dominated := make([]bool, n)
+	dominate := func(v int) {
+		dominated[v] = true
+		gain[v]--
+		bucket[gain[v]] = append(bucket[gain[v]], v)
+		for _, w := range adj[v] {
+			gain[w]--
+			bucket[gain[w]] = append(bucket[gain[w]], w)
+		}
+	}
+	in := make([]bool, n)
+	for d := n; d > 0; {
+		l := len(bucket[d])
+		if l == 0 {
+			d--
+			continue
+		}
+		v := bucket[d][l-1]
+		bucket[d] = bucket[d][:l-1]
+		if gain[v] != d {
+			continue // An outdated entry.
+		}
+		in[v] = true
+		if !dominated[v] {
+			dominate(v)
+		}
+		for _, w := range adj[v] {
+			if !dominated[w] {
+				dominate(w)
+			}
+		}
+	}
+	return members(in, true)
+}
+
+// KCenter computes k centers in g such that the radius, the largest
+// distance to a vertex from its nearest center, is small.
+// Only edges with non-negative costs are included, and the distances
+// are measured along shortest paths from the centers. The radius is -1
+// if some vertex cannot be reached from any center. The search stops
+// early if the radius becomes 0, for example if k is larger than the
+// number of vertices. It panics if k < 1.
+//
+// Finding an optimal set of centers is NP-hard. This implementation uses
+// the farthest-first traversal of Gonzalez: the first center is vertex 0,
+// and each following center is a vertex that is farthest from the centers
+// picked so far, with unreachable vertices first and ties broken by vertex
+// number. The centers are listed in the order they were picked, and the
+// first i of them are the centers for k = i. If g is undirected, the
+// radius is at most twice the optimal radius.
+//
+// The time complexity is O(k⋅(|E| + |V|)⋅log|V|), where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func KCenter(g Iterator, k int) (centers []int, radius int64) {
+	if k < 1 {
+		panic("invalid number of centers: " + strconv.Itoa(k))
+	}
+	n := g.Order()
+	centers = []int{}
+	if n == 0 {
+		return centers, 0
+	}
+	// dist[v] is the distance from the nearest center to v, or -1.
+	dist := make([]int64, n)
+	for v := range dist {
+		dist[v] = -1
+	}
+	for next := 0; ; {
+		centers = append(centers, next)
+		_, d := ShortestPaths(g, next)
+		for v, x := range d {
+			if x != -1 && (dist[v] == -1 || x < dist[v]) {
+				dist[v] = x
+			}
+		}
+		next, radius = farthest(dist)
+		if radius == 0 || len(centers) == k {
+			return centers, radius
+		}
+	}
+}
+
+// farthest returns the first vertex with the largest distance,
+// where -1 counts as infinity, and the distance.
+func farthest(dist []int64) (v int, d int64) {
+	for w, x := range dist {
+		switch {
+		case x == -1:
+			return w, -1
+		case x > d:
+			v, d = w, x
+		}
+	}
+	return
+}
@@ -0,0 +1,127 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkDominating checks that set is a sorted dominating set of g.
+func checkDominating(t *testing.T, g Iterator, set []int) {
+	dominated := make([]bool, g.Order())
+	in := make([]bool, g.Order())
+	for i, v := range set {
+		if i > 0 && set[i-1] >= v {
+			t.Errorf("GreedyDominatingSet: %v not sorted", set)
+		}
+		in[v], dominated[v] = true, true
+	}
+	VisitAll(g, func(v, w int, _ int64) (skip bool) {
+		if in[v] {
+			dominated[w] = true
+		}
+		if in[w] {
+			dominated[v] = true
+		}
+		return
+	})
+	for v, ok := range dominated {
+		if !ok {
+			t.Errorf("GreedyDominatingSet: %d not dominated by %v", v, set)
+		}
+	}
+}
+
+func TestGreedyDominatingSet(t *testing.T) {
+	if mess, diff := diff(GreedyDominatingSet(New(0)), []int{}); diff {
+		t.Errorf("GreedyDominatingSet %s", mess)
+	}
+
+	// Two stars with centers 1 and 5, joined by the edge {1, 5},
+	// and an isolated vertex with a self-loop.
+	g := New(10)
+	for _, v := range []int{0, 2, 3, 4} {
+		g.AddBoth(1, v)
+	}
+	for _, v := range []int{6, 7, 8} {
+		g.Add(v, 5)
+	}
+	g.AddBoth(1, 5)
+	g.Add(9, 9)
+	if mess, diff := diff(GreedyDominatingSet(g), []int{1, 5, 9}); diff {
+		t.Errorf("GreedyDominatingSet %s", mess)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		n := r.Intn(50)
+		g := New(n)
+		for j := r.Intn(2*n + 1); j > 0; j-- {
+			g.Add(r.Intn(n), r.Intn(n))
+		}
+		checkDominating(t, g, GreedyDominatingSet(g))
+	}
+}
+
+func TestKCenter(t *testing.T) {
+	centers, radius := KCenter(New(0), 2)
+	if mess, diff := diff(centers, []int{}); diff || radius != 0 {
+		t.Errorf("KCenter %s %d", mess, radius)
+	}
+
+	// A path 0-1-...-9 with edges of cost 1.
+	g := New(10)
+	for v := 0; v < 9; v++ {
+		g.AddBothCost(v, v+1, 1)
+	}
+	for _, test := range []struct {
+		k       int
+		centers []int
+		radius  int64
+	}{
+		{1, []int{0}, 9},
+		{2, []int{0, 9}, 4},
+		{3, []int{0, 9, 4}, 2},
+		{20, []int{0, 9, 4, 2, 6, 1, 3, 5, 7, 8}, 0},
+	} {
+		centers, radius := KCenter(g, test.k)
+		if mess, diff := diff(centers, test.centers); diff {
+			t.Errorf("KCenter(%d) %s", test.k, mess)
+		}
+		if mess, diff := diff(radius, test.radius); diff {
+			t.Errorf("KCenter(%d)->radius %s", test.k, mess)
+		}
+	}
+
+	// Three components and unreachable vertices.
+	g = New(5)
+	g.AddBothCost(0, 1, 3)
+	g.AddBoth(2, 3)
+	_, radius = KCenter(g, 2)
+	if radius != -1 {
+		t.Errorf("KCenter(2)->radius %d; want -1", radius)
+	}
+	centers, radius = KCenter(g, 3)
+	if mess, diff := diff(centers, []int{0, 2, 4}); diff || radius != 3 {
+		t.Errorf("KCenter(3) %s %d", mess, radius)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("KCenter(0): no panic")
+		}
+	}()
+	KCenter(g, 0)
+}
+
+func BenchmarkKCenter(b *testing.B) {
+	n := 1000
+	g := New(n)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 5*n; i++ {
+		g.AddBothCost(r.Intn(n), r.Intn(n), r.Int63n(100))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KCenter(g, 10)
+	}
+}
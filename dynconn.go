@@ -0,0 +1,237 @@
+package graph
+
+import (
+	"math/bits"
+	"strconv"
+)
+
+// DynamicConnectivity maintains the connected components of an undirected
+// graph with vertices 0..n-1 while edges are added and removed.
+// Connected takes O(log n) time, and AddEdge and RemoveEdge take
+// O(log² n) amortized time.
+//
+// The implementation is the algorithm by Holm, de Lichtenberg and Thorup.
+// Each edge has a level, initially 0, and for each level i there is
+// a spanning forest of the edges with level at least i, stored as Euler
+// tours in treaps. When a tree edge is removed, a replacement edge is searched
+// for in the smaller of the two remaining trees, and the edges that fail
+// to reconnect the trees get a higher level, which can happen at most
+// log n times for each edge.
+type DynamicConnectivity struct {
+	levels int
+	vertex [][]*ettNode     // vertex[i][v] is the node of v at level i, or nil
+	adj    [][]map[int]bool // adj[i][v] holds the non-tree edges of level i at v
+	edges  map[[2]int]*dynEdge
+	count  int
+	seed   uint64
+}
+
+type dynEdge struct {
+	level int
+	tree  bool
+	arcs  [][2]*ettNode // arcs[i] holds the nodes of a tree edge at level i
+}
+
+// NewDynamicConnectivity returns a DynamicConnectivity for a graph
+// with n vertices and no edges.
+func NewDynamicConnectivity(n int) *DynamicConnectivity {
+	levels := bits.Len(uint(n)) + 1
+	d := &DynamicConnectivity{
+		levels: levels,
+		vertex: make([][]*ettNode, levels),
+		adj:    make([][]map[int]bool, levels),
+		edges:  make(map[[2]int]*dynEdge),
+		count:  n,
+		seed:   0x9e3779b97f4a7c15,
+	}
+	for i := range d.vertex {
+		d.vertex[i] = make([]*ettNode, n)
+		d.adj[i] = make([]map[int]bool, n)
+	}
+	return d
+}
+
+// Len returns the number of vertices.
+func (d *DynamicConnectivity) Len() int {
+	return len(d.vertex[0])
+}
+
+// Count returns the number of connected components.
+func (d *DynamicConnectivity) Count() int {
+	return d.count
+}
+
+// Connected tells if there is a path between v and w.
+func (d *DynamicConnectivity) Connected(v, w int) bool {
+	d.check(v)
+	d.check(w)
+	return v == w || d.connected(0, v, w)
+}
+
+// Edge tells if there is an edge between v and w.
+func (d *DynamicConnectivity) Edge(v, w int) bool {
+	_, ok := d.edges[undirectedKey(v, w)]
+	return ok
+}
+
+// AddEdge inserts an edge between v and w. It returns false,
+// and does nothing, if the edge already exists or if v = w.
+func (d *DynamicConnectivity) AddEdge(v, w int) bool {
+	d.check(v)
+	d.check(w)
+	key := undirectedKey(v, w)
+	if _, ok := d.edges[key]; ok || v == w {
+		return false
+	}
+	e := new(dynEdge)
+	d.edges[key] = e
+	if d.connected(0, v, w) {
+		d.addNonTree(0, v, w)
+	} else {
+		e.tree = true
+		d.link(0, e, v, w)
+		d.count--
+	}
+	return true
+}
+
+// RemoveEdge removes the edge between v and w.
+// It returns false if there is no such edge.
+func (d *DynamicConnectivity) RemoveEdge(v, w int) bool {
+	key := undirectedKey(v, w)
+	e, ok := d.edges[key]
+	if !ok {
+		return false
+	}
+	delete(d.edges, key)
+	if !e.tree {
+		d.removeNonTree(e.level, v, w)
+		return true
+	}
+	for i := e.level; i >= 0; i-- {
+		d.cut(i, e)
+	}
+	for i := e.level; i >= 0; i-- {
+		if d.replace(i, v, w) {
+			return true
+		}
+	}
+	d.count++
+	return true
+}
+
+// replace looks for an edge of level i that reconnects the trees
+// of v and w in the forest of level i, and adds it to the forests.
+// The edges of the smaller tree that are examined get level i+1.
+func (d *DynamicConnectivity) replace(i, v, w int) bool {
+	small, large := d.node(i, v).root(), d.node(i, w).root()
+	if small.vertices > large.vertices {
+		small = large
+	}
+	for _, a := range ettFind(small, true, nil) {
+		e := d.edges[undirectedKey(a.ends[0], a.ends[1])]
+		e.level++
+		a.setFlag(false)
+		d.link(i+1, e, a.ends[0], a.ends[1])
+	}
+	for _, x := range ettFind(small, false, nil) {
+		u := x.vertex
+		for y := range d.adj[i][u] {
+			e := d.edges[undirectedKey(u, y)]
+			d.removeNonTree(i, u, y)
+			if d.node(i, y).root() == small {
+				e.level++
+				d.addNonTree(i+1, u, y)
+				continue
+			}
+			e.tree = true
+			for j := 0; j <= i; j++ {
+				d.link(j, e, u, y)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// node returns the node of v at level i.
+func (d *DynamicConnectivity) node(i, v int) *ettNode {
+	x := d.vertex[i][v]
+	if x == nil {
+		x = d.newNode(v)
+		d.vertex[i][v] = x
+	}
+	return x
+}
+
+func (d *DynamicConnectivity) newNode(v int) *ettNode {
+	// xorshift64*
+	d.seed ^= d.seed >> 12
+	d.seed ^= d.seed << 25
+	d.seed ^= d.seed >> 27
+	x := &ettNode{vertex: v, prio: d.seed * 2685821657736338717}
+	x.update()
+	return x
+}
+
+func (d *DynamicConnectivity) connected(i, v, w int) bool {
+	return d.node(i, v).root() == d.node(i, w).root()
+}
+
+// link adds the tree edge e between u and w to the forest of level i.
+func (d *DynamicConnectivity) link(i int, e *dynEdge, u, w int) {
+	a, b := d.newNode(-1), d.newNode(-1)
+	a.ends, b.ends = [2]int{u, w}, [2]int{w, u}
+	a.flag = e.level == i
+	a.update()
+	t := ettMerge(ettReroot(d.node(i, u)), a)
+	t = ettMerge(t, ettReroot(d.node(i, w)))
+	ettMerge(t, b)
+	e.arcs = append(e.arcs[:i], [2]*ettNode{a, b})
+}
+
+// cut removes the tree edge e from the forest of level i.
+func (d *DynamicConnectivity) cut(i int, e *dynEdge) {
+	a, b := e.arcs[i][0], e.arcs[i][1]
+	if a.index() > b.index() {
+		a, b = b, a
+	}
+	// The tour is L a M b R, where M is one of the new trees.
+	l, r := ettSplit(a, false)
+	_, r = ettSplit(a, true)
+	_, r = ettSplit(b, false)
+	_, r = ettSplit(b, true)
+	ettMerge(l, r)
+}
+
+func (d *DynamicConnectivity) addNonTree(i, v, w int) {
+	for _, x := range [2][2]int{{v, w}, {w, v}} {
+		m := d.adj[i][x[0]]
+		if m == nil {
+			m = make(map[int]bool)
+			d.adj[i][x[0]] = m
+		}
+		m[x[1]] = true
+		d.node(i, x[0]).setFlag(true)
+	}
+}
+
+func (d *DynamicConnectivity) removeNonTree(i, v, w int) {
+	for _, x := range [2][2]int{{v, w}, {w, v}} {
+		delete(d.adj[i][x[0]], x[1])
+		d.node(i, x[0]).setFlag(len(d.adj[i][x[0]]) > 0)
+	}
+}
+
+func (d *DynamicConnectivity) check(v int) {
+	if v < 0 || v >= d.Len() {
+		panic("vertex out of range: " + strconv.Itoa(v))
+	}
+}
+
+func undirectedKey(v, w int) [2]int {
+	if v > w {
+		v, w = w, v
+	}
+	return [2]int{v, w}
+}
@@ -0,0 +1,102 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDynamicConnectivity(t *testing.T) {
+	d := NewDynamicConnectivity(5)
+	d.AddEdge(0, 1)
+	d.AddEdge(1, 2)
+	d.AddEdge(2, 0)
+	d.AddEdge(3, 4)
+	if mess, diff := diff(d.Count(), 2); diff {
+		t.Errorf("Count %s", mess)
+	}
+	if d.AddEdge(1, 0) || d.AddEdge(2, 2) {
+		t.Errorf("AddEdge of existing edge or loop returned true")
+	}
+	d.RemoveEdge(0, 1)
+	if !d.Connected(0, 1) || d.Connected(0, 3) {
+		t.Errorf("Connected after removing a cycle edge")
+	}
+	d.RemoveEdge(1, 2)
+	if d.Connected(0, 1) || !d.Connected(0, 2) {
+		t.Errorf("Connected after removing a bridge")
+	}
+	if mess, diff := diff(d.Count(), 3); diff {
+		t.Errorf("Count %s", mess)
+	}
+	if d.RemoveEdge(1, 2) || !d.Edge(2, 0) || d.Edge(0, 1) {
+		t.Errorf("RemoveEdge or Edge of missing edge")
+	}
+}
+
+func TestDynamicConnectivityRandom(t *testing.T) {
+	for _, n := range []int{1, 2, 5, 10, 30, 100} {
+		d := NewDynamicConnectivity(n)
+		edges := make(map[[2]int]bool)
+		for op := 0; op < 20*n; op++ {
+			v, w := rand.Intn(n), rand.Intn(n)
+			key := undirectedKey(v, w)
+			if rand.Intn(3) > 0 {
+				if added := d.AddEdge(v, w); added != (v != w && !edges[key]) {
+					t.Fatalf("AddEdge(%d, %d) = %t", v, w, added)
+				}
+				if v != w {
+					edges[key] = true
+				}
+			} else if len(edges) > 0 {
+				// Remove an arbitrary existing edge.
+				for e := range edges {
+					key = e
+					break
+				}
+				if !d.RemoveEdge(key[0], key[1]) {
+					t.Fatalf("RemoveEdge(%d, %d) = false", key[0], key[1])
+				}
+				delete(edges, key)
+			}
+			u := NewUnionFind(n)
+			for e := range edges {
+				u.Union(e[0], e[1])
+			}
+			if d.Count() != u.Count() {
+				t.Fatalf("Count() = %d; want %d", d.Count(), u.Count())
+			}
+			for i := 0; i < 10; i++ {
+				v, w := rand.Intn(n), rand.Intn(n)
+				if d.Connected(v, w) != u.Same(v, w) {
+					t.Fatalf("Connected(%d, %d) = %t", v, w, !u.Same(v, w))
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkDynamicConnectivity(b *testing.B) {
+	b.StopTimer()
+	n := 10000
+	d := NewDynamicConnectivity(n)
+	var edges [][2]int
+	for i := 0; i < 2*n; i++ {
+		v, w := rand.Intn(n), rand.Intn(n)
+		if d.AddEdge(v, w) {
+			edges = append(edges, [2]int{v, w})
+		}
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		j := rand.Intn(len(edges))
+		e := edges[j]
+		d.RemoveEdge(e[0], e[1])
+		v, w := rand.Intn(n), rand.Intn(n)
+		if d.AddEdge(v, w) {
+			edges[j] = [2]int{v, w}
+		} else {
+			d.AddEdge(e[0], e[1])
+		}
+		d.Connected(rand.Intn(n), rand.Intn(n))
+	}
+}
@@ -0,0 +1,205 @@
+package graph
+
+import "strconv"
+
+// DynamicSSSP maintains the shortest paths from a fixed source
+// in a directed graph while edges are inserted, deleted, or change cost.
+// Only edges with non-negative costs are included, like in ShortestPaths.
+//
+// After each update, only the vertices whose distances might change
+// are visited, in the style of the algorithm by Ramalingam and Reps:
+// a cheaper edge starts a Dijkstra search from its head that only
+// follows improvements, and a more expensive or deleted tree edge
+// recomputes the distances in its subtree of the shortest path tree,
+// starting from the edges that enter the subtree.
+type DynamicSSSP struct {
+	source  int
+	out, in []map[int]int64
+	dist    []int64
+	parent  []int
+	queue   *quadHeapQueue
+}
+
+// NewDynamicSSSP returns a DynamicSSSP for the shortest paths from source
+// in a copy of g. For multiple edges, only the cheapest edge is kept.
+//
+// The time complexity is O((|E| + |V|)⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func NewDynamicSSSP(g Iterator, source int) *DynamicSSSP {
+	n := g.Order()
+	if source < 0 || source >= n {
+		panic("vertex out of range: " + strconv.Itoa(source))
+	}
+	s := &DynamicSSSP{
+		source: source,
+		out:    make([]map[int]int64, n),
+		in:     make([]map[int]int64, n),
+		queue:  NewQuadHeapQueue(n).(*quadHeapQueue),
+	}
+	for v := 0; v < n; v++ {
+		s.out[v] = make(map[int]int64)
+		s.in[v] = make(map[int]int64)
+	}
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if old, ok := s.out[v][w]; !ok || c < old {
+				s.out[v][w], s.in[w][v] = c, c
+			}
+			return
+		})
+	}
+	s.parent, s.dist = ShortestPaths(g, source)
+	return s
+}
+
+// Order returns the number of vertices.
+func (s *DynamicSSSP) Order() int {
+	return len(s.dist)
+}
+
+// Source returns the source of the shortest paths.
+func (s *DynamicSSSP) Source() int {
+	return s.source
+}
+
+// DistTo returns the length of a shortest path from the source to w,
+// or -1 if w cannot be reached.
+func (s *DynamicSSSP) DistTo(w int) int64 {
+	return s.dist[w]
+}
+
+// Parent returns the predecessor of w on a shortest path from the source,
+// or -1 if w is the source or cannot be reached.
+func (s *DynamicSSSP) Parent(w int) int {
+	return s.parent[w]
+}
+
+// PathTo returns a shortest path from the source to w,
+// or an empty path if w cannot be reached.
+func (s *DynamicSSSP) PathTo(w int) []int {
+	if s.dist[w] == -1 {
+		return []int{}
+	}
+	return followParents(s.parent, w)
+}
+
+// Edge tells if there is an edge from v to w.
+func (s *DynamicSSSP) Edge(v, w int) bool {
+	_, ok := s.out[v][w]
+	return ok
+}
+
+// Cost returns the cost of an edge from v to w, or 0 if no such edge exists.
+func (s *DynamicSSSP) Cost(v, w int) int64 {
+	return s.out[v][w]
+}
+
+// AddCost inserts a directed edge from v to w with cost c,
+// or changes the cost of the edge if it already exists,
+// and updates the shortest paths.
+func (s *DynamicSSSP) AddCost(v, w int, c int64) {
+	s.check(v)
+	s.check(w)
+	old, ok := s.out[v][w]
+	s.out[v][w], s.in[w][v] = c, c
+	switch {
+	case c < 0:
+		if ok && old >= 0 {
+			s.increase(v, w)
+		}
+	case !ok || old < 0 || c < old:
+		s.decrease(v, w, c)
+	case c > old:
+		s.increase(v, w)
+	}
+}
+
+// Delete removes the edge from v to w, if it exists,
+// and updates the shortest paths.
+func (s *DynamicSSSP) Delete(v, w int) {
+	s.check(v)
+	s.check(w)
+	if _, ok := s.out[v][w]; !ok {
+		return
+	}
+	delete(s.out[v], w)
+	delete(s.in[w], v)
+	s.increase(v, w)
+}
+
+// decrease updates the paths after the cost of the edge from v to w
+// has decreased to c.
+func (s *DynamicSSSP) decrease(v, w int, c int64) {
+	if s.dist[v] == -1 {
+		return
+	}
+	if d := s.dist[v] + c; s.dist[w] == -1 || d < s.dist[w] {
+		s.dist[w], s.parent[w] = d, v
+		s.search(func(int) bool { return true }, w)
+	}
+}
+
+// increase updates the paths after the cost of the edge from v to w
+// has increased, or the edge has been deleted.
+func (s *DynamicSSSP) increase(v, w int) {
+	if s.parent[w] != v {
+		return
+	}
+	// Find the subtree of w in the shortest path tree.
+	sub := []int{w}
+	inSub := map[int]bool{w: true}
+	for i := 0; i < len(sub); i++ {
+		x := sub[i]
+		for y := range s.out[x] {
+			if s.parent[y] == x && !inSub[y] {
+				inSub[y] = true
+				sub = append(sub, y)
+			}
+		}
+	}
+	// The best path into the subtree ends with an edge from outside.
+	var start []int
+	for _, y := range sub {
+		s.dist[y], s.parent[y] = -1, -1
+		for x, c := range s.in[y] {
+			if c < 0 || inSub[x] || s.dist[x] == -1 {
+				continue
+			}
+			if d := s.dist[x] + c; s.dist[y] == -1 || d < s.dist[y] {
+				s.dist[y], s.parent[y] = d, x
+			}
+		}
+		if s.dist[y] != -1 {
+			start = append(start, y)
+		}
+	}
+	s.search(func(y int) bool { return inSub[y] }, start...)
+}
+
+// search runs Dijkstra's algorithm from the given vertices,
+// whose distances have been set, and updates the vertices accepted
+// by keep whose distances improve.
+func (s *DynamicSSSP) search(keep func(w int) bool, start ...int) {
+	q := s.queue
+	for _, v := range start {
+		q.Push(v, s.dist[v])
+	}
+	for q.Len() > 0 {
+		v, d := q.Pop()
+		for w, c := range s.out[v] {
+			if c < 0 || !keep(w) {
+				continue
+			}
+			if alt := d + c; s.dist[w] == -1 || alt < s.dist[w] {
+				s.dist[w], s.parent[w] = alt, v
+				q.Push(w, alt)
+			}
+		}
+	}
+}
+
+func (s *DynamicSSSP) check(v int) {
+	if v < 0 || v >= len(s.dist) {
+		panic("vertex out of range: " + strconv.Itoa(v))
+	}
+}
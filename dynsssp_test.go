@@ -0,0 +1,76 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDynamicSSSP(t *testing.T) {
+	g := New(5)
+	g.AddCost(0, 1, 2)
+	g.AddCost(1, 2, 2)
+	g.AddCost(0, 2, 5)
+	g.AddCost(2, 3, 1)
+	s := NewDynamicSSSP(g, 0)
+	if mess, diff := diff(s.PathTo(3), []int{0, 1, 2, 3}); diff {
+		t.Errorf("PathTo(3) %s", mess)
+	}
+	s.AddCost(0, 3, 1)
+	if mess, diff := diff(s.PathTo(3), []int{0, 3}); diff {
+		t.Errorf("PathTo(3) after decrease %s", mess)
+	}
+	s.Delete(0, 3)
+	s.AddCost(1, 2, 10)
+	if mess, diff := diff(s.PathTo(3), []int{0, 2, 3}); diff {
+		t.Errorf("PathTo(3) after increase %s", mess)
+	}
+	if mess, diff := diff(s.DistTo(3), int64(6)); diff {
+		t.Errorf("DistTo(3) %s", mess)
+	}
+	s.Delete(0, 2)
+	s.AddCost(1, 2, -1)
+	if mess, diff := diff(s.PathTo(3), []int{}); diff {
+		t.Errorf("PathTo(3) after delete %s", mess)
+	}
+	if s.DistTo(4) != -1 || s.Parent(0) != -1 || s.Source() != 0 || s.Order() != 5 {
+		t.Errorf("DistTo, Parent, Source or Order")
+	}
+}
+
+func TestDynamicSSSPRandom(t *testing.T) {
+	for n := 1; n < 40; n += 1 + n/4 {
+		g := New(n)
+		for m := rand.Intn(3*n + 1); m > 0; m-- {
+			g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(10))
+		}
+		v := rand.Intn(n)
+		s := NewDynamicSSSP(g, v)
+		for op := 0; op < 10*n; op++ {
+			x, y := rand.Intn(n), rand.Intn(n)
+			if rand.Intn(4) == 0 {
+				s.Delete(x, y)
+				g.Delete(x, y)
+			} else {
+				c := rand.Int63n(10) - 1
+				s.AddCost(x, y, c)
+				g.AddCost(x, y, c)
+			}
+			_, expDist := ShortestPaths(g, v)
+			for w, exp := range expDist {
+				if s.DistTo(w) != exp {
+					t.Fatalf("DistTo(%d) = %d; want %d", w, s.DistTo(w), exp)
+				}
+				p := s.Parent(w)
+				if p == -1 {
+					if w != v && exp != -1 {
+						t.Fatalf("Parent(%d) = -1", w)
+					}
+					continue
+				}
+				if !g.Edge(p, w) || g.Cost(p, w) < 0 || exp != expDist[p]+g.Cost(p, w) {
+					t.Fatalf("Parent(%d) = %d isn't on a shortest path", w, p)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package graph
+
+import (
+	"sort"
+	"strconv"
+)
+
+// DynamicTopOrder maintains a topological ordering of a directed acyclic
+// graph while edges and vertices are added, rejecting edges that would
+// create a cycle. For every edge from v to w, v comes before w in the order.
+//
+// The implementation is the dynamic algorithm by Pearce and Kelly.
+// When an edge from v to w is added with w before v, only the vertices
+// between w and v in the order that are reachable from w, or reach v,
+// are searched and moved; other vertices keep their positions.
+type DynamicTopOrder struct {
+	out, in []map[int]bool
+	ord     []int // ord[v] is the position of v
+	vertex  []int // vertex[i] is the vertex at position i
+
+	// Workspace for searches.
+	visited []bool
+}
+
+// NewDynamicTopOrder returns a DynamicTopOrder for a graph
+// with n vertices and no edges, ordered as 0, 1, …, n-1.
+func NewDynamicTopOrder(n int) *DynamicTopOrder {
+	t := &DynamicTopOrder{}
+	for v := 0; v < n; v++ {
+		t.AddVertex()
+	}
+	return t
+}
+
+// Len returns the number of vertices.
+func (t *DynamicTopOrder) Len() int {
+	return len(t.ord)
+}
+
+// AddVertex adds a vertex without edges, last in the order, and returns it.
+func (t *DynamicTopOrder) AddVertex() int {
+	v := len(t.ord)
+	t.out = append(t.out, make(map[int]bool))
+	t.in = append(t.in, make(map[int]bool))
+	t.ord = append(t.ord, v)
+	t.vertex = append(t.vertex, v)
+	t.visited = append(t.visited, false)
+	return v
+}
+
+// Edge tells if there is an edge from v to w.
+func (t *DynamicTopOrder) Edge(v, w int) bool {
+	return t.out[v][w]
+}
+
+// Position returns the position of v in the order.
+func (t *DynamicTopOrder) Position(v int) int {
+	return t.ord[v]
+}
+
+// Before tells if v comes before w in the order.
+// If there is a path from v to w, v comes before w.
+func (t *DynamicTopOrder) Before(v, w int) bool {
+	return t.ord[v] < t.ord[w]
+}
+
+// Order returns the vertices in topological order.
+func (t *DynamicTopOrder) Order() []int {
+	return append([]int{}, t.vertex...)
+}
+
+// AddEdge inserts a directed edge from v to w and updates the order.
+// If the edge would create a cycle, it's not added, and AddEdge
+// returns false. A self-loop always creates a cycle.
+//
+// The time complexity is O(|E'| + |V'|⋅log|V'|), where |V'| is the number of
+// vertices that are moved, and |E'| the number of edges incident to them.
+func (t *DynamicTopOrder) AddEdge(v, w int) bool {
+	t.check(v)
+	t.check(w)
+	if t.out[v][w] {
+		return true
+	}
+	if v == w {
+		return false
+	}
+	if lb, ub := t.ord[w], t.ord[v]; lb < ub {
+		// Find the vertices in the affected region reachable from w,
+		// and the ones that reach v.
+		fwd, ok := t.search(w, v, func(x int) bool { return t.ord[x] <= ub }, t.out)
+		if !ok {
+			return false
+		}
+		bwd, _ := t.search(v, -1, func(x int) bool { return t.ord[x] >= lb }, t.in)
+		t.reorder(bwd, fwd)
+	}
+	t.out[v][w] = true
+	t.in[w][v] = true
+	return true
+}
+
+// DeleteEdge removes the edge from v to w, if it exists.
+// The order remains valid.
+func (t *DynamicTopOrder) DeleteEdge(v, w int) {
+	t.check(v)
+	t.check(w)
+	delete(t.out[v], w)
+	delete(t.in[w], v)
+}
+
+// search returns the vertices that are reachable from s in adj through
+// vertices accepted by keep. It returns false if it finds the vertex stop.
+// The visited marks are cleared before it returns.
+func (t *DynamicTopOrder) search(s, stop int, keep func(x int) bool, adj []map[int]bool) (found []int, ok bool) {
+	ok = true
+	t.visited[s] = true
+	found = []int{s}
+	for stack := []int{s}; len(stack) > 0 && ok; {
+		x := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for y := range adj[x] {
+			if y == stop {
+				ok = false
+				break
+			}
+			if !t.visited[y] && keep(y) {
+				t.visited[y] = true
+				found = append(found, y)
+				stack = append(stack, y)
+			}
+		}
+	}
+	for _, x := range found {
+		t.visited[x] = false
+	}
+	return
+}
+
+// reorder moves the vertices in bwd before the ones in fwd, using
+// the positions they currently occupy, and keeps the relative order
+// within each set.
+func (t *DynamicTopOrder) reorder(bwd, fwd []int) {
+	byOrd := func(list []int) {
+		sort.Slice(list, func(i, j int) bool { return t.ord[list[i]] < t.ord[list[j]] })
+	}
+	byOrd(bwd)
+	byOrd(fwd)
+	moved := append(bwd, fwd...)
+	pos := make([]int, len(moved))
+	for i, x := range moved {
+		pos[i] = t.ord[x]
+	}
+	sort.Ints(pos)
+	for i, x := range moved {
+		t.ord[x] = pos[i]
+		t.vertex[pos[i]] = x
+	}
+}
+
+func (t *DynamicTopOrder) check(v int) {
+	if v < 0 || v >= len(t.ord) {
+		panic("vertex out of range: " + strconv.Itoa(v))
+	}
+}
@@ -0,0 +1,84 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDynamicTopOrder(t *testing.T) {
+	o := NewDynamicTopOrder(4)
+	if !o.AddEdge(3, 1) || !o.AddEdge(1, 0) || !o.AddEdge(2, 3) {
+		t.Errorf("AddEdge rejected an acyclic edge")
+	}
+	if mess, diff := diff(o.Order(), []int{2, 3, 1, 0}); diff {
+		t.Errorf("Order %s", mess)
+	}
+	if o.AddEdge(0, 2) || o.AddEdge(1, 1) || o.Edge(0, 2) {
+		t.Errorf("AddEdge accepted a cycle")
+	}
+	if !o.Before(2, 0) || o.Position(1) != 2 {
+		t.Errorf("Before or Position")
+	}
+	o.DeleteEdge(1, 0)
+	if !o.AddEdge(0, 2) {
+		t.Errorf("AddEdge rejected an edge after DeleteEdge")
+	}
+	if v := o.AddVertex(); v != 4 || o.Position(v) != 4 || o.Len() != 5 {
+		t.Errorf("AddVertex")
+	}
+}
+
+func TestDynamicTopOrderRandom(t *testing.T) {
+	for n := 1; n < 50; n += 1 + n/4 {
+		o := NewDynamicTopOrder(n)
+		g := New(n)
+		for op := 0; op < 5*n; op++ {
+			v, w := rand.Intn(n), rand.Intn(n)
+			if rand.Intn(5) == 0 {
+				o.DeleteEdge(v, w)
+				g.Delete(v, w)
+				continue
+			}
+			cycle := false
+			BFS(g, w, func(_, x int, _ int64) {
+				if x == v {
+					cycle = true
+				}
+			})
+			cycle = cycle || v == w
+			if g.Edge(v, w) {
+				cycle = false
+			}
+			if ok := o.AddEdge(v, w); ok == cycle {
+				t.Fatalf("AddEdge(%d, %d) = %t", v, w, ok)
+			}
+			if !cycle {
+				g.Add(v, w)
+			}
+			order := o.Order()
+			for i, x := range order {
+				if o.Position(x) != i {
+					t.Fatalf("Position(%d) = %d; want %d", x, o.Position(x), i)
+				}
+			}
+			for x := 0; x < n; x++ {
+				g.Visit(x, func(y int, _ int64) (skip bool) {
+					if !o.Before(x, y) {
+						t.Fatalf("edge (%d, %d) in wrong order %v", x, y, order)
+					}
+					return
+				})
+			}
+		}
+	}
+}
+
+func BenchmarkDynamicTopOrder(b *testing.B) {
+	n := 1000
+	for i := 0; i < b.N; i++ {
+		o := NewDynamicTopOrder(n)
+		for j := 0; j < 5*n; j++ {
+			o.AddEdge(rand.Intn(n), rand.Intn(n))
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package graph
+
+// EdgeLister is an optional interface for graphs that can list
+// all of their edges more efficiently than by calling Visit
+// for each vertex.
+type EdgeLister interface {
+	Iterator
+
+	// VisitAll calls the do function for each edge from v to w
+	// of cost c in the graph, with the same edges as reported by Visit.
+	// If do returns true, VisitAll returns immediately, skipping
+	// any remaining edges, and returns true.
+	VisitAll(do func(v, w int, c int64) (skip bool)) (aborted bool)
+}
+
+// VisitAll calls the do function for each edge from v to w
+// of cost c in g, using the VisitAll method of g if it implements
+// EdgeLister, and otherwise the Visit method for each vertex.
+// An undirected edge is visited twice, once in each direction.
+// If do returns true, VisitAll returns immediately,
+// skipping any remaining edges, and returns true.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func VisitAll(g Iterator, do func(v, w int, c int64) (skip bool)) (aborted bool) {
+	if g, ok := g.(EdgeLister); ok {
+		return g.VisitAll(do)
+	}
+	for v := 0; v < g.Order(); v++ {
+		if g.Visit(v, func(w int, c int64) (skip bool) {
+			return do(v, w, c)
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+// Size returns the number of edges in g, counted as they're reported
+// by Visit: an undirected edge counts twice, once in each direction,
+// a self-loop once, and each edge of a multigraph counts separately.
+//
+// The time complexity is O(1) for Immutable and Multigraph, O(|V|)
+// for Mutable and Undirected, and O(|E| + |V|) for other graphs,
+// where |E| is the number of edges and |V| the number of vertices.
+func Size(g Iterator) int {
+	switch g := g.(type) {
+	case *Immutable:
+		return g.Size()
+	case *Mutable:
+		return g.Size()
+	case *Multigraph:
+		return g.Size()
+	case *Undirected:
+		m := 0
+		for _, ids := range g.adj {
+			m += len(ids)
+		}
+		return m
+	}
+	m := 0
+	VisitAll(g, func(_, _ int, _ int64) (skip bool) {
+		m++
+		return
+	})
+	return m
+}
+
+// Size returns the number of edges in the graph, with
+// an undirected edge counted twice, once in each direction.
+//
+// The time complexity is O(|V|), where |V| is the number of vertices in the graph.
+func (g *Mutable) Size() int {
+	m := 0
+	for _, neighbors := range g.edges {
+		m += len(neighbors)
+	}
+	return m
+}
+
+// VisitAll calls the do function for each edge from v to w
+// of cost c in the graph, in order of increasing v.
+// If do returns true, VisitAll returns immediately,
+// skipping any remaining edges, and returns true.
+func (g *Mutable) VisitAll(do func(v, w int, c int64) (skip bool)) (aborted bool) {
+	for v := range g.edges {
+		if g.Visit(v, func(w int, c int64) bool { return do(v, w, c) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// Size returns the number of edges in the graph, with
+// an undirected edge counted twice, once in each direction,
+// and each duplicate edge counted separately.
+func (g *Immutable) Size() int {
+	return len(g.edges)
+}
+
+// VisitAll calls the do function for each edge from v to w
+// of cost c in the graph, ordered by v and then by w.
+// If do returns true, VisitAll returns immediately,
+// skipping any remaining edges, and returns true.
+func (g *Immutable) VisitAll(do func(v, w int, c int64) (skip bool)) (aborted bool) {
+	for v := 0; v < g.Order(); v++ {
+		for _, e := range g.edges[g.offset[v]:g.offset[v+1]] {
+			if do(v, e.vertex, e.cost) {
+				return true
+			}
+		}
+	}
+	return false
+}
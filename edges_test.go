@@ -0,0 +1,60 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestVisitAll(t *testing.T) {
+	n := 10
+	g := New(n)
+	for m := 30; m > 0; m-- {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(10))
+	}
+	g.AddBoth(0, 1)
+	var exp []Edge
+	for e := range Edges(g) {
+		exp = append(exp, e)
+	}
+	sortEdges(exp)
+	for _, h := range []Iterator{g, Sort(g), Synchronized(g)} {
+		var res []Edge
+		VisitAll(h, func(v, w int, c int64) (skip bool) {
+			res = append(res, Edge{v, w, c})
+			return
+		})
+		sortEdges(res)
+		if mess, diff := diff(res, exp); diff {
+			t.Errorf("VisitAll(%T) %s", h, mess)
+		}
+		if mess, diff := diff(Size(h), len(exp)); diff {
+			t.Errorf("Size(%T) %s", h, mess)
+		}
+
+		count := 0
+		aborted := VisitAll(h, func(v, w int, c int64) (skip bool) {
+			count++
+			return count == 3
+		})
+		if !aborted || count != 3 {
+			t.Errorf("VisitAll(%T): aborted %t after %d edges; want true after 3", h, aborted, count)
+		}
+	}
+
+	u := NewUndirected(3)
+	u.Add(0, 1)
+	u.Add(1, 2)
+	u.Add(2, 2)
+	if mess, diff := diff(Size(u), 5); diff {
+		t.Errorf("Size(Undirected) %s", mess)
+	}
+	mg := NewMultigraph(2)
+	mg.AddEdge(0, 1, 1)
+	mg.AddEdge(0, 1, 2)
+	if mess, diff := diff(Size(mg), 2); diff {
+		t.Errorf("Size(Multigraph) %s", mess)
+	}
+	if mess, diff := diff(Size(New(0)), 0); diff {
+		t.Errorf("Size(New(0)) %s", mess)
+	}
+}
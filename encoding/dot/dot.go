@@ -0,0 +1,183 @@
+// Package dot reads and writes graphs in the DOT language of Graphviz.
+//
+// WriteDOT emits a graph with its edge costs as labels, together with
+// any vertex and edge attributes. The output can be rendered by the
+// Graphviz tools, for instance with the command "dot -Tsvg".
+// ReadDOT parses a useful subset of DOT: node, edge and attribute
+// statements, but not subgraphs or ports.
+package dot
+
+import (
+	"bufio"
+	"github.com/yourbasic/graph"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Options control the output of WriteDOT. The zero value gives
+// a directed graph with vertices named by their numbers.
+type Options struct {
+	// Name is the name of the graph.
+	Name string
+
+	// Undirected writes an undirected graph. An edge from v to w
+	// and an edge from w to v with the same cost are written as one edge.
+	Undirected bool
+
+	// ID returns the DOT node ID of v. If nil, the number v is used.
+	ID func(v int) string
+
+	// VertexAttrs returns the attributes of v, or nil.
+	VertexAttrs func(v int) map[string]string
+
+	// EdgeAttrs returns the attributes of the edge from v to w, or nil.
+	// Unless a "label" attribute is given, edges with non-zero
+	// cost are labeled by their cost.
+	EdgeAttrs func(v, w int) map[string]string
+}
+
+// WriteDOT writes g to w in the DOT language.
+// All vertices are written, in numerical order, before the edges;
+// the edges of each vertex are sorted by neighbor and cost.
+// If opts is nil, the default options are used.
+func WriteDOT(w io.Writer, g graph.Iterator, opts *Options) error {
+	if opts == nil {
+		opts = new(Options)
+	}
+	id := opts.ID
+	if id == nil {
+		id = strconv.Itoa
+	}
+	h := graph.Sort(g)
+	n := h.Order()
+	var t *graph.Immutable
+	if opts.Undirected {
+		t = graph.Transpose(h)
+	}
+
+	b := bufio.NewWriter(w)
+	kind, op := "digraph", " -> "
+	if opts.Undirected {
+		kind, op = "graph", " -- "
+	}
+	b.WriteString(kind)
+	if opts.Name != "" {
+		b.WriteString(" " + quote(opts.Name))
+	}
+	b.WriteString(" {\n")
+	for v := 0; v < n; v++ {
+		b.WriteString("\t" + quote(id(v)))
+		var attrs map[string]string
+		if opts.VertexAttrs != nil {
+			attrs = opts.VertexAttrs(v)
+		}
+		writeAttrs(b, attrs)
+		b.WriteString(";\n")
+	}
+	for v := 0; v < n; v++ {
+		// In undirected graphs, each reverse edge (w, v) with w < v
+		// matches an edge (v, w) that has already been written.
+		type edge struct {
+			w int
+			c int64
+		}
+		written := make(map[edge]int)
+		if opts.Undirected {
+			t.Visit(v, func(u int, c int64) (skip bool) {
+				if u < v {
+					written[edge{u, c}]++
+				}
+				return u >= v
+			})
+		}
+		h.Visit(v, func(w int, c int64) (skip bool) {
+			if e := (edge{w, c}); written[e] > 0 {
+				written[e]--
+				return
+			}
+			b.WriteString("\t" + quote(id(v)) + op + quote(id(w)))
+			attrs := map[string]string{}
+			if opts.EdgeAttrs != nil {
+				for k, x := range opts.EdgeAttrs(v, w) {
+					attrs[k] = x
+				}
+			}
+			if _, ok := attrs["label"]; !ok && c != 0 {
+				attrs["label"] = strconv.FormatInt(c, 10)
+			}
+			writeAttrs(b, attrs)
+			b.WriteString(";\n")
+			return
+		})
+	}
+	b.WriteString("}\n")
+	return b.Flush()
+}
+
+// writeAttrs writes an attribute list with the keys in sorted order.
+func writeAttrs(b *bufio.Writer, attrs map[string]string) {
+	if len(attrs) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	b.WriteString(" [")
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(quote(k) + "=" + quote(attrs[k]))
+	}
+	b.WriteString("]")
+}
+
+// quote returns s as a DOT ID, quoted unless it's
+// a plain identifier or an integer.
+func quote(s string) string {
+	if isIdent(s) && !keywords[strings.ToLower(s)] || isInteger(s) {
+		return s
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\n':
+			b.WriteString(`\n`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+var keywords = map[string]bool{
+	"node": true, "edge": true, "graph": true,
+	"digraph": true, "subgraph": true, "strict": true,
+}
+
+func isIdent(s string) bool {
+	for i, r := range s {
+		if !(r == '_' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || i > 0 && '0' <= r && r <= '9') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isInteger(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
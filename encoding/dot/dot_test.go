@@ -0,0 +1,104 @@
+package dot
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/yourbasic/graph"
+	"reflect"
+	"testing"
+)
+
+func diff(res, exp interface{}) (message string, diff bool) {
+	if !reflect.DeepEqual(res, exp) {
+		message = fmt.Sprintf("%v; want %v", res, exp)
+		diff = true
+	}
+	return
+}
+
+func TestWriteDOT(t *testing.T) {
+	g := graph.New(4)
+	g.AddCost(0, 1, 5)
+	g.Add(1, 2)
+	g.AddCost(2, 0, -1)
+	g.Add(3, 3)
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, g, nil); err != nil {
+		t.Fatalf("WriteDOT: %v", err)
+	}
+	exp := `digraph {
+	0;
+	1;
+	2;
+	3;
+	0 -> 1 [label=5];
+	1 -> 2;
+	2 -> 0 [label=-1];
+	3 -> 3;
+}
+`
+	if mess, diff := diff(buf.String(), exp); diff {
+		t.Errorf("WriteDOT %s", mess)
+	}
+
+	names := []string{"Stockholm", "New York", "graph", "x\"y"}
+	buf.Reset()
+	err := WriteDOT(&buf, g, &Options{
+		Name: "cities",
+		ID:   func(v int) string { return names[v] },
+		VertexAttrs: func(v int) map[string]string {
+			if v == 0 {
+				return map[string]string{"shape": "box", "color": "red"}
+			}
+			return nil
+		},
+		EdgeAttrs: func(v, w int) map[string]string {
+			if v == 1 {
+				return map[string]string{"label": "flight\nAA100"}
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("WriteDOT: %v", err)
+	}
+	exp = `digraph cities {
+	Stockholm [color=red, shape=box];
+	"New York";
+	"graph";
+	"x\"y";
+	Stockholm -> "New York" [label=5];
+	"New York" -> "graph" [label="flight\nAA100"];
+	"graph" -> Stockholm [label=-1];
+	"x\"y" -> "x\"y";
+}
+`
+	if mess, diff := diff(buf.String(), exp); diff {
+		t.Errorf("WriteDOT %s", mess)
+	}
+}
+
+func TestWriteDOTUndirected(t *testing.T) {
+	g := graph.New(3)
+	g.AddBothCost(0, 1, 2)
+	g.AddCost(1, 2, 3)
+	g.AddCost(2, 1, 4)
+	g.Add(2, 2)
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, g, &Options{Undirected: true}); err != nil {
+		t.Fatalf("WriteDOT: %v", err)
+	}
+	exp := `graph {
+	0;
+	1;
+	2;
+	0 -- 1 [label=2];
+	1 -- 2 [label=3];
+	2 -- 1 [label=4];
+	2 -- 2;
+}
+`
+	if mess, diff := diff(buf.String(), exp); diff {
+		t.Errorf("WriteDOT %s", mess)
+	}
+}
@@ -0,0 +1,469 @@
+package dot
+
+import (
+	"fmt"
+	"github.com/yourbasic/graph"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Graph is a graph read by ReadDOT.
+type Graph struct {
+	// Name is the name of the graph, or the empty string.
+	Name string
+
+	// Directed tells if the graph is a digraph. In an undirected graph,
+	// each edge is represented by edges in both directions.
+	Directed bool
+
+	// G holds the edges of the graph. The vertices are numbered
+	// in order of their first appearance in the input.
+	G *graph.Mutable
+
+	// IDs[v] is the DOT node ID of vertex v.
+	IDs []string
+
+	// Attrs holds the graph attributes.
+	Attrs map[string]string
+
+	vertexAttrs *graph.Labels[map[string]string]
+	edgeAttrs   *graph.EdgeLabels[map[string]string]
+}
+
+// ID returns the DOT node ID of v.
+func (d *Graph) ID(v int) string {
+	return d.IDs[v]
+}
+
+// VertexAttrs returns the attributes of v, or nil if it has none.
+func (d *Graph) VertexAttrs(v int) map[string]string {
+	attrs, _ := d.vertexAttrs.Get(v)
+	return attrs
+}
+
+// EdgeAttrs returns the attributes of the edge from v to w,
+// or nil if it has none.
+func (d *Graph) EdgeAttrs(v, w int) map[string]string {
+	attrs, _ := d.edgeAttrs.Get(v, w)
+	return attrs
+}
+
+// Options returns options for WriteDOT that write d with
+// its name, IDs and attributes.
+func (d *Graph) Options() *Options {
+	return &Options{
+		Name:        d.Name,
+		Undirected:  !d.Directed,
+		ID:          d.ID,
+		VertexAttrs: d.VertexAttrs,
+		EdgeAttrs:   d.EdgeAttrs,
+	}
+}
+
+// ReadDOT reads a graph in the DOT language from r.
+//
+// The cost of an edge is taken from its "label" attribute, or from
+// its "weight" attribute, if the value is an integer; otherwise it is 0.
+// Since G isn't a multigraph, only the last of several edges
+// between the same two vertices is kept.
+//
+// Subgraphs, ports and HTML strings aren't supported.
+func ReadDOT(r io.Reader) (*Graph, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{
+		lex:   lexer{data: string(data), line: 1},
+		index: make(map[string]int),
+		d: &Graph{
+			Attrs:       make(map[string]string),
+			vertexAttrs: graph.NewLabels[map[string]string](0),
+			edgeAttrs:   graph.NewEdgeLabels[map[string]string](),
+		},
+		nodeDefaults: make(map[string]string),
+		edgeDefaults: make(map[string]string),
+	}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	d := p.d
+	d.G = graph.New(len(d.IDs))
+	for _, e := range p.edges {
+		if d.Directed {
+			d.G.AddCost(e.v, e.w, e.c)
+		} else {
+			d.G.AddBothCost(e.v, e.w, e.c)
+		}
+	}
+	return d, nil
+}
+
+type parser struct {
+	lex   lexer
+	tok   token
+	d     *Graph
+	index map[string]int // index[id] is the vertex with the given ID
+	edges []struct {
+		v, w int
+		c    int64
+	}
+	nodeDefaults map[string]string
+	edgeDefaults map[string]string
+}
+
+// syntaxError is a panic value used to abort parsing.
+type syntaxError struct{ err error }
+
+func (p *parser) errorf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	panic(syntaxError{fmt.Errorf("dot: line %d: %s", p.tok.line, msg)})
+}
+
+func (p *parser) next() {
+	p.tok = p.lex.next()
+	if p.tok.kind == tokError {
+		p.errorf("%s", p.tok.text)
+	}
+}
+
+func (p *parser) expect(kind tokenKind, what string) token {
+	t := p.tok
+	if t.kind != kind {
+		p.errorf("expected %s, found %s", what, t)
+	}
+	p.next()
+	return t
+}
+
+// keyword tells if the current token is the given unquoted keyword.
+func (p *parser) keyword(k string) bool {
+	return p.tok.kind == tokID && !p.tok.quoted && strings.EqualFold(p.tok.text, k)
+}
+
+func (p *parser) parse() (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			se, ok := e.(syntaxError)
+			if !ok {
+				panic(e)
+			}
+			err = se.err
+		}
+	}()
+	p.next()
+	if p.keyword("strict") {
+		p.next()
+	}
+	switch {
+	case p.keyword("digraph"):
+		p.d.Directed = true
+	case p.keyword("graph"):
+	default:
+		p.errorf("expected graph or digraph, found %s", p.tok)
+	}
+	p.next()
+	if p.tok.kind == tokID {
+		p.d.Name = p.tok.text
+		p.next()
+	}
+	p.expect('{', "{")
+	for p.tok.kind != '}' {
+		p.statement()
+	}
+	p.next()
+	p.expect(tokEOF, "end of input")
+	return
+}
+
+func (p *parser) statement() {
+	switch {
+	case p.tok.kind == ';':
+		p.next()
+		return
+	case p.tok.kind == '{' || p.keyword("subgraph"):
+		p.errorf("subgraphs are not supported")
+	case p.keyword("graph") || p.keyword("node") || p.keyword("edge"):
+		kind := strings.ToLower(p.tok.text)
+		p.next()
+		defaults := map[string]map[string]string{
+			"graph": p.d.Attrs,
+			"node":  p.nodeDefaults,
+			"edge":  p.edgeDefaults,
+		}[kind]
+		for k, x := range p.attrList() {
+			defaults[k] = x
+		}
+		return
+	}
+	id := p.expect(tokID, "node ID").text
+	if p.tok.kind == '=' {
+		p.next()
+		p.d.Attrs[id] = p.expect(tokID, "attribute value").text
+		return
+	}
+	vertices := []int{p.vertex(id)}
+	for p.tok.kind == tokEdgeOp {
+		if p.tok.directed != p.d.Directed {
+			p.errorf("wrong edge operator %s", p.tok)
+		}
+		p.next()
+		vertices = append(vertices, p.vertex(p.expect(tokID, "node ID").text))
+	}
+	attrs := p.attrList()
+	if len(vertices) == 1 {
+		v := vertices[0]
+		if len(attrs) > 0 {
+			all := p.d.VertexAttrs(v)
+			if all == nil {
+				all = make(map[string]string)
+			}
+			for k, x := range attrs {
+				all[k] = x
+			}
+			p.d.vertexAttrs.Set(v, all)
+		}
+		return
+	}
+	all := make(map[string]string)
+	for k, x := range p.edgeDefaults {
+		all[k] = x
+	}
+	for k, x := range attrs {
+		all[k] = x
+	}
+	cost := int64(0)
+	if c, err := strconv.ParseInt(all["label"], 10, 64); err == nil {
+		cost = c
+	} else if c, err := strconv.ParseInt(all["weight"], 10, 64); err == nil {
+		cost = c
+	}
+	for i := 1; i < len(vertices); i++ {
+		v, w := vertices[i-1], vertices[i]
+		p.edges = append(p.edges, struct {
+			v, w int
+			c    int64
+		}{v, w, cost})
+		if len(all) > 0 {
+			if p.d.Directed {
+				p.d.edgeAttrs.Set(v, w, all)
+			} else {
+				p.d.edgeAttrs.SetBoth(v, w, all)
+			}
+		}
+	}
+}
+
+// vertex returns the vertex with the given ID, creating it if needed.
+func (p *parser) vertex(id string) int {
+	if p.tok.kind == ':' {
+		p.errorf("ports are not supported")
+	}
+	if v, ok := p.index[id]; ok {
+		return v
+	}
+	v := len(p.d.IDs)
+	p.index[id] = v
+	p.d.IDs = append(p.d.IDs, id)
+	if len(p.nodeDefaults) > 0 {
+		attrs := make(map[string]string)
+		for k, x := range p.nodeDefaults {
+			attrs[k] = x
+		}
+		p.d.vertexAttrs.Set(v, attrs)
+	}
+	return v
+}
+
+// attrList parses a possibly empty sequence of attribute lists.
+func (p *parser) attrList() map[string]string {
+	attrs := make(map[string]string)
+	for p.tok.kind == '[' {
+		p.next()
+		for p.tok.kind != ']' {
+			k := p.expect(tokID, "attribute name").text
+			p.expect('=', "=")
+			attrs[k] = p.expect(tokID, "attribute value").text
+			if p.tok.kind == ',' || p.tok.kind == ';' {
+				p.next()
+			}
+		}
+		p.next()
+	}
+	return attrs
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokError
+	tokID
+	tokEdgeOp
+	// Other tokens are represented by their character.
+)
+
+type token struct {
+	kind     tokenKind
+	text     string
+	quoted   bool // a quoted ID
+	directed bool // the edge operator ->
+	line     int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of input"
+	case tokID:
+		return strconv.Quote(t.text)
+	case tokEdgeOp:
+		if t.directed {
+			return "->"
+		}
+		return "--"
+	}
+	return string(rune(t.kind))
+}
+
+type lexer struct {
+	data string
+	pos  int
+	line int
+}
+
+func (l *lexer) next() token {
+	l.skipSpace()
+	t := token{line: l.line}
+	if l.pos >= len(l.data) {
+		return t
+	}
+	c := l.data[l.pos]
+	switch {
+	case strings.HasPrefix(l.data[l.pos:], "->"):
+		l.pos += 2
+		t.kind, t.directed = tokEdgeOp, true
+	case strings.HasPrefix(l.data[l.pos:], "--"):
+		l.pos += 2
+		t.kind = tokEdgeOp
+	case strings.IndexByte("{}[];,=:", c) >= 0:
+		l.pos++
+		t.kind = tokenKind(c)
+	case c == '"':
+		return l.quoted(t)
+	case c == '<':
+		t.kind, t.text = tokError, "HTML strings are not supported"
+	case c == '-' || c == '.' || '0' <= c && c <= '9':
+		start := l.pos
+		l.pos++
+		for l.pos < len(l.data) && (l.data[l.pos] == '.' || '0' <= l.data[l.pos] && l.data[l.pos] <= '9') {
+			l.pos++
+		}
+		t.kind, t.text = tokID, l.data[start:l.pos]
+	case c == '_' || c >= 0x80 || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+		start := l.pos
+		for l.pos < len(l.data) && isIdentByte(l.data[l.pos]) {
+			l.pos++
+		}
+		t.kind, t.text = tokID, l.data[start:l.pos]
+	default:
+		t.kind, t.text = tokError, "unexpected character "+strconv.QuoteRune(rune(c))
+	}
+	return t
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || c >= 0x80 || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// quoted reads a double-quoted string, and any strings concatenated to it
+// with +. The escapes \" and \n stand for a quote and a newline,
+// a backslash followed by a newline is a line continuation,
+// and other backslashes are kept as they are.
+func (l *lexer) quoted(t token) token {
+	var b strings.Builder
+	for l.pos++; l.pos < len(l.data); l.pos++ {
+		c := l.data[l.pos]
+		switch {
+		case c == '"':
+			l.pos++
+			t.kind, t.text, t.quoted = tokID, b.String(), true
+			// Concatenate strings joined by +.
+			save, line := l.pos, l.line
+			l.skipSpace()
+			if l.pos < len(l.data) && l.data[l.pos] == '+' {
+				l.pos++
+				l.skipSpace()
+				if l.pos < len(l.data) && l.data[l.pos] == '"' {
+					u := l.quoted(token{line: t.line})
+					if u.kind == tokError {
+						return u
+					}
+					t.text += u.text
+					return t
+				}
+			}
+			l.pos, l.line = save, line
+			return t
+		case c == '\\' && l.pos+1 < len(l.data):
+			switch l.data[l.pos+1] {
+			case '"':
+				b.WriteByte('"')
+				l.pos++
+			case '\n':
+				l.line++
+				l.pos++
+			case 'n':
+				b.WriteByte('\n')
+				l.pos++
+			default:
+				b.WriteByte(c)
+			}
+		default:
+			if c == '\n' {
+				l.line++
+			}
+			b.WriteByte(c)
+		}
+	}
+	t.kind, t.text = tokError, "unterminated string"
+	return t
+}
+
+// skipSpace skips white space and comments.
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case c == '\n':
+			l.line++
+			l.pos++
+			// A line starting with # is a preprocessor line.
+			if l.pos < len(l.data) && l.data[l.pos] == '#' {
+				l.skipLine()
+			}
+		case c == ' ' || c == '\t' || c == '\r':
+			l.pos++
+		case c == '#' && l.pos == 0:
+			l.skipLine()
+		case strings.HasPrefix(l.data[l.pos:], "//"):
+			l.skipLine()
+		case strings.HasPrefix(l.data[l.pos:], "/*"):
+			end := strings.Index(l.data[l.pos+2:], "*/")
+			if end == -1 {
+				end = len(l.data) - l.pos - 4
+			}
+			l.line += strings.Count(l.data[l.pos:l.pos+2+end], "\n")
+			l.pos += end + 4
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) skipLine() {
+	for l.pos < len(l.data) && l.data[l.pos] != '\n' {
+		l.pos++
+	}
+}
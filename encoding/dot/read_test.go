@@ -0,0 +1,126 @@
+package dot
+
+import (
+	"bytes"
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestReadDOT(t *testing.T) {
+	in := `# A preprocessor line.
+strict digraph "my graph" {
+	// Defaults apply to later nodes and edges.
+	rankdir = LR
+	node [shape=circle]
+	a -> b -> c [label=3, color="blue"];
+	b [label="B"] [color = red]
+	edge [style=dashed];
+	/* A comment
+	   over two lines. */
+	c -> "a" [weight=7]
+	d; "e \"quoted\"" -> d [label="x" + "y"]
+}
+`
+	d, err := ReadDOT(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("ReadDOT: %v", err)
+	}
+	if mess, diff := diff(d.Name, "my graph"); diff {
+		t.Errorf("ReadDOT: Name %s", mess)
+	}
+	if mess, diff := diff(d.Directed, true); diff {
+		t.Errorf("ReadDOT: Directed %s", mess)
+	}
+	if mess, diff := diff(d.IDs, []string{"a", "b", "c", "d", `e "quoted"`}); diff {
+		t.Errorf("ReadDOT: IDs %s", mess)
+	}
+	if mess, diff := diff(d.G.String(), "5 [(0 1):3 (1 2):3 (2 0):7 (4 3)]"); diff {
+		t.Errorf("ReadDOT: G %s", mess)
+	}
+	if mess, diff := diff(d.Attrs, map[string]string{"rankdir": "LR"}); diff {
+		t.Errorf("ReadDOT: Attrs %s", mess)
+	}
+	if mess, diff := diff(d.VertexAttrs(1), map[string]string{"shape": "circle", "label": "B", "color": "red"}); diff {
+		t.Errorf("ReadDOT: VertexAttrs %s", mess)
+	}
+	if mess, diff := diff(d.EdgeAttrs(0, 1), map[string]string{"label": "3", "color": "blue"}); diff {
+		t.Errorf("ReadDOT: EdgeAttrs %s", mess)
+	}
+	if mess, diff := diff(d.EdgeAttrs(4, 3), map[string]string{"label": "xy", "style": "dashed"}); diff {
+		t.Errorf("ReadDOT: EdgeAttrs %s", mess)
+	}
+	if d.EdgeAttrs(1, 0) != nil {
+		t.Errorf("ReadDOT: EdgeAttrs(1, 0) %v; want nil", d.EdgeAttrs(1, 0))
+	}
+
+	d, err = ReadDOT(strings.NewReader("graph { 0 -- 1 -- 2 [label=-4] }"))
+	if err != nil {
+		t.Fatalf("ReadDOT: %v", err)
+	}
+	if mess, diff := diff(d.G.String(), "3 [{0 1}:-4 {1 2}:-4]"); diff {
+		t.Errorf("ReadDOT: undirected %s", mess)
+	}
+	if mess, diff := diff(d.EdgeAttrs(2, 1)["label"], "-4"); diff {
+		t.Errorf("ReadDOT: undirected EdgeAttrs %s", mess)
+	}
+}
+
+func TestReadDOTErrors(t *testing.T) {
+	for _, e := range []struct{ in, err string }{
+		{"", "dot: line 1: expected graph or digraph, found end of input"},
+		{"digraph {", "dot: line 1: expected node ID, found end of input"},
+		{"digraph {\n a -- b }", "dot: line 2: wrong edge operator --"},
+		{"graph { a -> b }", "dot: line 1: wrong edge operator ->"},
+		{"graph { subgraph { a } }", "dot: line 1: subgraphs are not supported"},
+		{"graph { a:n -- b }", "dot: line 1: ports are not supported"},
+		{`graph { "a }`, "dot: line 1: unterminated string"},
+		{"graph { a [label=<b>] }", "dot: line 1: HTML strings are not supported"},
+		{"graph { a [label] }", `dot: line 1: expected =, found ]`},
+		{"graph { a } b", `dot: line 1: expected end of input, found "b"`},
+		{"graph { a @ b }", `dot: line 1: unexpected character '@'`},
+	} {
+		_, err := ReadDOT(strings.NewReader(e.in))
+		if err == nil || err.Error() != e.err {
+			t.Errorf("ReadDOT(%q): %v; want %s", e.in, err, e.err)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	n := 20
+	g := graph.New(n)
+	for i := 0; i < 3*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(5)-2)
+	}
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, g, nil); err != nil {
+		t.Fatalf("WriteDOT: %v", err)
+	}
+	d, err := ReadDOT(&buf)
+	if err != nil {
+		t.Fatalf("ReadDOT: %v", err)
+	}
+	if mess, diff := diff(d.G.String(), g.String()); diff {
+		t.Errorf("RoundTrip %s", mess)
+	}
+
+	in := `graph G {
+	"a b" [color=red];
+	c;
+	"a b" -- c [label="1", style=bold];
+}
+`
+	d, err = ReadDOT(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("ReadDOT: %v", err)
+	}
+	buf.Reset()
+	if err := WriteDOT(&buf, d.G, d.Options()); err != nil {
+		t.Fatalf("WriteDOT: %v", err)
+	}
+	if mess, diff := diff(buf.String(), strings.Replace(in, `"1"`, "1", 1)); diff {
+		t.Errorf("RoundTrip %s", mess)
+	}
+}
@@ -0,0 +1,345 @@
+// Package edgelist reads and writes graphs as lists of edges in text form,
+// the format used by SNAP and many other graph datasets.
+//
+// Each line holds one edge: the source and target vertices and,
+// optionally, the cost of the edge, separated by white space:
+//
+//	# A comment.
+//	0 1 5
+//	1 2
+//	2 0 -1
+//
+// Edges without a cost get cost 0. Additional fields are ignored.
+package edgelist
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/yourbasic/graph"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Options control how an edge list is read. The zero value reads
+// directed edges separated by white space, skips lines starting
+// with # or %, and determines the order of the graph from the edges.
+type Options struct {
+	// Comment lists the characters that start a comment line.
+	// If empty, "#%" is used.
+	Comment string
+
+	// Delimiter separates the fields of a line. If 0, the fields
+	// are separated by any amount of white space.
+	Delimiter rune
+
+	// Order is the number of vertices in the graph. If 0,
+	// the order is one more than the largest vertex in the input.
+	Order int
+
+	// Undirected adds an edge in both directions for each line.
+	Undirected bool
+
+	// Workers is the number of goroutines used by ReadAt and ReadFile.
+	// If 0, runtime.GOMAXPROCS(0) is used.
+	Workers int
+
+	// Progress, if not nil, is called now and then while the input
+	// is parsed, with the number of bytes read so far and the size
+	// of the input, or -1 if the size is unknown, as for Read.
+	// The calls are never concurrent, even with several workers.
+	Progress func(read, size int64)
+}
+
+// Read reads an edge list from r.
+// If opts is nil, the default options are used.
+func Read(r io.Reader, opts *Options) (*graph.Immutable, error) {
+	if opts == nil {
+		opts = new(Options)
+	}
+	if opts.Progress != nil {
+		r = &progressReader{r: r, p: newProgress(-1, opts.Progress)}
+	}
+	c := parse(r, opts)
+	if c.err != nil {
+		return nil, fmt.Errorf("edgelist: line %d: %v", c.line, c.err)
+	}
+	return build([]chunk{c}, opts)
+}
+
+// ReadFile reads an edge list from the named file. The file is split
+// into parts that are parsed in parallel.
+// If opts is nil, the default options are used.
+func ReadFile(name string, opts *Options) (*graph.Immutable, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return ReadAt(f, info.Size(), opts)
+}
+
+// ReadAt reads an edge list of the given size from r. The input is split
+// at line boundaries into opts.Workers parts that are parsed in parallel.
+// If opts is nil, the default options are used.
+func ReadAt(r io.ReaderAt, size int64, opts *Options) (*graph.Immutable, error) {
+	if opts == nil {
+		opts = new(Options)
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if int64(workers) > size/4096+1 {
+		workers = int(size/4096 + 1) // Each part should be at least a few KB.
+	}
+	bounds := make([]int64, workers+1)
+	bounds[workers] = size
+	for i := 1; i < workers; i++ {
+		b, err := lineStart(r, int64(i)*size/int64(workers), size)
+		if err != nil {
+			return nil, err
+		}
+		if b < bounds[i-1] {
+			b = bounds[i-1]
+		}
+		bounds[i] = b
+	}
+	chunks := make([]chunk, workers)
+	var p *progress
+	if opts.Progress != nil {
+		p = newProgress(size, opts.Progress)
+	}
+	var wg sync.WaitGroup
+	for i := range chunks {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var part io.Reader = io.NewSectionReader(r, bounds[i], bounds[i+1]-bounds[i])
+			if p != nil {
+				part = &progressReader{r: part, p: p}
+			}
+			chunks[i] = parse(part, opts)
+		}(i)
+	}
+	wg.Wait()
+	for i, c := range chunks {
+		if c.err == nil {
+			continue
+		}
+		if e, ok := c.err.(readError); ok {
+			return nil, e.err
+		}
+		// Count the lines before the part to get the line number.
+		lines, err := countLines(io.NewSectionReader(r, 0, bounds[i]))
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("edgelist: line %d: %v", lines+c.line, c.err)
+	}
+	return build(chunks, opts)
+}
+
+// lineStart returns the position of the first line that starts at or after p.
+func lineStart(r io.ReaderAt, p, size int64) (int64, error) {
+	pos := p - 1
+	buf := make([]byte, 4096)
+	for pos < size {
+		m, err := r.ReadAt(buf, pos)
+		if i := bytes.IndexByte(buf[:m], '\n'); i >= 0 {
+			return pos + int64(i) + 1, nil
+		}
+		if err == io.EOF {
+			return size, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		pos += int64(m)
+	}
+	return size, nil
+}
+
+func countLines(r io.Reader) (int, error) {
+	lines := 0
+	buf := make([]byte, 32*1024)
+	for {
+		m, err := r.Read(buf)
+		lines += bytes.Count(buf[:m], []byte{'\n'})
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// progress adds up the bytes read by several readers
+// and reports the total.
+type progress struct {
+	mu     sync.Mutex
+	read   int64
+	size   int64
+	report func(read, size int64)
+}
+
+func newProgress(size int64, report func(read, size int64)) *progress {
+	return &progress{size: size, report: report}
+}
+
+func (p *progress) add(n int) {
+	p.mu.Lock()
+	p.read += int64(n)
+	p.report(p.read, p.size)
+	p.mu.Unlock()
+}
+
+// progressReader reports the bytes read from r to p.
+type progressReader struct {
+	r io.Reader
+	p *progress
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	if n > 0 {
+		r.p.add(n)
+	}
+	return n, err
+}
+
+// chunk holds the result of parsing part of the input.
+type chunk struct {
+	edges []graph.Edge
+	max   int   // the largest vertex, or -1
+	err   error // a parse error, or a readError
+	line  int   // the line of the error within the part
+}
+
+type readError struct{ err error }
+
+func (e readError) Error() string { return e.err.Error() }
+
+func parse(r io.Reader, opts *Options) (c chunk) {
+	comment := opts.Comment
+	if comment == "" {
+		comment = "#%"
+	}
+	c.max = -1
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	for s.Scan() {
+		c.line++
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.IndexByte(comment, line[0]) >= 0 {
+			continue
+		}
+		var fields []string
+		if opts.Delimiter == 0 {
+			fields = strings.Fields(line)
+		} else {
+			fields = strings.Split(line, string(opts.Delimiter))
+			for i := range fields {
+				fields[i] = strings.TrimSpace(fields[i])
+			}
+		}
+		if len(fields) < 2 {
+			c.err = fmt.Errorf("expected at least 2 fields, found %d", len(fields))
+			return
+		}
+		var e graph.Edge
+		var err error
+		if e.V, err = vertex(fields[0]); err != nil {
+			c.err = err
+			return
+		}
+		if e.W, err = vertex(fields[1]); err != nil {
+			c.err = err
+			return
+		}
+		if len(fields) > 2 && fields[2] != "" {
+			if e.Cost, err = strconv.ParseInt(fields[2], 10, 64); err != nil {
+				c.err = fmt.Errorf("invalid cost %q", fields[2])
+				return
+			}
+		}
+		if e.V > c.max {
+			c.max = e.V
+		}
+		if e.W > c.max {
+			c.max = e.W
+		}
+		c.edges = append(c.edges, e)
+		if opts.Undirected && e.V != e.W {
+			c.edges = append(c.edges, graph.Edge{V: e.W, W: e.V, Cost: e.Cost})
+		}
+	}
+	if err := s.Err(); err != nil {
+		c.err = readError{err}
+	}
+	return
+}
+
+func vertex(s string) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid vertex %q", s)
+	}
+	return v, nil
+}
+
+func build(chunks []chunk, opts *Options) (*graph.Immutable, error) {
+	max, m := -1, 0
+	for _, c := range chunks {
+		if c.max > max {
+			max = c.max
+		}
+		m += len(c.edges)
+	}
+	n := max + 1
+	if opts.Order != 0 {
+		if max >= opts.Order {
+			return nil, fmt.Errorf("edgelist: vertex %d out of range for order %d", max, opts.Order)
+		}
+		n = opts.Order
+	}
+	edges := chunks[0].edges
+	if len(chunks) > 1 {
+		edges = make([]graph.Edge, 0, m)
+		for _, c := range chunks {
+			edges = append(edges, c.edges...)
+		}
+	}
+	return graph.FromEdges(n, edges), nil
+}
+
+// Write writes the edges of g to w, one edge per line,
+// with the cost of the edge omitted if it is 0.
+func Write(w io.Writer, g graph.Iterator) error {
+	b := bufio.NewWriter(w)
+	var buf []byte
+	for v := 0; v < g.Order(); v++ {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			buf = strconv.AppendInt(buf[:0], int64(v), 10)
+			buf = append(buf, ' ')
+			buf = strconv.AppendInt(buf, int64(w), 10)
+			if c != 0 {
+				buf = append(buf, ' ')
+				buf = strconv.AppendInt(buf, c, 10)
+			}
+			buf = append(buf, '\n')
+			b.Write(buf)
+			return
+		})
+	}
+	return b.Flush()
+}
@@ -0,0 +1,186 @@
+package edgelist
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func diff(res, exp interface{}) (message string, diff bool) {
+	if !reflect.DeepEqual(res, exp) {
+		message = fmt.Sprintf("%v; want %v", res, exp)
+		diff = true
+	}
+	return
+}
+
+func TestRead(t *testing.T) {
+	in := `# Directed graph (each unordered pair of nodes is saved once)
+% Another comment
+0	1 5
+  1 2
+
+2 0 -1 ignored
+3 3
+`
+	g, err := Read(strings.NewReader(in), nil)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if mess, diff := diff(g.String(), "4 [(0 1):5 (1 2) (2 0):-1 (3 3)]"); diff {
+		t.Errorf("Read %s", mess)
+	}
+
+	g, err = Read(strings.NewReader("// c\n0,1, 2\n1,2\n"), &Options{Comment: "/", Delimiter: ',', Order: 5, Undirected: true})
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if mess, diff := diff(g.String(), "5 [{0 1}:2 {1 2}]"); diff {
+		t.Errorf("Read %s", mess)
+	}
+
+	g, err = Read(strings.NewReader(""), nil)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if mess, diff := diff(g.String(), "0 []"); diff {
+		t.Errorf("Read %s", mess)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	for _, e := range []struct {
+		in   string
+		opts *Options
+		err  string
+	}{
+		{"0 1\n1\n", nil, "edgelist: line 2: expected at least 2 fields, found 1"},
+		{"# c\n0 x\n", nil, `edgelist: line 2: invalid vertex "x"`},
+		{"-1 0\n", nil, `edgelist: line 1: invalid vertex "-1"`},
+		{"0 1 2.5\n", nil, `edgelist: line 1: invalid cost "2.5"`},
+		{"0 3\n", &Options{Order: 3}, "edgelist: vertex 3 out of range for order 3"},
+	} {
+		_, err := Read(strings.NewReader(e.in), e.opts)
+		if err == nil || err.Error() != e.err {
+			t.Errorf("Read(%q): %v; want %s", e.in, err, e.err)
+		}
+		_, err = ReadAt(strings.NewReader(e.in), int64(len(e.in)), e.opts)
+		if err == nil || err.Error() != e.err {
+			t.Errorf("ReadAt(%q): %v; want %s", e.in, err, e.err)
+		}
+	}
+}
+
+func TestReadAt(t *testing.T) {
+	n := 1000
+	g := graph.New(n)
+	for i := 0; i < 10*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(100)-50)
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, g); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	data := buf.String()
+	for _, workers := range []int{0, 1, 3, 16} {
+		h, err := ReadAt(strings.NewReader(data), int64(len(data)), &Options{Workers: workers, Order: n})
+		if err != nil {
+			t.Fatalf("ReadAt: %v", err)
+		}
+		if mess, diff := diff(h.String(), g.String()); diff {
+			t.Errorf("ReadAt(%d workers) %s", workers, mess)
+		}
+	}
+
+	// An error late in the input is reported with the right line number.
+	lines := strings.Count(data, "\n")
+	bad := data + "1 2 x\n"
+	_, err := ReadAt(strings.NewReader(bad), int64(len(bad)), &Options{Workers: 4})
+	if exp := fmt.Sprintf(`edgelist: line %d: invalid cost "x"`, lines+1); err == nil || err.Error() != exp {
+		t.Errorf("ReadAt: %v; want %s", err, exp)
+	}
+
+	name := filepath.Join(t.TempDir(), "edges.txt")
+	if err := os.WriteFile(name, []byte(data), 0666); err != nil {
+		t.Fatal(err)
+	}
+	h, err := ReadFile(name, &Options{Order: n})
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if mess, diff := diff(h.String(), g.String()); diff {
+		t.Errorf("ReadFile %s", mess)
+	}
+	if _, err := ReadFile(filepath.Join(t.TempDir(), "missing"), nil); err == nil {
+		t.Errorf("ReadFile: no error for missing file")
+	}
+}
+
+func TestProgress(t *testing.T) {
+	var buf bytes.Buffer
+	for i := 0; i < 5000; i++ {
+		fmt.Fprintf(&buf, "%d %d\n", rand.Intn(100), rand.Intn(100))
+	}
+	data := buf.String()
+	size := int64(len(data))
+
+	var last, lastSize int64
+	calls := 0
+	opts := &Options{Progress: func(read, size int64) {
+		if read < last {
+			t.Errorf("Progress: %d bytes read after %d", read, last)
+		}
+		last, lastSize = read, size
+		calls++
+	}}
+	if _, err := Read(strings.NewReader(data), opts); err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if calls == 0 || last != size || lastSize != -1 {
+		t.Errorf("Read: Progress(%d, %d) after %d calls; want (%d, -1)", last, lastSize, calls, size)
+	}
+
+	last, calls = 0, 0
+	opts.Workers = 4
+	if _, err := ReadAt(strings.NewReader(data), size, opts); err != nil {
+		t.Fatalf("ReadAt: %v", err)
+	}
+	if calls == 0 || last != size || lastSize != size {
+		t.Errorf("ReadAt: Progress(%d, %d) after %d calls; want (%d, %d)", last, lastSize, calls, size, size)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	g := graph.New(3)
+	g.AddCost(0, 1, 5)
+	g.Add(2, 2)
+	var buf bytes.Buffer
+	if err := Write(&buf, graph.Sort(g)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if mess, diff := diff(buf.String(), "0 1 5\n2 2\n"); diff {
+		t.Errorf("Write %s", mess)
+	}
+}
+
+func BenchmarkReadAt(b *testing.B) {
+	n := 10000
+	b.StopTimer()
+	g := graph.New(n)
+	for i := 0; i < 10*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(100))
+	}
+	var buf bytes.Buffer
+	Write(&buf, g)
+	data := buf.Bytes()
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ReadAt(bytes.NewReader(data), int64(len(data)), nil)
+	}
+}
@@ -0,0 +1,166 @@
+// Package graphml reads and writes graphs in the GraphML format
+// used by tools such as Gephi, yEd and NetworkX.
+//
+// Edge costs are stored as an edge attribute named "weight".
+// Other vertex and edge attributes are described by typed keys.
+// Both Write and Read work in a streaming fashion, without building
+// an XML document tree in memory.
+// Nested graphs, hyperedges and ports aren't supported.
+package graphml
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"github.com/yourbasic/graph"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Key describes an attribute of the vertices or edges of a graph.
+type Key struct {
+	// For is "node" or "edge".
+	For string
+
+	// Name is the name of the attribute.
+	Name string
+
+	// Type is the type of the attribute: "boolean", "int", "long",
+	// "float", "double" or "string". The values of the attribute are
+	// represented by the Go types bool, int64, float64, and string.
+	Type string
+}
+
+// Options control the output of Write. The zero value gives
+// a directed graph with vertices named by their numbers.
+type Options struct {
+	// Undirected writes an undirected graph. An edge from v to w
+	// and an edge from w to v with the same cost are written as one edge.
+	Undirected bool
+
+	// ID returns the GraphML node ID of v. If nil, the number v is used.
+	ID func(v int) string
+
+	// Keys lists the attribute keys. Only attributes
+	// with a key are written.
+	Keys []Key
+
+	// VertexAttrs returns the attributes of v, or nil.
+	VertexAttrs func(v int) map[string]interface{}
+
+	// EdgeAttrs returns the attributes of the edge from v to w, or nil.
+	// A "weight" attribute is ignored, since the cost of the edge
+	// is written as its weight.
+	EdgeAttrs func(v, w int) map[string]interface{}
+}
+
+const weightID = "weight"
+
+// Write writes g to w in the GraphML format.
+// Edges with non-zero cost get a "weight" attribute of type long.
+// If opts is nil, the default options are used.
+func Write(w io.Writer, g graph.Iterator, opts *Options) error {
+	if opts == nil {
+		opts = new(Options)
+	}
+	id := opts.ID
+	if id == nil {
+		id = strconv.Itoa
+	}
+	h := graph.Sort(g)
+	n := h.Order()
+	var t *graph.Immutable
+	if opts.Undirected {
+		t = graph.Transpose(h)
+	}
+
+	b := bufio.NewWriter(w)
+	b.WriteString(xml.Header)
+	b.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	b.WriteString(`  <key id="weight" for="edge" attr.name="weight" attr.type="long"><default>0</default></key>` + "\n")
+	keyIDs := map[Key]string{}
+	for i, k := range opts.Keys {
+		if k.For == "edge" && k.Name == "weight" {
+			continue
+		}
+		keyIDs[k] = "d" + strconv.Itoa(i)
+		fmt.Fprintf(b, `  <key id="%s" for="%s" attr.name="%s" attr.type="%s"/>`+"\n",
+			keyIDs[k], escape(k.For), escape(k.Name), escape(k.Type))
+	}
+	edgedefault := "directed"
+	if opts.Undirected {
+		edgedefault = "undirected"
+	}
+	b.WriteString(`  <graph edgedefault="` + edgedefault + `">` + "\n")
+	// element writes an element with the given start tag and data lines.
+	element := func(indent, start, name string, data []string) {
+		b.WriteString(indent + start)
+		if len(data) == 0 {
+			b.WriteString("/>\n")
+			return
+		}
+		b.WriteString(">\n")
+		for _, line := range data {
+			b.WriteString(indent + "  " + line + "\n")
+		}
+		b.WriteString(indent + "</" + name + ">\n")
+	}
+	// data returns the data lines of the attributes with keys for kind.
+	data := func(kind string, attrs map[string]interface{}) (lines []string) {
+		for _, k := range opts.Keys {
+			if x, ok := attrs[k.Name]; ok && k.For == kind && keyIDs[k] != "" {
+				lines = append(lines, `<data key="`+keyIDs[k]+`">`+escape(fmt.Sprint(x))+`</data>`)
+			}
+		}
+		return
+	}
+	for v := 0; v < n; v++ {
+		var attrs map[string]interface{}
+		if opts.VertexAttrs != nil {
+			attrs = opts.VertexAttrs(v)
+		}
+		element("    ", `<node id="`+escape(id(v))+`"`, "node", data("node", attrs))
+	}
+	for v := 0; v < n; v++ {
+		// In undirected graphs, each reverse edge (w, v) with w < v
+		// matches an edge (v, w) that has already been written.
+		type edge struct {
+			w int
+			c int64
+		}
+		written := make(map[edge]int)
+		if opts.Undirected {
+			t.Visit(v, func(u int, c int64) (skip bool) {
+				if u < v {
+					written[edge{u, c}]++
+				}
+				return u >= v
+			})
+		}
+		h.Visit(v, func(w int, c int64) (skip bool) {
+			if e := (edge{w, c}); written[e] > 0 {
+				written[e]--
+				return
+			}
+			var lines []string
+			if c != 0 {
+				lines = append(lines, `<data key="`+weightID+`">`+strconv.FormatInt(c, 10)+`</data>`)
+			}
+			if opts.EdgeAttrs != nil {
+				lines = append(lines, data("edge", opts.EdgeAttrs(v, w))...)
+			}
+			element("    ", `<edge source="`+escape(id(v))+`" target="`+escape(id(w))+`"`, "edge", lines)
+			return
+		})
+	}
+	b.WriteString("  </graph>\n</graphml>\n")
+	return b.Flush()
+}
+
+// escape returns s with XML special characters escaped.
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package graphml
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/yourbasic/graph"
+	"reflect"
+	"testing"
+)
+
+func diff(res, exp interface{}) (message string, diff bool) {
+	if !reflect.DeepEqual(res, exp) {
+		message = fmt.Sprintf("%v; want %v", res, exp)
+		diff = true
+	}
+	return
+}
+
+func TestWrite(t *testing.T) {
+	g := graph.New(3)
+	g.AddCost(0, 1, 5)
+	g.Add(1, 2)
+	g.AddCost(2, 0, -1)
+	var buf bytes.Buffer
+	err := Write(&buf, g, &Options{
+		ID: func(v int) string { return []string{"a", "b", "<c>"}[v] },
+		Keys: []Key{
+			{For: "node", Name: "color", Type: "string"},
+			{For: "node", Name: "size", Type: "double"},
+			{For: "edge", Name: "toll", Type: "boolean"},
+		},
+		VertexAttrs: func(v int) map[string]interface{} {
+			if v == 0 {
+				return map[string]interface{}{"color": "red", "size": 1.5, "unknown": 1}
+			}
+			return nil
+		},
+		EdgeAttrs: func(v, w int) map[string]interface{} {
+			return map[string]interface{}{"toll": v == 1}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	exp := `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="weight" for="edge" attr.name="weight" attr.type="long"><default>0</default></key>
+  <key id="d0" for="node" attr.name="color" attr.type="string"/>
+  <key id="d1" for="node" attr.name="size" attr.type="double"/>
+  <key id="d2" for="edge" attr.name="toll" attr.type="boolean"/>
+  <graph edgedefault="directed">
+    <node id="a">
+      <data key="d0">red</data>
+      <data key="d1">1.5</data>
+    </node>
+    <node id="b"/>
+    <node id="&lt;c&gt;"/>
+    <edge source="a" target="b">
+      <data key="weight">5</data>
+      <data key="d2">false</data>
+    </edge>
+    <edge source="b" target="&lt;c&gt;">
+      <data key="d2">true</data>
+    </edge>
+    <edge source="&lt;c&gt;" target="a">
+      <data key="weight">-1</data>
+      <data key="d2">false</data>
+    </edge>
+  </graph>
+</graphml>
+`
+	if mess, diff := diff(buf.String(), exp); diff {
+		t.Errorf("Write %s", mess)
+	}
+
+	g = graph.New(2)
+	g.AddBothCost(0, 1, 2)
+	g.Add(1, 1)
+	buf.Reset()
+	if err := Write(&buf, g, &Options{Undirected: true}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	exp = `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="weight" for="edge" attr.name="weight" attr.type="long"><default>0</default></key>
+  <graph edgedefault="undirected">
+    <node id="0"/>
+    <node id="1"/>
+    <edge source="0" target="1">
+      <data key="weight">2</data>
+    </edge>
+    <edge source="1" target="1"/>
+  </graph>
+</graphml>
+`
+	if mess, diff := diff(buf.String(), exp); diff {
+		t.Errorf("Write undirected %s", mess)
+	}
+}
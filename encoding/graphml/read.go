@@ -0,0 +1,256 @@
+package graphml
+
+import (
+	"encoding/xml"
+	"errors"
+	"github.com/yourbasic/graph"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Graph is a graph read by Read.
+type Graph struct {
+	// Name is the ID of the graph element, or the empty string.
+	Name string
+
+	// Directed tells if the default edge type is directed.
+	// Undirected edges are represented by edges in both directions.
+	Directed bool
+
+	// G holds the edges of the graph. The vertices are numbered
+	// in order of their first appearance in the input.
+	G *graph.Mutable
+
+	// IDs[v] is the GraphML node ID of vertex v.
+	IDs []string
+
+	// Keys lists the node and edge attribute keys,
+	// except the "weight" key of the edges.
+	Keys []Key
+
+	vertexAttrs *graph.Labels[map[string]interface{}]
+	edgeAttrs   *graph.EdgeLabels[map[string]interface{}]
+}
+
+// ID returns the GraphML node ID of v.
+func (d *Graph) ID(v int) string {
+	return d.IDs[v]
+}
+
+// VertexAttrs returns the attributes of v, or nil if it has none.
+func (d *Graph) VertexAttrs(v int) map[string]interface{} {
+	attrs, _ := d.vertexAttrs.Get(v)
+	return attrs
+}
+
+// EdgeAttrs returns the attributes of the edge from v to w,
+// or nil if it has none. The weight isn't included.
+func (d *Graph) EdgeAttrs(v, w int) map[string]interface{} {
+	attrs, _ := d.edgeAttrs.Get(v, w)
+	return attrs
+}
+
+// Options returns options for Write that write d with
+// its IDs, keys and attributes.
+func (d *Graph) Options() *Options {
+	return &Options{
+		Undirected:  !d.Directed,
+		ID:          d.ID,
+		Keys:        d.Keys,
+		VertexAttrs: d.VertexAttrs,
+		EdgeAttrs:   d.EdgeAttrs,
+	}
+}
+
+// ErrFormat is returned by Read when the input isn't valid GraphML,
+// or uses features that aren't supported.
+var ErrFormat = errors.New("graphml: invalid format")
+
+// Read reads a graph in the GraphML format from r.
+// Only the first graph of the document is read.
+//
+// The cost of an edge is given by its "weight" attribute, rounded
+// to the nearest integer; it is 0 if the edge has no weight.
+// Since G isn't a multigraph, only the last of several edges
+// between the same two vertices is kept.
+func Read(r io.Reader) (*Graph, error) {
+	d := &Graph{
+		vertexAttrs: graph.NewLabels[map[string]interface{}](0),
+		edgeAttrs:   graph.NewEdgeLabels[map[string]interface{}](),
+	}
+	type edge struct {
+		v, w     int
+		c        int64
+		directed bool
+		attrs    map[string]interface{}
+	}
+	var edges []edge
+	keys := make(map[string]Key) // keys by ID
+	index := make(map[string]int)
+	vertex := func(id string) int {
+		if v, ok := index[id]; ok {
+			return v
+		}
+		v := len(d.IDs)
+		index[id] = v
+		d.IDs = append(d.IDs, id)
+		return v
+	}
+
+	dec := xml.NewDecoder(r)
+	var (
+		graphs  int // number of graph elements started
+		depth   int // nesting depth of graph elements
+		attrs   map[string]interface{}
+		current *edge  // the current edge element, or nil
+		node    = -1   // the current node element, or -1
+		dataKey string // key of the current data element
+		text    []byte
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			text = text[:0]
+			if graphs > 1 {
+				continue // Skip all graphs but the first.
+			}
+			a := attrMap(t.Attr)
+			switch t.Name.Local {
+			case "key":
+				k := Key{For: a["for"], Name: a["attr.name"], Type: a["attr.type"]}
+				if k.Type == "" {
+					k.Type = "string"
+				}
+				keys[a["id"]] = k
+				if k.For == "node" || k.For == "edge" && k.Name != "weight" {
+					d.Keys = append(d.Keys, k)
+				}
+			case "graph":
+				graphs++
+				depth++
+				if depth > 1 {
+					return nil, ErrFormat // Nested graphs aren't supported.
+				}
+				if graphs == 1 {
+					d.Name = a["id"]
+					d.Directed = a["edgedefault"] != "undirected"
+				}
+			case "node":
+				if depth == 0 {
+					return nil, ErrFormat
+				}
+				node = vertex(a["id"])
+				attrs = nil
+			case "edge":
+				source, ok1 := a["source"]
+				target, ok2 := a["target"]
+				if depth == 0 || !ok1 || !ok2 {
+					return nil, ErrFormat
+				}
+				directed := d.Directed
+				if x, ok := a["directed"]; ok {
+					directed = x == "true"
+				}
+				current = &edge{v: vertex(source), w: vertex(target), directed: directed}
+				attrs = nil
+			case "data":
+				dataKey = a["key"]
+			case "hyperedge", "port":
+				return nil, ErrFormat
+			}
+		case xml.CharData:
+			text = append(text, t...)
+		case xml.EndElement:
+			if graphs > 1 && t.Name.Local != "graph" {
+				continue
+			}
+			switch t.Name.Local {
+			case "graph":
+				depth--
+			case "data":
+				k, ok := keys[dataKey]
+				if !ok || node == -1 && current == nil {
+					continue // Graph data, or data without a key.
+				}
+				x, err := parse(k.Type, string(text))
+				if err != nil {
+					return nil, ErrFormat
+				}
+				if current != nil && k.For == "edge" && k.Name == "weight" {
+					f, ok := x.(float64)
+					if !ok {
+						if i, isInt := x.(int64); isInt {
+							f, ok = float64(i), true
+						}
+					}
+					if !ok {
+						return nil, ErrFormat
+					}
+					current.c = int64(math.Round(f))
+					continue
+				}
+				if attrs == nil {
+					attrs = make(map[string]interface{})
+				}
+				attrs[k.Name] = x
+			case "node":
+				if attrs != nil {
+					d.vertexAttrs.Set(node, attrs)
+				}
+				node = -1
+			case "edge":
+				current.attrs = attrs
+				edges = append(edges, *current)
+				current = nil
+			}
+		}
+	}
+	if graphs == 0 {
+		return nil, ErrFormat
+	}
+	d.G = graph.New(len(d.IDs))
+	for _, e := range edges {
+		d.G.AddCost(e.v, e.w, e.c)
+		if e.attrs != nil {
+			d.edgeAttrs.Set(e.v, e.w, e.attrs)
+		}
+		if !e.directed {
+			d.G.AddCost(e.w, e.v, e.c)
+			if e.attrs != nil {
+				d.edgeAttrs.Set(e.w, e.v, e.attrs)
+			}
+		}
+	}
+	return d, nil
+}
+
+func attrMap(attrs []xml.Attr) map[string]string {
+	m := make(map[string]string, len(attrs))
+	for _, a := range attrs {
+		m[a.Name.Local] = a.Value
+	}
+	return m
+}
+
+// parse converts the text s to a value of the given GraphML type.
+func parse(typ, s string) (interface{}, error) {
+	switch typ {
+	case "boolean":
+		return strconv.ParseBool(s)
+	case "int", "long":
+		return strconv.ParseInt(s, 10, 64)
+	case "float", "double":
+		return strconv.ParseFloat(s, 64)
+	case "string":
+		return s, nil
+	}
+	return nil, ErrFormat
+}
@@ -0,0 +1,121 @@
+package graphml
+
+import (
+	"bytes"
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// Output from NetworkX, with float weights and an undirected edge.
+const networkx = `<?xml version='1.0' encoding='utf-8'?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <key id="d2" for="edge" attr.name="weight" attr.type="double" />
+  <key id="d1" for="node" attr.name="population" attr.type="long" />
+  <key id="d0" for="node" attr.name="name" attr.type="string" />
+  <key id="g0" for="graph" attr.name="title" attr.type="string" />
+  <graph edgedefault="directed" id="roads">
+    <data key="g0">Roads</data>
+    <node id="sto">
+      <data key="d0">Stockholm</data>
+      <data key="d1">975551</data>
+    </node>
+    <node id="upp" />
+    <edge source="sto" target="upp">
+      <data key="d2">71.6</data>
+    </edge>
+    <edge source="upp" target="gbg" directed="false" />
+  </graph>
+  <graph id="ignored"><node id="x"/></graph>
+</graphml>
+`
+
+func TestRead(t *testing.T) {
+	d, err := Read(strings.NewReader(networkx))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if mess, diff := diff(d.Name, "roads"); diff {
+		t.Errorf("Read: Name %s", mess)
+	}
+	if mess, diff := diff(d.Directed, true); diff {
+		t.Errorf("Read: Directed %s", mess)
+	}
+	if mess, diff := diff(d.IDs, []string{"sto", "upp", "gbg"}); diff {
+		t.Errorf("Read: IDs %s", mess)
+	}
+	if mess, diff := diff(d.G.String(), "3 [(0 1):72 {1 2}]"); diff {
+		t.Errorf("Read: G %s", mess)
+	}
+	if mess, diff := diff(d.Keys, []Key{{"node", "population", "long"}, {"node", "name", "string"}}); diff {
+		t.Errorf("Read: Keys %s", mess)
+	}
+	if mess, diff := diff(d.VertexAttrs(0), map[string]interface{}{"name": "Stockholm", "population": int64(975551)}); diff {
+		t.Errorf("Read: VertexAttrs %s", mess)
+	}
+	if d.VertexAttrs(1) != nil || d.EdgeAttrs(0, 1) != nil {
+		t.Errorf("Read: unexpected attributes")
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"<graphml></graphml>",
+		"<graphml><node id='a'/></graphml>",
+		"<graphml><graph><edge source='a'/></graph></graphml>",
+		"<graphml><graph><graph/></graph></graphml>",
+		"<graphml><graph><hyperedge/></graph></graphml>",
+		`<graphml><key id="k" for="node" attr.type="int"/><graph><node id="a"><data key="k">x</data></node></graph></graphml>`,
+		`<graphml><key id="k" for="edge" attr.name="weight" attr.type="string"/><graph><edge source="a" target="b"><data key="k">x</data></edge></graph></graphml>`,
+	} {
+		if _, err := Read(strings.NewReader(in)); err != ErrFormat {
+			t.Errorf("Read(%q): %v; want %v", in, err, ErrFormat)
+		}
+	}
+	if _, err := Read(strings.NewReader("<graphml>")); err == nil {
+		t.Errorf("Read: no error for truncated XML")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	n := 20
+	g := graph.New(n)
+	for i := 0; i < 3*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(5)-2)
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, g, nil); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	d, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if mess, diff := diff(d.G.String(), g.String()); diff {
+		t.Errorf("RoundTrip %s", mess)
+	}
+
+	d, err = Read(strings.NewReader(networkx))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	buf.Reset()
+	if err := Write(&buf, d.G, d.Options()); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	e, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if mess, diff := diff(e.G.String(), d.G.String()); diff {
+		t.Errorf("RoundTrip %s", mess)
+	}
+	if mess, diff := diff(e.IDs, d.IDs); diff {
+		t.Errorf("RoundTrip: IDs %s", mess)
+	}
+	if mess, diff := diff(e.VertexAttrs(0), d.VertexAttrs(0)); diff {
+		t.Errorf("RoundTrip: VertexAttrs %s", mess)
+	}
+}
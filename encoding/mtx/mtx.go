@@ -0,0 +1,194 @@
+// Package mtx reads and writes graphs in the Matrix Market exchange format,
+// used by the SuiteSparse Matrix Collection and other sparse matrix datasets.
+//
+// A matrix is mapped to a graph with an edge from i to j for each
+// nonzero entry a(i, j), with the value of the entry as cost.
+// Matrix Market indices start at 1, while the vertices of the graph
+// are numbered from 0, so entry a(i, j) gives an edge from i-1 to j-1.
+// Only the coordinate format is supported.
+package mtx
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/yourbasic/graph"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const banner = "%%MatrixMarket"
+
+// Read reads a matrix in Matrix Market coordinate format from r.
+// The order of the graph is the larger of the number of rows
+// and columns.
+//
+// Entries of real matrices are rounded to the nearest integer,
+// and entries of pattern matrices give edges with cost 0.
+// A symmetric matrix gives edges in both directions for each
+// off-diagonal entry, and a skew-symmetric matrix gives an edge
+// with negated cost in the opposite direction.
+// Since the graph is an Immutable graph, duplicate entries are kept.
+func Read(r io.Reader) (*graph.Immutable, error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	line := 0
+	errorf := func(format string, args ...interface{}) error {
+		return fmt.Errorf("mtx: line %d: %s", line, fmt.Sprintf(format, args...))
+	}
+	if !s.Scan() {
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("mtx: empty input")
+	}
+	line++
+	header := strings.Fields(strings.ToLower(s.Text()))
+	if len(header) != 5 || header[0] != strings.ToLower(banner) || header[1] != "matrix" {
+		return nil, errorf("invalid header")
+	}
+	format, field, symmetry := header[2], header[3], header[4]
+	if format != "coordinate" {
+		return nil, errorf("unsupported format %s", format)
+	}
+	switch field {
+	case "real", "double", "integer", "pattern":
+	default:
+		return nil, errorf("unsupported field %s", field)
+	}
+	switch symmetry {
+	case "general", "symmetric", "skew-symmetric":
+	default:
+		return nil, errorf("unsupported symmetry %s", symmetry)
+	}
+
+	var rows, cols, nnz int
+	sized := false
+	var edges []graph.Edge
+	count := 0
+	for s.Scan() {
+		line++
+		text := strings.TrimSpace(s.Text())
+		if text == "" || text[0] == '%' {
+			continue
+		}
+		fields := strings.Fields(text)
+		if !sized {
+			if len(fields) != 3 {
+				return nil, errorf("expected rows, columns and entries")
+			}
+			var err1, err2, err3 error
+			rows, err1 = strconv.Atoi(fields[0])
+			cols, err2 = strconv.Atoi(fields[1])
+			nnz, err3 = strconv.Atoi(fields[2])
+			if err1 != nil || err2 != nil || err3 != nil || rows < 0 || cols < 0 || nnz < 0 {
+				return nil, errorf("invalid size line")
+			}
+			if symmetry != "general" && rows != cols {
+				return nil, errorf("%s matrix isn't square", symmetry)
+			}
+			sized = true
+			continue
+		}
+		want := 3
+		if field == "pattern" {
+			want = 2
+		}
+		if len(fields) < want {
+			return nil, errorf("expected %d fields, found %d", want, len(fields))
+		}
+		i, err1 := strconv.Atoi(fields[0])
+		j, err2 := strconv.Atoi(fields[1])
+		if err1 != nil || err2 != nil || i < 1 || i > rows || j < 1 || j > cols {
+			return nil, errorf("invalid index")
+		}
+		var c int64
+		if field != "pattern" {
+			x, err := strconv.ParseFloat(fields[2], 64)
+			if err != nil || math.IsNaN(x) || math.Abs(x) > math.MaxInt64 {
+				return nil, errorf("invalid value %q", fields[2])
+			}
+			c = int64(math.Round(x))
+		}
+		if count++; count > nnz {
+			return nil, errorf("more than %d entries", nnz)
+		}
+		edges = append(edges, graph.Edge{V: i - 1, W: j - 1, Cost: c})
+		if i != j {
+			switch symmetry {
+			case "symmetric":
+				edges = append(edges, graph.Edge{V: j - 1, W: i - 1, Cost: c})
+			case "skew-symmetric":
+				edges = append(edges, graph.Edge{V: j - 1, W: i - 1, Cost: -c})
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if !sized {
+		return nil, errors.New("mtx: missing size line")
+	}
+	if count < nnz {
+		return nil, fmt.Errorf("mtx: found %d entries; want %d", count, nnz)
+	}
+	n := rows
+	if cols > n {
+		n = cols
+	}
+	return graph.FromEdges(n, edges), nil
+}
+
+// Write writes g to w as a square integer matrix in coordinate format,
+// with one entry for each edge.
+func Write(w io.Writer, g graph.Iterator) error {
+	return write(w, graph.Sort(g), "general", func(v, w int) bool { return true })
+}
+
+// WriteSymmetric writes g to w as a symmetric integer matrix in
+// coordinate format, storing only the entries on or below the diagonal.
+// It returns an error, and writes nothing, if g isn't symmetric:
+// each edge from v to w must be matched by an edge from w to v
+// with the same cost.
+func WriteSymmetric(w io.Writer, g graph.Iterator) error {
+	h := graph.Sort(g)
+	t := graph.Transpose(h)
+	if graph.String(h) != graph.String(t) {
+		return errors.New("mtx: graph isn't symmetric")
+	}
+	return write(w, h, "symmetric", func(v, w int) bool { return w <= v })
+}
+
+func write(w io.Writer, g *graph.Immutable, symmetry string, include func(v, w int) bool) error {
+	n := g.Order()
+	nnz := 0
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			if include(v, w) {
+				nnz++
+			}
+			return
+		})
+	}
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "%s matrix coordinate integer %s\n", banner, symmetry)
+	fmt.Fprintf(b, "%d %d %d\n", n, n, nnz)
+	var buf []byte
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if include(v, w) {
+				buf = strconv.AppendInt(buf[:0], int64(v+1), 10)
+				buf = append(buf, ' ')
+				buf = strconv.AppendInt(buf, int64(w+1), 10)
+				buf = append(buf, ' ')
+				buf = strconv.AppendInt(buf, c, 10)
+				buf = append(buf, '\n')
+				b.Write(buf)
+			}
+			return
+		})
+	}
+	return b.Flush()
+}
@@ -0,0 +1,145 @@
+package mtx
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func diff(res, exp interface{}) (message string, diff bool) {
+	if !reflect.DeepEqual(res, exp) {
+		message = fmt.Sprintf("%v; want %v", res, exp)
+		diff = true
+	}
+	return
+}
+
+func TestRead(t *testing.T) {
+	for _, e := range []struct{ in, exp string }{
+		{`%%MatrixMarket matrix coordinate real general
+% A comment.
+3 4 3
+1 2 1.5
+3 1 -2
+2 4 7e0
+`, "4 [(0 1):2 (1 3):7 (2 0):-2]"},
+		{`%%MatrixMarket matrix coordinate pattern symmetric
+3 3 3
+1 1
+2 1
+3 2
+`, "3 [(0 0) {0 1} {1 2}]"},
+		{`%%MatrixMarket matrix coordinate integer skew-symmetric
+2 2 1
+2 1 3
+`, "2 [(0 1):-3 (1 0):3]"},
+		{`%%MatrixMarket matrix coordinate integer general
+2 2 2
+1 2 1
+1 2 1
+`, "2 [2×(0 1):1]"},
+	} {
+		g, err := Read(strings.NewReader(e.in))
+		if err != nil {
+			t.Errorf("Read(%q): %v", e.in, err)
+			continue
+		}
+		if mess, diff := diff(g.String(), e.exp); diff {
+			t.Errorf("Read(%q) %s", e.in, mess)
+		}
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	h := "%%MatrixMarket matrix coordinate integer general\n"
+	for _, e := range []struct{ in, err string }{
+		{"", "mtx: empty input"},
+		{"3 3 0\n", "mtx: line 1: invalid header"},
+		{"%%MatrixMarket matrix array real general\n", "mtx: line 1: unsupported format array"},
+		{"%%MatrixMarket matrix coordinate complex general\n", "mtx: line 1: unsupported field complex"},
+		{"%%MatrixMarket matrix coordinate real hermitian\n", "mtx: line 1: unsupported symmetry hermitian"},
+		{"%%MatrixMarket matrix coordinate real symmetric\n2 3 0\n", "mtx: line 2: symmetric matrix isn't square"},
+		{h, "mtx: missing size line"},
+		{h + "2 2\n", "mtx: line 2: expected rows, columns and entries"},
+		{h + "2 2 1\n1 3 1\n", "mtx: line 3: invalid index"},
+		{h + "2 2 1\n0 1 1\n", "mtx: line 3: invalid index"},
+		{h + "2 2 1\n1 1\n", "mtx: line 3: expected 3 fields, found 2"},
+		{h + "2 2 1\n1 1 x\n", `mtx: line 3: invalid value "x"`},
+		{h + "2 2 1\n1 1 1\n2 2 2\n", "mtx: line 4: more than 1 entries"},
+		{h + "2 2 2\n1 1 1\n", "mtx: found 1 entries; want 2"},
+	} {
+		_, err := Read(strings.NewReader(e.in))
+		if err == nil || err.Error() != e.err {
+			t.Errorf("Read(%q): %v; want %s", e.in, err, e.err)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	g := graph.New(3)
+	g.AddCost(0, 1, 5)
+	g.AddBoth(1, 2)
+	var buf bytes.Buffer
+	if err := Write(&buf, g); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	exp := `%%MatrixMarket matrix coordinate integer general
+3 3 3
+1 2 5
+2 3 0
+3 2 0
+`
+	if mess, diff := diff(buf.String(), exp); diff {
+		t.Errorf("Write %s", mess)
+	}
+
+	buf.Reset()
+	if err := WriteSymmetric(&buf, g); err == nil {
+		t.Errorf("WriteSymmetric: no error for asymmetric graph")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("WriteSymmetric: wrote %q for asymmetric graph", buf.String())
+	}
+	g.AddCost(1, 0, 5)
+	g.Add(2, 2)
+	if err := WriteSymmetric(&buf, g); err != nil {
+		t.Fatalf("WriteSymmetric: %v", err)
+	}
+	exp = `%%MatrixMarket matrix coordinate integer symmetric
+3 3 3
+2 1 5
+3 2 0
+3 3 0
+`
+	if mess, diff := diff(buf.String(), exp); diff {
+		t.Errorf("WriteSymmetric %s", mess)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	n := 20
+	g := graph.New(n)
+	for i := 0; i < 3*n; i++ {
+		g.AddBothCost(rand.Intn(n), rand.Intn(n), rand.Int63n(5)-2)
+	}
+	for _, write := range []func(*bytes.Buffer) error{
+		func(b *bytes.Buffer) error { return Write(b, g) },
+		func(b *bytes.Buffer) error { return WriteSymmetric(b, g) },
+	} {
+		var buf bytes.Buffer
+		if err := write(&buf); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		h, err := Read(&buf)
+		if err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+		if mess, diff := diff(h.String(), g.String()); diff {
+			t.Errorf("RoundTrip %s", mess)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package graph
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Errors returned by Validate and by the Checked variants of
+// the algorithms, often wrapped in an EdgeError or a VertexError;
+// use errors.Is to check for them.
+var (
+	ErrVertexOutOfRange = errors.New("graph: vertex out of range")
+	ErrSelfLoop         = errors.New("graph: self-loop")
+	ErrDuplicateEdge    = errors.New("graph: duplicate edge")
+	ErrAsymmetric       = errors.New("graph: edge without matching reverse edge")
+	ErrNegativeCost     = errors.New("graph: negative cost")
+	ErrNegativeCycle    = errors.New("graph: negative cycle")
+	ErrNotDAG           = errors.New("graph: not a directed acyclic graph")
+)
+
+// EdgeError describes a problem with the edge from V to W of cost Cost.
+type EdgeError struct {
+	V, W int
+	Cost int64
+	Err  error
+}
+
+func (e *EdgeError) Error() string {
+	return e.Err.Error() + ": edge (" + strconv.Itoa(e.V) + " " + strconv.Itoa(e.W) + "):" + strconv.FormatInt(e.Cost, 10)
+}
+
+func (e *EdgeError) Unwrap() error {
+	return e.Err
+}
+
+// VertexError describes a problem with the vertex V.
+type VertexError struct {
+	V   int
+	Err error
+}
+
+func (e *VertexError) Error() string {
+	return e.Err.Error() + ": vertex " + strconv.Itoa(e.V)
+}
+
+func (e *VertexError) Unwrap() error {
+	return e.Err
+}
+
+// ShortestPathsChecked is like ShortestPaths, but returns an error
+// instead of panicking or skipping edges: a *VertexError wrapping
+// ErrVertexOutOfRange if v is out of range, or an *EdgeError wrapping
+// ErrVertexOutOfRange or ErrNegativeCost for the first edge found during
+// the search that leads out of range or has a negative cost.
+// In that case, parent and dist are nil.
+func ShortestPathsChecked(g Iterator, v int) (parent []int, dist []int64, err error) {
+	c := &checkedIterator{g: g, nonNegative: true}
+	if err = c.vertex(v); err != nil {
+		return nil, nil, err
+	}
+	parent, dist = ShortestPaths(c, v)
+	if c.err != nil {
+		return nil, nil, c.err
+	}
+	return
+}
+
+// BFSTreeChecked is like BFSTree, but returns an error instead of
+// panicking: a *VertexError wrapping ErrVertexOutOfRange if v is out
+// of range, or an *EdgeError wrapping ErrVertexOutOfRange for the first
+// edge found during the search that leads out of range.
+// In that case, parent and dist are nil.
+func BFSTreeChecked(g Iterator, v int) (parent []int, dist []int, err error) {
+	c := &checkedIterator{g: g}
+	if err = c.vertex(v); err != nil {
+		return nil, nil, err
+	}
+	parent, dist = BFSTree(c, v)
+	if c.err != nil {
+		return nil, nil, c.err
+	}
+	return
+}
+
+// BellmanFordChecked is like BellmanFord, but returns an error instead
+// of setting ok to false or panicking: ErrNegativeCycle if a negative
+// cycle can be reached from v, a *VertexError wrapping ErrVertexOutOfRange
+// if v is out of range, or an *EdgeError wrapping ErrVertexOutOfRange
+// for the first edge that leads out of range.
+// In that case, parent and dist are nil.
+func BellmanFordChecked(g Iterator, v int) (parent []int, dist []int64, err error) {
+	c := &checkedIterator{g: g}
+	if err = c.vertex(v); err != nil {
+		return nil, nil, err
+	}
+	parent, dist, ok := BellmanFord(c, v)
+	switch {
+	case c.err != nil:
+		return nil, nil, c.err
+	case !ok:
+		return nil, nil, ErrNegativeCycle
+	}
+	return
+}
+
+// TopSortChecked is like TopSort, but returns ErrNotDAG if g has a cycle,
+// or an *EdgeError wrapping ErrVertexOutOfRange for the first edge
+// that leads out of range. In that case, order is nil.
+func TopSortChecked(g Iterator) (order []int, err error) {
+	c := &checkedIterator{g: g}
+	order, ok := TopSort(c)
+	switch {
+	case c.err != nil:
+		return nil, c.err
+	case !ok:
+		return nil, ErrNotDAG
+	}
+	return
+}
+
+// checkedIterator is a view of g that reports edges that lead out
+// of range, and negative costs if nonNegative is set, instead of
+// passing them on. Once an error has been found, all calls to Visit
+// return immediately.
+type checkedIterator struct {
+	g           Iterator
+	nonNegative bool
+	err         error
+}
+
+func (c *checkedIterator) Order() int {
+	return c.g.Order()
+}
+
+func (c *checkedIterator) Visit(v int, do func(w int, c int64) bool) bool {
+	if c.err != nil {
+		return true
+	}
+	n := c.g.Order()
+	return c.g.Visit(v, func(w int, cost int64) bool {
+		switch {
+		case w < 0 || w >= n:
+			c.err = &EdgeError{v, w, cost, ErrVertexOutOfRange}
+		case cost < 0 && c.nonNegative:
+			c.err = &EdgeError{v, w, cost, ErrNegativeCost}
+		default:
+			return do(w, cost)
+		}
+		return true
+	})
+}
+
+// vertex returns a *VertexError if v is out of range.
+func (c *checkedIterator) vertex(v int) error {
+	if v < 0 || v >= c.g.Order() {
+		return &VertexError{v, ErrVertexOutOfRange}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestChecked(t *testing.T) {
+	g := New(4)
+	g.AddCost(0, 1, 2)
+	g.AddCost(1, 2, 3)
+	g.AddCost(2, 3, 1)
+
+	parent, dist, err := ShortestPathsChecked(g, 0)
+	expParent, expDist := ShortestPaths(g, 0)
+	if err != nil {
+		t.Errorf("ShortestPathsChecked: %v", err)
+	}
+	if mess, diff := diff(parent, expParent); diff {
+		t.Errorf("ShortestPathsChecked->parent %s", mess)
+	}
+	if mess, diff := diff(dist, expDist); diff {
+		t.Errorf("ShortestPathsChecked->dist %s", mess)
+	}
+	bfsParent, bfsDist, err := BFSTreeChecked(g, 0)
+	if err != nil {
+		t.Errorf("BFSTreeChecked: %v", err)
+	}
+	expBFSParent, expBFSDist := BFSTree(g, 0)
+	if mess, diff := diff([][]int{bfsParent, bfsDist}, [][]int{expBFSParent, expBFSDist}); diff {
+		t.Errorf("BFSTreeChecked %s", mess)
+	}
+	if _, dist, err = BellmanFordChecked(g, 0); err != nil {
+		t.Errorf("BellmanFordChecked: %v", err)
+	}
+	if mess, diff := diff(dist, []int64{0, 2, 5, 6}); diff {
+		t.Errorf("BellmanFordChecked->dist %s", mess)
+	}
+	order, err := TopSortChecked(g)
+	if err != nil {
+		t.Errorf("TopSortChecked: %v", err)
+	}
+	if mess, diff := diff(order, []int{0, 1, 2, 3}); diff {
+		t.Errorf("TopSortChecked %s", mess)
+	}
+
+	// Errors
+	_, _, err = ShortestPathsChecked(g, 4)
+	if mess, diff := diff(err, error(&VertexError{4, ErrVertexOutOfRange})); diff {
+		t.Errorf("ShortestPathsChecked %s", mess)
+	}
+	if mess, diff := diff(err.Error(), "graph: vertex out of range: vertex 4"); diff {
+		t.Errorf("VertexError.Error %s", mess)
+	}
+	_, _, err = BFSTreeChecked(g, -1)
+	if !errors.Is(err, ErrVertexOutOfRange) {
+		t.Errorf("BFSTreeChecked: %v; want %v", err, ErrVertexOutOfRange)
+	}
+	g.AddCost(2, 1, -4)
+	parent, dist, err = ShortestPathsChecked(g, 0)
+	if mess, diff := diff(err, error(&EdgeError{2, 1, -4, ErrNegativeCost})); diff {
+		t.Errorf("ShortestPathsChecked %s", mess)
+	}
+	if parent != nil || dist != nil {
+		t.Errorf("ShortestPathsChecked: non-nil result with error")
+	}
+	if _, _, err = BellmanFordChecked(g, 0); err != ErrNegativeCycle {
+		t.Errorf("BellmanFordChecked: %v; want %v", err, ErrNegativeCycle)
+	}
+	if _, err = TopSortChecked(g); err != ErrNotDAG {
+		t.Errorf("TopSortChecked: %v; want %v", err, ErrNotDAG)
+	}
+
+	bad := badGraph{3, []Edge{{0, 1, 0}, {1, 3, 0}}}
+	exp := error(&EdgeError{1, 3, 0, ErrVertexOutOfRange})
+	_, _, err = ShortestPathsChecked(bad, 0)
+	if mess, diff := diff(err, exp); diff {
+		t.Errorf("ShortestPathsChecked %s", mess)
+	}
+	_, _, err = BFSTreeChecked(bad, 0)
+	if mess, diff := diff(err, exp); diff {
+		t.Errorf("BFSTreeChecked %s", mess)
+	}
+	_, _, err = BellmanFordChecked(bad, 0)
+	if mess, diff := diff(err, exp); diff {
+		t.Errorf("BellmanFordChecked %s", mess)
+	}
+	_, err = TopSortChecked(bad)
+	if mess, diff := diff(err, exp); diff {
+		t.Errorf("TopSortChecked %s", mess)
+	}
+}
@@ -0,0 +1,154 @@
+package graph
+
+// An ettNode is a node in a treap that stores an Euler tour of a tree,
+// as used by DynamicConnectivity. The tour holds one node for each vertex
+// and two nodes, one in each direction, for each edge of the tree.
+// The order of the nodes in the treap is the order in the tour.
+type ettNode struct {
+	left, right, parent *ettNode
+	prio                uint64
+
+	vertex int    // the vertex, or -1 for an edge
+	ends   [2]int // the endpoints of an edge
+	// For a vertex: the vertex has non-tree edges at the level of the tour.
+	// For an edge: the edge has the level of the tour.
+	flag bool
+
+	// Aggregates of the subtree rooted at this node.
+	nodes     int  // number of nodes
+	vertices  int  // number of vertex nodes
+	anyEdge   bool // some flagged node in the subtree is an edge
+	anyVertex bool // some flagged node in the subtree is a vertex
+}
+
+// update recomputes the aggregates of x from its children.
+func (x *ettNode) update() {
+	x.nodes, x.vertices = 1, 0
+	x.anyEdge = x.flag && x.vertex == -1
+	x.anyVertex = x.flag && x.vertex != -1
+	if x.vertex != -1 {
+		x.vertices = 1
+	}
+	for _, c := range [2]*ettNode{x.left, x.right} {
+		if c != nil {
+			x.nodes += c.nodes
+			x.vertices += c.vertices
+			x.anyEdge = x.anyEdge || c.anyEdge
+			x.anyVertex = x.anyVertex || c.anyVertex
+		}
+	}
+}
+
+// setFlag sets the flag of x and updates the aggregates of its ancestors.
+func (x *ettNode) setFlag(flag bool) {
+	x.flag = flag
+	for ; x != nil; x = x.parent {
+		x.update()
+	}
+}
+
+// root returns the root of the treap containing x.
+func (x *ettNode) root() *ettNode {
+	for x.parent != nil {
+		x = x.parent
+	}
+	return x
+}
+
+// index returns the position of x in its tour.
+func (x *ettNode) index() int {
+	i := 0
+	if x.left != nil {
+		i = x.left.nodes
+	}
+	for ; x.parent != nil; x = x.parent {
+		if p := x.parent; p.right == x {
+			i++
+			if p.left != nil {
+				i += p.left.nodes
+			}
+		}
+	}
+	return i
+}
+
+// ettMerge concatenates the tours with roots a and b.
+func ettMerge(a, b *ettNode) *ettNode {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.prio > b.prio:
+		a.right = ettMerge(a.right, b)
+		a.right.parent = a
+		a.update()
+		return a
+	default:
+		b.left = ettMerge(a, b.left)
+		b.left.parent = b
+		b.update()
+		return b
+	}
+}
+
+// ettSplit splits the tour containing x just before x, or just after x
+// if after is true, and returns the roots of the two parts.
+func ettSplit(x *ettNode, after bool) (l, r *ettNode) {
+	if after {
+		r, x.right = x.right, nil
+		l = x
+	} else {
+		l, x.left = x.left, nil
+		r = x
+	}
+	if l != nil && l != x {
+		l.parent = nil
+	}
+	if r != nil && r != x {
+		r.parent = nil
+	}
+	child, p := x, x.parent
+	x.parent = nil
+	x.update()
+	for p != nil {
+		next := p.parent
+		p.parent = nil
+		if p.left == child {
+			p.left = r
+			if r != nil {
+				r.parent = p
+			}
+			p.update()
+			r = p
+		} else {
+			p.right = l
+			if l != nil {
+				l.parent = p
+			}
+			p.update()
+			l = p
+		}
+		child, p = p, next
+	}
+	return
+}
+
+// ettReroot rotates the tour containing x so that it starts at x,
+// and returns its root.
+func ettReroot(x *ettNode) *ettNode {
+	l, r := ettSplit(x, false)
+	return ettMerge(r, l)
+}
+
+// ettFind appends the flagged nodes of the given kind in the subtree of x.
+func ettFind(x *ettNode, edges bool, list []*ettNode) []*ettNode {
+	if x == nil || edges && !x.anyEdge || !edges && !x.anyVertex {
+		return list
+	}
+	list = ettFind(x.left, edges, list)
+	if x.flag && (x.vertex == -1) == edges {
+		list = append(list, x)
+	}
+	return ettFind(x.right, edges, list)
+}
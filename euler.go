@@ -139,3 +139,43 @@ func EulerUndirected(g Iterator) (walk []int, ok bool) {
 	}
 	return walk, true
 }
+
+// EulerCircuitDirected returns an Euler circuit in a directed graph:
+// a walk that uses each edge exactly once and ends where it starts.
+// If no such circuit exists, it returns an empty walk and sets ok to false.
+func EulerCircuitDirected(g Iterator) (walk []int, ok bool) {
+	degree := make([]int, g.Order()) // outdegree - indegree for each vertex
+	for v := range degree {
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			degree[v]++
+			degree[w]--
+			return
+		})
+	}
+	for _, d := range degree {
+		if d != 0 {
+			return []int{}, false
+		}
+	}
+	return EulerDirected(g)
+}
+
+// EulerCircuitUndirected returns an Euler circuit following undirected
+// edges in only one direction: a walk that uses each edge exactly once
+// and ends where it starts. If no such circuit exists, it returns
+// an empty walk and sets ok to false.
+func EulerCircuitUndirected(g Iterator) (walk []int, ok bool) {
+	for v := 0; v < g.Order(); v++ {
+		out := 0
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			if v != w {
+				out++
+			}
+			return
+		})
+		if out&1 == 1 {
+			return []int{}, false
+		}
+	}
+	return EulerUndirected(g)
+}
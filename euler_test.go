@@ -155,3 +155,43 @@ func TestEulerUndirected(t *testing.T) {
 		t.Errorf("EulerUndirected: %s", mess)
 	}
 }
+
+func TestEulerCircuit(t *testing.T) {
+	g := New(3)
+	g.Add(0, 1)
+	g.Add(1, 2)
+	walk, ok := EulerCircuitDirected(g)
+	if mess, diff := diff(walk, []int{}); diff {
+		t.Errorf("EulerCircuitDirected->walk %s", mess)
+	}
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("EulerCircuitDirected->ok %s", mess)
+	}
+	g.Add(2, 0)
+	walk, ok = EulerCircuitDirected(g)
+	if mess, diff := diff(walk, []int{0, 1, 2, 0}); diff {
+		t.Errorf("EulerCircuitDirected->walk %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("EulerCircuitDirected->ok %s", mess)
+	}
+
+	g = New(3)
+	g.AddBoth(0, 1)
+	g.AddBoth(1, 2)
+	walk, ok = EulerCircuitUndirected(g)
+	if mess, diff := diff(walk, []int{}); diff {
+		t.Errorf("EulerCircuitUndirected->walk %s", mess)
+	}
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("EulerCircuitUndirected->ok %s", mess)
+	}
+	g.AddBoth(2, 0)
+	walk, ok = EulerCircuitUndirected(g)
+	if len(walk) != 4 || walk[0] != walk[3] {
+		t.Errorf("EulerCircuitUndirected->walk %v", walk)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("EulerCircuitUndirected->ok %s", mess)
+	}
+}
@@ -0,0 +1,129 @@
+package graph
+
+// FeedbackArcSet returns a small feedback arc set of g: a set of edges
+// whose removal makes g acyclic. It also returns the vertex ordering
+// that defines the set; the arcs are the edges that point backwards
+// in this ordering, including all self-loops, and the ordering is a
+// topological ordering of the graph without these edges.
+// The arcs are listed in the order produced by Sort(g).Visit for
+// the vertices 0..n-1.
+//
+// Finding a minimum feedback arc set is NP-hard. This implementation
+// uses the greedy heuristic of Eades, Lin and Smyth: it repeatedly moves
+// sinks to the end of the ordering and sources to the front and,
+// when there are neither, moves a vertex with a maximum difference
+// between outdegree and indegree to the front.
+//
+// The time complexity is O(|E| + |V|), not counting the time to sort g.
+func FeedbackArcSet(g Iterator) (arcs []Edge, order []int) {
+	h, t := Sort(g), Transpose(g)
+	n := h.Order()
+	in, out := make([]int, n), make([]int, n)
+	for v := 0; v < n; v++ {
+		h.Visit(v, func(w int, _ int64) (skip bool) {
+			if w != v {
+				out[v]++
+				in[w]++
+			}
+			return
+		})
+	}
+
+	// The vertices are kept in stacks with lazy deletion: an entry is
+	// skipped if the vertex has been removed or has changed class.
+	removed := make([]bool, n)
+	var sinks, sources []int
+	bucket := make([][]int, 2*n+1) // bucket[out-in+n]
+	top := 0                       // no bucket above top is nonempty
+	classify := func(v int) {
+		switch {
+		case out[v] == 0:
+			sinks = append(sinks, v)
+		case in[v] == 0:
+			sources = append(sources, v)
+		default:
+			d := out[v] - in[v] + n
+			bucket[d] = append(bucket[d], v)
+			if d > top {
+				top = d
+			}
+		}
+	}
+	for v := 0; v < n; v++ {
+		classify(v)
+	}
+	remove := func(v int) {
+		removed[v] = true
+		h.Visit(v, func(w int, _ int64) (skip bool) {
+			if w != v && !removed[w] {
+				in[w]--
+				classify(w)
+			}
+			return
+		})
+		t.Visit(v, func(u int, _ int64) (skip bool) {
+			if u != v && !removed[u] {
+				out[u]--
+				classify(u)
+			}
+			return
+		})
+	}
+
+	var front, back []int
+	for left := n; left > 0; {
+		if k := len(sinks); k > 0 {
+			v := sinks[k-1]
+			sinks = sinks[:k-1]
+			if !removed[v] && out[v] == 0 {
+				back = append(back, v)
+				remove(v)
+				left--
+			}
+			continue
+		}
+		if k := len(sources); k > 0 {
+			v := sources[k-1]
+			sources = sources[:k-1]
+			if !removed[v] && in[v] == 0 && out[v] > 0 {
+				front = append(front, v)
+				remove(v)
+				left--
+			}
+			continue
+		}
+		for len(bucket[top]) == 0 {
+			top--
+		}
+		k := len(bucket[top])
+		v := bucket[top][k-1]
+		bucket[top] = bucket[top][:k-1]
+		if !removed[v] && in[v] > 0 && out[v] > 0 && out[v]-in[v]+n == top {
+			front = append(front, v)
+			remove(v)
+			left--
+		}
+	}
+
+	order = front
+	for i := len(back) - 1; i >= 0; i-- {
+		order = append(order, back[i])
+	}
+	if order == nil {
+		order = []int{}
+	}
+	pos := make([]int, n)
+	for i, v := range order {
+		pos[v] = i
+	}
+	arcs = []Edge{}
+	for v := 0; v < n; v++ {
+		h.Visit(v, func(w int, c int64) (skip bool) {
+			if pos[w] <= pos[v] {
+				arcs = append(arcs, Edge{v, w, c})
+			}
+			return
+		})
+	}
+	return
+}
@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkFeedback checks that arcs are the edges of g that point backwards
+// in order, and that removing them makes g acyclic.
+func checkFeedback(t *testing.T, g Iterator, arcs []Edge, order []int) {
+	t.Helper()
+	n := g.Order()
+	pos := make([]int, n)
+	for i := range pos {
+		pos[i] = -1
+	}
+	for i, v := range order {
+		if pos[v] != -1 {
+			t.Errorf("FeedbackArcSet(%v)->order %v: duplicate vertex %d", g, order, v)
+			return
+		}
+		pos[v] = i
+	}
+	if len(order) != n {
+		t.Errorf("FeedbackArcSet(%v)->order %v: wrong length", g, order)
+		return
+	}
+	exp := []Edge{}
+	h := New(n)
+	s := Sort(g)
+	for v := 0; v < n; v++ {
+		s.Visit(v, func(w int, c int64) (skip bool) {
+			if pos[w] <= pos[v] {
+				exp = append(exp, Edge{v, w, c})
+			} else {
+				h.Add(v, w)
+			}
+			return
+		})
+	}
+	if mess, diff := diff(arcs, exp); diff {
+		t.Errorf("FeedbackArcSet(%v)->arcs %s", g, mess)
+	}
+	if !Acyclic(h) {
+		t.Errorf("FeedbackArcSet(%v): remaining graph %v has a cycle", g, h)
+	}
+}
+
+func TestFeedbackArcSet(t *testing.T) {
+	arcs, order := FeedbackArcSet(New(0))
+	if mess, diff := diff(arcs, []Edge{}); diff {
+		t.Errorf("FeedbackArcSet->arcs %s", mess)
+	}
+	if mess, diff := diff(order, []int{}); diff {
+		t.Errorf("FeedbackArcSet->order %s", mess)
+	}
+
+	// A cycle needs one arc, and a self-loop is always an arc.
+	g := New(4)
+	g.Add(0, 1)
+	g.AddCost(1, 2, 5)
+	g.Add(2, 3)
+	g.Add(3, 0)
+	g.Add(2, 2)
+	arcs, order = FeedbackArcSet(g)
+	checkFeedback(t, g, arcs, order)
+	if mess, diff := diff(len(arcs), 2); diff {
+		t.Errorf("FeedbackArcSet->len(arcs) %s", mess)
+	}
+
+	// A DAG needs no arcs.
+	g = New(5)
+	g.Add(0, 1)
+	g.Add(0, 2)
+	g.Add(2, 1)
+	g.Add(3, 4)
+	g.Add(4, 1)
+	arcs, order = FeedbackArcSet(g)
+	checkFeedback(t, g, arcs, order)
+	if mess, diff := diff(arcs, []Edge{}); diff {
+		t.Errorf("FeedbackArcSet(DAG)->arcs %s", mess)
+	}
+
+	// Two disjoint 2-cycles need two arcs.
+	g = New(4)
+	g.AddBoth(0, 1)
+	g.AddBoth(2, 3)
+	arcs, order = FeedbackArcSet(g)
+	checkFeedback(t, g, arcs, order)
+	if mess, diff := diff(len(arcs), 2); diff {
+		t.Errorf("FeedbackArcSet->len(arcs) %s", mess)
+	}
+
+	for i := 0; i < 200; i++ {
+		n := 1 + rand.Intn(20)
+		g := New(n)
+		for m := rand.Intn(n*n/2 + 1); m > 0; m-- {
+			g.Add(rand.Intn(n), rand.Intn(n))
+		}
+		arcs, order := FeedbackArcSet(g)
+		checkFeedback(t, g, arcs, order)
+		if size := Check(g).Size; 2*len(arcs) > size+Check(g).Loops {
+			t.Errorf("FeedbackArcSet(%v): %d arcs for %d edges", g, len(arcs), size)
+		}
+	}
+
+	h := FromEdges(3, []Edge{{0, 1, 0}, {0, 1, 1}, {1, 2, 0}, {2, 0, 0}, {2, 0, 3}})
+	arcs, order = FeedbackArcSet(h)
+	checkFeedback(t, h, arcs, order)
+}
+
+func BenchmarkFeedbackArcSet(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 10*n; i++ {
+		g.Add(rand.Intn(n), rand.Intn(n))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = FeedbackArcSet(g)
+	}
+}
@@ -0,0 +1,249 @@
+package flow
+
+import (
+	"container/heap"
+	"github.com/yourbasic/graph"
+	"sort"
+)
+
+// StoerWagner computes a global minimum cut of an undirected graph with
+// non-negative edge costs: a partition of the vertices into two non-empty
+// sets such that the total cost of the edges between them is minimal.
+// It returns the vertices on one side of the cut, in increasing order,
+// and the total cost of the cut edges. If g has fewer than two vertices,
+// there is no cut; the function then returns an empty slice and graph.Max.
+//
+// The time complexity is O(|V|⋅|E|⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func StoerWagner(g graph.Iterator) (cut []int, weight int64) {
+	n := g.Order()
+	if n < 2 {
+		return []int{}, graph.Max
+	}
+	// adj[v] holds the total edge cost between v and each neighbor,
+	// where each vertex may stand for a set of merged vertices.
+	adj := make([]map[int]int64, n)
+	members := make([][]int, n)
+	for v := range adj {
+		adj[v] = make(map[int]int64)
+		members[v] = []int{v}
+	}
+	for v := range adj {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if v != w {
+				adj[v][w] += c
+			}
+			return
+		})
+	}
+
+	weight = graph.Max
+	active := make([]int, n)
+	for v := range active {
+		active[v] = v
+	}
+	added := make([]bool, n)
+	conn := make([]int64, n)
+	for len(active) > 1 {
+		// Add the vertices in maximum adjacency order.
+		for _, v := range active {
+			added[v], conn[v] = false, 0
+		}
+		q := &connQueue{{active[0], 0}}
+		s, t := -1, -1
+		var cutOfPhase int64
+		for q.Len() > 0 {
+			e := heap.Pop(q).(connEntry)
+			v := e.v
+			if added[v] || e.c < conn[v] {
+				continue // An outdated entry.
+			}
+			added[v] = true
+			s, t, cutOfPhase = t, v, e.c
+			for w, c := range adj[v] {
+				if !added[w] {
+					conn[w] += c
+					heap.Push(q, connEntry{w, conn[w]})
+				}
+			}
+		}
+		if s == -1 || !allAdded(active, added) {
+			// A disconnected graph: the vertices reached form a cut.
+			cut = []int{}
+			for _, v := range active {
+				if added[v] {
+					cut = append(cut, members[v]...)
+				}
+			}
+			sort.Ints(cut)
+			return cut, 0
+		}
+		if cutOfPhase < weight {
+			weight = cutOfPhase
+			cut = append([]int{}, members[t]...)
+		}
+
+		// Merge t into s.
+		for w, c := range adj[t] {
+			delete(adj[w], t)
+			if w != s {
+				adj[s][w] += c
+				adj[w][s] += c
+			}
+		}
+		adj[t] = nil
+		members[s] = append(members[s], members[t]...)
+		members[t] = nil
+		for i, v := range active {
+			if v == t {
+				active = append(active[:i], active[i+1:]...)
+				break
+			}
+		}
+	}
+	sort.Ints(cut)
+	return
+}
+
+func allAdded(vertices []int, added []bool) bool {
+	for _, v := range vertices {
+		if !added[v] {
+			return false
+		}
+	}
+	return true
+}
+
+type connEntry struct {
+	v int
+	c int64
+}
+
+// connQueue is a max-heap of vertices ordered by connectivity.
+type connQueue []connEntry
+
+func (q connQueue) Len() int            { return len(q) }
+func (q connQueue) Less(i, j int) bool  { return q[i].c > q[j].c }
+func (q connQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *connQueue) Push(x interface{}) { *q = append(*q, x.(connEntry)) }
+
+func (q *connQueue) Pop() interface{} {
+	n := len(*q) - 1
+	x := (*q)[n]
+	*q = (*q)[:n]
+	return x
+}
+
+// CutTree is a Gomory–Hu tree of an undirected graph. For each pair
+// of vertices v and w, the minimum cost of a cut separating v from w
+// equals the smallest edge cost on the path from v to w in the tree,
+// and removing that edge from the tree splits the vertices into
+// the two sides of such a cut.
+type CutTree struct {
+	parent []int
+	weight []int64
+	depth  []int
+}
+
+// GomoryHu computes a Gomory–Hu tree of an undirected graph with
+// non-negative edge costs, using Gusfield's algorithm with |V|-1
+// maximum flow computations. The tree is rooted at vertex 0.
+//
+// The time complexity is O(|V|) times that of MaxFlow,
+// where |V| is the number of vertices in the graph.
+func GomoryHu(g graph.Iterator) *CutTree {
+	n := g.Order()
+	t := &CutTree{
+		parent: make([]int, n),
+		weight: make([]int64, n),
+		depth:  make([]int, n),
+	}
+	if n == 0 {
+		return t
+	}
+	t.parent[0], t.weight[0] = -1, 0
+	inCut := make([]bool, n)
+	for s := 1; s < n; s++ {
+		u := t.parent[s]
+		f := MaxFlow(g, s, u)
+		for v := range inCut {
+			inCut[v] = false
+		}
+		for _, v := range f.Cut {
+			inCut[v] = true
+		}
+		t.weight[s] = f.Value
+		for v := 0; v < n; v++ {
+			if v != s && inCut[v] && t.parent[v] == u {
+				t.parent[v] = s
+			}
+		}
+		if p := t.parent[u]; p != -1 && inCut[p] {
+			t.parent[s], t.parent[u] = p, s
+			t.weight[s], t.weight[u] = t.weight[u], f.Value
+		}
+	}
+	// Find the depth of each vertex; the parent of a vertex may have
+	// a larger number.
+	for v := range t.depth {
+		t.depth[v] = -1
+	}
+	var path []int
+	for v := range t.depth {
+		for u := v; u != -1 && t.depth[u] == -1; u = t.parent[u] {
+			path = append(path, u)
+		}
+		for i := len(path) - 1; i >= 0; i-- {
+			u := path[i]
+			if p := t.parent[u]; p == -1 {
+				t.depth[u] = 0
+			} else {
+				t.depth[u] = t.depth[p] + 1
+			}
+		}
+		path = path[:0]
+	}
+	return t
+}
+
+// Order returns the number of vertices in the tree.
+func (t *CutTree) Order() int {
+	return len(t.parent)
+}
+
+// Parent returns the parent of v in the tree, or -1 if v is the root,
+// and the cost of the minimum cut separating v from its parent.
+func (t *CutTree) Parent(v int) (parent int, weight int64) {
+	return t.parent[v], t.weight[v]
+}
+
+// MinCut returns the minimum cost of a cut separating v from w,
+// or graph.Max if v equals w.
+//
+// The time complexity is O(|V|), where |V| is the number of vertices
+// in the graph.
+func (t *CutTree) MinCut(v, w int) int64 {
+	res := graph.Max
+	for v != w {
+		if t.depth[v] < t.depth[w] {
+			v, w = w, v
+		}
+		if t.weight[v] < res {
+			res = t.weight[v]
+		}
+		v = t.parent[v]
+	}
+	return res
+}
+
+// Graph returns the tree as an undirected graph, where the cost
+// of each edge is the cost of the corresponding minimum cut.
+func (t *CutTree) Graph() *graph.Mutable {
+	g := graph.New(len(t.parent))
+	for v, p := range t.parent {
+		if p != -1 {
+			g.AddBothCost(v, p, t.weight[v])
+		}
+	}
+	return g
+}
@@ -0,0 +1,150 @@
+package flow
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"testing"
+)
+
+func TestStoerWagner(t *testing.T) {
+	cut, weight := StoerWagner(graph.New(1))
+	if mess, diff := diff(cut, []int{}); diff {
+		t.Errorf("StoerWagner %s", mess)
+	}
+	if mess, diff := diff(weight, graph.Max); diff {
+		t.Errorf("StoerWagner->weight %s", mess)
+	}
+
+	// The example from Stoer and Wagner's paper, numbered from 0.
+	g := graph.New(8)
+	for _, e := range []struct {
+		v, w int
+		c    int64
+	}{
+		{0, 1, 2}, {0, 4, 3}, {1, 2, 3}, {1, 4, 2}, {1, 5, 2},
+		{2, 3, 4}, {2, 6, 2}, {3, 6, 2}, {3, 7, 2}, {4, 5, 3},
+		{5, 6, 1}, {6, 7, 3},
+	} {
+		g.AddBothCost(e.v, e.w, e.c)
+	}
+	cut, weight = StoerWagner(g)
+	if mess, diff := diff(weight, int64(4)); diff {
+		t.Errorf("StoerWagner->weight %s", mess)
+	}
+	if cut[0] == 0 {
+		if mess, diff := diff(cut, []int{0, 1, 4, 5}); diff {
+			t.Errorf("StoerWagner %s", mess)
+		}
+	} else if mess, diff := diff(cut, []int{2, 3, 6, 7}); diff {
+		t.Errorf("StoerWagner %s", mess)
+	}
+
+	g = graph.New(4)
+	g.AddBothCost(0, 1, 5)
+	g.AddBothCost(2, 3, 5)
+	cut, weight = StoerWagner(g)
+	if mess, diff := diff(weight, int64(0)); diff {
+		t.Errorf("StoerWagner->weight %s", mess)
+	}
+	if mess, diff := diff(cut, []int{0, 1}); diff {
+		t.Errorf("StoerWagner %s", mess)
+	}
+}
+
+func TestCutsRandom(t *testing.T) {
+	for i := 0; i < 30; i++ {
+		n := 2 + rand.Intn(15)
+		g := graph.New(n)
+		for j := 0; j < 2*n; j++ {
+			g.AddBothCost(rand.Intn(n), rand.Intn(n), rand.Int63n(10))
+		}
+		h := graph.Sort(g)
+		tree := GomoryHu(h)
+		best := graph.Max
+		for v := 0; v < n; v++ {
+			for w := 0; w < n; w++ {
+				if v == w {
+					continue
+				}
+				exp := MaxFlow(h, v, w).Value
+				if res := tree.MinCut(v, w); res != exp {
+					t.Errorf("MinCut(%d, %d) %d; want %d", v, w, res, exp)
+				}
+				if exp < best {
+					best = exp
+				}
+			}
+		}
+		cut, weight := StoerWagner(h)
+		if weight != best {
+			t.Errorf("StoerWagner->weight %d; want %d", weight, best)
+		}
+		in := make([]bool, n)
+		for _, v := range cut {
+			in[v] = true
+		}
+		if len(cut) == 0 || len(cut) == n {
+			t.Errorf("StoerWagner: cut %v", cut)
+		}
+		var sum int64
+		for v := 0; v < n; v++ {
+			h.Visit(v, func(w int, c int64) (skip bool) {
+				if in[v] && !in[w] {
+					sum += c
+				}
+				return
+			})
+		}
+		if sum != weight {
+			t.Errorf("StoerWagner: cut %v has cost %d; want %d", cut, sum, weight)
+		}
+
+		// Removing a tree edge splits the vertices into the sides of a cut.
+		for v := 1; v < n; v++ {
+			p, c := tree.Parent(v)
+			tg := tree.Graph()
+			tg.DeleteBoth(v, p)
+			side := make([]bool, n)
+			graph.BFS(tg, v, func(_, w int, _ int64) { side[w] = true })
+			side[v] = true
+			var sum int64
+			for u := 0; u < n; u++ {
+				h.Visit(u, func(w int, cost int64) (skip bool) {
+					if side[u] && !side[w] {
+						sum += cost
+					}
+					return
+				})
+			}
+			if sum != c {
+				t.Errorf("GomoryHu: cut at (%d, %d) has cost %d; want %d", v, p, sum, c)
+			}
+		}
+	}
+}
+
+func BenchmarkStoerWagner(b *testing.B) {
+	n := 200
+	b.StopTimer()
+	g := graph.New(n)
+	for i := 0; i < 5*n; i++ {
+		g.AddBothCost(rand.Intn(n), rand.Intn(n), rand.Int63n(100))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = StoerWagner(g)
+	}
+}
+
+func BenchmarkGomoryHu(b *testing.B) {
+	n := 200
+	b.StopTimer()
+	g := graph.New(n)
+	for i := 0; i < 5*n; i++ {
+		g.AddBothCost(rand.Intn(n), rand.Intn(n), rand.Int63n(100))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = GomoryHu(g)
+	}
+}
@@ -0,0 +1,129 @@
+package flow
+
+import (
+	"context"
+	"github.com/yourbasic/graph"
+)
+
+// MaxFlow computes a maximum flow from s to t in g using Dinic's
+// algorithm, with the cost of each edge as its capacity.
+//
+// The time complexity is O(|E|⋅|V|²), where |E| is the number of edges
+// and |V| the number of vertices in the graph. For unit capacities
+// it's O(|E|⋅min(√|E|, |V|^⅔)).
+func MaxFlow(g graph.Iterator, s, t int) *Flow {
+	return MaxFlowFunc(g, s, t, Cost)
+}
+
+// MaxFlowFunc is like MaxFlow, but the capacity of the edge (v, w)
+// of cost c is given by capacity(v, w, c).
+func MaxFlowFunc(g graph.Iterator, s, t int, capacity func(v, w int, c int64) int64) *Flow {
+	f, _ := MaxFlowFuncCtx(context.Background(), g, s, t, capacity)
+	return f
+}
+
+// MaxFlowCtx is like MaxFlow, but stops early and returns ctx.Err()
+// if the context is cancelled or its deadline expires during
+// the computation. In that case, the flow is nil.
+func MaxFlowCtx(ctx context.Context, g graph.Iterator, s, t int) (*Flow, error) {
+	return MaxFlowFuncCtx(ctx, g, s, t, Cost)
+}
+
+// MaxFlowFuncCtx is like MaxFlowFunc, but stops early and returns ctx.Err()
+// if the context is cancelled or its deadline expires during
+// the computation. In that case, the flow is nil.
+func MaxFlowFuncCtx(ctx context.Context, g graph.Iterator, s, t int, capacity func(v, w int, c int64) int64) (*Flow, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	nw := newNetwork(g, s, t, capacity)
+	if s == t {
+		return nw.result(s, t, graph.Max), nil
+	}
+	n := len(nw.adj)
+	level := make([]int, n)
+	next := make([]int, n) // The next arc to try at each vertex.
+	var value int64
+	for nw.levels(s, t, level) {
+		for v := range next {
+			next[v] = 0
+		}
+		for i := 1; ; i++ {
+			// Check the context before each phase, and now and then within.
+			if i%ctxCheckInterval == 1 && ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			x := nw.augment(s, t, level, next)
+			if x == 0 {
+				break
+			}
+			value += x
+		}
+	}
+	return nw.result(s, t, value), nil
+}
+
+// ctxCheckInterval is the number of augmenting paths between two checks
+// of the context during a phase of MaxFlowFuncCtx.
+const ctxCheckInterval = 1 << 8
+
+// levels computes the BFS distance from s to each vertex in the
+// residual network, or -1 if the vertex can't be reached.
+// It tells if t can be reached.
+func (nw *network) levels(s, t int, level []int) bool {
+	for v := range level {
+		level[v] = -1
+	}
+	level[s] = 0
+	for queue := []int{s}; len(queue) > 0; queue = queue[1:] {
+		v := queue[0]
+		for _, a := range nw.adj[v] {
+			if w := nw.to[a]; level[w] == -1 && nw.cap[a] > 0 {
+				level[w] = level[v] + 1
+				queue = append(queue, w)
+			}
+		}
+	}
+	return level[t] != -1
+}
+
+// augment finds a path from s to t in the level graph, pushes as much
+// flow as possible along it, and returns the amount of flow pushed,
+// or 0 if no such path exists. Arcs that can't be part of a path are
+// skipped by advancing next.
+func (nw *network) augment(s, t int, level, next []int) int64 {
+	var path []int
+	for v := s; v != t; {
+		found := false
+		for ; next[v] < len(nw.adj[v]); next[v]++ {
+			a := nw.adj[v][next[v]]
+			if w := nw.to[a]; nw.cap[a] > 0 && level[w] == level[v]+1 {
+				path = append(path, a)
+				v, found = w, true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		// A dead end: retreat along the last arc.
+		if v == s {
+			return 0
+		}
+		level[v] = -1
+		a := path[len(path)-1]
+		path = path[:len(path)-1]
+		v = nw.to[a^1]
+		next[v]++
+	}
+	x := graph.Max
+	for _, a := range path {
+		if nw.cap[a] < x {
+			x = nw.cap[a]
+		}
+	}
+	for _, a := range path {
+		nw.push(a, x)
+	}
+	return x
+}
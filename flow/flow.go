@@ -0,0 +1,157 @@
+// Package flow implements maximum flow algorithms.
+//
+// The capacity of an edge is given by its cost, or by a capacity function.
+// Capacities must be non-negative.
+// All algorithms return a Flow, which holds the value of a maximum flow,
+// the flow along each edge, and a minimum cut separating the source
+// from the sink.
+//
+// Dinic's algorithm, used by MaxFlow, is the best choice for most graphs.
+// The push-relabel algorithm, used by PushRelabel, is often faster
+// for dense graphs. The algorithm of Boykov and Kolmogorov, used by
+// BoykovKolmogorov, is designed for the grid-like graphs of computer
+// vision. MinCostFlow finds a flow of minimum total cost,
+// which solves assignment and transportation problems.
+//
+// For undirected graphs, StoerWagner finds a global minimum cut,
+// and GomoryHu builds a tree that answers minimum cut queries
+// for all pairs of vertices.
+//
+// Segment solves the binary labeling problems of image segmentation
+// by graph cuts: it computes the labeling of minimum energy, given
+// the costs of each label at each vertex and of the boundary edges,
+// with BoykovKolmogorov.
+package flow
+
+import (
+	"github.com/yourbasic/graph"
+	"strconv"
+)
+
+// Flow is a maximum flow from a source s to a sink t.
+type Flow struct {
+	// Value is the total amount of flow from s to t,
+	// or graph.Max if s equals t.
+	Value int64
+
+	// Edges lists the edges of the graph, in the order produced by
+	// calling Visit for the vertices 0..n-1, with Cost equal to
+	// the flow along the edge. Another call to Visit gives the same
+	// order only for deterministic iterators, such as graph.Immutable
+	// or graph.Sort(g); a graph.Mutable may visit the neighbors of
+	// a vertex in a different order each time.
+	Edges []graph.Edge
+
+	// Cut lists the vertices on the source side of a minimum s-t cut,
+	// the vertices that can be reached from s in the residual graph,
+	// in increasing order. It's empty if s equals t.
+	Cut []int
+
+	// CutEdges lists the edges leading from a vertex in Cut to a vertex
+	// outside of Cut, with Cost equal to the capacity of the edge.
+	// The capacities of these edges add up to Value.
+	CutEdges []graph.Edge
+
+	n int
+}
+
+// Graph returns a graph with an edge (v, w) of cost c for each pair
+// of vertices with a positive flow c from v to w.
+func (f *Flow) Graph() *graph.Immutable {
+	res := graph.New(f.n)
+	for _, e := range f.Edges {
+		if e.Cost > 0 && e.V != e.W {
+			res.AddCost(e.V, e.W, res.Cost(e.V, e.W)+e.Cost)
+		}
+	}
+	return graph.Sort(res)
+}
+
+// Cost returns the cost c of an edge (v, w) as its capacity.
+func Cost(v, w int, c int64) int64 {
+	return c
+}
+
+// network is a residual network. The arcs 2i and 2i+1 are the forward
+// and backward arcs of the i:th edge; their capacities are stored in cap,
+// and their costs, c and -c, in cost.
+type network struct {
+	adj   [][]int // adj[v] lists the arcs leaving v.
+	to    []int
+	cap   []int64
+	cost  []int64
+	edges []graph.Edge
+}
+
+func newNetwork(g graph.Iterator, s, t int, capacity func(v, w int, c int64) int64) *network {
+	n := g.Order()
+	if s < 0 || s >= n {
+		panic("vertex out of range: " + strconv.Itoa(s))
+	}
+	if t < 0 || t >= n {
+		panic("vertex out of range: " + strconv.Itoa(t))
+	}
+	nw := &network{adj: make([][]int, n)}
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			x := capacity(v, w, c)
+			if x < 0 {
+				panic("negative capacity: " + strconv.FormatInt(x, 10))
+			}
+			a := len(nw.to)
+			nw.adj[v] = append(nw.adj[v], a)
+			nw.adj[w] = append(nw.adj[w], a+1)
+			nw.to = append(nw.to, w, v)
+			nw.cap = append(nw.cap, x, 0)
+			nw.cost = append(nw.cost, c, -c)
+			nw.edges = append(nw.edges, graph.Edge{V: v, W: w, Cost: x})
+			return
+		})
+	}
+	return nw
+}
+
+// result computes the flow along each edge and a minimum cut.
+func (nw *network) result(s, t int, value int64) *Flow {
+	n := len(nw.adj)
+	f := &Flow{
+		Value:    value,
+		Edges:    make([]graph.Edge, len(nw.edges)),
+		Cut:      []int{},
+		CutEdges: []graph.Edge{},
+		n:        n,
+	}
+	for i, e := range nw.edges {
+		f.Edges[i] = graph.Edge{V: e.V, W: e.W, Cost: nw.cap[2*i+1]}
+	}
+	if s == t {
+		return f
+	}
+	seen := make([]bool, n)
+	seen[s] = true
+	for queue := []int{s}; len(queue) > 0; queue = queue[1:] {
+		for _, a := range nw.adj[queue[0]] {
+			if w := nw.to[a]; !seen[w] && nw.cap[a] > 0 {
+				seen[w] = true
+				queue = append(queue, w)
+			}
+		}
+	}
+	for v := range seen {
+		if seen[v] {
+			f.Cut = append(f.Cut, v)
+		}
+	}
+	for _, e := range nw.edges {
+		if seen[e.V] && !seen[e.W] {
+			f.CutEdges = append(f.CutEdges, e)
+		}
+	}
+	return f
+}
+
+// push sends x units of flow along arc a.
+func (nw *network) push(a int, x int64) {
+	nw.cap[a] -= x
+	nw.cap[a^1] += x
+}
@@ -0,0 +1,224 @@
+package flow
+
+import (
+	"context"
+	"fmt"
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func diff(res, exp interface{}) (message string, diff bool) {
+	if !reflect.DeepEqual(res, exp) {
+		message = fmt.Sprintf("%v; want %v", res, exp)
+		diff = true
+	}
+	return
+}
+
+var algorithms = []struct {
+	name string
+	f    func(g graph.Iterator, s, t int, capacity func(v, w int, c int64) int64) *Flow
+}{
+	{"MaxFlowFunc", MaxFlowFunc},
+	{"PushRelabelFunc", PushRelabelFunc},
+	{"BoykovKolmogorovFunc", BoykovKolmogorovFunc},
+}
+
+func TestFlow(t *testing.T) {
+	g := graph.New(6)
+	for _, e := range []struct {
+		v, w int
+		c    int64
+	}{
+		{0, 1, 16}, {0, 2, 13}, {1, 2, 10}, {2, 1, 4},
+		{1, 3, 12}, {2, 4, 14}, {3, 2, 9}, {4, 3, 7},
+		{3, 5, 20}, {4, 5, 4},
+	} {
+		g.AddCost(e.v, e.w, e.c)
+	}
+	h := graph.Sort(g)
+	for _, alg := range algorithms {
+		f := alg.f(h, 0, 5, Cost)
+		if mess, diff := diff(f.Value, int64(23)); diff {
+			t.Errorf("%s->Value %s", alg.name, mess)
+		}
+		if mess, diff := diff(f.Cut, []int{0, 1, 2, 4}); diff {
+			t.Errorf("%s->Cut %s", alg.name, mess)
+		}
+		exp := []graph.Edge{
+			{V: 1, W: 3, Cost: 12},
+			{V: 4, W: 3, Cost: 7},
+			{V: 4, W: 5, Cost: 4},
+		}
+		if mess, diff := diff(f.CutEdges, exp); diff {
+			t.Errorf("%s->CutEdges %s", alg.name, mess)
+		}
+		checkFlow(t, alg.name, h, 0, 5, Cost, f)
+
+		f = alg.f(h, 0, 5, func(v, w int, c int64) int64 { return 1 })
+		if mess, diff := diff(f.Value, int64(2)); diff {
+			t.Errorf("%s->Value %s", alg.name, mess)
+		}
+		if mess, diff := diff(f.Cut, []int{0}); diff {
+			t.Errorf("%s->Cut %s", alg.name, mess)
+		}
+
+		f = alg.f(h, 5, 0, Cost)
+		if mess, diff := diff(f.Value, int64(0)); diff {
+			t.Errorf("%s->Value %s", alg.name, mess)
+		}
+		if mess, diff := diff(f.Cut, []int{5}); diff {
+			t.Errorf("%s->Cut %s", alg.name, mess)
+		}
+		if mess, diff := diff(f.Graph().String(), "6 []"); diff {
+			t.Errorf("%s->Graph %s", alg.name, mess)
+		}
+
+		f = alg.f(h, 2, 2, Cost)
+		if mess, diff := diff(f.Value, graph.Max); diff {
+			t.Errorf("%s->Value %s", alg.name, mess)
+		}
+		if mess, diff := diff(f.Cut, []int{}); diff {
+			t.Errorf("%s->Cut %s", alg.name, mess)
+		}
+	}
+
+	f := MaxFlow(h, 0, 1)
+	if mess, diff := diff(f.Graph().String(), "6 [(0 1):16 (0 2):4 (2 1):4]"); diff {
+		t.Errorf("Graph %s", mess)
+	}
+	if mess, diff := diff(PushRelabel(h, 0, 1).Value, int64(20)); diff {
+		t.Errorf("PushRelabel %s", mess)
+	}
+}
+
+func TestMultigraph(t *testing.T) {
+	g := graph.FromEdges(3, []graph.Edge{
+		{V: 0, W: 1, Cost: 3},
+		{V: 0, W: 1, Cost: 4},
+		{V: 1, W: 2, Cost: 5},
+		{V: 1, W: 2, Cost: 1},
+		{V: 1, W: 1, Cost: 9},
+	})
+	for _, alg := range algorithms {
+		f := alg.f(g, 0, 2, Cost)
+		if mess, diff := diff(f.Value, int64(6)); diff {
+			t.Errorf("%s->Value %s", alg.name, mess)
+		}
+		checkFlow(t, alg.name, g, 0, 2, Cost, f)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		n := 2 + rand.Intn(30)
+		m := graph.New(n)
+		for j := 0; j < 4*n; j++ {
+			m.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(20))
+		}
+		g := graph.Sort(m)
+		s, tt := rand.Intn(n), rand.Intn(n)
+		if s == tt {
+			continue
+		}
+		exp, _ := graph.MaxFlow(g, s, tt)
+		for _, alg := range algorithms {
+			f := alg.f(g, s, tt, Cost)
+			if f.Value != exp {
+				t.Errorf("%s(%d, %d)->Value %d; want %d", alg.name, s, tt, f.Value, exp)
+			}
+			checkFlow(t, alg.name, g, s, tt, Cost, f)
+		}
+	}
+}
+
+func TestMaxFlowCtx(t *testing.T) {
+	g := graph.New(4)
+	g.AddCost(0, 1, 3)
+	g.AddCost(0, 2, 2)
+	g.AddCost(1, 3, 2)
+	g.AddCost(2, 3, 3)
+	f, err := MaxFlowCtx(context.Background(), g, 0, 3)
+	if mess, diff := diff(err, nil); diff {
+		t.Errorf("MaxFlowCtx->err %s", mess)
+	}
+	if mess, diff := diff(f.Value, int64(4)); diff {
+		t.Errorf("MaxFlowCtx->Value %s", mess)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	f, err = MaxFlowCtx(ctx, g, 0, 3)
+	if mess, diff := diff(err, context.Canceled); diff {
+		t.Errorf("MaxFlowCtx cancelled->err %s", mess)
+	}
+	if f != nil {
+		t.Errorf("MaxFlowCtx cancelled: non-nil flow")
+	}
+}
+
+// checkFlow checks that f is a valid flow and that the cut matches its value.
+func checkFlow(t *testing.T, name string, g graph.Iterator, s, tt int, capacity func(v, w int, c int64) int64, f *Flow) {
+	n := g.Order()
+	balance := make([]int64, n)
+	i := 0
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			e := f.Edges[i]
+			i++
+			if x := capacity(v, w, c); e.V != v || e.W != w || e.Cost < 0 || e.Cost > x {
+				t.Errorf("%s: edge %v with capacity %d", name, e, x)
+			}
+			balance[v] -= e.Cost
+			balance[w] += e.Cost
+			return
+		})
+	}
+	for v, b := range balance {
+		switch {
+		case v == s && b != -f.Value, v == tt && b != f.Value, v != s && v != tt && b != 0:
+			t.Errorf("%s: vertex %d has balance %d", name, v, b)
+		}
+	}
+	in := make([]bool, n)
+	for _, v := range f.Cut {
+		in[v] = true
+	}
+	if !in[s] || in[tt] {
+		t.Errorf("%s: cut %v doesn't separate %d from %d", name, f.Cut, s, tt)
+	}
+	var cut int64
+	for _, e := range f.CutEdges {
+		cut += e.Cost
+	}
+	if cut != f.Value {
+		t.Errorf("%s: cut capacity %d; want %d", name, cut, f.Value)
+	}
+}
+
+func BenchmarkMaxFlow(b *testing.B) {
+	benchmark(b, MaxFlow)
+}
+
+func BenchmarkPushRelabel(b *testing.B) {
+	benchmark(b, PushRelabel)
+}
+
+func BenchmarkBoykovKolmogorov(b *testing.B) {
+	benchmark(b, BoykovKolmogorov)
+}
+
+func benchmark(b *testing.B, f func(g graph.Iterator, s, t int) *Flow) {
+	n := 1000
+	b.StopTimer()
+	g := graph.New(n)
+	for i := 0; i < 10*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(100))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = f(g, 0, 1)
+	}
+}
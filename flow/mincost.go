@@ -0,0 +1,119 @@
+package flow
+
+import "github.com/yourbasic/graph"
+
+// MinCostFlow computes a flow of value at most limit from s to t in g with
+// minimum total cost, where the capacity of the edge (v, w) of cost c
+// is given by capacity(v, w, c), and sending x units of flow along the edge
+// costs x⋅c. Use limit graph.Max to find a minimum cost maximum flow.
+// Edge costs may be negative.
+//
+// If there is a cycle of negative cost reachable from s, with positive
+// capacity along all of its edges, the flow isn't well defined and ok
+// is set to false.
+//
+// The algorithm, successive shortest paths with Johnson potentials,
+// repeatedly sends flow along a cheapest path from s to t.
+// The time complexity is O(|E|⋅|V| + F⋅|E|⋅log|V|), where F is the value
+// of the flow, |E| is the number of edges and |V| the number of vertices
+// in the graph.
+func MinCostFlow(g graph.Iterator, s, t int, capacity func(v, w int, c int64) int64, limit int64) (f *Flow, cost int64, ok bool) {
+	nw := newNetwork(g, s, t, capacity)
+	if s == t {
+		return nw.result(s, t, 0), 0, true
+	}
+	n := len(nw.adj)
+	potential, ok := nw.potentials(s)
+	if !ok {
+		return nil, 0, false
+	}
+	dist := make([]int64, n)
+	parent := make([]int, n) // The arc leading to each vertex.
+	var value int64
+	for value < limit && nw.cheapestPath(s, t, potential, dist, parent) {
+		x := limit - value
+		for v := t; v != s; v = nw.to[parent[v]^1] {
+			x = min(x, nw.cap[parent[v]])
+		}
+		for v := t; v != s; v = nw.to[parent[v]^1] {
+			a := parent[v]
+			nw.push(a, x)
+			cost += x * nw.cost[a]
+		}
+		value += x
+		for v, d := range dist {
+			if d != -1 {
+				potential[v] += d
+			}
+		}
+	}
+	return nw.result(s, t, value), cost, true
+}
+
+// potentials computes the distances from s to all vertices along arcs
+// with positive capacity using the Bellman-Ford algorithm.
+// It returns false if there is a reachable cycle of negative cost.
+func (nw *network) potentials(s int) (dist []int64, ok bool) {
+	n := len(nw.adj)
+	dist = make([]int64, n)
+	for v := range dist {
+		dist[v] = graph.Max
+	}
+	dist[s] = 0
+	for round := 0; round < n; round++ {
+		changed := false
+		for v, arcs := range nw.adj {
+			if dist[v] == graph.Max {
+				continue
+			}
+			for _, a := range arcs {
+				w := nw.to[a]
+				if alt := dist[v] + nw.cost[a]; nw.cap[a] > 0 && alt < dist[w] {
+					dist[w] = alt
+					changed = true
+				}
+			}
+		}
+		if !changed {
+			for v := range dist {
+				if dist[v] == graph.Max {
+					dist[v] = 0
+				}
+			}
+			return dist, true
+		}
+	}
+	return nil, false
+}
+
+// cheapestPath runs Dijkstra's algorithm from s in the residual network,
+// using the reduced costs cost(v, w) + potential[v] - potential[w],
+// which are non-negative. It sets dist[v] to the reduced distance
+// from s to v, or -1 if v can't be reached, and parent[v] to the arc
+// leading to v on a cheapest path. It tells if t can be reached.
+func (nw *network) cheapestPath(s, t int, potential, dist []int64, parent []int) bool {
+	for v := range dist {
+		dist[v], parent[v] = -1, -1
+	}
+	dist[s] = 0
+	q := graph.NewHeapQueue(len(dist))
+	q.Push(s, 0)
+	for q.Len() > 0 {
+		v, d := q.Pop()
+		if d > dist[v] {
+			continue // An outdated entry.
+		}
+		for _, a := range nw.adj[v] {
+			if nw.cap[a] == 0 {
+				continue
+			}
+			w := nw.to[a]
+			alt := d + nw.cost[a] + potential[v] - potential[w]
+			if dist[w] == -1 || alt < dist[w] {
+				dist[w], parent[w] = alt, a
+				q.Push(w, alt)
+			}
+		}
+	}
+	return dist[t] != -1
+}
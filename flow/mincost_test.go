@@ -0,0 +1,125 @@
+package flow
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"testing"
+)
+
+func TestMinCostFlow(t *testing.T) {
+	// An assignment problem: workers 1..3 and jobs 4..6,
+	// with source 0 and sink 7.
+	cost := [][]int64{
+		{9, 2, 7},
+		{6, 4, 3},
+		{5, 8, 1},
+	}
+	g := graph.New(8)
+	for i := 0; i < 3; i++ {
+		g.AddCost(0, 1+i, 0)
+		g.AddCost(4+i, 7, 0)
+		for j := 0; j < 3; j++ {
+			g.AddCost(1+i, 4+j, cost[i][j])
+		}
+	}
+	unit := func(v, w int, c int64) int64 { return 1 }
+	f, c, ok := MinCostFlow(graph.Sort(g), 0, 7, unit, graph.Max)
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("MinCostFlow->ok %s", mess)
+	}
+	if mess, diff := diff(f.Value, int64(3)); diff {
+		t.Errorf("MinCostFlow->Value %s", mess)
+	}
+	if mess, diff := diff(c, int64(9)); diff {
+		t.Errorf("MinCostFlow->cost %s", mess)
+	}
+	exp := "8 [(0 1):1 (0 2):1 (0 3):1 (1 5):1 (2 4):1 (3 6):1 (4 7):1 (5 7):1 (6 7):1]"
+	if mess, diff := diff(f.Graph().String(), exp); diff {
+		t.Errorf("MinCostFlow->Graph %s", mess)
+	}
+
+	f, c, _ = MinCostFlow(g, 0, 7, unit, 1)
+	if mess, diff := diff(f.Value, int64(1)); diff {
+		t.Errorf("MinCostFlow->Value %s", mess)
+	}
+	if mess, diff := diff(c, int64(1)); diff {
+		t.Errorf("MinCostFlow->cost %s", mess)
+	}
+
+	// Negative costs.
+	g = graph.New(3)
+	g.AddCost(0, 1, -2)
+	g.AddCost(1, 2, 3)
+	g.AddCost(0, 2, 2)
+	_, c, ok = MinCostFlow(g, 0, 2, unit, graph.Max)
+	if mess, diff := diff(c, int64(3)); diff {
+		t.Errorf("MinCostFlow->cost %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("MinCostFlow->ok %s", mess)
+	}
+
+	g.AddCost(1, 0, -1)
+	_, _, ok = MinCostFlow(g, 0, 2, unit, graph.Max)
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("MinCostFlow->ok %s", mess)
+	}
+}
+
+func TestMinCostFlowRandom(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		n := 2 + rand.Intn(20)
+		m := graph.New(n)
+		capacity := make(map[[2]int]int64)
+		for j := 0; j < 3*n; j++ {
+			v, w := rand.Intn(n), rand.Intn(n)
+			m.AddCost(v, w, rand.Int63n(10))
+			capacity[[2]int{v, w}] = 1 + rand.Int63n(5)
+		}
+		g := graph.Sort(m)
+		capFunc := func(v, w int, _ int64) int64 { return capacity[[2]int{v, w}] }
+		s, tt := rand.Intn(n), rand.Intn(n)
+		if s == tt {
+			continue
+		}
+		f, cost, ok := MinCostFlow(g, s, tt, capFunc, graph.Max)
+		if !ok {
+			t.Fatalf("MinCostFlow->ok false; want true")
+		}
+		if exp := MaxFlowFunc(g, s, tt, capFunc).Value; f.Value != exp {
+			t.Errorf("MinCostFlow->Value %d; want %d", f.Value, exp)
+		}
+		checkFlow(t, "MinCostFlow", g, s, tt, capFunc, f)
+
+		// A minimum cost flow has no negative cycle in its residual graph.
+		var total int64
+		res := graph.New(n)
+		for _, e := range f.Edges {
+			c := m.Cost(e.V, e.W)
+			total += c * e.Cost
+			if e.Cost < capFunc(e.V, e.W, c) {
+				addMin(res, e.V, e.W, c)
+			}
+			if e.Cost > 0 {
+				addMin(res, e.W, e.V, -c)
+			}
+		}
+		if total != cost {
+			t.Errorf("MinCostFlow->cost %d; want %d", cost, total)
+		}
+		if cycle, found := graph.FindNegativeCycle(res); found {
+			t.Errorf("MinCostFlow: negative cycle %v in residual graph", cycle)
+		}
+	}
+}
+
+// addMin adds the edge (v, w) of cost c to g, unless g already has
+// a cheaper edge from v to w.
+func addMin(g *graph.Mutable, v, w int, c int64) {
+	if v == w {
+		return
+	}
+	if !g.Edge(v, w) || c < g.Cost(v, w) {
+		g.AddCost(v, w, c)
+	}
+}
@@ -0,0 +1,94 @@
+package flow
+
+import "github.com/yourbasic/graph"
+
+// PushRelabel computes a maximum flow from s to t in g using the
+// push-relabel algorithm with FIFO vertex selection and the gap
+// heuristic, with the cost of each edge as its capacity.
+//
+// The time complexity is O(|V|³), where |V| is the number of vertices
+// in the graph.
+func PushRelabel(g graph.Iterator, s, t int) *Flow {
+	return PushRelabelFunc(g, s, t, Cost)
+}
+
+// PushRelabelFunc is like PushRelabel, but the capacity of the edge (v, w)
+// of cost c is given by capacity(v, w, c).
+func PushRelabelFunc(g graph.Iterator, s, t int, capacity func(v, w int, c int64) int64) *Flow {
+	nw := newNetwork(g, s, t, capacity)
+	if s == t {
+		return nw.result(s, t, graph.Max)
+	}
+	n := len(nw.adj)
+	height := make([]int, n)
+	count := make([]int, 2*n+1) // count[h] is the number of vertices of height h.
+	excess := make([]int64, n)
+	next := make([]int, n)
+	active := make([]bool, n)
+	var queue []int
+	enqueue := func(v int) {
+		if !active[v] && v != s && v != t && excess[v] > 0 {
+			active[v] = true
+			queue = append(queue, v)
+		}
+	}
+
+	height[s] = n
+	count[0], count[n] = n-1, 1
+	for _, a := range nw.adj[s] {
+		if x := nw.cap[a]; x > 0 {
+			w := nw.to[a]
+			nw.push(a, x)
+			excess[w] += x
+			excess[s] -= x
+			enqueue(w)
+		}
+	}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		active[v] = false
+		// Discharge v.
+		for excess[v] > 0 {
+			if next[v] == len(nw.adj[v]) {
+				// Relabel v.
+				old := height[v]
+				h := 2 * n
+				for _, a := range nw.adj[v] {
+					if w := nw.to[a]; nw.cap[a] > 0 && height[w]+1 < h {
+						h = height[w] + 1
+					}
+				}
+				count[old]--
+				height[v] = h
+				count[h]++
+				next[v] = 0
+				if count[old] == 0 && old < n {
+					// No vertex has height old: the vertices above it,
+					// but below n, can no longer reach t.
+					for u := range height {
+						if old < height[u] && height[u] < n {
+							count[height[u]]--
+							height[u] = n + 1
+							count[n+1]++
+							next[u] = 0
+						}
+					}
+				}
+				continue
+			}
+			a := nw.adj[v][next[v]]
+			w := nw.to[a]
+			if nw.cap[a] > 0 && height[v] == height[w]+1 {
+				x := min(excess[v], nw.cap[a])
+				nw.push(a, x)
+				excess[v] -= x
+				excess[w] += x
+				enqueue(w)
+			} else {
+				next[v]++
+			}
+		}
+	}
+	return nw.result(s, t, excess[t])
+}
@@ -0,0 +1,111 @@
+package flow
+
+import (
+	"github.com/yourbasic/graph"
+	"strconv"
+)
+
+// Segment labels the vertices of g with 0 or 1 so as to minimize
+// the energy
+//
+//	Σ source[v] for v labeled 1 + Σ sink[v] for v labeled 0
+//	    + Σ c for edges (v, w) of cost c with v labeled 0 and w labeled 1,
+//
+// which is the energy minimization problem of binary image segmentation
+// by graph cuts, as formulated by Boykov and Kolmogorov. Typically,
+// source[v] is the penalty for putting v in the background, sink[v]
+// the penalty for putting it in the foreground, and the edges join
+// neighboring pixels, for example in a graph.Grid, with costs that
+// penalize different labels. For undirected graphs, both directions
+// of an edge must be given, and the cost of a boundary between v and w
+// is then counted once.
+//
+// The weights source[v] and sink[v] may be negative, but the edge costs
+// must be non-negative. It returns the labels and the minimum energy;
+// a vertex that can be labeled either way gets the label 1.
+//
+// The problem is solved by computing a minimum cut with BoykovKolmogorov
+// in a graph with a source linked to each vertex v by an edge of capacity
+// source[v], and each v linked to a sink by an edge of capacity sink[v];
+// the vertices labeled 0 are on the source side of the cut.
+// The time complexity is the same as for BoykovKolmogorov on this graph.
+func Segment(g graph.Iterator, source, sink []int64) (label []int, energy int64) {
+	return SegmentFunc(g, source, sink, Cost)
+}
+
+// SegmentFunc is like Segment, but the cost of the edge (v, w)
+// of cost c is given by weight(v, w, c).
+func SegmentFunc(g graph.Iterator, source, sink []int64, weight func(v, w int, c int64) int64) (label []int, energy int64) {
+	n := g.Order()
+	for _, x := range [][]int64{source, sink} {
+		if len(x) != n {
+			panic("slice length " + strconv.Itoa(len(x)) + " doesn't match graph order " + strconv.Itoa(n))
+		}
+	}
+	// Subtracting the same amount from source[v] and sink[v] changes
+	// the energy of all labelings by the same constant, and makes
+	// both capacities non-negative.
+	h := &terminals{g: g, source: make([]int64, n), sink: make([]int64, n), weight: weight}
+	for v := 0; v < n; v++ {
+		m := source[v]
+		if sink[v] < m {
+			m = sink[v]
+		}
+		energy += m
+		h.source[v], h.sink[v] = source[v]-m, sink[v]-m
+	}
+	f := BoykovKolmogorovFunc(h, n, n+1, h.capacity)
+	label = make([]int, n)
+	for v := range label {
+		label[v] = 1
+	}
+	for _, v := range f.Cut {
+		if v < n {
+			label[v] = 0
+		}
+	}
+	return label, energy + f.Value
+}
+
+// terminals is the graph g with an added source, vertex n, and sink,
+// vertex n+1, where n is the order of g.
+type terminals struct {
+	g            graph.Iterator
+	source, sink []int64
+	weight       func(v, w int, c int64) int64
+}
+
+func (h *terminals) Order() int {
+	return h.g.Order() + 2
+}
+
+func (h *terminals) Visit(v int, do func(w int, c int64) (skip bool)) (aborted bool) {
+	n := h.g.Order()
+	switch {
+	case v < n:
+		if h.g.Visit(v, do) {
+			return true
+		}
+		if h.sink[v] > 0 {
+			return do(n+1, 0)
+		}
+	case v == n:
+		for w, x := range h.source {
+			if x > 0 && do(w, 0) {
+				return true
+			}
+		}
+	}
+	return
+}
+
+func (h *terminals) capacity(v, w int, c int64) int64 {
+	n := h.g.Order()
+	switch {
+	case v == n:
+		return h.source[w]
+	case w == n+1:
+		return h.sink[v]
+	}
+	return h.weight(v, w, c)
+}
@@ -0,0 +1,98 @@
+package flow
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"testing"
+)
+
+// energy returns the energy of a labeling, as defined by Segment.
+func energy(g graph.Iterator, source, sink []int64, label []int) int64 {
+	var e int64
+	for v, l := range label {
+		if l == 0 {
+			e += sink[v]
+		} else {
+			e += source[v]
+		}
+	}
+	graph.VisitAll(g, func(v, w int, c int64) (skip bool) {
+		if label[v] == 0 && label[w] == 1 {
+			e += c
+		}
+		return
+	})
+	return e
+}
+
+func TestSegment(t *testing.T) {
+	label, e := Segment(graph.New(0), nil, nil)
+	if mess, diff := diff(label, []int{}); diff || e != 0 {
+		t.Errorf("Segment %s %d", mess, e)
+	}
+
+	// A 3×4 image with a bright 2×2 square at the right,
+	// and edges of cost 2 between neighboring pixels.
+	g := graph.NewGrid(3, 4, 4)
+	g.Straight = 2
+	pixels := []int64{
+		1, 2, 8, 9,
+		0, 1, 9, 7,
+		1, 3, 1, 2,
+	}
+	source, sink := make([]int64, 12), make([]int64, 12)
+	for v, x := range pixels {
+		source[v], sink[v] = x, 10-x
+	}
+	label, e = Segment(g, source, sink)
+	exp := []int{
+		1, 1, 0, 0,
+		1, 1, 0, 0,
+		1, 1, 1, 1,
+	}
+	if mess, diff := diff(label, exp); diff {
+		t.Errorf("Segment %s", mess)
+	}
+	if mess, diff := diff(e, energy(g, source, sink, exp)); diff {
+		t.Errorf("Segment->energy %s", mess)
+	}
+
+	// With expensive boundaries, all pixels get the same label.
+	label, e = SegmentFunc(g, source, sink, func(v, w int, c int64) int64 { return 100 })
+	exp = []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}
+	if mess, diff := diff(label, exp); diff || e != 44 {
+		t.Errorf("SegmentFunc %s %d", mess, e)
+	}
+}
+
+func TestSegmentRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		n := r.Intn(10)
+		g := graph.New(n)
+		for j := r.Intn(3*n + 1); j > 0; j-- {
+			g.AddCost(r.Intn(n), r.Intn(n), r.Int63n(10))
+		}
+		source, sink := make([]int64, n), make([]int64, n)
+		for v := range source {
+			source[v], sink[v] = r.Int63n(20)-5, r.Int63n(20)-5
+		}
+		label, e := Segment(g, source, sink)
+		if mess, diff := diff(e, energy(g, source, sink, label)); diff {
+			t.Errorf("Segment->energy %s", mess)
+		}
+		best := graph.Max
+		l := make([]int, n)
+		for s := 0; s < 1<<uint(n); s++ {
+			for v := range l {
+				l[v] = s >> uint(v) & 1
+			}
+			if x := energy(g, source, sink, l); x < best {
+				best = x
+			}
+		}
+		if e != best {
+			t.Errorf("Segment: energy %d; want %d", e, best)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package graph
+
+import "strconv"
+
+// Grid is a graph whose vertices are the cells of a rectangular grid.
+// The cell in row r and column c is vertex r*Cols() + c.
+// Open cells are connected to their open horizontal and vertical
+// neighbors and, with 8-connectivity, also to their open diagonal
+// neighbors. Blocked cells have no edges.
+//
+// A diagonal move is allowed only if the two cells it passes between
+// are both open, so paths never cut corners.
+//
+// The Grid type implements the Iterator interface, and can be used
+// directly by any algorithm in this package. It shouldn't be modified
+// while such an algorithm is running.
+type Grid struct {
+	// Straight is the cost of a horizontal or vertical move,
+	// and Diagonal the cost of a diagonal move. NewGrid sets both to 1;
+	// use, for instance, 10 and 14 to approximate Euclidean distances.
+	Straight, Diagonal int64
+
+	rows, cols int
+	diagonal   bool
+	blocked    []bool
+}
+
+// NewGrid returns a grid with the given number of rows and columns,
+// in which all cells are open. The connectivity must be 4 or 8.
+func NewGrid(rows, cols, connectivity int) *Grid {
+	if rows < 0 || cols < 0 {
+		panic("negative grid size: " + strconv.Itoa(rows) + "x" + strconv.Itoa(cols))
+	}
+	if connectivity != 4 && connectivity != 8 {
+		panic("connectivity must be 4 or 8: " + strconv.Itoa(connectivity))
+	}
+	return &Grid{
+		Straight: 1,
+		Diagonal: 1,
+		rows:     rows,
+		cols:     cols,
+		diagonal: connectivity == 8,
+		blocked:  make([]bool, rows*cols),
+	}
+}
+
+// Rows returns the number of rows in the grid.
+func (g *Grid) Rows() int { return g.rows }
+
+// Cols returns the number of columns in the grid.
+func (g *Grid) Cols() int { return g.cols }
+
+// Vertex returns the vertex of the cell in row r and column c.
+func (g *Grid) Vertex(r, c int) int {
+	g.check(r, c)
+	return r*g.cols + c
+}
+
+// Cell returns the row and column of the cell of vertex v.
+func (g *Grid) Cell(v int) (r, c int) {
+	if v < 0 || v >= len(g.blocked) {
+		panic("vertex out of range: " + strconv.Itoa(v))
+	}
+	return v / g.cols, v % g.cols
+}
+
+// Contains tells if row r and column c are inside the grid.
+func (g *Grid) Contains(r, c int) bool {
+	return r >= 0 && r < g.rows && c >= 0 && c < g.cols
+}
+
+// Block blocks the cell in row r and column c.
+func (g *Grid) Block(r, c int) {
+	g.check(r, c)
+	g.blocked[r*g.cols+c] = true
+}
+
+// Unblock opens the cell in row r and column c.
+func (g *Grid) Unblock(r, c int) {
+	g.check(r, c)
+	g.blocked[r*g.cols+c] = false
+}
+
+// Blocked tells if the cell in row r and column c is blocked.
+func (g *Grid) Blocked(r, c int) bool {
+	g.check(r, c)
+	return g.blocked[r*g.cols+c]
+}
+
+func (g *Grid) check(r, c int) {
+	if !g.Contains(r, c) {
+		panic("cell out of range: (" + strconv.Itoa(r) + ", " + strconv.Itoa(c) + ")")
+	}
+}
+
+// open tells if row r and column c is an open cell inside the grid.
+func (g *Grid) open(r, c int) bool {
+	return g.Contains(r, c) && !g.blocked[r*g.cols+c]
+}
+
+// Heuristic returns a function that estimates the distance from a vertex
+// to w, for use with AStar. The estimate never exceeds the length
+// of a shortest path, as long as Straight and Diagonal are non-negative.
+func (g *Grid) Heuristic(w int) func(v int) int64 {
+	wr, wc := g.Cell(w)
+	straight, diagonal := g.Straight, g.Diagonal
+	if diagonal > 2*straight {
+		diagonal = 2 * straight
+	}
+	return func(v int) int64 {
+		r, c := g.Cell(v)
+		dr, dc := int64(r-wr), int64(c-wc)
+		if dr < 0 {
+			dr = -dr
+		}
+		if dc < 0 {
+			dc = -dc
+		}
+		if !g.diagonal {
+			return straight * (dr + dc)
+		}
+		if dr > dc {
+			dr, dc = dc, dr
+		}
+		return diagonal*dr + straight*(dc-dr)
+	}
+}
+
+// String returns a string representation of the graph.
+func (g *Grid) String() string {
+	return String(g)
+}
+
+// Order returns the number of vertices in the graph,
+// including the blocked cells.
+func (g *Grid) Order() int {
+	return len(g.blocked)
+}
+
+// Visit calls the do function for each neighbor w of v,
+// with c equal to the cost of the edge from v to w.
+// The neighbors are visited in increasing numerical order.
+// If do returns true, Visit returns immediately,
+// skipping any remaining neighbors, and returns true.
+func (g *Grid) Visit(v int, do func(w int, c int64) bool) bool {
+	r, c := g.Cell(v)
+	if g.blocked[v] {
+		return false
+	}
+	for dr := -1; dr <= 1; dr++ {
+		for dc := -1; dc <= 1; dc++ {
+			if dr == 0 && dc == 0 || !g.open(r+dr, c+dc) {
+				continue
+			}
+			cost := g.Straight
+			if dr != 0 && dc != 0 {
+				if !g.diagonal || !g.open(r+dr, c) || !g.open(r, c+dc) {
+					continue
+				}
+				cost = g.Diagonal
+			}
+			if do(v+dr*g.cols+dc, cost) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGrid(t *testing.T) {
+	g := NewGrid(2, 3, 4)
+	if mess, diff := diff(g.String(), "6 [{0 1}:1 {0 3}:1 {1 2}:1 {1 4}:1 {2 5}:1 {3 4}:1 {4 5}:1]"); diff {
+		t.Errorf("Grid %s", mess)
+	}
+	if mess, diff := diff(g.Vertex(1, 2), 5); diff {
+		t.Errorf("Grid->Vertex %s", mess)
+	}
+	r, c := g.Cell(4)
+	if mess, diff := diff([]int{r, c}, []int{1, 1}); diff {
+		t.Errorf("Grid->Cell %s", mess)
+	}
+
+	g.Block(0, 1)
+	if mess, diff := diff(g.Blocked(0, 1), true); diff {
+		t.Errorf("Grid->Blocked %s", mess)
+	}
+	if mess, diff := diff(g.String(), "6 [{0 3}:1 {2 5}:1 {3 4}:1 {4 5}:1]"); diff {
+		t.Errorf("Grid %s", mess)
+	}
+	g.Unblock(0, 1)
+	if mess, diff := diff(g.Blocked(0, 1), false); diff {
+		t.Errorf("Grid->Blocked %s", mess)
+	}
+
+	g = NewGrid(2, 2, 8)
+	g.Straight, g.Diagonal = 10, 14
+	exp := "4 [{0 1}:10 {0 2}:10 {0 3}:14 {1 2}:14 {1 3}:10 {2 3}:10]"
+	if mess, diff := diff(g.String(), exp); diff {
+		t.Errorf("Grid %s", mess)
+	}
+	// No corner cutting.
+	g.Block(0, 1)
+	if mess, diff := diff(g.String(), "4 [{0 2}:10 {2 3}:10]"); diff {
+		t.Errorf("Grid %s", mess)
+	}
+
+	if mess, diff := diff(NewGrid(0, 5, 8).String(), "0 []"); diff {
+		t.Errorf("Grid %s", mess)
+	}
+	if mess, diff := diff(NewGrid(1, 1, 4).String(), "1 []"); diff {
+		t.Errorf("Grid %s", mess)
+	}
+	if mess, diff := diff(g.Contains(2, 0), false); diff {
+		t.Errorf("Grid->Contains %s", mess)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Grid->Vertex(2, 0) should panic")
+		}
+	}()
+	g.Vertex(2, 0)
+}
+
+func TestGridAStar(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		rows, cols := 1+rand.Intn(20), 1+rand.Intn(20)
+		connectivity := 4 + 4*rand.Intn(2)
+		g := NewGrid(rows, cols, connectivity)
+		g.Straight, g.Diagonal = 10, 14
+		for m := rand.Intn(rows*cols/3 + 1); m > 0; m-- {
+			g.Block(rand.Intn(rows), rand.Intn(cols))
+		}
+		v, w := rand.Intn(g.Order()), rand.Intn(g.Order())
+		_, exp := ShortestPath(g, v, w)
+		path, dist := AStar(g, v, w, g.Heuristic(w))
+		if mess, diff := diff(dist, exp); diff {
+			t.Errorf("AStar(%dx%d, %d)->dist %s", rows, cols, connectivity, mess)
+		}
+		if dist == -1 {
+			continue
+		}
+		var sum int64
+		for j := 1; j < len(path); j++ {
+			sum += edgeCost(g, path[j-1], path[j])
+		}
+		if mess, diff := diff(sum, dist); diff {
+			t.Errorf("AStar(%dx%d, %d)->path cost %s", rows, cols, connectivity, mess)
+		}
+
+		h := g.Heuristic(w)
+		_, dists := ShortestPaths(Transpose(g), w)
+		for u, d := range dists {
+			if d != -1 && h(u) > d {
+				t.Errorf("Heuristic(%d)(%d) = %d; distance %d", w, u, h(u), d)
+			}
+		}
+	}
+}
+
+func BenchmarkGridAStar(b *testing.B) {
+	b.StopTimer()
+	g := NewGrid(300, 300, 8)
+	for m := 10000; m > 0; m-- {
+		g.Block(rand.Intn(300), rand.Intn(300))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = AStar(g, 0, g.Order()-1, g.Heuristic(g.Order()-1))
+	}
+}
@@ -0,0 +1,174 @@
+// Package hub implements hub labeling, a technique for answering
+// exact shortest path distance queries on a static graph.
+//
+// The preprocessing step computes a 2-hop cover: each vertex v gets
+// an out-label, a list of hubs with their distances from v, and an in-label,
+// a list of hubs with their distances to v, such that some shortest path
+// from v to w passes through a hub in both the out-label of v and the
+// in-label of w. A query only merges two short sorted lists.
+//
+// The labels are computed by pruned landmark labeling, as described by
+// Akiba, Iwata and Yoshida: the vertices are processed in order of
+// decreasing degree, and a Dijkstra search from each vertex stops at
+// every vertex whose distance is already given by the existing labels.
+// On sparse graphs with a few central vertices, such as social and
+// road networks, labels typically hold tens to hundreds of hubs.
+//
+// The labels can be saved with WriteTo and loaded with ReadFrom,
+// so that preprocessing is only done once.
+package hub
+
+import (
+	"github.com/yourbasic/graph"
+	"sort"
+)
+
+// Labeling is a 2-hop cover of a directed graph
+// with non-negative edge costs.
+type Labeling struct {
+	// out[v] lists the hubs reachable from v, and in[v] the hubs
+	// that reach v, sorted by rank: the position of the hub in the order
+	// in which the vertices were processed.
+	out, in [][]entry
+}
+
+type entry struct {
+	hub  int // the rank of the hub
+	dist int64
+}
+
+// New preprocesses g into a hub labeling.
+// Only edges with non-negative costs are included.
+//
+// The time complexity depends heavily on the structure of the graph.
+// Each of the |V| pruned searches takes O(L⋅(|E| + |V|)⋅log|V|) time
+// in the worst case, where L is the size of a label.
+func New(g graph.Iterator) *Labeling {
+	n := g.Order()
+	h := graph.Sort(g)
+	t := graph.Transpose(g)
+	order := make([]int, n)
+	for v := range order {
+		order[v] = v
+	}
+	degree := func(v int) int { return h.Degree(v) + t.Degree(v) }
+	sort.SliceStable(order, func(i, j int) bool { return degree(order[i]) > degree(order[j]) })
+
+	l := &Labeling{out: make([][]entry, n), in: make([][]entry, n)}
+	s := &search{
+		dist:  make([]int64, n),
+		hubs:  make([]int64, n),
+		queue: graph.NewQuadHeapQueue(n),
+	}
+	for v := range s.dist {
+		s.dist[v], s.hubs[v] = -1, -1
+	}
+	for rank, v := range order {
+		// Paths from v are covered by the in-labels of their ends,
+		// and paths to v by the out-labels of their starts.
+		s.run(h, v, rank, l.out[v], l.in)
+		s.run(t, v, rank, l.in[v], l.out)
+	}
+	return l
+}
+
+// search is a workspace for pruned Dijkstra searches.
+type search struct {
+	dist    []int64 // tentative distances, or -1
+	hubs    []int64 // distances to the hubs of the source, by rank, or -1
+	touched []int
+	queue   graph.DistQueue
+}
+
+// run performs a pruned search from v, with the given rank, in g,
+// and adds v as a hub to the labels of the vertices it reaches.
+// The list own is the label of v that covers the other direction.
+func (s *search) run(g graph.Iterator, v, rank int, own []entry, labels [][]entry) {
+	for _, e := range own {
+		s.hubs[e.hub] = e.dist
+	}
+	s.hubs[rank] = 0
+	s.dist[v] = 0
+	s.touched = append(s.touched[:0], v)
+	s.queue.Push(v, 0)
+	for s.queue.Len() > 0 {
+		w, d := s.queue.Pop()
+		if d > s.dist[w] {
+			continue // An outdated entry.
+		}
+		if s.covered(labels[w], d) {
+			continue
+		}
+		labels[w] = append(labels[w], entry{rank, d})
+		g.Visit(w, func(x int, c int64) (skip bool) {
+			if c < 0 {
+				return
+			}
+			if alt := d + c; s.dist[x] == -1 || alt < s.dist[x] {
+				if s.dist[x] == -1 {
+					s.touched = append(s.touched, x)
+				}
+				s.dist[x] = alt
+				s.queue.Push(x, alt)
+			}
+			return
+		})
+	}
+	for _, w := range s.touched {
+		s.dist[w] = -1
+	}
+	for _, e := range own {
+		s.hubs[e.hub] = -1
+	}
+	s.hubs[rank] = -1
+}
+
+// covered tells if the current labels give a path of length
+// at most d through one of the hubs of the source.
+func (s *search) covered(label []entry, d int64) bool {
+	for _, e := range label {
+		if h := s.hubs[e.hub]; h != -1 && h+e.dist <= d {
+			return true
+		}
+	}
+	return false
+}
+
+// Order returns the number of vertices in the labeling.
+func (l *Labeling) Order() int {
+	return len(l.out)
+}
+
+// Size returns the total number of hubs in all labels.
+func (l *Labeling) Size() int {
+	size := 0
+	for v := range l.out {
+		size += len(l.out[v]) + len(l.in[v])
+	}
+	return size
+}
+
+// Dist returns the length of a shortest path from v to w,
+// or -1 if w cannot be reached.
+//
+// The time complexity is O(L), where L is the size
+// of the out-label of v and the in-label of w.
+func (l *Labeling) Dist(v, w int) int64 {
+	a, b := l.out[v], l.in[w]
+	dist := int64(-1)
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i].hub < b[j].hub:
+			i++
+		case a[i].hub > b[j].hub:
+			j++
+		default:
+			if d := a[i].dist + b[j].dist; dist == -1 || d < dist {
+				dist = d
+			}
+			i++
+			j++
+		}
+	}
+	return dist
+}
@@ -0,0 +1,132 @@
+package hub
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func diff(res, exp interface{}) (message string, diff bool) {
+	if !reflect.DeepEqual(res, exp) {
+		message = fmt.Sprintf("%v; want %v", res, exp)
+		diff = true
+	}
+	return
+}
+
+func TestDist(t *testing.T) {
+	g := graph.New(6)
+	g.AddCost(0, 1, 1)
+	g.AddCost(0, 2, 1)
+	g.AddCost(0, 3, 3)
+	g.AddCost(1, 3, 0)
+	g.AddCost(2, 3, 1)
+	g.AddCost(2, 5, 8)
+	g.AddCost(3, 5, 7)
+	g.AddCost(1, 5, -1)
+	l := New(g)
+	if mess, diff := diff(l.Order(), 6); diff {
+		t.Errorf("Order %s", mess)
+	}
+	for v, exp := range []int64{0, 1, 1, 1, -1, 8} {
+		if mess, diff := diff(l.Dist(0, v), exp); diff {
+			t.Errorf("Dist(0, %d) %s", v, mess)
+		}
+	}
+	if mess, diff := diff(l.Dist(5, 0), int64(-1)); diff {
+		t.Errorf("Dist(5, 0) %s", mess)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	for n := 1; n < 60; n += 1 + n/4 {
+		g := graph.New(n)
+		for m := rand.Intn(4*n + 1); m > 0; m-- {
+			g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(20))
+		}
+		l := New(g)
+		for v := 0; v < n; v++ {
+			_, dist := graph.ShortestPaths(g, v)
+			for w, exp := range dist {
+				if d := l.Dist(v, w); d != exp {
+					t.Errorf("Dist(%d, %d) = %d; want %d", v, w, d, exp)
+				}
+			}
+		}
+		if l.Size() < 2*n {
+			t.Errorf("Size() = %d; want at least %d", l.Size(), 2*n)
+		}
+	}
+}
+
+func TestReadWrite(t *testing.T) {
+	n := 50
+	g := graph.New(n)
+	for i := 0; i < 3*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(1000))
+	}
+	l := New(g)
+	var buf bytes.Buffer
+	m, err := l.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if mess, diff := diff(m, int64(buf.Len())); diff {
+		t.Errorf("WriteTo %s", mess)
+	}
+	data := buf.Bytes()
+
+	l2 := new(Labeling)
+	m, err = l2.ReadFrom(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+	if mess, diff := diff(m, int64(len(data))); diff {
+		t.Errorf("ReadFrom %s", mess)
+	}
+	if mess, diff := diff(l2, l); diff {
+		t.Errorf("ReadFrom %s", mess)
+	}
+
+	for _, bad := range [][]byte{
+		nil,
+		[]byte("not a labeling"),
+		data[:len(data)-1],
+		append([]byte(magic), 2),
+		append([]byte(magic), 1, 1, 1, 0, 0), // a hub with rank difference 0
+	} {
+		if _, err := new(Labeling).ReadFrom(bytes.NewReader(bad)); err != ErrFormat {
+			t.Errorf("ReadFrom(%q): %v; want %v", bad, err, ErrFormat)
+		}
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	b.StopTimer()
+	n := 2000
+	g := graph.New(n)
+	for i := 0; i < 3*n; i++ {
+		g.AddBothCost(rand.Intn(n), rand.Intn(n), rand.Int63n(1000))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = New(g)
+	}
+}
+
+func BenchmarkDist(b *testing.B) {
+	b.StopTimer()
+	n := 2000
+	g := graph.New(n)
+	for i := 0; i < 3*n; i++ {
+		g.AddBothCost(rand.Intn(n), rand.Intn(n), rand.Int63n(1000))
+	}
+	l := New(g)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = l.Dist(rand.Intn(n), rand.Intn(n))
+	}
+}
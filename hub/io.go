@@ -0,0 +1,77 @@
+package hub
+
+import (
+	"errors"
+	"github.com/yourbasic/graph/internal/binio"
+	"io"
+)
+
+// The binary format starts with a magic string and a version number.
+// All integers that follow are varint encoded:
+//
+//	n
+//	for each vertex v: len(out[v]) followed by its entries
+//	for each vertex v: len(in[v]) followed by its entries
+//
+// where each entry is encoded as the difference between its rank
+// and the rank of the previous entry in the list, and its distance.
+const (
+	magic   = "yourbasic/graph/hub"
+	version = 1
+)
+
+// ErrFormat is returned by ReadFrom when the input isn't a labeling.
+var ErrFormat = errors.New("hub: invalid format")
+
+// WriteTo writes a binary representation of l to w.
+// It returns the number of bytes written.
+func (l *Labeling) WriteTo(w io.Writer) (int64, error) {
+	bw := binio.NewWriter(w)
+	bw.WriteString(magic)
+	bw.WriteUvarint(version)
+	bw.WriteUvarint(uint64(len(l.out)))
+	for _, labels := range [][][]entry{l.out, l.in} {
+		for _, list := range labels {
+			bw.WriteUvarint(uint64(len(list)))
+			prev := -1
+			for _, e := range list {
+				bw.WriteUvarint(uint64(e.hub - prev))
+				bw.WriteUvarint(uint64(e.dist))
+				prev = e.hub
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadFrom reads a labeling written by WriteTo from r, replacing
+// the contents of l. It returns the number of bytes read.
+// If the input is malformed, ReadFrom returns ErrFormat.
+func (l *Labeling) ReadFrom(r io.Reader) (int64, error) {
+	br := binio.NewReader(r, ErrFormat)
+	br.ReadHeader(magic, version)
+	n := br.ReadInt(-1)
+	var labels [2][][]entry
+	for i := range labels {
+		for v := 0; v < n && br.Err() == nil; v++ {
+			m := br.ReadInt(n + 1)
+			var list []entry
+			prev := -1
+			for j := 0; j < m && br.Err() == nil; j++ {
+				hub := prev + br.ReadInt(n-prev)
+				dist := int64(br.ReadUvarint())
+				if hub == prev || dist < 0 {
+					br.Fail()
+				}
+				list = append(list, entry{hub, dist})
+				prev = hub
+			}
+			labels[i] = append(labels[i], list)
+		}
+	}
+	if err := br.Err(); err != nil {
+		return br.Count(), err
+	}
+	l.out, l.in = labels[0], labels[1]
+	return br.Count(), nil
+}
@@ -1,19 +1,24 @@
 package graph
 
 import (
+	"runtime"
 	"sort"
 	"strconv"
+	"sync"
 )
 
 // Immutable is a compact representation of an immutable graph.
-// The implementation uses lists to associate each vertex in the graph
-// with its adjacent vertices. This makes for fast and predictable
-// iteration: the Visit method produces its elements by reading
-// from a fixed sorted precomputed list. This type supports multigraphs.
+// The implementation stores the graph in compressed sparse row (CSR)
+// format: the neighbors of all vertices are kept in one contiguous array,
+// with the neighbors of each vertex stored in a sorted subrange.
+// This makes for fast and predictable iteration: the Visit method
+// produces its elements by reading from a fixed sorted precomputed list.
+// This type supports multigraphs.
 type Immutable struct {
-	// edges[v] is a sorted list of v's neighbors.
-	edges [][]neighbor
-	stats Stats
+	// The neighbors of v are edges[offset[v]:offset[v+1]], sorted by vertex.
+	edges  []neighbor
+	offset []int
+	stats  Stats
 }
 
 type neighbor struct {
@@ -21,13 +26,29 @@ type neighbor struct {
 	cost   int64
 }
 
+// Edge is a directed edge from V to W with cost Cost.
+type Edge struct {
+	V, W int
+	Cost int64
+}
+
 // Sort returns an immutable copy of g with a Visit method
 // that returns its neighbors in increasing numerical order.
 func Sort(g Iterator) *Immutable {
 	if g, ok := g.(*Immutable); ok {
 		return g
 	}
-	return build(g, false)
+	return build(g, false, 1)
+}
+
+// SortParallel is like Sort, but builds the copy using all available CPUs.
+// The Visit method of g must be safe for concurrent use; this is true
+// for Mutable and Immutable graphs that aren't modified during the call.
+func SortParallel(g Iterator) *Immutable {
+	if g, ok := g.(*Immutable); ok {
+		return g
+	}
+	return build(g, false, runtime.GOMAXPROCS(0))
 }
 
 // Transpose returns the transpose graph of g.
@@ -35,54 +56,168 @@ func Sort(g Iterator) *Immutable {
 // but all of the edges are reversed compared to the orientation
 // of the corresponding edges in g.
 func Transpose(g Iterator) *Immutable {
-	return build(g, true)
+	return build(g, true, 1)
 }
 
-func build(g Iterator, transpose bool) *Immutable {
+// FromEdges returns an immutable graph with n vertices
+// and the given list of edges.
+// The list may contain duplicate edges.
+func FromEdges(n int, edges []Edge) *Immutable {
+	h := &Immutable{edges: make([]neighbor, len(edges)), offset: make([]int, n+1)}
+	for _, e := range edges {
+		if e.V < 0 || e.V >= n {
+			panic("vertex out of range: " + strconv.Itoa(e.V))
+		}
+		if e.W < 0 || e.W >= n {
+			panic("vertex out of range: " + strconv.Itoa(e.W))
+		}
+		h.offset[e.V+1]++
+	}
+	for v := 0; v < n; v++ {
+		h.offset[v+1] += h.offset[v]
+	}
+	next := make([]int, n)
+	copy(next, h.offset)
+	for _, e := range edges {
+		h.edges[next[e.V]] = neighbor{e.W, e.Cost}
+		next[e.V]++
+	}
+	h.finish(runtime.GOMAXPROCS(0))
+	return h
+}
+
+func build(g Iterator, transpose bool, workers int) *Immutable {
 	n := g.Order()
-	h := &Immutable{edges: make([][]neighbor, n)}
-	for v := range h.edges {
-		g.Visit(v, func(w int, c int64) (skip bool) {
-			if w < 0 || w >= n {
-				panic("vertex out of range: " + strconv.Itoa(w))
-			}
-			if transpose {
-				h.edges[w] = append(h.edges[w], neighbor{v, c})
-			} else {
-				h.edges[v] = append(h.edges[v], neighbor{w, c})
+	h := &Immutable{offset: make([]int, n+1)}
+	// First count the edges of each vertex, then fill in the neighbors.
+	if transpose {
+		for v := 0; v < n; v++ {
+			g.Visit(v, func(w int, c int64) (skip bool) {
+				if w < 0 || w >= n {
+					panic("vertex out of range: " + strconv.Itoa(w))
+				}
+				h.offset[w+1]++
+				return
+			})
+		}
+	} else {
+		bad := parallel(n, workers, func(lo, hi int) (bad int) {
+			bad = -1
+			for v := lo; v < hi; v++ {
+				g.Visit(v, func(w int, c int64) (skip bool) {
+					if w < 0 || w >= n {
+						bad = w
+						return true
+					}
+					h.offset[v+1]++
+					return
+				})
 			}
 			return
 		})
-		sort.Slice(h.edges[v], func(i, j int) bool {
-			if e := h.edges[v]; e[i].vertex == e[j].vertex {
-				return e[i].cost < e[j].cost
-			} else {
-				return e[i].vertex < e[j].vertex
-			}
-		})
+		if bad != -1 {
+			panic("vertex out of range: " + strconv.Itoa(bad))
+		}
+	}
+	for v := 0; v < n; v++ {
+		h.offset[v+1] += h.offset[v]
 	}
-	for v, neighbors := range h.edges {
-		if len(neighbors) == 0 {
-			h.stats.Isolated++
+	h.edges = make([]neighbor, h.offset[n])
+	if transpose {
+		next := make([]int, n)
+		copy(next, h.offset)
+		for v := 0; v < n; v++ {
+			g.Visit(v, func(w int, c int64) (skip bool) {
+				h.edges[next[w]] = neighbor{v, c}
+				next[w]++
+				return
+			})
 		}
-		prev := -1
-		for _, e := range neighbors {
-			w, c := e.vertex, e.cost
-			if v == w {
-				h.stats.Loops++
+	} else {
+		parallel(n, workers, func(lo, hi int) int {
+			for v := lo; v < hi; v++ {
+				i := h.offset[v]
+				g.Visit(v, func(w int, c int64) (skip bool) {
+					h.edges[i] = neighbor{w, c}
+					i++
+					return
+				})
 			}
-			if c != 0 {
-				h.stats.Weighted++
+			return -1
+		})
+	}
+	h.finish(workers)
+	return h
+}
+
+// finish sorts the neighbors of each vertex and computes the statistics.
+func (h *Immutable) finish(workers int) {
+	var mu sync.Mutex
+	parallel(h.Order(), workers, func(lo, hi int) int {
+		var s Stats
+		for v := lo; v < hi; v++ {
+			neighbors := h.edges[h.offset[v]:h.offset[v+1]]
+			sort.Slice(neighbors, func(i, j int) bool {
+				if e := neighbors; e[i].vertex == e[j].vertex {
+					return e[i].cost < e[j].cost
+				} else {
+					return e[i].vertex < e[j].vertex
+				}
+			})
+			if len(neighbors) == 0 {
+				s.Isolated++
 			}
-			if w == prev {
-				h.stats.Multi++
-			} else {
-				h.stats.Size++
-				prev = w
+			prev := -1
+			for _, e := range neighbors {
+				w, c := e.vertex, e.cost
+				if v == w {
+					s.Loops++
+				}
+				if c != 0 {
+					s.Weighted++
+				}
+				if w == prev {
+					s.Multi++
+				} else {
+					s.Size++
+					prev = w
+				}
 			}
 		}
+		mu.Lock()
+		h.stats.Size += s.Size
+		h.stats.Multi += s.Multi
+		h.stats.Weighted += s.Weighted
+		h.stats.Loops += s.Loops
+		h.stats.Isolated += s.Isolated
+		mu.Unlock()
+		return -1
+	})
+}
+
+// parallel splits the vertices 0..n-1 into at most workers ranges
+// and calls f concurrently on each range.
+// It returns a value other than -1 returned by f, or -1 if there is none.
+func parallel(n, workers int, f func(lo, hi int) int) int {
+	if workers <= 1 || n < 2*workers {
+		return f(0, n)
 	}
-	return h
+	res := make([]int, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			res[i] = f(i*n/workers, (i+1)*n/workers)
+		}(i)
+	}
+	wg.Wait()
+	for _, r := range res {
+		if r != -1 {
+			return r
+		}
+	}
+	return -1
 }
 
 // Visit calls the do function for each neighbor w of v,
@@ -91,7 +226,7 @@ func build(g Iterator, transpose bool) *Immutable {
 // If do returns true, Visit returns immediately,
 // skipping any remaining neighbors, and returns true.
 func (g *Immutable) Visit(v int, do func(w int, c int64) bool) bool {
-	for _, e := range g.edges[v] {
+	for _, e := range g.edges[g.offset[v]:g.offset[v+1]] {
 		if do(e.vertex, e.cost) {
 			return true
 		}
@@ -105,7 +240,7 @@ func (g *Immutable) Visit(v int, do func(w int, c int64) bool) bool {
 // If do returns true, VisitFrom returns immediately,
 // skipping any remaining neighbors, and returns true.
 func (g *Immutable) VisitFrom(v int, a int, do func(w int, c int64) bool) bool {
-	neighbors := g.edges[v]
+	neighbors := g.edges[g.offset[v]:g.offset[v+1]]
 	n := len(neighbors)
 	i := sort.Search(n, func(i int) bool { return a <= neighbors[i].vertex })
 	for ; i < n; i++ {
@@ -124,15 +259,18 @@ func (g *Immutable) String() string {
 
 // Order returns the number of vertices in the graph.
 func (g *Immutable) Order() int {
-	return len(g.edges)
+	if len(g.offset) == 0 {
+		return 0 // The zero value.
+	}
+	return len(g.offset) - 1
 }
 
 // Edge tells if there is an edge from v to w.
 func (g *Immutable) Edge(v, w int) bool {
-	if v < 0 || v >= len(g.edges) {
+	if v < 0 || v >= g.Order() {
 		return false
 	}
-	edges := g.edges[v]
+	edges := g.edges[g.offset[v]:g.offset[v+1]]
 	n := len(edges)
 	i := sort.Search(n, func(i int) bool { return w <= edges[i].vertex })
 	return i < n && w == edges[i].vertex
@@ -140,5 +278,5 @@ func (g *Immutable) Edge(v, w int) bool {
 
 // Degree returns the number of outward directed edges from v.
 func (g *Immutable) Degree(v int) int {
-	return len(g.edges[v])
+	return g.offset[v+1] - g.offset[v]
 }
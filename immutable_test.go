@@ -79,6 +79,46 @@ func TestSort(t *testing.T) {
 	Consistent("Sort Sort(rand)", t, Sort(Sort(g)))
 }
 
+func TestSortParallel(t *testing.T) {
+	n := 1000
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(10))
+	}
+	h := SortParallel(g)
+	Consistent("SortParallel", t, h)
+	if mess, diff := diff(h, Sort(g)); diff {
+		t.Errorf("SortParallel %s", mess)
+	}
+	if mess, diff := diff(SortParallel(h) == h, true); diff {
+		t.Errorf("SortParallel(Immutable) %s", mess)
+	}
+}
+
+func TestFromEdges(t *testing.T) {
+	res := FromEdges(0, nil)
+	if mess, diff := diff(res.String(), "0 []"); diff {
+		t.Errorf("FromEdges: %s", mess)
+	}
+	Consistent("FromEdges empty", t, res)
+
+	res = FromEdges(5, []Edge{{2, 3, 1}, {0, 1, 0}, {2, 3, 1}, {2, 0, -1}, {4, 4, 0}})
+	exp := "5 [(0 1) (2 0):-1 2×(2 3):1 (4 4)]"
+	if mess, diff := diff(res.String(), exp); diff {
+		t.Errorf("FromEdges: %s", mess)
+	}
+	if mess, diff := diff(Check(res), Stats{Size: 4, Multi: 1, Weighted: 3, Loops: 1, Isolated: 2}); diff {
+		t.Errorf("FromEdges: Check %s", mess)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("FromEdges: no panic for vertex out of range")
+		}
+	}()
+	FromEdges(2, []Edge{{0, 2, 0}})
+}
+
 func TestTranspose(t *testing.T) {
 	g0, g1, g1c, g5, g5c := SetUpImm()
 
@@ -144,6 +184,16 @@ func TestOrderImm(t *testing.T) {
 	if mess, diff := diff(g5c.Order(), 5); diff {
 		t.Errorf("g5.%s %s", s, mess)
 	}
+	var zero Immutable
+	if mess, diff := diff(zero.Order(), 0); diff {
+		t.Errorf("zero.%s %s", s, mess)
+	}
+	if mess, diff := diff(zero.Edge(0, 0), false); diff {
+		t.Errorf("zero.Edge(0, 0) %s", mess)
+	}
+	if mess, diff := diff(String(&zero), "0 []"); diff {
+		t.Errorf("String(zero) %s", mess)
+	}
 }
 
 func TestEdgeImm(t *testing.T) {
@@ -197,3 +247,29 @@ func TestDegreeImm(t *testing.T) {
 		t.Errorf("g5c.Degree(1) %s", mess)
 	}
 }
+
+func BenchmarkSort(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 10*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(10))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Sort(g)
+	}
+}
+
+func BenchmarkSortParallel(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 10*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(10))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = SortParallel(g)
+	}
+}
@@ -0,0 +1,160 @@
+// Package binio implements the varint encoded binary formats
+// of the graph, ch and hub packages.
+//
+// A Writer and a Reader count the bytes written or read and remember
+// the first error, so that a sequence of calls only needs to check
+// for errors at the end.
+package binio
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+)
+
+// Writer writes varint encoded data to a buffered writer.
+type Writer struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+	buf [binary.MaxVarintLen64]byte
+}
+
+// NewWriter returns a Writer that writes to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// WriteString writes s.
+func (w *Writer) WriteString(s string) {
+	if w.err != nil {
+		return
+	}
+	m, err := w.w.WriteString(s)
+	w.n += int64(m)
+	w.err = err
+}
+
+// WriteUvarint writes x as an unsigned varint.
+func (w *Writer) WriteUvarint(x uint64) {
+	if w.err != nil {
+		return
+	}
+	m, err := w.w.Write(w.buf[:binary.PutUvarint(w.buf[:], x)])
+	w.n += int64(m)
+	w.err = err
+}
+
+// WriteVarint writes x as a signed varint.
+func (w *Writer) WriteVarint(x int64) {
+	if w.err != nil {
+		return
+	}
+	m, err := w.w.Write(w.buf[:binary.PutVarint(w.buf[:], x)])
+	w.n += int64(m)
+	w.err = err
+}
+
+// Flush writes any buffered data, and returns the number of bytes
+// written and the first error.
+func (w *Writer) Flush() (int64, error) {
+	if w.err == nil {
+		w.err = w.w.Flush()
+	}
+	return w.n, w.err
+}
+
+// Reader reads varint encoded data from a buffered reader.
+// Once an error has occurred, all reads return zero values.
+type Reader struct {
+	r      *bufio.Reader
+	n      int64
+	err    error
+	format error
+}
+
+// NewReader returns a Reader that reads from r, where format
+// is the error returned for malformed input.
+func NewReader(r io.Reader, format error) *Reader {
+	return &Reader{r: bufio.NewReader(r), format: format}
+}
+
+// Read implements the io.Reader interface.
+func (r *Reader) Read(p []byte) (int, error) {
+	m, err := r.r.Read(p)
+	r.n += int64(m)
+	return m, err
+}
+
+// ReadByte implements the io.ByteReader interface.
+func (r *Reader) ReadByte() (byte, error) {
+	b, err := r.r.ReadByte()
+	if err == nil {
+		r.n++
+	}
+	return b, err
+}
+
+// ReadHeader reads a magic string followed by a version number,
+// and fails if they don't match magic and version.
+func (r *Reader) ReadHeader(magic string, version uint64) {
+	buf := make([]byte, len(magic))
+	if _, err := io.ReadFull(r, buf); err != nil {
+		r.err = err
+		return
+	}
+	if string(buf) != magic || r.ReadUvarint() != version {
+		r.Fail()
+	}
+}
+
+// ReadUvarint reads an unsigned varint.
+func (r *Reader) ReadUvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	x, err := binary.ReadUvarint(r)
+	r.err = err
+	return x
+}
+
+// ReadVarint reads a signed varint.
+func (r *Reader) ReadVarint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	x, err := binary.ReadVarint(r)
+	r.err = err
+	return x
+}
+
+// ReadInt reads a non-negative int smaller than max, or any
+// non-negative int if max is -1.
+func (r *Reader) ReadInt(max int) int {
+	x := r.ReadUvarint()
+	if r.err == nil && (x > uint64(^uint(0)>>1) || max != -1 && x >= uint64(max)) {
+		r.Fail()
+	}
+	return int(x)
+}
+
+// Fail marks the input as malformed, unless an error has already occurred.
+func (r *Reader) Fail() {
+	if r.err == nil {
+		r.err = r.format
+	}
+}
+
+// Err returns the first error, where an unexpected end of input
+// counts as malformed input.
+func (r *Reader) Err() error {
+	if r.err == io.EOF || r.err == io.ErrUnexpectedEOF {
+		return r.format
+	}
+	return r.err
+}
+
+// Count returns the number of bytes read.
+func (r *Reader) Count() int64 {
+	return r.n
+}
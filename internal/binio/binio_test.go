@@ -0,0 +1,60 @@
+package binio
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+var errFormat = errors.New("binio: invalid format")
+
+func TestReadWrite(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.WriteString("magic")
+	w.WriteUvarint(1)
+	w.WriteUvarint(300)
+	w.WriteVarint(-5)
+	w.WriteUvarint(7)
+	n, err := w.Flush()
+	if err != nil || n != int64(buf.Len()) {
+		t.Fatalf("Flush: %d, %v; want %d, nil", n, err, buf.Len())
+	}
+	data := buf.Bytes()
+
+	r := NewReader(bytes.NewReader(data), errFormat)
+	r.ReadHeader("magic", 1)
+	if x := r.ReadUvarint(); x != 300 {
+		t.Errorf("ReadUvarint: %d; want 300", x)
+	}
+	if x := r.ReadVarint(); x != -5 {
+		t.Errorf("ReadVarint: %d; want -5", x)
+	}
+	if x := r.ReadInt(8); x != 7 {
+		t.Errorf("ReadInt: %d; want 7", x)
+	}
+	if r.Err() != nil || r.Count() != int64(len(data)) {
+		t.Errorf("Reader: %d, %v; want %d, nil", r.Count(), r.Err(), len(data))
+	}
+
+	for _, test := range []struct {
+		data    []byte
+		magic   string
+		version uint64
+		max     int
+	}{
+		{data[:len(data)-1], "magic", 1, -1}, // unexpected end of input
+		{data, "other", 1, -1},
+		{data, "magic", 2, -1},
+		{data, "magic", 1, 300}, // too large
+	} {
+		r := NewReader(bytes.NewReader(test.data), errFormat)
+		r.ReadHeader(test.magic, test.version)
+		r.ReadInt(test.max)
+		r.ReadVarint()
+		r.ReadUvarint()
+		if err := r.Err(); err != errFormat {
+			t.Errorf("Reader(%q, %d): %v; want %v", test.magic, test.version, err, errFormat)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"github.com/yourbasic/graph/internal/binio"
+	"io"
+)
+
+// The binary format of an immutable graph starts with a magic string
+// and a version number. All integers that follow are varint encoded:
+//
+//	n
+//	for each vertex v: its degree, followed by its neighbors
+//
+// where the neighbors are sorted, and each neighbor is encoded as
+// the difference from the previous neighbor (or from 0),
+// followed by the cost of the edge as a signed varint.
+const (
+	magic   = "yourbasic/graph"
+	version = 1
+)
+
+// ErrFormat is returned when reading a graph whose binary
+// representation is malformed.
+var ErrFormat = errors.New("graph: invalid format")
+
+// WriteTo writes a binary representation of g to w.
+// It returns the number of bytes written.
+func (g *Immutable) WriteTo(w io.Writer) (int64, error) {
+	bw := binio.NewWriter(w)
+	bw.WriteString(magic)
+	bw.WriteUvarint(version)
+	n := g.Order()
+	bw.WriteUvarint(uint64(n))
+	for v := 0; v < n; v++ {
+		bw.WriteUvarint(uint64(g.Degree(v)))
+		prev := 0
+		for _, e := range g.edges[g.offset[v]:g.offset[v+1]] {
+			bw.WriteUvarint(uint64(e.vertex - prev))
+			bw.WriteVarint(e.cost)
+			prev = e.vertex
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadFrom reads a graph written by WriteTo from r, replacing
+// the contents of g. It returns the number of bytes read.
+// If the input is malformed, ReadFrom returns ErrFormat.
+func (g *Immutable) ReadFrom(r io.Reader) (int64, error) {
+	br := binio.NewReader(r, ErrFormat)
+	br.ReadHeader(magic, version)
+	n := br.ReadInt(-1)
+	// The slices grow as data is read, so that a corrupt header
+	// can't cause a huge allocation.
+	offset := []int{0}
+	var edges []neighbor
+	for v := 0; v < n && br.Err() == nil; v++ {
+		deg := br.ReadInt(-1)
+		w := 0
+		for i := 0; i < deg && br.Err() == nil; i++ {
+			w += br.ReadInt(n)
+			c := br.ReadVarint()
+			if w >= n {
+				br.Fail()
+			}
+			edges = append(edges, neighbor{w, c})
+		}
+		offset = append(offset, len(edges))
+	}
+	if err := br.Err(); err != nil {
+		return br.Count(), err
+	}
+	h := &Immutable{edges: edges, offset: offset}
+	h.finish(1)
+	*g = *h
+	return br.Count(), nil
+}
+
+// MarshalBinary returns a binary representation of g,
+// in the format written by WriteTo.
+func (g *Immutable) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := g.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a graph written by WriteTo or MarshalBinary,
+// replacing the contents of g. The data may, for instance, be a
+// memory-mapped file; it isn't retained after the call.
+// If the data is malformed, UnmarshalBinary returns ErrFormat.
+func (g *Immutable) UnmarshalBinary(data []byte) error {
+	m, err := g.ReadFrom(bytes.NewReader(data))
+	if err == nil && m != int64(len(data)) {
+		err = ErrFormat
+	}
+	return err
+}
@@ -0,0 +1,88 @@
+package graph
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestReadWrite(t *testing.T) {
+	n := 100
+	g := New(n)
+	for i := 0; i < 5*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(2000)-1000)
+	}
+	for _, h := range []*Immutable{
+		Sort(New(0)),
+		Sort(g),
+		FromEdges(3, []Edge{{0, 2, 1}, {0, 2, 1}, {2, 2, Min}, {1, 0, Max}}),
+	} {
+		var buf bytes.Buffer
+		m, err := h.WriteTo(&buf)
+		if err != nil {
+			t.Fatalf("WriteTo: %v", err)
+		}
+		if mess, diff := diff(m, int64(buf.Len())); diff {
+			t.Errorf("WriteTo %s", mess)
+		}
+		data := buf.Bytes()
+
+		h2 := new(Immutable)
+		m, err = h2.ReadFrom(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("ReadFrom: %v", err)
+		}
+		if mess, diff := diff(m, int64(len(data))); diff {
+			t.Errorf("ReadFrom %s", mess)
+		}
+		if mess, diff := diff(h2.String(), h.String()); diff {
+			t.Errorf("ReadFrom %s", mess)
+		}
+		if mess, diff := diff(Check(h2), Check(h)); diff {
+			t.Errorf("ReadFrom: Check %s", mess)
+		}
+
+		b, err := h.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary: %v", err)
+		}
+		if mess, diff := diff(b, data); diff {
+			t.Errorf("MarshalBinary %s", mess)
+		}
+		h3 := new(Immutable)
+		if err := h3.UnmarshalBinary(b); err != nil {
+			t.Fatalf("UnmarshalBinary: %v", err)
+		}
+		if mess, diff := diff(h3.String(), h.String()); diff {
+			t.Errorf("UnmarshalBinary %s", mess)
+		}
+	}
+
+	data, _ := Sort(g).MarshalBinary()
+	for _, bad := range [][]byte{
+		nil,
+		[]byte("not a graph"),
+		data[:len(data)-1],
+		append(data, 0),
+		append([]byte(magic), 2),
+		append([]byte(magic), 1, 1, 1, 1, 0), // vertex out of range
+	} {
+		if err := new(Immutable).UnmarshalBinary(bad); err != ErrFormat {
+			t.Errorf("UnmarshalBinary(%q): %v; want %v", bad, err, ErrFormat)
+		}
+	}
+}
+
+func BenchmarkReadFrom(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 10*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(1000))
+	}
+	data, _ := Sort(g).MarshalBinary()
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = new(Immutable).UnmarshalBinary(data)
+	}
+}
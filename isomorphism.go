@@ -0,0 +1,330 @@
+package graph
+
+import (
+	"iter"
+	"sort"
+)
+
+// IsIsomorphic tells if g and h are isomorphic: if there is a bijection
+// between their vertices such that (v, w) is an edge in g if and only if
+// the image of (v, w) is an edge in h. Edge costs are ignored.
+//
+// The implementation uses the VF2 algorithm, which takes exponential
+// time in the worst case but is fast for most graphs.
+func IsIsomorphic(g, h Iterator) bool {
+	for range Isomorphisms(g, h, nil, nil) {
+		return true
+	}
+	return false
+}
+
+// Isomorphisms returns an iterator over all isomorphisms from g to h.
+// Each isomorphism is a new slice m, where m[v] is the vertex in h
+// that corresponds to v in g.
+//
+// If vertexMatch is not nil, a vertex v in g may only correspond to w
+// in h if vertexMatch(v, w) is true. If edgeMatch is not nil, an edge e
+// in g may only correspond to f in h if edgeMatch(e, f) is true.
+// Parallel edges are treated as a single edge with the smallest cost.
+//
+// The implementation uses the VF2 algorithm of Cordella et al.
+func Isomorphisms(g, h Iterator, vertexMatch func(v, w int) bool, edgeMatch func(e, f Edge) bool) iter.Seq[[]int] {
+	return isomorphisms(g, h, isomorphic, vertexMatch, edgeMatch)
+}
+
+// SubgraphIsomorphisms returns an iterator over all isomorphisms from
+// pattern to induced subgraphs of g: injective maps m from the vertices
+// of pattern to the vertices of g such that (v, w) is an edge in pattern
+// if and only if (m[v], m[w]) is an edge in g. The predicates are used
+// as in Isomorphisms.
+func SubgraphIsomorphisms(pattern, g Iterator, vertexMatch func(v, w int) bool, edgeMatch func(e, f Edge) bool) iter.Seq[[]int] {
+	return isomorphisms(pattern, g, induced, vertexMatch, edgeMatch)
+}
+
+// SubgraphMonomorphisms returns an iterator over all monomorphisms from
+// pattern to g: injective maps m from the vertices of pattern to the
+// vertices of g such that (m[v], m[w]) is an edge in g whenever (v, w)
+// is an edge in pattern. Unlike SubgraphIsomorphisms, g may have other
+// edges between the vertices in the image. The predicates are used
+// as in Isomorphisms.
+func SubgraphMonomorphisms(pattern, g Iterator, vertexMatch func(v, w int) bool, edgeMatch func(e, f Edge) bool) iter.Seq[[]int] {
+	return isomorphisms(pattern, g, monomorphic, vertexMatch, edgeMatch)
+}
+
+const (
+	isomorphic = iota
+	induced
+	monomorphic
+)
+
+func isomorphisms(g, h Iterator, mode int, vertexMatch func(v, w int) bool, edgeMatch func(e, f Edge) bool) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		n1, n2 := g.Order(), h.Order()
+		if n1 > n2 || mode == isomorphic && n1 != n2 {
+			return
+		}
+		m := &vf2{
+			mode:        mode,
+			vertexMatch: vertexMatch,
+			edgeMatch:   edgeMatch,
+			yield:       yield,
+		}
+		for i, x := range []Iterator{g, h} {
+			m.succ[i] = simple(x)
+			m.pred[i] = Transpose(m.succ[i])
+			n := x.Order()
+			m.core[i] = make([]int, n)
+			m.in[i] = make([]int, n)
+			m.out[i] = make([]int, n)
+			for v := range m.core[i] {
+				m.core[i][v] = -1
+			}
+		}
+		if mode == isomorphic && !sameDegrees(m.succ[0], m.succ[1]) {
+			return
+		}
+		m.match(1)
+	}
+}
+
+// sameDegrees tells if g and h have the same out- and in-degree sequences.
+func sameDegrees(g, h *Immutable) bool {
+	degrees := func(g *Immutable) []Edge {
+		n := g.Order()
+		d := make([]Edge, n)
+		for v := 0; v < n; v++ {
+			d[v].V = len(neighbors(g, v))
+			for _, a := range neighbors(g, v) {
+				d[a.vertex].W++
+			}
+		}
+		sort.Slice(d, func(i, j int) bool {
+			return d[i].V < d[j].V || d[i].V == d[j].V && d[i].W < d[j].W
+		})
+		return d
+	}
+	a, b := degrees(g), degrees(h)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// simple returns a sorted copy of g without parallel edges.
+// Of several parallel edges, the one with the smallest cost is kept.
+func simple(g Iterator) *Immutable {
+	h := Sort(g)
+	var edges []Edge
+	for v := 0; v < h.Order(); v++ {
+		for i, a := range neighbors(h, v) {
+			if i == 0 || a.vertex != h.edges[h.offset[v]+i-1].vertex {
+				edges = append(edges, Edge{v, a.vertex, a.cost})
+			}
+		}
+	}
+	return FromEdges(h.Order(), edges)
+}
+
+// neighbors returns the neighbors of v in g.
+func neighbors(g *Immutable, v int) []neighbor {
+	return g.edges[g.offset[v]:g.offset[v+1]]
+}
+
+// vf2 holds the state of the VF2 search. Index 0 is the first graph,
+// or pattern, and index 1 is the second graph.
+type vf2 struct {
+	mode        int
+	vertexMatch func(v, w int) bool
+	edgeMatch   func(e, f Edge) bool
+	yield       func([]int) bool
+
+	succ, pred [2]*Immutable
+	// core[i][v] is the vertex that v is mapped to, or -1.
+	core [2][]int
+	// in[i][v] and out[i][v] are the depths at which v entered the set of
+	// vertices with an edge to or from a mapped vertex, or 0.
+	in, out [2][]int
+}
+
+// cost returns the cost of the edge (v, w) in g, and tells if it exists.
+func cost(g *Immutable, v, w int) (int64, bool) {
+	list := neighbors(g, v)
+	i := sort.Search(len(list), func(i int) bool { return list[i].vertex >= w })
+	if i < len(list) && list[i].vertex == w {
+		return list[i].cost, true
+	}
+	return 0, false
+}
+
+// match extends the mapping with depth-1 vertices. It returns false
+// if the iteration was stopped.
+func (m *vf2) match(depth int) bool {
+	if depth > len(m.core[0]) {
+		return m.yield(append([]int{}, m.core[0]...))
+	}
+	v, candidates := m.candidates()
+	for _, w := range candidates {
+		if !m.feasible(v, w) {
+			continue
+		}
+		m.add(v, w, depth)
+		if !m.match(depth + 1) {
+			return false
+		}
+		m.remove(v, w, depth)
+	}
+	return true
+}
+
+// candidates returns an unmapped vertex v in the first graph
+// and the vertices in the second graph that v may be mapped to.
+func (m *vf2) candidates() (v int, candidates []int) {
+	for _, set := range [][2][]int{m.out, m.in} {
+		v = -1
+		for x, d := range set[0] {
+			if d != 0 && m.core[0][x] == -1 {
+				v = x
+				break
+			}
+		}
+		for w, d := range set[1] {
+			if d != 0 && m.core[1][w] == -1 {
+				candidates = append(candidates, w)
+			}
+		}
+		if v != -1 && len(candidates) > 0 {
+			return
+		}
+		candidates = candidates[:0]
+	}
+	for x, w := range m.core[0] {
+		if w == -1 {
+			v = x
+			break
+		}
+	}
+	for w, x := range m.core[1] {
+		if x == -1 {
+			candidates = append(candidates, w)
+		}
+	}
+	return
+}
+
+// feasible tells if v in the first graph may be mapped to w in the second.
+func (m *vf2) feasible(v, w int) bool {
+	if m.vertexMatch != nil && !m.vertexMatch(v, w) {
+		return false
+	}
+	m.core[0][v], m.core[1][w] = w, v
+	ok := m.edgesMapped(0, v) && (m.mode == monomorphic || m.edgesMapped(1, w))
+	m.core[0][v], m.core[1][w] = -1, -1
+	if !ok || m.mode == monomorphic {
+		return ok
+	}
+	// Look ahead: count the unmapped neighbors in the terminal sets.
+	a, b := m.lookahead(0, v), m.lookahead(1, w)
+	for i := range a {
+		if m.mode == isomorphic && a[i] != b[i] || a[i] > b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// edgesMapped tells if each edge between v and a mapped vertex in the
+// graph with index i corresponds to an edge in the other graph.
+// The edges of the first graph are also checked with edgeMatch.
+func (m *vf2) edgesMapped(i, v int) bool {
+	for j, g := range []*Immutable{m.succ[i], m.pred[i]} {
+		for _, a := range neighbors(g, v) {
+			if m.core[i][a.vertex] == -1 {
+				continue
+			}
+			e := Edge{v, a.vertex, a.cost}
+			if j == 1 {
+				e.V, e.W = a.vertex, v
+			}
+			f := Edge{V: m.core[i][e.V], W: m.core[i][e.W]}
+			c, ok := cost(m.succ[1-i], f.V, f.W)
+			if !ok {
+				return false
+			}
+			f.Cost = c
+			if i == 0 && m.edgeMatch != nil && !m.edgeMatch(e, f) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// lookahead returns the number of unmapped successors and predecessors
+// of v in the graph with index i that belong to the in set, the out set,
+// or neither.
+func (m *vf2) lookahead(i, v int) (count [6]int) {
+	for j, g := range []*Immutable{m.succ[i], m.pred[i]} {
+		for _, a := range neighbors(g, v) {
+			x := a.vertex
+			if m.core[i][x] != -1 {
+				continue
+			}
+			if m.in[i][x] != 0 {
+				count[3*j]++
+			}
+			if m.out[i][x] != 0 {
+				count[3*j+1]++
+			}
+			if m.in[i][x] == 0 && m.out[i][x] == 0 {
+				count[3*j+2]++
+			}
+		}
+	}
+	return
+}
+
+func (m *vf2) add(v, w, depth int) {
+	m.core[0][v], m.core[1][w] = w, v
+	for i, x := range []int{v, w} {
+		if m.in[i][x] == 0 {
+			m.in[i][x] = depth
+		}
+		if m.out[i][x] == 0 {
+			m.out[i][x] = depth
+		}
+		for _, a := range neighbors(m.pred[i], x) {
+			if m.in[i][a.vertex] == 0 {
+				m.in[i][a.vertex] = depth
+			}
+		}
+		for _, a := range neighbors(m.succ[i], x) {
+			if m.out[i][a.vertex] == 0 {
+				m.out[i][a.vertex] = depth
+			}
+		}
+	}
+}
+
+func (m *vf2) remove(v, w, depth int) {
+	m.core[0][v], m.core[1][w] = -1, -1
+	for i, x := range []int{v, w} {
+		for _, g := range []*Immutable{m.pred[i], m.succ[i]} {
+			for _, a := range neighbors(g, x) {
+				if m.in[i][a.vertex] == depth {
+					m.in[i][a.vertex] = 0
+				}
+				if m.out[i][a.vertex] == depth {
+					m.out[i][a.vertex] = 0
+				}
+			}
+		}
+		if m.in[i][x] == depth {
+			m.in[i][x] = 0
+		}
+		if m.out[i][x] == depth {
+			m.out[i][x] = 0
+		}
+	}
+}
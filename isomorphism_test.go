@@ -0,0 +1,207 @@
+package graph
+
+import (
+	"iter"
+	"math/rand"
+	"testing"
+)
+
+func countMaps(seq iter.Seq[[]int]) int {
+	n := 0
+	for range seq {
+		n++
+	}
+	return n
+}
+
+func TestIsomorphisms(t *testing.T) {
+	if mess, diff := diff(IsIsomorphic(New(0), New(0)), true); diff {
+		t.Errorf("IsIsomorphic %s", mess)
+	}
+	if mess, diff := diff(IsIsomorphic(New(1), New(2)), false); diff {
+		t.Errorf("IsIsomorphic %s", mess)
+	}
+
+	// An undirected 5-cycle has 10 automorphisms, a directed one 5.
+	g, h := New(5), New(5)
+	for v := 0; v < 5; v++ {
+		g.AddBoth(v, (v+1)%5)
+		h.Add(v, (v+1)%5)
+	}
+	if mess, diff := diff(countMaps(Isomorphisms(g, g, nil, nil)), 10); diff {
+		t.Errorf("Isomorphisms %s", mess)
+	}
+	if mess, diff := diff(countMaps(Isomorphisms(h, h, nil, nil)), 5); diff {
+		t.Errorf("Isomorphisms %s", mess)
+	}
+	if mess, diff := diff(IsIsomorphic(g, h), false); diff {
+		t.Errorf("IsIsomorphic %s", mess)
+	}
+
+	// Reverse the direction of the directed cycle.
+	r := New(5)
+	for v := 0; v < 5; v++ {
+		r.Add((v+1)%5, v)
+	}
+	if mess, diff := diff(IsIsomorphic(h, r), true); diff {
+		t.Errorf("IsIsomorphic %s", mess)
+	}
+	for m := range Isomorphisms(h, r, nil, nil) {
+		for v := 0; v < 5; v++ {
+			if !r.Edge(m[v], m[(v+1)%5]) {
+				t.Errorf("Isomorphisms: %v isn't an isomorphism", m)
+			}
+		}
+	}
+
+	// Vertex and edge predicates.
+	h.AddCost(0, 1, 7)
+	vertexMatch := func(v, w int) bool { return v == 0 || w != 0 }
+	if mess, diff := diff(countMaps(Isomorphisms(h, h, vertexMatch, nil)), 1); diff {
+		t.Errorf("Isomorphisms %s", mess)
+	}
+	edgeMatch := func(e, f Edge) bool { return e.Cost == f.Cost }
+	if mess, diff := diff(countMaps(Isomorphisms(h, h, nil, edgeMatch)), 1); diff {
+		t.Errorf("Isomorphisms %s", mess)
+	}
+}
+
+func TestSubgraphIsomorphisms(t *testing.T) {
+	k4 := New(4)
+	for v := 0; v < 4; v++ {
+		for w := v + 1; w < 4; w++ {
+			k4.AddBoth(v, w)
+		}
+	}
+	triangle, path := New(3), New(3)
+	triangle.AddBoth(0, 1)
+	triangle.AddBoth(1, 2)
+	triangle.AddBoth(2, 0)
+	path.AddBoth(0, 1)
+	path.AddBoth(1, 2)
+	if mess, diff := diff(countMaps(SubgraphIsomorphisms(triangle, k4, nil, nil)), 24); diff {
+		t.Errorf("SubgraphIsomorphisms %s", mess)
+	}
+	if mess, diff := diff(countMaps(SubgraphIsomorphisms(path, k4, nil, nil)), 0); diff {
+		t.Errorf("SubgraphIsomorphisms %s", mess)
+	}
+	if mess, diff := diff(countMaps(SubgraphMonomorphisms(path, k4, nil, nil)), 24); diff {
+		t.Errorf("SubgraphMonomorphisms %s", mess)
+	}
+	if mess, diff := diff(countMaps(SubgraphMonomorphisms(k4, path, nil, nil)), 0); diff {
+		t.Errorf("SubgraphMonomorphisms %s", mess)
+	}
+	if mess, diff := diff(countMaps(SubgraphIsomorphisms(New(0), k4, nil, nil)), 1); diff {
+		t.Errorf("SubgraphIsomorphisms %s", mess)
+	}
+
+	// Stop early.
+	n := 0
+	for range SubgraphMonomorphisms(path, k4, nil, nil) {
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	if mess, diff := diff(n, 3); diff {
+		t.Errorf("SubgraphMonomorphisms %s", mess)
+	}
+}
+
+func TestIsomorphismsRandom(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		n1, n2 := rand.Intn(5), rand.Intn(6)
+		g, h := New(n1), New(n2)
+		for m := rand.Intn(2*n1 + 1); m > 0; m-- {
+			g.AddCost(rand.Intn(n1), rand.Intn(n1), rand.Int63n(2))
+		}
+		for m := rand.Intn(3*n2 + 1); m > 0; m-- {
+			h.AddCost(rand.Intn(n2), rand.Intn(n2), rand.Int63n(2))
+		}
+		edgeMatch := func(e, f Edge) bool { return e.Cost == f.Cost }
+		for mode, seq := range []func(Iterator, Iterator, func(v, w int) bool, func(e, f Edge) bool) iter.Seq[[]int]{
+			Isomorphisms, SubgraphIsomorphisms, SubgraphMonomorphisms,
+		} {
+			if mess, diff := diff(countMaps(seq(g, h, nil, nil)), bruteIsomorphisms(g, h, mode, false)); diff {
+				t.Errorf("mode %d: %s", mode, mess)
+			}
+			if mess, diff := diff(countMaps(seq(g, h, nil, edgeMatch)), bruteIsomorphisms(g, h, mode, true)); diff {
+				t.Errorf("mode %d with costs: %s", mode, mess)
+			}
+		}
+
+		// A random permutation of a graph is isomorphic to it.
+		perm := rand.Perm(n2)
+		p := New(n2)
+		for v := 0; v < n2; v++ {
+			h.Visit(v, func(w int, c int64) (skip bool) {
+				p.AddCost(perm[v], perm[w], c)
+				return
+			})
+		}
+		if !IsIsomorphic(h, p) {
+			t.Errorf("IsIsomorphic(%v, %v) false", h, p)
+		}
+	}
+}
+
+// bruteIsomorphisms counts the maps from g to h by trying all
+// injective maps, where mode is isomorphic, induced or monomorphic.
+func bruteIsomorphisms(g, h *Mutable, mode int, costs bool) int {
+	n1, n2 := g.Order(), h.Order()
+	if mode == isomorphic && n1 != n2 {
+		return 0
+	}
+	m := make([]int, n1)
+	used := make([]bool, n2)
+	ok := func() bool {
+		for v := 0; v < n1; v++ {
+			for w := 0; w < n1; w++ {
+				e, f := g.Edge(v, w), h.Edge(m[v], m[w])
+				if e && !f || e != f && mode != monomorphic {
+					return false
+				}
+				if e && costs && g.Cost(v, w) != h.Cost(m[v], m[w]) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	var try func(v int) int
+	try = func(v int) int {
+		if v == n1 {
+			if ok() {
+				return 1
+			}
+			return 0
+		}
+		res := 0
+		for w := 0; w < n2; w++ {
+			if !used[w] {
+				used[w], m[v] = true, w
+				res += try(v + 1)
+				used[w] = false
+			}
+		}
+		return res
+	}
+	return try(0)
+}
+
+func BenchmarkSubgraphIsomorphisms(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 5*n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	triangle := New(3)
+	triangle.AddBoth(0, 1)
+	triangle.AddBoth(1, 2)
+	triangle.AddBoth(2, 0)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = countMaps(SubgraphIsomorphisms(triangle, g, nil, nil))
+	}
+}
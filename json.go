@@ -0,0 +1,143 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// The node-link JSON format used by D3, Cytoscape.js and NetworkX:
+//
+//	{
+//	  "directed": true,
+//	  "nodes": [{"id": 0}, {"id": 1}],
+//	  "links": [{"source": 0, "target": 1, "weight": 5}]
+//	}
+//
+// The weight is omitted for edges with zero cost.
+type nodeLink struct {
+	Directed   bool   `json:"directed"`
+	Multigraph bool   `json:"multigraph"`
+	Nodes      []node `json:"nodes"`
+	Links      []link `json:"links"`
+}
+
+type node struct {
+	ID json.RawMessage `json:"id"`
+}
+
+type link struct {
+	Source json.RawMessage `json:"source"`
+	Target json.RawMessage `json:"target"`
+	Weight int64           `json:"weight,omitempty"`
+}
+
+// MarshalJSON returns a node-link JSON representation of g,
+// with the vertices numbered from 0 to n-1 as node IDs.
+func (g *Mutable) MarshalJSON() ([]byte, error) {
+	return marshalNodeLink(g, false)
+}
+
+// UnmarshalJSON decodes a node-link JSON representation,
+// replacing the contents of g; see Immutable.UnmarshalJSON.
+// If there are several links between the same two nodes,
+// only the last one is kept.
+func (g *Mutable) UnmarshalJSON(data []byte) error {
+	n, edges, err := unmarshalNodeLink(data)
+	if err != nil {
+		return err
+	}
+	h := New(n)
+	h.SetSorted(g.Sorted())
+	for _, e := range edges {
+		h.AddCost(e.V, e.W, e.Cost)
+	}
+	*g = *h
+	return nil
+}
+
+// MarshalJSON returns a node-link JSON representation of g,
+// with the vertices numbered from 0 to n-1 as node IDs.
+func (g *Immutable) MarshalJSON() ([]byte, error) {
+	return marshalNodeLink(g, g.stats.Multi > 0)
+}
+
+// UnmarshalJSON decodes a node-link JSON representation,
+// replacing the contents of g.
+// The vertices are numbered in the order of the nodes. The source and
+// target of a link are node IDs or, if there is no node with the given
+// ID, node indices. If the graph isn't directed, each link gives
+// edges in both directions. The cost of an edge is its integer weight.
+func (g *Immutable) UnmarshalJSON(data []byte) error {
+	n, edges, err := unmarshalNodeLink(data)
+	if err != nil {
+		return err
+	}
+	*g = *FromEdges(n, edges)
+	return nil
+}
+
+func marshalNodeLink(g Iterator, multi bool) ([]byte, error) {
+	h := Sort(g)
+	n := h.Order()
+	nl := nodeLink{Directed: true, Multigraph: multi, Nodes: make([]node, n), Links: []link{}}
+	id := func(v int) json.RawMessage {
+		b, _ := json.Marshal(v)
+		return b
+	}
+	for v := range nl.Nodes {
+		nl.Nodes[v].ID = id(v)
+	}
+	for v := 0; v < n; v++ {
+		h.Visit(v, func(w int, c int64) (skip bool) {
+			nl.Links = append(nl.Links, link{id(v), id(w), c})
+			return
+		})
+	}
+	return json.Marshal(nl)
+}
+
+var errNodeLink = errors.New("graph: invalid node-link JSON")
+
+func unmarshalNodeLink(data []byte) (n int, edges []Edge, err error) {
+	nl := nodeLink{Directed: true}
+	if err = json.Unmarshal(data, &nl); err != nil {
+		return
+	}
+	n = len(nl.Nodes)
+	index := make(map[string]int, n)
+	for v, node := range nl.Nodes {
+		index[compact(node.ID)] = v
+	}
+	vertex := func(id json.RawMessage) (int, bool) {
+		if v, ok := index[compact(id)]; ok {
+			return v, true
+		}
+		var v int
+		if json.Unmarshal(id, &v) != nil || v < 0 || v >= n {
+			return 0, false
+		}
+		return v, true
+	}
+	edges = []Edge{}
+	for _, l := range nl.Links {
+		v, ok1 := vertex(l.Source)
+		w, ok2 := vertex(l.Target)
+		if !ok1 || !ok2 {
+			return 0, nil, errNodeLink
+		}
+		edges = append(edges, Edge{v, w, l.Weight})
+		if !nl.Directed && v != w {
+			edges = append(edges, Edge{w, v, l.Weight})
+		}
+	}
+	return
+}
+
+func compact(b json.RawMessage) string {
+	var buf bytes.Buffer
+	if json.Compact(&buf, b) != nil {
+		return string(b)
+	}
+	return buf.String()
+}
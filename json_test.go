@@ -0,0 +1,96 @@
+package graph
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	g := New(3)
+	g.AddCost(0, 1, 5)
+	g.Add(1, 2)
+	b, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	exp := `{"directed":true,"multigraph":false,"nodes":[{"id":0},{"id":1},{"id":2}],` +
+		`"links":[{"source":0,"target":1,"weight":5},{"source":1,"target":2}]}`
+	if mess, diff := diff(string(b), exp); diff {
+		t.Errorf("Marshal %s", mess)
+	}
+
+	b, err = json.Marshal(FromEdges(1, []Edge{{0, 0, 1}, {0, 0, 1}}))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	exp = `{"directed":true,"multigraph":true,"nodes":[{"id":0}],` +
+		`"links":[{"source":0,"target":0,"weight":1},{"source":0,"target":0,"weight":1}]}`
+	if mess, diff := diff(string(b), exp); diff {
+		t.Errorf("Marshal %s", mess)
+	}
+
+	b, _ = json.Marshal(New(0))
+	if mess, diff := diff(string(b), `{"directed":true,"multigraph":false,"nodes":[],"links":[]}`); diff {
+		t.Errorf("Marshal %s", mess)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	// Node IDs as in NetworkX, and node indices as in D3.
+	in := `{
+		"directed": false,
+		"nodes": [{"id": "a", "group": 1}, {"id": "b"}, {"id": 7}],
+		"links": [
+			{"source": "a", "target": "b", "weight": 3},
+			{"source": 1, "target": 7, "value": 1},
+			{"source": 2, "target": 2}
+		]
+	}`
+	g := new(Mutable)
+	if err := json.Unmarshal([]byte(in), g); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if mess, diff := diff(g.String(), "3 [{0 1}:3 {1 2} (2 2)]"); diff {
+		t.Errorf("Unmarshal %s", mess)
+	}
+
+	in = `{"nodes": [{"id": 0}, {"id": 1}], "links": [{"source": 0, "target": 1}, {"source": 0, "target": 1}]}`
+	h := new(Immutable)
+	if err := json.Unmarshal([]byte(in), h); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if mess, diff := diff(h.String(), "2 [2×(0 1)]"); diff {
+		t.Errorf("Unmarshal %s", mess)
+	}
+
+	for _, bad := range []string{
+		`[]`,
+		`{"nodes": [{"id": 0}], "links": [{"source": 0, "target": 1}]}`,
+		`{"nodes": [{"id": 0}], "links": [{"source": "x", "target": 0}]}`,
+		`{"nodes": [], "links": [{"source": 0, "target": 0, "weight": 1.5}]}`,
+	} {
+		if err := json.Unmarshal([]byte(bad), new(Mutable)); err == nil {
+			t.Errorf("Unmarshal(%s): no error", bad)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	n := 20
+	g := New(n)
+	for i := 0; i < 3*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(5)-2)
+	}
+	b, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	h := new(Mutable)
+	if err := json.Unmarshal(b, h); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if mess, diff := diff(h.String(), g.String()); diff {
+		t.Errorf("RoundTrip %s", mess)
+	}
+}
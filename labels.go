@@ -0,0 +1,139 @@
+package graph
+
+import "strconv"
+
+// Labels associates values of type T with vertices.
+// It can also be used to label the edges of a Multigraph by edge ID.
+// The zero value is an empty set of labels ready to use.
+//
+// Values are kept in a slice indexed by vertex, which grows as needed,
+// so the storage is compact for graphs where most vertices are labeled.
+type Labels[T any] struct {
+	values []T
+	has    []bool
+	n      int
+}
+
+// NewLabels returns an empty set of labels for vertices 0 to n-1.
+// The labels grow to accommodate larger vertices.
+func NewLabels[T any](n int) *Labels[T] {
+	return &Labels[T]{values: make([]T, n), has: make([]bool, n)}
+}
+
+// Len returns the number of labeled vertices.
+func (l *Labels[T]) Len() int {
+	return l.n
+}
+
+// Get returns the label of v. If v has no label, it returns
+// the zero value and ok is false.
+func (l *Labels[T]) Get(v int) (x T, ok bool) {
+	if v < 0 || v >= len(l.values) || !l.has[v] {
+		return
+	}
+	return l.values[v], true
+}
+
+// Set sets the label of v to x.
+func (l *Labels[T]) Set(v int, x T) {
+	if v < 0 {
+		panic("vertex out of range: " + strconv.Itoa(v))
+	}
+	if v >= len(l.values) {
+		n := 2 * len(l.values)
+		if n <= v {
+			n = v + 1
+		}
+		values := make([]T, n)
+		copy(values, l.values)
+		has := make([]bool, n)
+		copy(has, l.has)
+		l.values, l.has = values, has
+	}
+	if !l.has[v] {
+		l.has[v] = true
+		l.n++
+	}
+	l.values[v] = x
+}
+
+// Delete removes the label of v.
+func (l *Labels[T]) Delete(v int) {
+	if v < 0 || v >= len(l.values) || !l.has[v] {
+		return
+	}
+	var zero T
+	l.values[v], l.has[v] = zero, false
+	l.n--
+}
+
+// Visit calls the do function for each labeled vertex v in increasing
+// numerical order, with x equal to the label of v.
+// If do returns true, Visit returns immediately,
+// skipping any remaining vertices, and returns true.
+func (l *Labels[T]) Visit(do func(v int, x T) bool) bool {
+	for v, ok := range l.has {
+		if ok && do(v, l.values[v]) {
+			return true
+		}
+	}
+	return false
+}
+
+// EdgeLabels associates values of type T with edges,
+// identified by their endpoints v and w.
+// The zero value is an empty set of labels ready to use.
+type EdgeLabels[T any] struct {
+	m map[edgeKey]T
+}
+
+type edgeKey struct{ v, w int }
+
+// NewEdgeLabels returns an empty set of edge labels.
+func NewEdgeLabels[T any]() *EdgeLabels[T] {
+	return &EdgeLabels[T]{m: make(map[edgeKey]T)}
+}
+
+// Len returns the number of labeled edges.
+func (l *EdgeLabels[T]) Len() int {
+	return len(l.m)
+}
+
+// Get returns the label of the edge from v to w. If the edge has no label,
+// it returns the zero value and ok is false.
+func (l *EdgeLabels[T]) Get(v, w int) (x T, ok bool) {
+	x, ok = l.m[edgeKey{v, w}]
+	return
+}
+
+// Set sets the label of the edge from v to w to x.
+func (l *EdgeLabels[T]) Set(v, w int, x T) {
+	if l.m == nil {
+		l.m = make(map[edgeKey]T)
+	}
+	l.m[edgeKey{v, w}] = x
+}
+
+// SetBoth sets the labels of the edges from v to w and from w to v to x.
+func (l *EdgeLabels[T]) SetBoth(v, w int, x T) {
+	l.Set(v, w, x)
+	l.Set(w, v, x)
+}
+
+// Delete removes the label of the edge from v to w.
+func (l *EdgeLabels[T]) Delete(v, w int) {
+	delete(l.m, edgeKey{v, w})
+}
+
+// Visit calls the do function for each labeled edge (v, w),
+// with x equal to its label. The iteration order is not specified.
+// If do returns true, Visit returns immediately,
+// skipping any remaining edges, and returns true.
+func (l *EdgeLabels[T]) Visit(do func(v, w int, x T) bool) bool {
+	for e, x := range l.m {
+		if do(e.v, e.w, x) {
+			return true
+		}
+	}
+	return false
+}
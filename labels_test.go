@@ -0,0 +1,95 @@
+package graph
+
+import "testing"
+
+func TestLabels(t *testing.T) {
+	var l Labels[string]
+	if _, ok := l.Get(0); ok {
+		t.Errorf("Get(0) on zero value ok")
+	}
+	l.Set(3, "c")
+	l.Set(0, "a")
+	l.Set(3, "d")
+	if mess, diff := diff(l.Len(), 2); diff {
+		t.Errorf("Len %s", mess)
+	}
+	for _, e := range []struct {
+		v  int
+		x  string
+		ok bool
+	}{
+		{-1, "", false},
+		{0, "a", true},
+		{1, "", false},
+		{3, "d", true},
+		{4, "", false},
+	} {
+		x, ok := l.Get(e.v)
+		if x != e.x || ok != e.ok {
+			t.Errorf("Get(%d) (%q, %t); want (%q, %t)", e.v, x, ok, e.x, e.ok)
+		}
+	}
+	var visited []int
+	l.Visit(func(v int, x string) (skip bool) {
+		visited = append(visited, v)
+		return
+	})
+	if mess, diff := diff(visited, []int{0, 3}); diff {
+		t.Errorf("Visit %s", mess)
+	}
+	if !l.Visit(func(int, string) bool { return true }) {
+		t.Errorf("Visit: abort returned false")
+	}
+	l.Delete(0)
+	l.Delete(1)
+	l.Delete(10)
+	if mess, diff := diff(l.Len(), 1); diff {
+		t.Errorf("Delete: Len %s", mess)
+	}
+	if _, ok := l.Get(0); ok {
+		t.Errorf("Get(0) ok after delete")
+	}
+
+	p := NewLabels[[2]float64](2)
+	p.Set(1, [2]float64{1.5, 2})
+	if x, _ := p.Get(1); x != [2]float64{1.5, 2} {
+		t.Errorf("Get(1) %v", x)
+	}
+}
+
+func TestEdgeLabels(t *testing.T) {
+	var l EdgeLabels[int64]
+	l.Set(0, 1, 10)
+	m := NewEdgeLabels[int64]()
+	m.SetBoth(1, 2, 5)
+	m.Set(1, 2, 6)
+	for _, e := range []struct {
+		l    *EdgeLabels[int64]
+		v, w int
+		x    int64
+		ok   bool
+	}{
+		{&l, 0, 1, 10, true},
+		{&l, 1, 0, 0, false},
+		{m, 1, 2, 6, true},
+		{m, 2, 1, 5, true},
+		{m, 0, 1, 0, false},
+	} {
+		x, ok := e.l.Get(e.v, e.w)
+		if x != e.x || ok != e.ok {
+			t.Errorf("Get(%d, %d) (%d, %t); want (%d, %t)", e.v, e.w, x, ok, e.x, e.ok)
+		}
+	}
+	sum := int64(0)
+	m.Visit(func(v, w int, x int64) (skip bool) {
+		sum += x
+		return
+	})
+	if mess, diff := diff(sum, int64(11)); diff {
+		t.Errorf("Visit %s", mess)
+	}
+	m.Delete(1, 2)
+	if mess, diff := diff(m.Len(), 1); diff {
+		t.Errorf("Delete: Len %s", mess)
+	}
+}
@@ -0,0 +1,134 @@
+package graph
+
+import (
+	"math/bits"
+	"strconv"
+)
+
+// LCA answers lowest common ancestor queries in a forest given by
+// parent pointers, such as the ones returned by ShortestPaths.
+// The lowest common ancestor of v and w is the vertex farthest from
+// the root that is an ancestor of both; a vertex is its own ancestor.
+//
+// The implementation stores an Euler tour of the forest in a sparse table.
+// It uses O(|V|⋅log|V|) space, and answers queries in constant time.
+type LCA struct {
+	depth []int
+	root  []int   // root[v] is the root of the tree of v
+	first []int   // first[v] is the first position of v in the tour
+	table [][]int // table[k][i] is a least deep vertex in tour[i:i+2ᵏ]
+}
+
+// NewLCA returns an LCA for the forest in which parent[v] is the
+// parent of v, or -1 if v is a root. It panics if the parent pointers
+// form a cycle. The slice is not retained.
+//
+// The time complexity is O(|V|⋅log|V|), where |V| is the number of vertices.
+func NewLCA(parent []int) *LCA {
+	n := len(parent)
+	l := &LCA{
+		depth: make([]int, n),
+		root:  make([]int, n),
+		first: make([]int, n),
+	}
+	// The children of v are child[start[v]:start[v+1]].
+	start := make([]int, n+1)
+	for _, p := range parent {
+		if p < -1 || p >= n {
+			panic("vertex out of range: " + strconv.Itoa(p))
+		}
+		if p != -1 {
+			start[p+1]++
+		}
+	}
+	for v := 0; v < n; v++ {
+		start[v+1] += start[v]
+	}
+	child := make([]int, start[n])
+	next := make([]int, n)
+	copy(next, start)
+	for v, p := range parent {
+		if p != -1 {
+			child[next[p]] = v
+			next[p]++
+		}
+	}
+
+	tour := make([]int, 0, 2*n)
+	seen := 0
+	for r, p := range parent {
+		if p != -1 {
+			continue
+		}
+		copy(next, start)
+		l.root[r], l.first[r] = r, len(tour)
+		tour = append(tour, r)
+		seen++
+		for stack := []int{r}; len(stack) > 0; {
+			v := stack[len(stack)-1]
+			if next[v] == start[v+1] {
+				stack = stack[:len(stack)-1]
+				if len(stack) > 0 {
+					tour = append(tour, stack[len(stack)-1])
+				}
+				continue
+			}
+			w := child[next[v]]
+			next[v]++
+			l.depth[w], l.root[w], l.first[w] = l.depth[v]+1, r, len(tour)
+			tour = append(tour, w)
+			seen++
+			stack = append(stack, w)
+		}
+	}
+	if seen < n {
+		panic("parent pointers form a cycle")
+	}
+
+	l.table = [][]int{tour}
+	for k := 1; 1<<uint(k) <= len(tour); k++ {
+		prev, half := l.table[k-1], 1<<uint(k-1)
+		row := make([]int, len(tour)-1<<uint(k)+1)
+		for i := range row {
+			row[i] = l.shallower(prev[i], prev[i+half])
+		}
+		l.table = append(l.table, row)
+	}
+	return l
+}
+
+func (l *LCA) shallower(v, w int) int {
+	if l.depth[w] < l.depth[v] {
+		return w
+	}
+	return v
+}
+
+// Ancestor returns the lowest common ancestor of v and w,
+// or -1 if they belong to different trees.
+func (l *LCA) Ancestor(v, w int) int {
+	if l.root[v] != l.root[w] {
+		return -1
+	}
+	i, j := l.first[v], l.first[w]
+	if i > j {
+		i, j = j, i
+	}
+	k := bits.Len(uint(j-i+1)) - 1
+	return l.shallower(l.table[k][i], l.table[k][j-1<<uint(k)+1])
+}
+
+// Depth returns the number of edges from the root of the tree of v to v.
+func (l *LCA) Depth(v int) int {
+	return l.depth[v]
+}
+
+// Dist returns the number of edges on the path between v and w,
+// or -1 if they belong to different trees.
+func (l *LCA) Dist(v, w int) int {
+	a := l.Ancestor(v, w)
+	if a == -1 {
+		return -1
+	}
+	return l.depth[v] + l.depth[w] - 2*l.depth[a]
+}
@@ -0,0 +1,96 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// bruteAncestor finds the lowest common ancestor of v and w
+// by following the parent pointers.
+func bruteAncestor(parent []int, v, w int) int {
+	seen := make(map[int]bool)
+	for x := v; x != -1; x = parent[x] {
+		seen[x] = true
+	}
+	for x := w; x != -1; x = parent[x] {
+		if seen[x] {
+			return x
+		}
+	}
+	return -1
+}
+
+func TestLCA(t *testing.T) {
+	//     0       5
+	//    / \      |
+	//   1   2     6
+	//  / \
+	// 3   4
+	parent := []int{-1, 0, 0, 1, 1, -1, 5}
+	l := NewLCA(parent)
+	for _, q := range []struct{ v, w, a, dist int }{
+		{3, 4, 1, 2},
+		{3, 2, 0, 3},
+		{1, 3, 1, 1},
+		{0, 0, 0, 0},
+		{4, 4, 4, 0},
+		{3, 6, -1, -1},
+		{6, 5, 5, 1},
+	} {
+		if mess, diff := diff(l.Ancestor(q.v, q.w), q.a); diff {
+			t.Errorf("Ancestor(%d, %d) %s", q.v, q.w, mess)
+		}
+		if mess, diff := diff(l.Dist(q.v, q.w), q.dist); diff {
+			t.Errorf("Dist(%d, %d) %s", q.v, q.w, mess)
+		}
+	}
+	if mess, diff := diff(l.Depth(4), 2); diff {
+		t.Errorf("Depth %s", mess)
+	}
+	NewLCA([]int{})
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewLCA with a cycle should panic")
+		}
+	}()
+	NewLCA([]int{-1, 2, 1})
+}
+
+func TestLCARandom(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		n := 1 + rand.Intn(50)
+		parent := make([]int, n)
+		perm := rand.Perm(n)
+		for i, v := range perm {
+			parent[v] = -1
+			if i > 0 && rand.Intn(10) != 0 {
+				parent[v] = perm[rand.Intn(i)]
+			}
+		}
+		l := NewLCA(parent)
+		for j := 0; j < 50; j++ {
+			v, w := rand.Intn(n), rand.Intn(n)
+			if mess, diff := diff(l.Ancestor(v, w), bruteAncestor(parent, v, w)); diff {
+				t.Errorf("Ancestor(%d, %d) in %v %s", v, w, parent, mess)
+			}
+		}
+	}
+}
+
+func BenchmarkLCA(b *testing.B) {
+	n := 10000
+	b.StopTimer()
+	parent := make([]int, n)
+	parent[0] = -1
+	for v := 1; v < n; v++ {
+		parent[v] = rand.Intn(v)
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		l := NewLCA(parent)
+		for v := 1; v < n; v++ {
+			l.Ancestor(v-1, v)
+		}
+	}
+}
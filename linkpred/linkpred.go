@@ -0,0 +1,255 @@
+// Package linkpred scores pairs of vertices by how likely they are to be
+// connected, based on the neighbors they have in common.
+//
+// The scores are computed by an Index, a compressed sparse row copy of
+// an undirected graph with sorted neighbor lists, which lets the common
+// neighbors of two vertices be found by merging their lists.
+// The Index can score given pairs of vertices, or find the top k pairs
+// of non-adjacent vertices, the most likely new edges.
+package linkpred
+
+import (
+	"container/heap"
+	"github.com/yourbasic/graph"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Measure is a similarity measure of two vertices v and w,
+// based on their sets of neighbors N(v) and N(w).
+type Measure int
+
+const (
+	// CommonNeighbors is the number of common neighbors, |N(v) ∩ N(w)|.
+	CommonNeighbors Measure = iota
+
+	// Jaccard is the Jaccard coefficient |N(v) ∩ N(w)| / |N(v) ∪ N(w)|,
+	// or 0 if v and w have no neighbors.
+	Jaccard
+
+	// AdamicAdar is the Adamic–Adar index, the sum of 1/log|N(u)|
+	// over all common neighbors u, which gives more weight to
+	// neighbors with few neighbors of their own.
+	AdamicAdar
+)
+
+// Index holds the neighbors of each vertex in a graph,
+// in compressed sparse row format.
+type Index struct {
+	offset []int // the neighbors of v are adj[offset[v]:offset[v+1]]
+	adj    []int
+}
+
+// New returns an index of g, where the neighbors of v are the vertices
+// connected to v by an edge in either direction. Self-loops and
+// duplicate edges are ignored. The index does not change if g does.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in g.
+func New(g graph.Iterator) *Index {
+	n := g.Order()
+	both := make([][]int, n)
+	for v := range both {
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			if v != w {
+				both[v] = append(both[v], w)
+				both[w] = append(both[w], v)
+			}
+			return
+		})
+	}
+	// Appending v to the list of each neighbor, for v in increasing
+	// order, gives sorted lists; duplicates end up next to each other.
+	lists := make([][]int, n)
+	for v, list := range both {
+		for _, w := range list {
+			if l := len(lists[w]); l == 0 || lists[w][l-1] != v {
+				lists[w] = append(lists[w], v)
+			}
+		}
+	}
+	x := &Index{offset: make([]int, n+1)}
+	for v, list := range lists {
+		x.offset[v+1] = x.offset[v] + len(list)
+	}
+	x.adj = make([]int, 0, x.offset[n])
+	for _, list := range lists {
+		x.adj = append(x.adj, list...)
+	}
+	return x
+}
+
+// Order returns the number of vertices.
+func (x *Index) Order() int {
+	return len(x.offset) - 1
+}
+
+// Degree returns the number of neighbors of v.
+func (x *Index) Degree(v int) int {
+	return x.offset[v+1] - x.offset[v]
+}
+
+// Neighbors returns the neighbors of v in increasing order.
+// The slice is shared with the index and must not be modified.
+func (x *Index) Neighbors(v int) []int {
+	return x.adj[x.offset[v]:x.offset[v+1]:x.offset[v+1]]
+}
+
+// Score returns the similarity of v and w according to the measure m.
+//
+// The time complexity is O(d), where d is the sum of the degrees of v and w.
+func (x *Index) Score(m Measure, v, w int) float64 {
+	x.check(v)
+	x.check(w)
+	a, b := x.Neighbors(v), x.Neighbors(w)
+	common := 0
+	sum := 0.0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			common++
+			if m == AdamicAdar {
+				sum += x.weight(a[i])
+			}
+			i++
+			j++
+		}
+	}
+	return x.score(m, v, w, common, sum)
+}
+
+// Scores returns the similarity of each pair of vertices according to
+// the measure m: res[i] is the score of pairs[i][0] and pairs[i][1].
+func (x *Index) Scores(m Measure, pairs [][2]int) []float64 {
+	res := make([]float64, len(pairs))
+	for i, p := range pairs {
+		res[i] = x.Score(m, p[0], p[1])
+	}
+	return res
+}
+
+func (x *Index) check(v int) {
+	if v < 0 || v >= x.Order() {
+		panic("vertex out of range: " + strconv.Itoa(v))
+	}
+}
+
+// weight returns the Adamic–Adar weight of a common neighbor u,
+// which has at least two neighbors unless v = w.
+func (x *Index) weight(u int) float64 {
+	if d := x.Degree(u); d > 1 {
+		return 1 / math.Log(float64(d))
+	}
+	return 0
+}
+
+// score returns the score of v and w, given their number of common
+// neighbors and the Adamic–Adar sum.
+func (x *Index) score(m Measure, v, w, common int, sum float64) float64 {
+	switch m {
+	case CommonNeighbors:
+		return float64(common)
+	case Jaccard:
+		if union := x.Degree(v) + x.Degree(w) - common; union > 0 {
+			return float64(common) / float64(union)
+		}
+		return 0
+	case AdamicAdar:
+		return sum
+	}
+	panic("unknown measure: " + strconv.Itoa(int(m)))
+}
+
+// A Candidate is a pair of vertices V < W with a score.
+type Candidate struct {
+	V, W  int
+	Score float64
+}
+
+// TopK returns the k pairs of distinct, non-adjacent vertices with
+// the highest scores according to the measure m, in order of decreasing
+// score, with ties broken by V and then by W. Only pairs with at least
+// one common neighbor are considered, since the other pairs score 0;
+// fewer than k pairs are returned if there aren't enough of them.
+//
+// The time complexity is O(Σ d(u)² + |V| + p⋅log k), where the sum
+// is over all vertices u, d(u) is the degree of u, |V| is the number
+// of vertices, and p ≤ Σ d(u)² is the number of pairs considered.
+func (x *Index) TopK(m Measure, k int) []Candidate {
+	if k <= 0 {
+		return []Candidate{}
+	}
+	n := x.Order()
+	h := make(minHeap, 0, k)
+	common := make([]int, n)
+	sum := make([]float64, n)
+	mark := make([]int, n) // mark[w] is v+1 if w is v or a neighbor of v
+	var touched []int
+	for v := 0; v < n; v++ {
+		mark[v] = v + 1
+		for _, u := range x.Neighbors(v) {
+			mark[u] = v + 1
+		}
+		// Count the common neighbors of v and each w > v.
+		touched = touched[:0]
+		for _, u := range x.Neighbors(v) {
+			for _, w := range x.Neighbors(u) {
+				if w <= v || mark[w] == v+1 {
+					continue
+				}
+				if common[w] == 0 {
+					touched = append(touched, w)
+				}
+				common[w]++
+				if m == AdamicAdar {
+					sum[w] += x.weight(u)
+				}
+			}
+		}
+		for _, w := range touched {
+			c := Candidate{v, w, x.score(m, v, w, common[w], sum[w])}
+			common[w], sum[w] = 0, 0
+			switch {
+			case len(h) < k:
+				heap.Push(&h, c)
+			case before(c, h[0]):
+				h[0] = c
+				heap.Fix(&h, 0)
+			}
+		}
+	}
+	res := []Candidate(h)
+	sort.Slice(res, func(i, j int) bool { return before(res[i], res[j]) })
+	return res
+}
+
+// before tells if a comes before b in the order of TopK.
+func before(a, b Candidate) bool {
+	switch {
+	case a.Score != b.Score:
+		return a.Score > b.Score
+	case a.V != b.V:
+		return a.V < b.V
+	}
+	return a.W < b.W
+}
+
+// minHeap holds the best candidates so far, with the worst one on top.
+type minHeap []Candidate
+
+func (h minHeap) Len() int            { return len(h) }
+func (h minHeap) Less(i, j int) bool  { return before(h[j], h[i]) }
+func (h minHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *minHeap) Push(x interface{}) { *h = append(*h, x.(Candidate)) }
+func (h *minHeap) Pop() interface{} {
+	old := *h
+	n := len(old) - 1
+	x := old[n]
+	*h = old[:n]
+	return x
+}
@@ -0,0 +1,136 @@
+package linkpred
+
+import (
+	"fmt"
+	"github.com/yourbasic/graph"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func diff(res, exp interface{}) (message string, diff bool) {
+	if !reflect.DeepEqual(res, exp) {
+		message = fmt.Sprintf("%v; want %v", res, exp)
+		diff = true
+	}
+	return
+}
+
+// example returns the graph with edges 0-1, 0-2, 1-2, 1-3, 2-3, 3-4,
+// where 0 and 3 have the common neighbors 1 and 2.
+func example() *graph.Mutable {
+	g := graph.New(5)
+	for _, e := range [][2]int{{0, 1}, {0, 2}, {1, 2}, {1, 3}, {2, 3}, {3, 4}} {
+		g.AddBoth(e[0], e[1])
+	}
+	return g
+}
+
+func TestIndex(t *testing.T) {
+	g := graph.New(4)
+	g.Add(2, 0)
+	g.Add(0, 2)
+	g.Add(0, 3)
+	g.Add(1, 1)
+	x := New(g)
+	if mess, diff := diff(x.Order(), 4); diff {
+		t.Errorf("Order %s", mess)
+	}
+	for v, exp := range [][]int{{2, 3}, {}, {0}, {0}} {
+		if mess, diff := diff(x.Neighbors(v), exp); diff {
+			t.Errorf("Neighbors(%d) %s", v, mess)
+		}
+		if mess, diff := diff(x.Degree(v), len(exp)); diff {
+			t.Errorf("Degree(%d) %s", v, mess)
+		}
+	}
+}
+
+func TestScore(t *testing.T) {
+	x := New(example())
+	if mess, diff := diff(x.Score(CommonNeighbors, 0, 3), 2.0); diff {
+		t.Errorf("CommonNeighbors %s", mess)
+	}
+	// N(0) = {1, 2}, N(3) = {1, 2, 4}.
+	if mess, diff := diff(x.Score(Jaccard, 0, 3), 2.0/3); diff {
+		t.Errorf("Jaccard %s", mess)
+	}
+	// Both 1 and 2 have 3 neighbors.
+	if res, exp := x.Score(AdamicAdar, 0, 3), 2/math.Log(3); math.Abs(res-exp) > 1e-12 {
+		t.Errorf("AdamicAdar %v; want %v", res, exp)
+	}
+	if mess, diff := diff(x.Scores(CommonNeighbors, [][2]int{{0, 4}, {1, 2}, {4, 1}}), []float64{0, 2, 1}); diff {
+		t.Errorf("Scores %s", mess)
+	}
+	if mess, diff := diff(New(graph.New(2)).Score(Jaccard, 0, 1), 0.0); diff {
+		t.Errorf("Jaccard %s", mess)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	x := New(example())
+	exp := []Candidate{{0, 3, 2}, {1, 4, 1}, {2, 4, 1}}
+	if mess, diff := diff(x.TopK(CommonNeighbors, 10), exp); diff {
+		t.Errorf("TopK %s", mess)
+	}
+	if mess, diff := diff(x.TopK(CommonNeighbors, 2), exp[:2]); diff {
+		t.Errorf("TopK %s", mess)
+	}
+	if mess, diff := diff(x.TopK(Jaccard, 0), []Candidate{}); diff {
+		t.Errorf("TopK %s", mess)
+	}
+
+	// Compare with scoring all non-adjacent pairs.
+	r := rand.New(rand.NewSource(1))
+	g := graph.New(30)
+	for i := 0; i < 60; i++ {
+		g.AddBoth(r.Intn(30), r.Intn(30))
+	}
+	x = New(g)
+	for _, m := range []Measure{CommonNeighbors, Jaccard, AdamicAdar} {
+		var all []Candidate
+		for v := 0; v < 30; v++ {
+			for w := v + 1; w < 30; w++ {
+				if s := x.Score(m, v, w); s > 0 && !g.Edge(v, w) {
+					all = append(all, Candidate{v, w, s})
+				}
+			}
+		}
+		top := x.TopK(m, 15)
+		if len(top) != 15 {
+			t.Fatalf("TopK(%d): %d candidates", m, len(top))
+		}
+		for i, c := range top {
+			if i > 0 && before(c, top[i-1]) {
+				t.Errorf("TopK(%d): %v not sorted", m, top)
+			}
+			if math.Abs(c.Score-x.Score(m, c.V, c.W)) > 1e-12 {
+				t.Errorf("TopK(%d): %v has score %v", m, c, x.Score(m, c.V, c.W))
+			}
+		}
+		count := 0
+		for _, c := range all {
+			if c.Score > top[14].Score+1e-12 {
+				count++
+			}
+		}
+		if count > 14 {
+			t.Errorf("TopK(%d): %d pairs have higher score than %v", m, count, top[14])
+		}
+	}
+}
+
+func BenchmarkTopK(b *testing.B) {
+	n := 10000
+	g := graph.New(n)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 5*n; i++ {
+		g.AddBoth(r.Intn(n), r.Intn(n))
+	}
+	x := New(g)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.TopK(AdamicAdar, 100)
+	}
+}
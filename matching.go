@@ -0,0 +1,208 @@
+package graph
+
+// BipartiteMatching computes a maximum matching in an undirected
+// bipartite graph: a largest set of edges without common vertices.
+// Each edge in the matching goes from a vertex in the set returned by
+// Bipartition to a vertex outside of it, and the edges are sorted by
+// their first vertex. If g isn't bipartite, it returns an empty slice
+// and sets ok to false.
+//
+// The implementation uses the Hopcroft–Karp algorithm.
+// The time complexity is O(|E|⋅√|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func BipartiteMatching(g Iterator) (matching []Edge, ok bool) {
+	left, ok := Bipartition(g)
+	if !ok {
+		return []Edge{}, false
+	}
+	m := newMatcher(g, left)
+	m.run()
+	matching = []Edge{}
+	for i, u := range left {
+		if w := m.mate[u]; w != -1 {
+			for _, e := range m.adj[i] {
+				if e.vertex == w {
+					matching = append(matching, Edge{u, w, e.cost})
+					break
+				}
+			}
+		}
+	}
+	return matching, true
+}
+
+// BipartiteVertexCover computes a minimum vertex cover of an undirected
+// bipartite graph: a smallest set of vertices such that each edge
+// has at least one endpoint in the set. The vertices are sorted in
+// increasing order. By Kőnig's theorem, the cover has the same size
+// as a maximum matching. If g isn't bipartite, it returns an empty slice
+// and sets ok to false.
+//
+// The time complexity is O(|E|⋅√|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func BipartiteVertexCover(g Iterator) (cover []int, ok bool) {
+	left, ok := Bipartition(g)
+	if !ok {
+		return []int{}, false
+	}
+	m := newMatcher(g, left)
+	m.run()
+
+	// Search alternating paths from the unmatched left vertices.
+	// The cover consists of the left vertices not reached
+	// and the right vertices reached.
+	reached := make([]bool, g.Order())
+	var queue []int
+	for i, u := range left {
+		if m.mate[u] == -1 {
+			reached[u] = true
+			queue = append(queue, i)
+		}
+	}
+	for ; len(queue) > 0; queue = queue[1:] {
+		for _, e := range m.adj[queue[0]] {
+			w := e.vertex
+			if reached[w] {
+				continue
+			}
+			reached[w] = true
+			if u := m.mate[w]; u != -1 && !reached[u] {
+				reached[u] = true
+				queue = append(queue, m.index[u])
+			}
+		}
+	}
+	cover = []int{}
+	isLeft := make([]bool, g.Order())
+	for _, u := range left {
+		isLeft[u] = true
+	}
+	for v := range reached {
+		if isLeft[v] != reached[v] {
+			cover = append(cover, v)
+		}
+	}
+	return cover, true
+}
+
+// matcher holds the state of the Hopcroft–Karp algorithm.
+// The left vertices are numbered by their position in left.
+type matcher struct {
+	left  []int
+	index []int        // index[u] is the position of u in left.
+	adj   [][]neighbor // The neighbors of each left vertex.
+	mate  []int        // The vertex matched to each vertex, or -1.
+	dist  []int        // The BFS layer of each left vertex.
+	next  []int        // The next neighbor to try for each left vertex.
+}
+
+func newMatcher(g Iterator, left []int) *matcher {
+	n := g.Order()
+	m := &matcher{
+		left:  left,
+		index: make([]int, n),
+		adj:   make([][]neighbor, len(left)),
+		mate:  make([]int, n),
+		dist:  make([]int, len(left)),
+		next:  make([]int, len(left)),
+	}
+	for v := range m.mate {
+		m.mate[v] = -1
+	}
+	for i, u := range left {
+		m.index[u] = i
+		g.Visit(u, func(w int, c int64) (skip bool) {
+			m.adj[i] = append(m.adj[i], neighbor{w, c})
+			return
+		})
+	}
+	return m
+}
+
+func (m *matcher) run() {
+	for {
+		free := m.layers()
+		if free == -1 {
+			return
+		}
+		for i := range m.next {
+			m.next[i] = 0
+		}
+		for i, u := range m.left {
+			if m.mate[u] == -1 {
+				m.augment(i, free)
+			}
+		}
+	}
+}
+
+// layers computes the BFS layers of the left vertices, starting with
+// the unmatched ones at layer 0, and returns the length of a shortest
+// augmenting path, or -1 if there is none.
+func (m *matcher) layers() (free int) {
+	free = -1
+	var queue []int
+	for i, u := range m.left {
+		if m.mate[u] == -1 {
+			m.dist[i] = 0
+			queue = append(queue, i)
+		} else {
+			m.dist[i] = -1
+		}
+	}
+	for ; len(queue) > 0; queue = queue[1:] {
+		i := queue[0]
+		if free != -1 && m.dist[i] >= free {
+			break
+		}
+		for _, e := range m.adj[i] {
+			u := m.mate[e.vertex]
+			switch {
+			case u == -1:
+				if free == -1 {
+					free = m.dist[i] + 1
+				}
+			case m.dist[m.index[u]] == -1:
+				m.dist[m.index[u]] = m.dist[i] + 1
+				queue = append(queue, m.index[u])
+			}
+		}
+	}
+	return
+}
+
+// augment searches for a shortest augmenting path of length free
+// starting at the left vertex root, and flips the path if found.
+func (m *matcher) augment(root, free int) {
+	path := []int{root} // Left vertices.
+	var via []int       // via[k] is the right vertex between path[k] and path[k+1].
+	for len(path) > 0 {
+		i := path[len(path)-1]
+		if m.next[i] == len(m.adj[i]) {
+			m.dist[i] = -1 // A dead end.
+			path = path[:len(path)-1]
+			if len(via) > 0 {
+				via = via[:len(via)-1]
+			}
+			continue
+		}
+		w := m.adj[i][m.next[i]].vertex
+		m.next[i]++
+		u := m.mate[w]
+		switch {
+		case u == -1:
+			if m.dist[i]+1 != free {
+				continue
+			}
+			via = append(via, w)
+			for k, i := range path {
+				u, w := m.left[i], via[k]
+				m.mate[u], m.mate[w] = w, u
+			}
+			return
+		case m.dist[m.index[u]] == m.dist[i]+1:
+			via = append(via, w)
+			path = append(path, m.index[u])
+		}
+	}
+}
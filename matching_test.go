@@ -0,0 +1,148 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBipartiteMatching(t *testing.T) {
+	g := New(0)
+	matching, ok := BipartiteMatching(g)
+	if mess, diff := diff(matching, []Edge{}); diff {
+		t.Errorf("BipartiteMatching %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("BipartiteMatching->ok %s", mess)
+	}
+
+	// 0 - 4
+	//   X
+	// 1 - 5
+	//   /
+	// 2   6
+	//   \
+	// 3 - 7
+	g = New(8)
+	g.AddBothCost(0, 4, 1)
+	g.AddBothCost(0, 5, 2)
+	g.AddBoth(1, 4)
+	g.AddBoth(1, 5)
+	g.AddBoth(2, 5)
+	g.AddBoth(2, 7)
+	g.AddBoth(3, 7)
+	matching, ok = BipartiteMatching(Sort(g))
+	if mess, diff := diff(len(matching), 3); diff {
+		t.Errorf("BipartiteMatching %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("BipartiteMatching->ok %s", mess)
+	}
+	checkMatching(t, g, matching)
+
+	cover, ok := BipartiteVertexCover(Sort(g))
+	if mess, diff := diff(cover, []int{4, 5, 7}); diff {
+		t.Errorf("BipartiteVertexCover %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("BipartiteVertexCover->ok %s", mess)
+	}
+
+	g.AddBoth(0, 1)
+	if _, ok := Bipartition(g); ok {
+		t.Errorf("Bipartition->ok true for an odd cycle")
+	}
+	matching, ok = BipartiteMatching(g)
+	if mess, diff := diff(matching, []Edge{}); diff {
+		t.Errorf("BipartiteMatching %s", mess)
+	}
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("BipartiteMatching->ok %s", mess)
+	}
+	cover, ok = BipartiteVertexCover(g)
+	if mess, diff := diff(cover, []int{}); diff {
+		t.Errorf("BipartiteVertexCover %s", mess)
+	}
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("BipartiteVertexCover->ok %s", mess)
+	}
+}
+
+func TestBipartiteMatchingRandom(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		n := 1 + rand.Intn(20)
+		g := New(2 * n)
+		for j := 0; j < 2*n; j++ {
+			g.AddBoth(rand.Intn(n), n+rand.Intn(n))
+		}
+		matching, _ := BipartiteMatching(g)
+		checkMatching(t, g, matching)
+
+		// Compare with a maximum flow from a source connected
+		// to the left side to a sink connected to the right side.
+		left, _ := Bipartition(g)
+		isLeft := make([]bool, 2*n)
+		for _, v := range left {
+			isLeft[v] = true
+		}
+		h := New(2*n + 2)
+		for v := 0; v < 2*n; v++ {
+			if isLeft[v] {
+				h.AddCost(2*n, v, 1)
+				g.Visit(v, func(w int, _ int64) (skip bool) {
+					h.AddCost(v, w, 1)
+					return
+				})
+			} else {
+				h.AddCost(v, 2*n+1, 1)
+			}
+		}
+		flow, _ := MaxFlow(h, 2*n, 2*n+1)
+		if int64(len(matching)) != flow {
+			t.Errorf("BipartiteMatching: %d edges; want %d", len(matching), flow)
+		}
+
+		cover, _ := BipartiteVertexCover(g)
+		if len(cover) != len(matching) {
+			t.Errorf("BipartiteVertexCover: %d vertices; want %d", len(cover), len(matching))
+		}
+		in := make([]bool, 2*n)
+		for _, v := range cover {
+			in[v] = true
+		}
+		for v := 0; v < 2*n; v++ {
+			g.Visit(v, func(w int, _ int64) (skip bool) {
+				if !in[v] && !in[w] {
+					t.Errorf("BipartiteVertexCover: edge {%d %d} not covered", v, w)
+				}
+				return
+			})
+		}
+	}
+}
+
+// checkMatching checks that matching is a matching in g.
+func checkMatching(t *testing.T, g *Mutable, matching []Edge) {
+	used := make([]bool, g.Order())
+	for _, e := range matching {
+		if !g.Edge(e.V, e.W) || g.Cost(e.V, e.W) != e.Cost {
+			t.Errorf("matching: %v isn't an edge", e)
+		}
+		if used[e.V] || used[e.W] {
+			t.Errorf("matching: %v shares a vertex", e)
+		}
+		used[e.V], used[e.W] = true, true
+	}
+}
+
+func BenchmarkBipartiteMatching(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(2 * n)
+	for i := 0; i < 4*n; i++ {
+		g.AddBoth(rand.Intn(n), n+rand.Intn(n))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = BipartiteMatching(g)
+	}
+}
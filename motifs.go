@@ -0,0 +1,224 @@
+package graph
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Motif identifies one of the connected undirected graphs
+// with three or four vertices.
+type Motif int
+
+// The connected graphs with three and four vertices.
+const (
+	MotifPath3    Motif = iota // path with 3 vertices
+	MotifTriangle              // cycle with 3 vertices
+	MotifPath4                 // path with 4 vertices
+	MotifStar                  // star with 3 leaves
+	MotifCycle4                // cycle with 4 vertices
+	MotifPaw                   // triangle with a pendant vertex
+	MotifDiamond               // cycle with 4 vertices and one chord
+	MotifClique4               // complete graph with 4 vertices
+)
+
+// NumMotifs is the number of motifs counted by MotifCounts.
+const NumMotifs = 8
+
+var motifNames = [NumMotifs]string{
+	"path3", "triangle", "path4", "star", "cycle4", "paw", "diamond", "clique4",
+}
+
+// String returns a short lowercase name of the motif, such as "paw".
+func (m Motif) String() string {
+	if m < 0 || m >= NumMotifs {
+		return "Motif(" + strconv.Itoa(int(m)) + ")"
+	}
+	return motifNames[m]
+}
+
+// NumOrbits is the number of orbits counted by OrbitCounts.
+const NumOrbits = 15
+
+// MotifCounts returns the number of induced subgraphs of g that are
+// isomorphic to each motif: res[m] is the number of sets of vertices
+// whose induced subgraph is the motif m. The graph is treated as
+// undirected, as in the coloring functions; self-loops and duplicate
+// edges are ignored. For example, res[MotifPath3] is the number of
+// paths of length two whose endpoints aren't adjacent.
+//
+// The implementation enumerates each connected induced subgraph with
+// three or four vertices once, with the ESU algorithm by Wernicke.
+// The time complexity is O(|E| + |V| + s⋅log|V|), where |E| is the number
+// of edges and |V| the number of vertices in the graph, and s ≤ |V|⋅Δ³
+// is the number of such subgraphs, with Δ the largest degree in g.
+func MotifCounts(g Iterator) []int {
+	res := make([]int, NumMotifs)
+	motifs(undirected(g), func(m Motif, _ []int, _ []int) {
+		res[m]++
+	})
+	return res
+}
+
+// OrbitCounts returns the graphlet orbit counts of each vertex in g,
+// as defined by Pržulj: res[v][i] is the number of times v appears
+// at orbit i, a position in one of the motifs that's indistinguishable
+// from the other positions in the same orbit by symmetry.
+// The graph is treated as undirected, as in MotifCounts.
+//
+//	0   degree: the endpoint of an edge
+//	1   the end of a MotifPath3
+//	2   the middle of a MotifPath3
+//	3   a vertex of a MotifTriangle
+//	4   an end of a MotifPath4
+//	5   an inner vertex of a MotifPath4
+//	6   a leaf of a MotifStar
+//	7   the center of a MotifStar
+//	8   a vertex of a MotifCycle4
+//	9   the pendant vertex of a MotifPaw
+//	10  a triangle vertex of degree 2 in a MotifPaw
+//	11  the vertex of degree 3 in a MotifPaw
+//	12  a vertex of degree 2 in a MotifDiamond
+//	13  a vertex of degree 3 in a MotifDiamond
+//	14  a vertex of a MotifClique4
+//
+// The orbit counts of a vertex, known as its graphlet degree vector,
+// describe the structure of its neighborhood.
+//
+// The time complexity is the same as for MotifCounts.
+func OrbitCounts(g Iterator) [][NumOrbits]int {
+	adj := undirected(g)
+	res := make([][NumOrbits]int, len(adj))
+	for v, list := range adj {
+		res[v][0] = len(list)
+	}
+	motifs(adj, func(m Motif, sub []int, deg []int) {
+		for i, v := range sub {
+			res[v][orbit(m, deg[i])]++
+		}
+	})
+	return res
+}
+
+// orbit returns the orbit of a vertex with degree d in the motif m.
+func orbit(m Motif, d int) int {
+	switch m {
+	case MotifPath3:
+		return d // 1 or 2
+	case MotifTriangle:
+		return 3
+	case MotifPath4:
+		return 3 + d // 4 or 5
+	case MotifStar:
+		if d == 1 {
+			return 6
+		}
+		return 7
+	case MotifCycle4:
+		return 8
+	case MotifPaw:
+		return 8 + d // 9, 10 or 11
+	case MotifDiamond:
+		return 10 + d // 12 or 13
+	}
+	return 14
+}
+
+// motifs calls do for each connected induced subgraph with three or
+// four vertices in the undirected graph with the given sorted neighbor lists,
+// with the vertices of the subgraph and their degrees in the subgraph.
+func motifs(adj [][]int, do func(m Motif, sub []int, deg []int)) {
+	edge := func(v, w int) bool {
+		list := adj[v]
+		i := sort.SearchInts(list, w)
+		return i < len(list) && list[i] == w
+	}
+	var deg [4]int
+	classify := func(sub []int) {
+		k := len(sub)
+		edges, maxDeg := 0, 0
+		for i := range sub {
+			deg[i] = 0
+			for j := range sub {
+				if i != j && edge(sub[i], sub[j]) {
+					deg[i]++
+				}
+			}
+			edges += deg[i]
+			if deg[i] > maxDeg {
+				maxDeg = deg[i]
+			}
+		}
+		edges /= 2
+		var m Motif
+		switch {
+		case k == 3 && edges == 2:
+			m = MotifPath3
+		case k == 3:
+			m = MotifTriangle
+		case edges == 3 && maxDeg == 3:
+			m = MotifStar
+		case edges == 3:
+			m = MotifPath4
+		case edges == 4 && maxDeg == 3:
+			m = MotifPaw
+		case edges == 4:
+			m = MotifCycle4
+		case edges == 5:
+			m = MotifDiamond
+		default:
+			m = MotifClique4
+		}
+		do(m, sub, deg[:k])
+	}
+
+	// The ESU algorithm: a subgraph is only extended with vertices larger
+	// than its first vertex v, taken from the extension set, which holds
+	// neighbors of the subgraph that may still be added. When a vertex w is
+	// added, its exclusive neighbors, which aren't in or next to
+	// the subgraph before w, join the extension set.
+	sub := make([]int, 0, 4)
+	var extend func(ext []int, v int)
+	extend = func(ext []int, v int) {
+		if len(sub) == 3 {
+			classify(sub)
+		}
+		for len(ext) > 0 {
+			w := ext[len(ext)-1]
+			ext = ext[:len(ext)-1]
+			if len(sub) == 3 {
+				classify(append(sub, w))
+				continue
+			}
+			next := append([]int(nil), ext...)
+			for _, u := range adj[w] {
+				if u <= v || touches(u, sub, edge) {
+					continue
+				}
+				next = append(next, u)
+			}
+			sub = append(sub, w)
+			extend(next, v)
+			sub = sub[:len(sub)-1]
+		}
+	}
+	for v, list := range adj {
+		ext := make([]int, 0, len(list))
+		for _, w := range list {
+			if w > v {
+				ext = append(ext, w)
+			}
+		}
+		sub = append(sub[:0], v)
+		extend(ext, v)
+	}
+}
+
+// touches tells if u is in sub or adjacent to a vertex in sub.
+func touches(u int, sub []int, edge func(v, w int) bool) bool {
+	for _, s := range sub {
+		if u == s || edge(s, u) {
+			return true
+		}
+	}
+	return false
+}
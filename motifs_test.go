@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMotifCounts(t *testing.T) {
+	// A paw: the triangle 0-1-2 with the pendant vertex 3 attached to 2.
+	g := New(5)
+	g.AddBoth(0, 1)
+	g.AddBoth(1, 2)
+	g.AddBoth(0, 2)
+	g.AddBoth(2, 3)
+	g.Add(4, 4)
+	exp := make([]int, NumMotifs)
+	exp[MotifPath3] = 2
+	exp[MotifTriangle] = 1
+	exp[MotifPaw] = 1
+	if mess, diff := diff(MotifCounts(g), exp); diff {
+		t.Errorf("MotifCounts %s", mess)
+	}
+	orbits := OrbitCounts(g)
+	expOrbits := [][NumOrbits]int{
+		{0: 2, 1: 1, 3: 1, 10: 1},
+		{0: 2, 1: 1, 3: 1, 10: 1},
+		{0: 3, 2: 2, 3: 1, 11: 1},
+		{0: 1, 1: 2, 9: 1},
+		{},
+	}
+	if mess, diff := diff(orbits, expOrbits); diff {
+		t.Errorf("OrbitCounts %s", mess)
+	}
+	if mess, diff := diff(MotifDiamond.String(), "diamond"); diff {
+		t.Errorf("String %s", mess)
+	}
+	if mess, diff := diff(Motif(8).String(), "Motif(8)"); diff {
+		t.Errorf("String %s", mess)
+	}
+}
+
+// motifPatterns returns a graph for each motif.
+func motifPatterns() []*Mutable {
+	edges := [NumMotifs][][2]int{
+		MotifPath3:    {{0, 1}, {1, 2}},
+		MotifTriangle: {{0, 1}, {1, 2}, {2, 0}},
+		MotifPath4:    {{0, 1}, {1, 2}, {2, 3}},
+		MotifStar:     {{0, 1}, {0, 2}, {0, 3}},
+		MotifCycle4:   {{0, 1}, {1, 2}, {2, 3}, {3, 0}},
+		MotifPaw:      {{0, 1}, {1, 2}, {2, 0}, {2, 3}},
+		MotifDiamond:  {{0, 1}, {1, 2}, {2, 3}, {3, 0}, {0, 2}},
+		MotifClique4:  {{0, 1}, {1, 2}, {2, 3}, {3, 0}, {0, 2}, {1, 3}},
+	}
+	res := make([]*Mutable, NumMotifs)
+	for m, list := range edges {
+		res[m] = New(4)
+		if Motif(m) <= MotifTriangle {
+			res[m] = New(3)
+		}
+		for _, e := range list {
+			res[m].AddBoth(e[0], e[1])
+		}
+	}
+	return res
+}
+
+func TestMotifCountsRandom(t *testing.T) {
+	patterns := motifPatterns()
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		n := 4 + r.Intn(9)
+		g := New(n)
+		for j := r.Intn(3 * n); j > 0; j-- {
+			g.AddBoth(r.Intn(n), r.Intn(n))
+		}
+		h := Copy(g)
+		for v := 0; v < n; v++ {
+			h.Delete(v, v)
+		}
+		exp := make([]int, NumMotifs)
+		var count func(sub []int, next int)
+		count = func(sub []int, next int) {
+			if len(sub) == 3 || len(sub) == 4 {
+				s := Subgraph(h, sub)
+				for m, p := range patterns {
+					if p.Order() == len(sub) && IsIsomorphic(s, p) {
+						exp[m]++
+					}
+				}
+			}
+			if len(sub) == 4 {
+				return
+			}
+			for v := next; v < n; v++ {
+				count(append(sub, v), v+1)
+			}
+		}
+		count(nil, 0)
+		if mess, diff := diff(MotifCounts(g), exp); diff {
+			t.Errorf("MotifCounts(%v) %s", g, mess)
+		}
+
+		// Each motif contributes to the orbits of all its vertices.
+		var sum [NumOrbits]int
+		for _, counts := range OrbitCounts(g) {
+			for j, c := range counts {
+				sum[j] += c
+			}
+		}
+		size := 0
+		for v := 0; v < n; v++ {
+			size += h.Degree(v)
+		}
+		expSum := [NumOrbits]int{
+			size,
+			2 * exp[MotifPath3], exp[MotifPath3], 3 * exp[MotifTriangle],
+			2 * exp[MotifPath4], 2 * exp[MotifPath4],
+			3 * exp[MotifStar], exp[MotifStar],
+			4 * exp[MotifCycle4],
+			exp[MotifPaw], 2 * exp[MotifPaw], exp[MotifPaw],
+			2 * exp[MotifDiamond], 2 * exp[MotifDiamond],
+			4 * exp[MotifClique4],
+		}
+		if mess, diff := diff(sum, expSum); diff {
+			t.Errorf("OrbitCounts %s", mess)
+		}
+	}
+}
+
+func BenchmarkMotifCounts(b *testing.B) {
+	n := 1000
+	g := New(n)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 5*n; i++ {
+		g.AddBoth(r.Intn(n), r.Intn(n))
+	}
+	h := Sort(g)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MotifCounts(h)
+	}
+}
@@ -1,5 +1,7 @@
 package graph
 
+import "sort"
+
 // MST computes a minimum spanning tree for each connected component
 // of an undirected weighted graph.
 // The forest of spanning trees is returned as a slice of parent pointers:
@@ -32,3 +34,102 @@ func MST(g Iterator) (parent []int) {
 	}
 	return
 }
+
+// Prim computes a minimum spanning forest of an undirected weighted graph
+// using Prim's algorithm with q as its priority queue.
+// The forest is returned as parent pointers, like in MST,
+// and total is the sum of the edge costs in the forest.
+// The trees are rooted at the smallest vertex of each component.
+//
+// The queue must be empty. Unlike ShortestPathsWithQueue, Prim's algorithm
+// may push a distance smaller than the most recently popped one;
+// the queue returned by NewHeapQueue supports this.
+//
+// The time complexity is O(|E|⋅log|V|) with a heap queue, where |E| is
+// the number of edges and |V| the number of vertices in the graph.
+func Prim(g Iterator, q DistQueue) (parent []int, total int64) {
+	n := g.Order()
+	parent = make([]int, n)
+	cost := make([]int64, n)
+	done := make([]bool, n)
+	for i := range parent {
+		parent[i] = -1
+		cost[i] = Max
+	}
+	for root := 0; root < n; root++ {
+		if done[root] {
+			continue
+		}
+		cost[root] = 0
+		q.Push(root, 0)
+		for q.Len() > 0 {
+			v, d := q.Pop()
+			if done[v] || d > cost[v] {
+				continue // An outdated entry.
+			}
+			done[v] = true
+			total += d
+			g.Visit(v, func(w int, c int64) (skip bool) {
+				if !done[w] && c < cost[w] {
+					cost[w], parent[w] = c, v
+					q.Push(w, c)
+				}
+				return
+			})
+		}
+	}
+	return
+}
+
+// Kruskal computes a minimum spanning forest of an undirected weighted graph
+// using Kruskal's algorithm. It returns the same values as Prim.
+// Among edges of equal cost, the one with the smallest endpoints is
+// preferred, so the result doesn't depend on the order of the neighbors.
+//
+// The time complexity is O(|E|⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func Kruskal(g Iterator) (parent []int, total int64) {
+	n := g.Order()
+	var edges []Edge
+	VisitAll(g, func(v, w int, c int64) (skip bool) {
+		if v < w {
+			edges = append(edges, Edge{v, w, c})
+		}
+		return
+	})
+	sort.Slice(edges, func(i, j int) bool {
+		e, f := edges[i], edges[j]
+		if e.Cost != f.Cost {
+			return e.Cost < f.Cost
+		}
+		if e.V != f.V {
+			return e.V < f.V
+		}
+		return e.W < f.W
+	})
+
+	tree := New(n)
+	sets := NewUnionFind(n)
+	for _, e := range edges {
+		if sets.Union(e.V, e.W) {
+			tree.AddBothCost(e.V, e.W, e.Cost)
+			total += e.Cost
+		}
+	}
+
+	// Orient the forest, with the smallest vertex of each tree as root.
+	parent = make([]int, n)
+	for i := range parent {
+		parent[i] = -2
+	}
+	for root := 0; root < n; root++ {
+		if parent[root] != -2 {
+			continue
+		}
+		parent[root] = -1
+		BFS(tree, root, func(v, w int, _ int64) {
+			parent[w] = v
+		})
+	}
+	return
+}
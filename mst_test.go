@@ -44,3 +44,101 @@ func BenchmarkMST(b *testing.B) {
 		_ = MST(g)
 	}
 }
+
+func TestPrimKruskal(t *testing.T) {
+	g := New(0)
+	parent, total := Prim(g, NewHeapQueue(0))
+	if mess, diff := diff(parent, []int{}); diff {
+		t.Errorf("Prim->parent %s", mess)
+	}
+	if mess, diff := diff(total, int64(0)); diff {
+		t.Errorf("Prim->total %s", mess)
+	}
+	parent, total = Kruskal(g)
+	if mess, diff := diff(parent, []int{}); diff {
+		t.Errorf("Kruskal->parent %s", mess)
+	}
+
+	g = New(10)
+	g.AddBothCost(0, 1, 4)
+	g.AddBothCost(0, 7, 8)
+	g.AddBothCost(1, 2, 8)
+	g.AddBothCost(1, 7, 11)
+	g.AddBothCost(2, 3, 7)
+	g.AddBothCost(2, 8, 2)
+	g.AddBothCost(2, 5, 4)
+	g.AddBothCost(3, 4, 9)
+	g.AddBothCost(3, 5, 14)
+	g.AddBothCost(4, 5, 10)
+	g.AddBothCost(5, 6, 2)
+	g.AddBothCost(6, 7, 1)
+	g.AddBothCost(6, 8, 6)
+	g.AddBothCost(7, 8, 7)
+	exp := []int{-1, 0, 5, 2, 3, 6, 7, 0, 2, -1}
+	parent, total = Prim(g, NewHeapQueue(10))
+	if mess, diff := diff(parent, exp); diff {
+		t.Errorf("Prim->parent %s", mess)
+	}
+	if mess, diff := diff(total, int64(37)); diff {
+		t.Errorf("Prim->total %s", mess)
+	}
+	parent, total = Kruskal(g)
+	if mess, diff := diff(parent, exp); diff {
+		t.Errorf("Kruskal->parent %s", mess)
+	}
+	if mess, diff := diff(total, int64(37)); diff {
+		t.Errorf("Kruskal->total %s", mess)
+	}
+}
+
+func TestPrimKruskalRandom(t *testing.T) {
+	n := 100
+	for i := 0; i < 10; i++ {
+		g := New(n)
+		for j := 0; j < n; j++ {
+			g.AddBothCost(rand.Intn(n), rand.Intn(n), rand.Int63n(20)-5)
+		}
+		exp := forestCost(g, MST(g))
+		parent, total := Prim(g, NewHeapQueue(n))
+		if total != exp || forestCost(g, parent) != exp {
+			t.Errorf("Prim->total %d; want %d", total, exp)
+		}
+		parent, total = Kruskal(g)
+		if total != exp || forestCost(g, parent) != exp {
+			t.Errorf("Kruskal->total %d; want %d", total, exp)
+		}
+		_, count := components(g)
+		roots := 0
+		for _, p := range parent {
+			if p == -1 {
+				roots++
+			}
+		}
+		if roots != count {
+			t.Errorf("Kruskal: %d trees; want %d", roots, count)
+		}
+	}
+}
+
+// forestCost returns the total cost of the edges in a forest of g.
+func forestCost(g *Mutable, parent []int) (total int64) {
+	for v, p := range parent {
+		if p != -1 {
+			total += g.Cost(p, v)
+		}
+	}
+	return
+}
+
+func BenchmarkKruskal(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 2*n; i++ {
+		g.AddBothCost(rand.Intn(n), rand.Intn(n), int64(rand.Int()))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Kruskal(g)
+	}
+}
@@ -0,0 +1,146 @@
+package graph
+
+import "strconv"
+
+// Multigraph represents a directed multigraph with a fixed number of vertices
+// and weighted edges that can be added or removed. Unlike Mutable,
+// it may have several edges between the same pair of vertices.
+// Each edge is identified by an ID returned by AddEdge.
+// IDs are assigned in increasing order, starting at 0, and are never reused.
+type Multigraph struct {
+	// out[v] lists the IDs of the edges from v.
+	out   [][]int
+	edges []multiEdge
+	size  int
+}
+
+type multiEdge struct {
+	from, to int
+	cost     int64
+	index    int // position in out[from], or -1 if deleted
+}
+
+// NewMultigraph constructs a new multigraph with n vertices,
+// numbered from 0 to n-1, and no edges.
+func NewMultigraph(n int) *Multigraph {
+	return &Multigraph{out: make([][]int, n)}
+}
+
+// String returns a string representation of the graph.
+func (g *Multigraph) String() string {
+	return String(g)
+}
+
+// Order returns the number of vertices in the graph.
+func (g *Multigraph) Order() int {
+	return len(g.out)
+}
+
+// Size returns the number of edges in the graph.
+func (g *Multigraph) Size() int {
+	return g.size
+}
+
+// Visit calls the do function for each edge from v to a neighbor w,
+// with c equal to the cost of the edge. A neighbor is visited once
+// for each parallel edge.
+// If do returns true, Visit returns immediately,
+// skipping any remaining neighbors, and returns true.
+//
+// The iteration order is not specified.
+func (g *Multigraph) Visit(v int, do func(w int, c int64) bool) bool {
+	for _, id := range g.out[v] {
+		if e := g.edges[id]; do(e.to, e.cost) {
+			return true
+		}
+	}
+	return false
+}
+
+// VisitEdges is like Visit, but also reports the edge ID.
+// It is safe to delete the visited edge during a call to this method.
+func (g *Multigraph) VisitEdges(v int, do func(w int, c int64, id int) bool) bool {
+	// Deleting an edge moves the last edge to its position,
+	// so iterate backwards to visit each edge exactly once.
+	for i := len(g.out[v]) - 1; i >= 0; i-- {
+		id := g.out[v][i]
+		if e := g.edges[id]; do(e.to, e.cost, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// Degree returns the number of outward directed edges from v.
+func (g *Multigraph) Degree(v int) int {
+	return len(g.out[v])
+}
+
+// AddEdge inserts a directed edge from v to w with cost c,
+// and returns the ID of the new edge.
+func (g *Multigraph) AddEdge(v, w int, c int64) (id int) {
+	n := len(g.out)
+	if v < 0 || v >= n {
+		panic("vertex out of range: " + strconv.Itoa(v))
+	}
+	if w < 0 || w >= n {
+		panic("vertex out of range: " + strconv.Itoa(w))
+	}
+	id = len(g.edges)
+	g.edges = append(g.edges, multiEdge{v, w, c, len(g.out[v])})
+	g.out[v] = append(g.out[v], id)
+	g.size++
+	return
+}
+
+// Lookup returns the endpoints and cost of the edge with the given ID.
+// If there is no such edge, ok is false.
+func (g *Multigraph) Lookup(id int) (v, w int, c int64, ok bool) {
+	if id < 0 || id >= len(g.edges) || g.edges[id].index == -1 {
+		return 0, 0, 0, false
+	}
+	e := g.edges[id]
+	return e.from, e.to, e.cost, true
+}
+
+// SetCost sets the cost of the edge with the given ID.
+// It returns false if there is no such edge.
+func (g *Multigraph) SetCost(id int, c int64) bool {
+	if _, _, _, ok := g.Lookup(id); !ok {
+		return false
+	}
+	g.edges[id].cost = c
+	return true
+}
+
+// DeleteEdge removes the edge with the given ID.
+// It returns false if there is no such edge.
+// Other edges between the same vertices are not affected.
+func (g *Multigraph) DeleteEdge(id int) bool {
+	if _, _, _, ok := g.Lookup(id); !ok {
+		return false
+	}
+	e := &g.edges[id]
+	out := g.out[e.from]
+	last := len(out) - 1
+	out[e.index] = out[last]
+	g.edges[out[last]].index = e.index
+	g.out[e.from] = out[:last]
+	e.index = -1
+	g.size--
+	return true
+}
+
+// Edges returns the IDs of all edges from v to w.
+func (g *Multigraph) Edges(v, w int) []int {
+	ids := []int{}
+	if v < 0 || v >= len(g.out) {
+		return ids
+	}
+	for _, id := range g.out[v] {
+		if g.edges[id].to == w {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
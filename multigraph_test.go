@@ -0,0 +1,122 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMultigraph(t *testing.T) {
+	g := NewMultigraph(0)
+	if mess, diff := diff(g.String(), "0 []"); diff {
+		t.Errorf("NewMultigraph %s", mess)
+	}
+
+	g = NewMultigraph(3)
+	a := g.AddEdge(0, 1, 5)
+	b := g.AddEdge(0, 1, 2)
+	c := g.AddEdge(0, 1, 5)
+	d := g.AddEdge(2, 2, 0)
+	if mess, diff := diff([]int{a, b, c, d}, []int{0, 1, 2, 3}); diff {
+		t.Errorf("AddEdge %s", mess)
+	}
+	if mess, diff := diff(g.String(), "3 [(0 1):2 2×(0 1):5 (2 2)]"); diff {
+		t.Errorf("AddEdge %s", mess)
+	}
+	if mess, diff := diff(g.Size(), 4); diff {
+		t.Errorf("Size %s", mess)
+	}
+	if mess, diff := diff(g.Degree(0), 3); diff {
+		t.Errorf("Degree %s", mess)
+	}
+	if mess, diff := diff(g.Edges(0, 1), []int{0, 1, 2}); diff {
+		t.Errorf("Edges %s", mess)
+	}
+	if mess, diff := diff(g.Edges(1, 0), []int{}); diff {
+		t.Errorf("Edges %s", mess)
+	}
+	if mess, diff := diff(Check(g), Stats{Size: 2, Multi: 2, Weighted: 3, Loops: 1, Isolated: 1}); diff {
+		t.Errorf("Check %s", mess)
+	}
+
+	if !g.DeleteEdge(a) {
+		t.Errorf("DeleteEdge(%d) false", a)
+	}
+	if g.DeleteEdge(a) || g.DeleteEdge(-1) || g.DeleteEdge(4) {
+		t.Errorf("DeleteEdge of missing edge true")
+	}
+	if mess, diff := diff(g.String(), "3 [(0 1):2 (0 1):5 (2 2)]"); diff {
+		t.Errorf("DeleteEdge %s", mess)
+	}
+	if _, _, _, ok := g.Lookup(a); ok {
+		t.Errorf("Lookup(%d) ok after delete", a)
+	}
+	v, w, cost, ok := g.Lookup(c)
+	if mess, diff := diff([]int64{int64(v), int64(w), cost}, []int64{0, 1, 5}); diff || !ok {
+		t.Errorf("Lookup(%d) %s", c, mess)
+	}
+	if !g.SetCost(c, 7) || g.SetCost(a, 7) {
+		t.Errorf("SetCost: wrong result")
+	}
+	if mess, diff := diff(g.String(), "3 [(0 1):2 (0 1):7 (2 2)]"); diff {
+		t.Errorf("SetCost %s", mess)
+	}
+	if mess, diff := diff(g.AddEdge(1, 0, 0), 4); diff {
+		t.Errorf("AddEdge %s", mess)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("AddEdge(0, 3): no panic")
+		}
+	}()
+	g.AddEdge(0, 3, 0)
+}
+
+func TestMultigraphVisitEdges(t *testing.T) {
+	n := 10
+	g := NewMultigraph(n)
+	for i := 0; i < 10*n; i++ {
+		g.AddEdge(rand.Intn(n), rand.Intn(n), rand.Int63n(3))
+	}
+	// Delete all edges of cost 0 while visiting.
+	exp := 0
+	for v := 0; v < n; v++ {
+		deg := g.Degree(v)
+		seen := make(map[int]bool)
+		g.VisitEdges(v, func(w int, c int64, id int) (skip bool) {
+			if seen[id] {
+				t.Errorf("VisitEdges(%d): edge %d visited twice", v, id)
+			}
+			seen[id] = true
+			if c == 0 {
+				g.DeleteEdge(id)
+			} else {
+				exp++
+			}
+			return
+		})
+		if len(seen) != deg {
+			t.Errorf("VisitEdges(%d): visited %d edges; want %d", v, len(seen), deg)
+		}
+	}
+	if mess, diff := diff(g.Size(), exp); diff {
+		t.Errorf("VisitEdges: Size %s", mess)
+	}
+	for v := 0; v < n; v++ {
+		if countCost(g, v, 0) != 0 {
+			t.Errorf("VisitEdges(%d): edge of cost 0 not deleted", v)
+		}
+	}
+}
+
+// countCost returns the number of edges from v with cost c.
+func countCost(g *Multigraph, v int, c int64) int {
+	count := 0
+	g.Visit(v, func(_ int, cost int64) (skip bool) {
+		if cost == c {
+			count++
+		}
+		return
+	})
+	return count
+}
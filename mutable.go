@@ -1,22 +1,37 @@
 package graph
 
 import (
+	"sort"
 	"strconv"
 )
 
 const initialMapSize = 4
 
-// Mutable represents a directed graph with a fixed number
-// of vertices and weighted edges that can be added or removed.
+// Mutable represents a directed graph with weighted edges
+// that can be added or removed. The vertices are numbered from 0 to n-1;
+// vertices can be added and removed with AddVertex and RemoveVertex.
 // The implementation uses hash maps to associate each vertex in the graph with
 // its adjacent vertices. This gives constant time performance for
-// all basic operations.
+// all basic operations. The neighbors are visited in no particular
+// order, unless sorted mode is turned on with SetSorted.
 //
 type Mutable struct {
 	// The map edges[v] contains the mapping {w:c} if there is an edge
 	// from v to w, and c is the cost assigned to this edge.
 	// The maps may be nil and are allocated as needed.
 	edges []map[int]int64
+
+	// removed[v] tells if v has been removed; free lists the removed
+	// vertices available for reuse. Both are nil until a vertex is removed.
+	removed []bool
+	free    []int
+
+	// obs holds the observers of the graph, or is nil if there are none.
+	obs *observers
+
+	// In sorted mode, sorted[v] lists the neighbors of v in increasing
+	// order. The slice is nil if the graph isn't in sorted mode.
+	sorted [][]int
 }
 
 // New constructs a new graph with n vertices, numbered from 0 to n-1, and no edges.
@@ -46,6 +61,16 @@ func Copy(g Iterator) *Mutable {
 
 func copyMutable(g *Mutable) *Mutable {
 	h := New(g.Order())
+	if g.removed != nil {
+		h.removed = append([]bool(nil), g.removed...)
+		h.free = append([]int(nil), g.free...)
+	}
+	if g.sorted != nil {
+		h.sorted = make([][]int, len(g.sorted))
+		for v, ws := range g.sorted {
+			h.sorted[v] = append([]int(nil), ws...)
+		}
+	}
 	for v, neighbors := range g.edges {
 		if deg := len(neighbors); deg > 0 {
 			h.edges[v] = make(map[int]int64, deg)
@@ -59,7 +84,8 @@ func copyMutable(g *Mutable) *Mutable {
 
 func copyImmutable(g *Immutable) *Mutable {
 	h := New(g.Order())
-	for v, neighbors := range g.edges {
+	for v := range h.edges {
+		neighbors := g.edges[g.offset[v]:g.offset[v+1]]
 		if deg := len(neighbors); deg > 0 {
 			h.edges[v] = make(map[int]int64, deg)
 			for _, edge := range neighbors {
@@ -86,10 +112,23 @@ func (g *Mutable) Order() int {
 // skipping any remaining neighbors, and returns true.
 //
 // The iteration order is not specified and is not guaranteed
-// to be the same every time.
+// to be the same every time, unless the graph is in sorted mode;
+// see SetSorted.
 // It is safe to delete, but not to add, edges adjacent to v
 // during a call to this method.
 func (g *Mutable) Visit(v int, do func(w int, c int64) bool) bool {
+	if g.sorted != nil {
+		for _, w := range g.sorted[v] {
+			c, ok := g.edges[v][w]
+			if !ok {
+				continue // deleted during the call
+			}
+			if do(w, c) {
+				return true
+			}
+		}
+		return false
+	}
 	for w, c := range g.edges[v] {
 		if do(w, c) {
 			return true
@@ -98,6 +137,43 @@ func (g *Mutable) Visit(v int, do func(w int, c int64) bool) bool {
 	return false
 }
 
+// SetSorted turns sorted mode on or off. In sorted mode, Visit calls
+// the do function for the neighbors in increasing numerical order,
+// so that algorithms give the same results every time they are run,
+// also when there are several equally good answers.
+// The price is that adding a new edge or deleting an edge takes
+// time proportional to the degree of its first vertex.
+// Copies of a graph in sorted mode are also in sorted mode.
+//
+// The time complexity of turning sorted mode on is O(|E|⋅log|V|),
+// where |E| is the number of edges and |V| the number of vertices in the graph.
+func (g *Mutable) SetSorted(on bool) {
+	if !on {
+		g.sorted = nil
+		return
+	}
+	if g.sorted != nil {
+		return
+	}
+	g.sorted = make([][]int, len(g.edges))
+	for v, neighbors := range g.edges {
+		if len(neighbors) == 0 {
+			continue
+		}
+		ws := make([]int, 0, len(neighbors))
+		for w := range neighbors {
+			ws = append(ws, w)
+		}
+		sort.Ints(ws)
+		g.sorted[v] = ws
+	}
+}
+
+// Sorted tells if the graph is in sorted mode.
+func (g *Mutable) Sorted() bool {
+	return g.sorted != nil
+}
+
 // Degree returns the number of outward directed edges from v.
 func (g *Mutable) Degree(v int) int {
 	return len(g.edges[v])
@@ -128,15 +204,37 @@ func (g *Mutable) Add(v, w int) {
 
 // AddCost inserts a directed edge from v to w with cost c.
 // It overwrites the previous cost if this edge already exists.
+// A vertex removed by RemoveVertex becomes live again if it's v or w.
 func (g *Mutable) AddCost(v, w int, c int64) {
 	// Make sure not to break internal state.
 	if w < 0 || w >= len(g.edges) {
 		panic("vertex out of range: " + strconv.Itoa(w))
 	}
+	for _, u := range [2]int{v, w} {
+		if g.Removed(u) {
+			g.revive(u)
+		}
+	}
 	if g.edges[v] == nil {
 		g.edges[v] = make(map[int]int64, initialMapSize)
 	}
+	if g.sorted != nil {
+		if _, ok := g.edges[v][w]; !ok {
+			g.sorted[v] = insertSorted(g.sorted[v], w)
+		}
+	}
+	if g.obs == nil {
+		g.edges[v][w] = c
+		return
+	}
+	old, ok := g.edges[v][w]
 	g.edges[v][w] = c
+	switch {
+	case !ok:
+		g.obs.added(v, w, c)
+	case c != old:
+		g.obs.changed(v, w, old, c)
+	}
 }
 
 // AddBoth inserts edges with zero cost between v and w.
@@ -159,7 +257,19 @@ func (g *Mutable) AddBothCost(v, w int, c int64) {
 
 // Delete removes an edge from v to w.
 func (g *Mutable) Delete(v, w int) {
-	delete(g.edges[v], w)
+	if g.sorted != nil {
+		if _, ok := g.edges[v][w]; ok {
+			g.sorted[v] = deleteSorted(g.sorted[v], w)
+		}
+	}
+	if g.obs == nil {
+		delete(g.edges[v], w)
+		return
+	}
+	if c, ok := g.edges[v][w]; ok {
+		delete(g.edges[v], w)
+		g.obs.deleted(v, w, c)
+	}
 }
 
 // DeleteBoth removes all edges between v and w.
@@ -169,3 +279,140 @@ func (g *Mutable) DeleteBoth(v, w int) {
 		g.Delete(w, v)
 	}
 }
+
+// AddVertex adds a vertex without edges to the graph and returns it.
+// A previously removed vertex is reused, if there is one;
+// otherwise the new vertex is n, and the order of the graph grows to n+1.
+func (g *Mutable) AddVertex() int {
+	if n := len(g.free); n > 0 {
+		v := g.free[n-1]
+		g.free = g.free[:n-1]
+		g.removed[v] = false
+		return v
+	}
+	g.edges = append(g.edges, nil)
+	if g.removed != nil {
+		g.removed = append(g.removed, false)
+	}
+	if g.sorted != nil {
+		g.sorted = append(g.sorted, nil)
+	}
+	return len(g.edges) - 1
+}
+
+// RemoveVertex removes all edges to and from v, and makes v
+// available for reuse by AddVertex. The order of the graph is unchanged;
+// a removed vertex looks like an isolated vertex until it is reused.
+// Use Compact to renumber the remaining vertices.
+//
+// The time complexity is O(|V|), where |V| is the number of vertices in the graph.
+func (g *Mutable) RemoveVertex(v int) {
+	n := len(g.edges)
+	if v < 0 || v >= n {
+		panic("vertex out of range: " + strconv.Itoa(v))
+	}
+	if g.Removed(v) {
+		return
+	}
+	out := g.edges[v]
+	g.edges[v] = nil
+	if g.sorted != nil {
+		g.sorted[v] = nil
+	}
+	for u := range g.edges {
+		g.Delete(u, v)
+	}
+	if g.obs != nil {
+		for w, c := range out {
+			g.obs.deleted(v, w, c)
+		}
+	}
+	if g.removed == nil {
+		g.removed = make([]bool, n)
+	}
+	g.removed[v] = true
+	g.free = append(g.free, v)
+}
+
+// revive makes the removed vertex v live again, as if reused by AddVertex.
+func (g *Mutable) revive(v int) {
+	for i, u := range g.free {
+		if u == v {
+			g.free = append(g.free[:i], g.free[i+1:]...)
+			break
+		}
+	}
+	g.removed[v] = false
+}
+
+// Removed tells if v has been removed by RemoveVertex and not reused.
+func (g *Mutable) Removed(v int) bool {
+	return v >= 0 && v < len(g.removed) && g.removed[v]
+}
+
+// Compact renumbers the vertices that haven't been removed so that
+// they are numbered from 0 to n-1, keeping their relative order,
+// and shrinks the order of the graph to n.
+// The number index[v] is the new number of vertex v,
+// or -1 if v has been removed.
+func (g *Mutable) Compact() (index []int) {
+	index = make([]int, len(g.edges))
+	n := 0
+	for v := range g.edges {
+		if g.Removed(v) {
+			index[v] = -1
+			continue
+		}
+		index[v] = n
+		n++
+	}
+	edges := make([]map[int]int64, n)
+	for v, neighbors := range g.edges {
+		if index[v] == -1 || len(neighbors) == 0 {
+			continue
+		}
+		m := make(map[int]int64, len(neighbors))
+		for w, c := range neighbors {
+			if index[w] != -1 {
+				m[index[w]] = c
+			}
+		}
+		edges[index[v]] = m
+	}
+	g.edges, g.removed, g.free = edges, nil, nil
+	if g.sorted != nil {
+		// The renumbering keeps the relative order of the vertices.
+		sorted := make([][]int, n)
+		for v, ws := range g.sorted {
+			if index[v] == -1 {
+				continue
+			}
+			var list []int
+			for _, w := range ws {
+				if index[w] != -1 {
+					list = append(list, index[w])
+				}
+			}
+			sorted[index[v]] = list
+		}
+		g.sorted = sorted
+	}
+	return
+}
+
+// insertSorted inserts w into the sorted list ws.
+func insertSorted(ws []int, w int) []int {
+	i := sort.SearchInts(ws, w)
+	ws = append(ws, 0)
+	copy(ws[i+1:], ws[i:])
+	ws[i] = w
+	return ws
+}
+
+// deleteSorted returns a copy of the sorted list ws without w.
+// The list isn't changed in place, since a call to Visit may be
+// iterating over it.
+func deleteSorted(ws []int, w int) []int {
+	i := sort.SearchInts(ws, w)
+	return append(ws[:i:i], ws[i+1:]...)
+}
@@ -267,3 +267,194 @@ func TestAddBothNewCost(t *testing.T) {
 		t.Errorf("g5.Cost(3, 2) %s", mess)
 	}
 }
+
+func TestAddRemoveVertex(t *testing.T) {
+	g := New(0)
+	if mess, diff := diff(g.AddVertex(), 0); diff {
+		t.Errorf("AddVertex %s", mess)
+	}
+	if mess, diff := diff(g.AddVertex(), 1); diff {
+		t.Errorf("AddVertex %s", mess)
+	}
+	g.AddVertex()
+	g.AddVertex()
+	g.AddBothCost(0, 1, 1)
+	g.AddCost(1, 2, 2)
+	g.AddCost(2, 3, 3)
+	g.AddCost(3, 1, 4)
+	g.Add(1, 1)
+
+	g.RemoveVertex(1)
+	g.RemoveVertex(1)
+	if mess, diff := diff(g.String(), "4 [(2 3):3]"); diff {
+		t.Errorf("RemoveVertex %s", mess)
+	}
+	Consistent("RemoveVertex", t, g)
+	if mess, diff := diff([]bool{g.Removed(0), g.Removed(1), g.Removed(4)}, []bool{false, true, false}); diff {
+		t.Errorf("Removed %s", mess)
+	}
+	if h := Copy(g); h.String() != g.String() || !h.Removed(1) {
+		t.Errorf("Copy %v; removed %t", h, h.Removed(1))
+	}
+
+	// A removed vertex is reused.
+	if mess, diff := diff(g.AddVertex(), 1); diff {
+		t.Errorf("AddVertex %s", mess)
+	}
+	if mess, diff := diff(g.AddVertex(), 4); diff {
+		t.Errorf("AddVertex %s", mess)
+	}
+	if g.Removed(1) {
+		t.Errorf("Removed(1) true after reuse")
+	}
+	g.AddCost(4, 1, 5)
+	g.RemoveVertex(0)
+	g.RemoveVertex(2)
+	if mess, diff := diff(g.String(), "5 [(4 1):5]"); diff {
+		t.Errorf("RemoveVertex %s", mess)
+	}
+	index := g.Compact()
+	if mess, diff := diff(index, []int{-1, 0, -1, 1, 2}); diff {
+		t.Errorf("Compact %s", mess)
+	}
+	if mess, diff := diff(g.String(), "3 [(2 0):5]"); diff {
+		t.Errorf("Compact %s", mess)
+	}
+	Consistent("Compact", t, g)
+	if mess, diff := diff(g.AddVertex(), 3); diff {
+		t.Errorf("AddVertex after Compact %s", mess)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("RemoveVertex(4): no panic")
+		}
+	}()
+	g.RemoveVertex(4)
+}
+
+func TestAddRemovedVertex(t *testing.T) {
+	g := New(3)
+	g.Add(0, 1)
+	g.RemoveVertex(1)
+	g.Add(0, 1)
+	if g.Removed(1) {
+		t.Errorf("Removed(1) true after Add(0, 1)")
+	}
+	if mess, diff := diff(g.Compact(), []int{0, 1, 2}); diff {
+		t.Errorf("Compact %s", mess)
+	}
+	Consistent("Compact", t, g)
+	if _, dist := ShortestPaths(g, 0); dist[1] != 0 || dist[2] != -1 {
+		t.Errorf("ShortestPaths: dist %v", dist)
+	}
+
+	// The revived vertex isn't handed out again.
+	g.RemoveVertex(2)
+	g.RemoveVertex(1)
+	g.AddCost(2, 0, 1)
+	if mess, diff := diff(g.AddVertex(), 1); diff {
+		t.Errorf("AddVertex %s", mess)
+	}
+	if mess, diff := diff(g.AddVertex(), 3); diff {
+		t.Errorf("AddVertex %s", mess)
+	}
+	if mess, diff := diff(g.String(), "4 [(2 0):1]"); diff {
+		t.Errorf("AddCost %s", mess)
+	}
+}
+
+func TestSorted(t *testing.T) {
+	visitOrder := func(g Iterator, v int) []int {
+		res := []int{}
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			res = append(res, w)
+			return
+		})
+		return res
+	}
+	// sortedOK checks that each vertex visits its neighbors in order.
+	sortedOK := func(name string, g *Mutable) {
+		for v := 0; v < g.Order(); v++ {
+			res := visitOrder(g, v)
+			for i := 1; i < len(res); i++ {
+				if res[i-1] >= res[i] {
+					t.Errorf("%s: Visit(%d) %v not sorted", name, v, res)
+					break
+				}
+			}
+			if len(res) != g.Degree(v) {
+				t.Errorf("%s: Visit(%d) %v; degree %d", name, v, res, g.Degree(v))
+			}
+		}
+		Consistent(name, t, g)
+	}
+
+	n := 20
+	g := New(n)
+	for m := 100; m > 0; m-- {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(10))
+	}
+	if g.Sorted() {
+		t.Errorf("Sorted: true for new graph")
+	}
+	g.SetSorted(true)
+	if !g.Sorted() {
+		t.Errorf("Sorted: false after SetSorted(true)")
+	}
+	sortedOK("SetSorted", g)
+
+	for m := 200; m > 0; m-- {
+		v, w := rand.Intn(n), rand.Intn(n)
+		switch rand.Intn(3) {
+		case 0:
+			g.Delete(v, w)
+		default:
+			g.AddCost(v, w, rand.Int63n(10))
+		}
+	}
+	sortedOK("AddCost/Delete", g)
+
+	h := Copy(g)
+	if !h.Sorted() {
+		t.Errorf("Copy: not sorted")
+	}
+	sortedOK("Copy", h)
+
+	g.RemoveVertex(3)
+	g.RemoveVertex(7)
+	sortedOK("RemoveVertex", g)
+	g.AddVertex()
+	g.AddVertex()
+	g.AddVertex()
+	g.AddCost(n, 0, 1)
+	g.AddCost(n, 5, 1)
+	sortedOK("AddVertex", g)
+	g.RemoveVertex(0)
+	g.Compact()
+	sortedOK("Compact", g)
+
+	// Deleting during Visit is safe also in sorted mode.
+	g = New(4)
+	g.SetSorted(true)
+	g.Add(0, 1)
+	g.Add(0, 2)
+	g.Add(0, 3)
+	var res []int
+	g.Visit(0, func(w int, _ int64) (skip bool) {
+		res = append(res, w)
+		g.Delete(0, 2)
+		return
+	})
+	if mess, diff := diff(res, []int{1, 3}); diff {
+		t.Errorf("Delete during Visit %s", mess)
+	}
+
+	g.SetSorted(false)
+	if g.Sorted() {
+		t.Errorf("Sorted: true after SetSorted(false)")
+	}
+	if mess, diff := diff(g.String(), "4 [(0 1) (0 3)]"); diff {
+		t.Errorf("SetSorted(false) %s", mess)
+	}
+}
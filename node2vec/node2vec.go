@@ -0,0 +1,343 @@
+// Package node2vec generates corpora of biased random walks for
+// learning vertex embeddings, as in the node2vec algorithm by
+// Grover and Leskovec.
+//
+// A walk is second order: the probability of the next step depends
+// on both the current and the previous vertex. The return parameter P
+// controls how likely the walk is to go straight back, and the in-out
+// parameter Q how likely it is to move away from the previous vertex.
+// With P = Q = 1 the walks are ordinary random walks, as in DeepWalk.
+//
+// Walk takes a single walk, while Stream and Write generate a complete
+// corpus in parallel and pass it on to a channel or an io.Writer.
+// The corpus only depends on the graph and the options, not on the
+// number of workers or the order in which Visit lists the neighbors.
+package node2vec
+
+import (
+	"bufio"
+	"context"
+	"github.com/yourbasic/graph"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Options configure the walks.
+// A nil *Options is the same as the zero value.
+type Options struct {
+	// Length is the number of vertices in a walk, including the start.
+	// A walk that reaches a vertex without outgoing edges is shorter.
+	// If Length is 0, 80 is used.
+	Length int
+
+	// Walks is the number of walks that start at each vertex.
+	// If Walks is 0, 10 is used.
+	Walks int
+
+	// P is the return parameter: the weight of the edge back to the
+	// previous vertex is divided by P. If P is 0, 1 is used.
+	P float64
+
+	// Q is the in-out parameter: the weight of an edge to a vertex
+	// that isn't a neighbor of the previous vertex is divided by Q.
+	// If Q is 0, 1 is used.
+	Q float64
+
+	// Weighted tells if the weight of an edge is its cost;
+	// otherwise all edges have weight 1. With Weighted set,
+	// edges with a cost of 0 or less are never followed.
+	Weighted bool
+
+	// Workers is the number of goroutines that generate walks
+	// in parallel. If Workers ≤ 1, a single goroutine is used.
+	Workers int
+
+	// Seed initializes the random choices of the walks.
+	Seed int64
+}
+
+func (o *Options) length() int {
+	if o.Length > 0 {
+		return o.Length
+	}
+	return 80
+}
+
+func (o *Options) walks() int {
+	if o.Walks > 0 {
+		return o.Walks
+	}
+	return 10
+}
+
+// walker holds a sorted copy of the adjacency lists of a graph.
+type walker struct {
+	adj    [][]int
+	weight [][]float64
+	inv    [2]float64 // 1/P and 1/Q
+	length int
+}
+
+func newWalker(g graph.Iterator, opts *Options) *walker {
+	n := g.Order()
+	wk := &walker{
+		adj:    make([][]int, n),
+		weight: make([][]float64, n),
+		length: opts.length(),
+	}
+	for i, x := range [2]float64{opts.P, opts.Q} {
+		wk.inv[i] = 1
+		if x > 0 {
+			wk.inv[i] = 1 / x
+		}
+	}
+	type edge struct {
+		w int
+		c float64
+	}
+	var edges []edge
+	for v := 0; v < n; v++ {
+		edges = edges[:0]
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			x := 1.0
+			if opts.Weighted {
+				x = float64(c)
+			}
+			if x > 0 {
+				edges = append(edges, edge{w, x})
+			}
+			return
+		})
+		sort.Slice(edges, func(i, j int) bool {
+			if edges[i].w != edges[j].w {
+				return edges[i].w < edges[j].w
+			}
+			return edges[i].c < edges[j].c
+		})
+		wk.adj[v] = make([]int, len(edges))
+		wk.weight[v] = make([]float64, len(edges))
+		for i, e := range edges {
+			wk.adj[v][i] = e.w
+			wk.weight[v][i] = e.c
+		}
+	}
+	return wk
+}
+
+// adjacent tells if there is an edge from v to w.
+func (wk *walker) adjacent(v, w int) bool {
+	a := wk.adj[v]
+	i := sort.SearchInts(a, w)
+	return i < len(a) && a[i] == w
+}
+
+// walk appends a walk from start to path and returns the result.
+// The scratch slice is used for the transition weights.
+func (wk *walker) walk(path []int, start int, r *rand.Rand, scratch []float64) ([]int, []float64) {
+	path = append(path, start)
+	prev := -1
+	for v := start; len(path) < wk.length; {
+		adj, weight := wk.adj[v], wk.weight[v]
+		if len(adj) == 0 {
+			break
+		}
+		scratch = scratch[:0]
+		total := 0.0
+		for i, w := range adj {
+			x := weight[i]
+			switch {
+			case prev == -1:
+			case w == prev:
+				x *= wk.inv[0]
+			case !wk.adjacent(prev, w):
+				x *= wk.inv[1]
+			}
+			total += x
+			scratch = append(scratch, total)
+		}
+		i := sort.SearchFloat64s(scratch, r.Float64()*total)
+		if i == len(adj) {
+			i--
+		}
+		prev, v = v, adj[i]
+		path = append(path, v)
+	}
+	return path, scratch
+}
+
+// seed returns the seed of the i:th walk, mixed with the splitmix64
+// finalizer so that nearby walks are seeded very differently.
+func seed(s int64, i int) int64 {
+	z := uint64(s) + uint64(i+1)*0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return int64(z ^ z>>31)
+}
+
+// Walk returns a single biased random walk in g that begins at start.
+// Only the Length, P, Q and Weighted options are used.
+// The same source gives the same walk.
+//
+// Preparing the walk takes O(|E|⋅log|V| + |V|) time, where |E| is the
+// number of edges and |V| the number of vertices in the graph;
+// use Stream to take many walks in the same graph.
+func Walk(g graph.Iterator, start int, opts *Options, src rand.Source) []int {
+	if start < 0 || start >= g.Order() {
+		panic("vertex out of range: " + strconv.Itoa(start))
+	}
+	if opts == nil {
+		opts = new(Options)
+	}
+	path, _ := newWalker(g, opts).walk(nil, start, rand.New(src), nil)
+	return path
+}
+
+// Stream generates opts.Walks walks from each vertex of g and sends them
+// on ch. The walks are sent in rounds; in each round, there is one walk
+// from each vertex, in increasing order of the start vertex.
+// The order is the same for any number of workers, and each walk is
+// a new slice that the receiver may keep. Stream doesn't close ch.
+//
+// If the context is cancelled or its deadline expires, Stream stops
+// early and returns ctx.Err(); otherwise it returns nil once all walks
+// have been sent. The Visit method of g is only called before
+// the first walk is generated.
+//
+// The time complexity is O(|E|⋅log|V| + |V|⋅k⋅ℓ⋅d⋅log d), where |E| is the
+// number of edges and |V| the number of vertices in the graph, k is
+// opts.Walks, ℓ is opts.Length and d is the largest degree of a vertex.
+func Stream(ctx context.Context, g graph.Iterator, opts *Options, ch chan<- []int) error {
+	return generate(ctx, g, opts, func(path []int) error {
+		select {
+		case ch <- path:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// Write generates the same walks as Stream and writes them to w,
+// one walk per line, with the vertices separated by single spaces.
+// This is the text format expected by most word2vec implementations.
+//
+// It returns the first error from w, or ctx.Err() if the context
+// is done before all walks have been written.
+func Write(ctx context.Context, w io.Writer, g graph.Iterator, opts *Options) error {
+	b := bufio.NewWriter(w)
+	var line []byte
+	err := generate(ctx, g, opts, func(path []int) error {
+		line = line[:0]
+		for i, v := range path {
+			if i > 0 {
+				line = append(line, ' ')
+			}
+			line = strconv.AppendInt(line, int64(v), 10)
+		}
+		line = append(line, '\n')
+		_, err := b.Write(line)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return b.Flush()
+}
+
+// generate computes all walks in parallel and calls emit for each one,
+// in order, from a single goroutine. It stops at the first error.
+func generate(ctx context.Context, g graph.Iterator, opts *Options, emit func([]int) error) error {
+	if opts == nil {
+		opts = new(Options)
+	}
+	wk := newWalker(g, opts)
+	n, total := g.Order(), g.Order()*opts.walks()
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	if workers == 1 {
+		r := rand.New(rand.NewSource(0))
+		var scratch []float64
+		for i := 0; i < total; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			r.Seed(seed(opts.Seed, i))
+			var path []int
+			path, scratch = wk.walk(make([]int, 0, wk.length), i%n, r, scratch)
+			if err := emit(path); err != nil {
+				return err
+			}
+		}
+		return ctx.Err()
+	}
+
+	// Each job has its own result channel; the channels are queued
+	// in order, which lets the walks be emitted in order while
+	// at most cap(results) of them are pending.
+	type job struct {
+		i   int
+		res chan []int
+	}
+	inner, cancel := context.WithCancel(ctx)
+	defer cancel()
+	jobs := make(chan job)
+	results := make(chan chan []int, 4*workers)
+	var wg sync.WaitGroup
+	wg.Add(workers + 1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+		defer close(results)
+		for i := 0; i < total; i++ {
+			j := job{i, make(chan []int, 1)}
+			select {
+			case results <- j.res:
+			case <-inner.Done():
+				return
+			}
+			select {
+			case jobs <- j:
+			case <-inner.Done():
+				return
+			}
+		}
+	}()
+	for k := 0; k < workers; k++ {
+		go func() {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(0))
+			var scratch []float64
+			for j := range jobs {
+				r.Seed(seed(opts.Seed, j.i))
+				var path []int
+				path, scratch = wk.walk(make([]int, 0, wk.length), j.i%n, r, scratch)
+				j.res <- path
+			}
+		}()
+	}
+	var err error
+	for res := range results {
+		var path []int
+		select {
+		case path = <-res:
+		case <-inner.Done():
+		}
+		if path == nil {
+			break
+		}
+		if err = emit(path); err != nil {
+			break
+		}
+	}
+	cancel()
+	wg.Wait()
+	if err != nil {
+		return err
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,214 @@
+package node2vec
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// cycle returns an undirected cycle with n vertices.
+func cycle(n int) *graph.Mutable {
+	g := graph.New(n)
+	for v := 0; v < n; v++ {
+		g.AddBoth(v, (v+1)%n)
+	}
+	return g
+}
+
+// collect returns all walks sent by Stream.
+func collect(t *testing.T, g graph.Iterator, opts *Options) [][]int {
+	ch := make(chan []int)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- Stream(context.Background(), g, opts, ch)
+		close(ch)
+	}()
+	var walks [][]int
+	for path := range ch {
+		walks = append(walks, path)
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	return walks
+}
+
+func TestStream(t *testing.T) {
+	g := cycle(20)
+	g.Add(5, 5)
+	opts := &Options{Length: 15, Walks: 3, P: 0.5, Q: 2, Seed: 1}
+	walks := collect(t, g, opts)
+	if len(walks) != 60 {
+		t.Fatalf("Stream: %d walks, want 60", len(walks))
+	}
+	for i, path := range walks {
+		if path[0] != i%20 || len(path) != 15 {
+			t.Fatalf("Stream: walk %d is %v", i, path)
+		}
+		for j := 1; j < len(path); j++ {
+			if !g.Edge(path[j-1], path[j]) {
+				t.Fatalf("Stream: walk %d has no edge %d→%d", i, path[j-1], path[j])
+			}
+		}
+	}
+	for _, workers := range []int{2, 7} {
+		opts.Workers = workers
+		if res := collect(t, g, opts); !reflect.DeepEqual(res, walks) {
+			t.Errorf("Stream: %d workers gives other walks", workers)
+		}
+	}
+	opts.Seed = 2
+	if res := collect(t, g, opts); reflect.DeepEqual(res, walks) {
+		t.Errorf("Stream: seed is ignored")
+	}
+
+	// The walk stops at a vertex without outgoing edges.
+	h := graph.New(3)
+	h.Add(0, 1)
+	h.Add(1, 2)
+	res := collect(t, h, &Options{Length: 5, Walks: 1})
+	exp := [][]int{{0, 1, 2}, {1, 2}, {2}}
+	if !reflect.DeepEqual(res, exp) {
+		t.Errorf("Stream %v; want %v", res, exp)
+	}
+}
+
+func TestBias(t *testing.T) {
+	// In a cycle, each step either returns to the previous vertex,
+	// with weight 1/p, or moves on, with weight 1/q.
+	g := cycle(10)
+	for _, x := range []struct {
+		p, q     float64
+		min, max float64
+	}{
+		{1, 1, 0.4, 0.6},
+		{0.01, 1, 0.95, 1},
+		{100, 1, 0, 0.05},
+		{1, 0.01, 0, 0.05},
+	} {
+		returns, steps := 0, 0
+		for _, path := range collect(t, g, &Options{Length: 50, P: x.p, Q: x.q}) {
+			for i := 2; i < len(path); i++ {
+				if path[i] == path[i-2] {
+					returns++
+				}
+				steps++
+			}
+		}
+		if f := float64(returns) / float64(steps); f < x.min || f > x.max {
+			t.Errorf("p=%v q=%v: %.3f of the steps return, want %v to %v", x.p, x.q, f, x.min, x.max)
+		}
+	}
+
+	// A neighbor of the previous vertex has weight 1, never divided.
+	h := graph.New(4)
+	h.AddBoth(0, 1)
+	h.AddBoth(1, 2)
+	h.AddBoth(0, 2)
+	h.AddBoth(1, 3)
+	count := make([]int, 4)
+	src := rand.NewSource(1)
+	for i := 0; i < 2000; i++ {
+		path := Walk(h, 0, &Options{Length: 3, P: 1e9, Q: 1e9}, src)
+		if path[1] == 1 {
+			count[path[2]]++
+		}
+	}
+	if count[0] > 0 || count[3] > 0 || count[2] == 0 {
+		t.Errorf("Walk: steps after 0→1 are %v, want only 2", count)
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	g := graph.New(3)
+	g.AddCost(0, 1, 9)
+	g.AddCost(0, 2, 1)
+	g.AddCost(1, 0, 0)
+	count := make([]int, 3)
+	for _, path := range collect(t, g, &Options{Length: 2, Walks: 1000, Weighted: true}) {
+		if path[0] == 0 {
+			count[path[1]]++
+		}
+		if path[0] == 1 && len(path) != 1 {
+			t.Errorf("Weighted: walk %v follows an edge of cost 0", path)
+		}
+	}
+	if count[1] < 850 || count[1] > 950 {
+		t.Errorf("Weighted: %d of 1000 walks go to 1, want about 900", count[1])
+	}
+}
+
+func TestWrite(t *testing.T) {
+	g := cycle(6)
+	opts := &Options{Length: 4, Walks: 2, Workers: 3}
+	var buf bytes.Buffer
+	if err := Write(context.Background(), &buf, g, opts); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	var exp strings.Builder
+	for _, path := range collect(t, g, opts) {
+		exp.WriteString(strings.Trim(fmt.Sprint(path), "[]") + "\n")
+	}
+	if buf.String() != exp.String() {
+		t.Errorf("Write:\n%s\nwant\n%s", buf.String(), exp.String())
+	}
+}
+
+type errWriter int
+
+func (w *errWriter) Write(p []byte) (int, error) {
+	if *w -= errWriter(len(p)); *w < 0 {
+		return 0, errFull
+	}
+	return len(p), nil
+}
+
+var errFull = errors.New("full")
+
+func TestErrors(t *testing.T) {
+	g := cycle(100)
+	for _, workers := range []int{1, 4} {
+		opts := &Options{Workers: workers}
+		w := errWriter(10000)
+		if err := Write(context.Background(), &w, g, opts); err != errFull {
+			t.Errorf("Write with %d workers: %v, want %v", workers, err, errFull)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan []int)
+		errc := make(chan error, 1)
+		go func() { errc <- Stream(ctx, g, opts, ch) }()
+		for i := 0; i < 10; i++ {
+			<-ch
+		}
+		cancel()
+		if err := <-errc; err != context.Canceled {
+			t.Errorf("Stream with %d workers: %v, want %v", workers, err, context.Canceled)
+		}
+	}
+}
+
+func BenchmarkStream(b *testing.B) {
+	g := graph.New(1000)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		g.AddBoth(r.Intn(1000), r.Intn(1000))
+	}
+	opts := &Options{Workers: 4}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ch := make(chan []int, 100)
+		go func() {
+			Stream(context.Background(), g, opts, ch)
+			close(ch)
+		}()
+		for range ch {
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package graph
+
+// observers holds the functions registered with OnAddEdge,
+// OnDeleteEdge and OnCostChange. Canceled functions are nil.
+type observers struct {
+	add, del []func(v, w int, c int64)
+	change   []func(v, w int, old, new int64)
+}
+
+// OnAddEdge registers f to be called after an edge from v to w
+// with cost c has been added to the graph; changing the cost of an existing
+// edge is reported to OnCostChange instead. It returns a function
+// that unregisters f.
+//
+// Observers are called synchronously, in the order they were registered,
+// by AddCost and the other methods that modify the graph. They must not
+// modify the graph. Compact renumbers the vertices without calling
+// any observers, and copies of the graph have no observers.
+func (g *Mutable) OnAddEdge(f func(v, w int, c int64)) (cancel func()) {
+	o := g.observers()
+	o.add = append(o.add, f)
+	i := len(o.add) - 1
+	return func() { o.add[i] = nil }
+}
+
+// OnDeleteEdge registers f to be called after the edge from v to w
+// with cost c has been removed from the graph, by Delete, DeleteBoth
+// or RemoveVertex. It returns a function that unregisters f.
+// The rules for observers are described in OnAddEdge.
+func (g *Mutable) OnDeleteEdge(f func(v, w int, c int64)) (cancel func()) {
+	o := g.observers()
+	o.del = append(o.del, f)
+	i := len(o.del) - 1
+	return func() { o.del[i] = nil }
+}
+
+// OnCostChange registers f to be called after the cost of the edge
+// from v to w has changed from old to new. It returns a function
+// that unregisters f. The rules for observers are described in OnAddEdge.
+func (g *Mutable) OnCostChange(f func(v, w int, old, new int64)) (cancel func()) {
+	o := g.observers()
+	o.change = append(o.change, f)
+	i := len(o.change) - 1
+	return func() { o.change[i] = nil }
+}
+
+func (g *Mutable) observers() *observers {
+	if g.obs == nil {
+		g.obs = new(observers)
+	}
+	return g.obs
+}
+
+func (o *observers) added(v, w int, c int64) {
+	for _, f := range o.add {
+		if f != nil {
+			f(v, w, c)
+		}
+	}
+}
+
+func (o *observers) deleted(v, w int, c int64) {
+	for _, f := range o.del {
+		if f != nil {
+			f(v, w, c)
+		}
+	}
+}
+
+func (o *observers) changed(v, w int, old, new int64) {
+	for _, f := range o.change {
+		if f != nil {
+			f(v, w, old, new)
+		}
+	}
+}
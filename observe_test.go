@@ -0,0 +1,63 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestObservers(t *testing.T) {
+	g := New(3)
+	var events []string
+	cancel := g.OnAddEdge(func(v, w int, c int64) {
+		events = append(events, fmt.Sprintf("add %d %d %d", v, w, c))
+	})
+	g.OnDeleteEdge(func(v, w int, c int64) {
+		events = append(events, fmt.Sprintf("delete %d %d %d", v, w, c))
+	})
+	g.OnCostChange(func(v, w int, old, new int64) {
+		events = append(events, fmt.Sprintf("change %d %d %d %d", v, w, old, new))
+	})
+	g.AddCost(0, 1, 5)
+	g.AddCost(0, 1, 5)
+	g.AddCost(0, 1, 7)
+	g.AddBoth(1, 2)
+	g.Delete(2, 0)
+	g.DeleteBoth(1, 2)
+	g.Add(1, 1)
+	g.RemoveVertex(1)
+	cancel()
+	g.Add(2, 0)
+	exp := []string{
+		"add 0 1 5",
+		"change 0 1 5 7",
+		"add 1 2 0",
+		"add 2 1 0",
+		"delete 1 2 0",
+		"delete 2 1 0",
+		"add 1 1 0",
+		"delete 0 1 7",
+		"delete 1 1 0",
+	}
+	if mess, diff := diff(events, exp); diff {
+		t.Errorf("observers %s", mess)
+	}
+	if Copy(g).obs != nil {
+		t.Errorf("Copy kept the observers")
+	}
+}
+
+// Keep the in-degrees of a graph up to date.
+func ExampleMutable_OnAddEdge() {
+	g := New(3)
+	in := make([]int, g.Order())
+	g.OnAddEdge(func(v, w int, c int64) { in[w]++ })
+	g.OnDeleteEdge(func(v, w int, c int64) { in[w]-- })
+
+	g.Add(0, 2)
+	g.Add(1, 2)
+	g.AddCost(1, 2, 8)
+	g.Delete(0, 2)
+	g.Add(2, 0)
+	fmt.Println(in)
+	// Output: [1 0 1]
+}
@@ -0,0 +1,240 @@
+// Package partition splits the vertices of a graph into balanced parts
+// with few edges between them, for example to distribute a graph
+// across several workers.
+//
+// KWay computes a k-way partition by recursive bisection, and Bisect
+// splits a graph in two. Each bisection is multilevel: the graph is
+// coarsened by repeatedly contracting a heavy-edge matching, the
+// coarsest graph is split by greedy graph growing, and the split is
+// then projected back to the original graph and refined at each level
+// with the Kernighan–Lin heuristic, in the variant by Fiduccia and
+// Mattheyses.
+//
+// The graphs are undirected: an undirected edge {v, w} is represented by
+// the two directed edges (v, w) and (w, v), as in the graph package.
+package partition
+
+import (
+	"github.com/yourbasic/graph"
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+// Options configure the partitioning.
+// A nil *Options is the same as the zero value.
+type Options struct {
+	// Weighted tells if the cost of an edge is its weight;
+	// otherwise each edge has weight 1. The costs must be non-negative.
+	Weighted bool
+
+	// Imbalance is the allowed imbalance ε: each of the k parts
+	// gets at most (1+ε)⋅n/k of the n vertices, rounded up.
+	// If Imbalance is 0, 0.03 is used.
+	Imbalance float64
+
+	// Seed initializes the random choices of the algorithm.
+	Seed int64
+}
+
+// KWay splits the vertices of g into k parts of almost equal size,
+// with as few edges as possible between different parts. The vertex v
+// belongs to part[v], a number from 0 to k-1, and cut is the total weight
+// of the edges between different parts. It panics if k < 1.
+//
+// The k parts are found by recursive bisection, where each bisection
+// is computed as in Bisect. The time complexity is typically
+// O((|E| + |V|)⋅log k), where |E| is the number of edges and |V|
+// the number of vertices in the graph.
+func KWay(g graph.Iterator, k int, opts *Options) (part []int, cut int64) {
+	if k < 1 {
+		panic("invalid number of parts: " + strconv.Itoa(k))
+	}
+	if opts == nil {
+		opts = new(Options)
+	}
+	n := g.Order()
+	eps := opts.imbalance()
+	// The imbalance compounds over the levels of the recursion.
+	levels := 0
+	for 1<<levels < k {
+		levels++
+	}
+	if levels > 1 {
+		eps = math.Pow(1+eps, 1/float64(levels)) - 1
+	}
+	p := &partitioner{
+		r:   rand.New(rand.NewSource(opts.Seed)),
+		eps: eps,
+		max: int64(math.Ceil((1 + opts.imbalance()) * float64(n) / float64(k))),
+	}
+	ids := make([]int, n)
+	for v := range ids {
+		ids[v] = v
+	}
+	part = make([]int, n)
+	p.split(newWGraph(g, opts.Weighted), ids, k, 0, part)
+	return part, CutSize(g, part, opts)
+}
+
+// Bisect splits the vertices of g into two parts of almost equal size,
+// with as few edges as possible between them. It's the same as KWay(g, 2, opts).
+//
+// The time complexity is typically O(|E| + |V|), where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func Bisect(g graph.Iterator, opts *Options) (part []int, cut int64) {
+	return KWay(g, 2, opts)
+}
+
+// CutSize returns the total weight of the edges in g between vertices
+// in different parts, where vertex v belongs to part[v].
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func CutSize(g graph.Iterator, part []int, opts *Options) int64 {
+	if n := g.Order(); len(part) != n {
+		panic("slice length " + strconv.Itoa(len(part)) + " doesn't match graph order " + strconv.Itoa(n))
+	}
+	weighted := opts != nil && opts.Weighted
+	var cut int64
+	graph.VisitAll(g, func(v, w int, c int64) (skip bool) {
+		if part[v] != part[w] {
+			if weighted {
+				cut += c
+			} else {
+				cut++
+			}
+		}
+		return
+	})
+	// Each undirected edge is visited twice.
+	return cut / 2
+}
+
+func (opts *Options) imbalance() float64 {
+	if opts.Imbalance <= 0 {
+		return 0.03
+	}
+	return opts.Imbalance
+}
+
+// A partitioner splits a graph by recursive bisection. Each bisection
+// may be unbalanced by eps, but a side that is to be split into j parts
+// never gets a weight of more than j⋅max, which keeps all final parts
+// within the limit max.
+type partitioner struct {
+	r   *rand.Rand
+	eps float64
+	max int64
+}
+
+// split assigns the vertices of g, which are the vertices ids of the
+// original graph, to the k parts that start at first.
+func (p *partitioner) split(g *wgraph, ids []int, k, first int, part []int) {
+	if k == 1 || g.order() == 0 {
+		for _, v := range ids {
+			part[v] = first
+		}
+		return
+	}
+	k0 := k / 2
+	side := p.bisect(g, k0, k-k0)
+	for s, k := range [2]int{k0, k - k0} {
+		sub, index := g.induced(func(v int) bool { return side[v] == s })
+		subIds := make([]int, sub.order())
+		for v, i := range index {
+			if i != -1 {
+				subIds[i] = ids[v]
+			}
+		}
+		p.split(sub, subIds, k, first+s*k0, part)
+	}
+}
+
+// coarsest is the order at which coarsening stops.
+const coarsest = 64
+
+// bisect splits g into two sides that are to be split further into
+// k0 and k1 parts, with weights proportional to those numbers.
+func (p *partitioner) bisect(g *wgraph, k0, k1 int) []int {
+	total := g.totalWeight()
+	frac := float64(k0) / float64(k0+k1)
+	var limit [2]int64
+	for s, k := range [2]int{k0, k1} {
+		f := float64(k) / float64(k0+k1)
+		limit[s] = int64(math.Ceil(f * float64(total) * (1 + p.eps)))
+		if m := int64(k) * p.max; limit[s] > m {
+			limit[s] = m
+		}
+	}
+
+	// Coarsen.
+	graphs := []*wgraph{g}
+	var maps [][]int
+	for g.order() > coarsest {
+		c, cmap := p.coarsen(g, total/coarsest+1)
+		if 10*c.order() > 9*g.order() {
+			break
+		}
+		graphs, maps = append(graphs, c), append(maps, cmap)
+		g = c
+	}
+
+	// Split the coarsest graph.
+	var side []int
+	best := int64(-1)
+	for try := 0; try < 4; try++ {
+		s := p.grow(g, frac, limit)
+		cut := p.refine(g, s, limit)
+		if best == -1 || cut < best {
+			side, best = s, cut
+		}
+	}
+
+	// Uncoarsen and refine.
+	for i := len(maps) - 1; i >= 0; i-- {
+		g = graphs[i]
+		fine := make([]int, g.order())
+		for v, c := range maps[i] {
+			fine[v] = side[c]
+		}
+		side = fine
+		p.refine(g, side, limit)
+	}
+	return side
+}
+
+// grow returns an initial split of g, found by growing side 0 from
+// a random vertex in breadth-first order until it gets frac of the weight.
+func (p *partitioner) grow(g *wgraph, frac float64, limit [2]int64) []int {
+	n := g.order()
+	side := make([]int, n)
+	for v := range side {
+		side[v] = 1
+	}
+	target := int64(frac * float64(g.totalWeight()))
+	var weight int64
+	starts := p.r.Perm(n)
+	queue := make([]int, 0, n)
+	for next := 0; weight < target && next < n; next++ {
+		if side[starts[next]] == 0 {
+			continue
+		}
+		queue = append(queue[:0], starts[next])
+		side[starts[next]] = 0
+		weight += g.vw[starts[next]]
+		for i := 0; i < len(queue) && weight < target; i++ {
+			for _, w := range g.adj[queue[i]] {
+				if side[w] == 1 && weight+g.vw[w] <= limit[0] {
+					side[w] = 0
+					weight += g.vw[w]
+					queue = append(queue, w)
+					if weight >= target {
+						break
+					}
+				}
+			}
+		}
+	}
+	return side
+}
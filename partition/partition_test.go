@@ -0,0 +1,142 @@
+package partition
+
+import (
+	"github.com/yourbasic/graph"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// grid returns an undirected w×h grid graph.
+func grid(w, h int) *graph.Mutable {
+	g := graph.New(w * h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := y*w + x
+			if x+1 < w {
+				g.AddBoth(v, v+1)
+			}
+			if y+1 < h {
+				g.AddBoth(v, v+w)
+			}
+		}
+	}
+	return g
+}
+
+// checkParts checks that part has k balanced parts and that cut is correct.
+func checkParts(t *testing.T, name string, g graph.Iterator, k int, part []int, cut int64, eps float64) {
+	t.Helper()
+	n := g.Order()
+	size := make([]int, k)
+	for _, p := range part {
+		if p < 0 || p >= k {
+			t.Fatalf("%s: part %d out of range", name, p)
+		}
+		size[p]++
+	}
+	limit := int(math.Ceil((1 + eps) * float64(n) / float64(k)))
+	for p, s := range size {
+		if s > limit {
+			t.Errorf("%s: part %d has %d vertices, more than %d", name, p, s, limit)
+		}
+	}
+	if exp := CutSize(g, part, nil); cut != exp {
+		t.Errorf("%s: cut %d; want %d", name, cut, exp)
+	}
+}
+
+func TestBisect(t *testing.T) {
+	// Two cliques of size 8, joined by two edges.
+	g := graph.New(16)
+	for _, offset := range []int{0, 8} {
+		for v := 0; v < 8; v++ {
+			for w := v + 1; w < 8; w++ {
+				g.AddBoth(offset+v, offset+w)
+			}
+		}
+	}
+	g.AddBoth(0, 8)
+	g.AddBoth(7, 15)
+	part, cut := Bisect(g, nil)
+	checkParts(t, "Bisect", g, 2, part, cut, 0.03)
+	if cut != 2 {
+		t.Errorf("Bisect: cut %d; want 2", cut)
+	}
+
+	// Edges added with AddBoth have cost 0.
+	g.AddBothCost(1, 9, 5)
+	part, cut = Bisect(g, &Options{Weighted: true})
+	if cut != 0 {
+		t.Errorf("Bisect(weighted): cut %d; want 0", cut)
+	}
+	if part[1] != part[9] {
+		t.Errorf("Bisect(weighted): %d and %d in different parts", 1, 9)
+	}
+
+	g = grid(40, 40)
+	part, cut = Bisect(g, &Options{Seed: 1})
+	checkParts(t, "Bisect(grid)", g, 2, part, cut, 0.03)
+	if cut > 64 {
+		t.Errorf("Bisect(grid): cut %d; want at most 64, and 40 is optimal", cut)
+	}
+
+	// The same seed gives the same result, even though
+	// g visits its neighbors in a different order each time.
+	for i := 0; i < 5; i++ {
+		again, _ := Bisect(g, &Options{Seed: 1})
+		for v := range part {
+			if again[v] != part[v] {
+				t.Errorf("Bisect(grid): vertex %d in part %d, then %d, with the same seed", v, part[v], again[v])
+				break
+			}
+		}
+	}
+}
+
+func TestKWay(t *testing.T) {
+	g := grid(30, 30)
+	for _, k := range []int{1, 3, 4, 7, 16} {
+		part, cut := KWay(g, k, &Options{Imbalance: 0.05})
+		checkParts(t, "KWay", g, k, part, cut, 0.05)
+		// A good k-way split of the grid has a cut of less than 80⋅√k.
+		if limit := int64(80 * math.Sqrt(float64(k))); k > 1 && cut > limit {
+			t.Errorf("KWay(%d): cut %d, more than %d", k, cut, limit)
+		}
+	}
+	part, cut := KWay(g, 1, nil)
+	if cut != 0 || part[899] != 0 {
+		t.Errorf("KWay(1): cut %d", cut)
+	}
+
+	// Random graphs, with isolated vertices and more parts than vertices.
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		n := 1 + r.Intn(200)
+		g := graph.New(n)
+		for j := r.Intn(3 * n); j > 0; j-- {
+			g.AddBoth(r.Intn(n), r.Intn(n))
+		}
+		k := 1 + r.Intn(10)
+		part, cut := KWay(g, k, &Options{Seed: int64(i)})
+		checkParts(t, "KWay(random)", g, k, part, cut, 0.03)
+	}
+	part, _ = KWay(graph.New(3), 5, nil)
+	if len(part) != 3 {
+		t.Errorf("KWay(k > n) %v", part)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("KWay(0): no panic")
+		}
+	}()
+	KWay(g, 0, nil)
+}
+
+func BenchmarkKWay(b *testing.B) {
+	g := grid(300, 300)
+	for i := 0; i < b.N; i++ {
+		KWay(g, 8, nil)
+	}
+}
@@ -0,0 +1,137 @@
+package partition
+
+import "container/heap"
+
+// maxStall is the number of moves without improvement
+// after which a refinement pass stops.
+const maxStall = 64
+
+// refine improves the split of g in place with the Fiduccia–Mattheyses
+// heuristic, and returns the weight of the cut. Side s may have at most
+// the weight limit[s]; a split that breaks the limits is first balanced.
+//
+// Each pass moves unlocked vertices one at a time to the other side,
+// always picking a vertex with the largest gain, the decrease in cut
+// weight, among the moves that keep the sides within the limits.
+// The moved vertex is then locked. At the end of the pass, the moves after
+// the best split seen during the pass are undone. The passes are repeated
+// until there is no improvement.
+func (p *partitioner) refine(g *wgraph, side []int, limit [2]int64) int64 {
+	n := g.order()
+	gain := make([]int64, n)
+	locked := make([]bool, n)
+	var weight [2]int64
+	var cut int64
+	for v := 0; v < n; v++ {
+		weight[side[v]] += g.vw[v]
+		for i, w := range g.adj[v] {
+			if side[w] != side[v] {
+				cut += g.ew[v][i]
+			}
+		}
+	}
+	cut /= 2
+	// excess is the amount by which the split breaks the limits.
+	excess := func() int64 {
+		var sum int64
+		for s, w := range weight {
+			if w > limit[s] {
+				sum += w - limit[s]
+			}
+		}
+		return sum
+	}
+
+	var moves []int
+	for pass := 0; pass < 16; pass++ {
+		h := gainHeap{}
+		for v := 0; v < n; v++ {
+			gain[v] = 0
+			for i, w := range g.adj[v] {
+				if side[w] != side[v] {
+					gain[v] += g.ew[v][i]
+				} else {
+					gain[v] -= g.ew[v][i]
+				}
+			}
+			locked[v] = false
+			h = append(h, gainEntry{v, gain[v]})
+		}
+		heap.Init(&h)
+
+		moves = moves[:0]
+		bestExcess, bestCut, best := excess(), cut, 0
+		for h.Len() > 0 && len(moves)-best < maxStall {
+			e := heap.Pop(&h).(gainEntry)
+			v := e.v
+			if locked[v] || e.gain != gain[v] {
+				continue // moved, or an outdated entry
+			}
+			// A vertex that can't be moved now stays locked in this pass.
+			locked[v] = true
+			s, t := side[v], 1-side[v]
+			before := excess()
+			weight[s] -= g.vw[v]
+			weight[t] += g.vw[v]
+			if weight[t] > limit[t] && excess() >= before {
+				weight[s] += g.vw[v]
+				weight[t] -= g.vw[v]
+				continue
+			}
+			side[v] = t
+			cut -= gain[v]
+			moves = append(moves, v)
+			for i, w := range g.adj[v] {
+				if side[w] == t {
+					gain[w] -= 2 * g.ew[v][i]
+				} else {
+					gain[w] += 2 * g.ew[v][i]
+				}
+				if !locked[w] {
+					heap.Push(&h, gainEntry{w, gain[w]})
+				}
+			}
+			if x := excess(); x < bestExcess || x == bestExcess && cut < bestCut {
+				bestExcess, bestCut, best = x, cut, len(moves)
+			}
+		}
+		// Undo the moves after the best split.
+		for _, v := range moves[best:] {
+			s, t := side[v], 1-side[v]
+			weight[s] -= g.vw[v]
+			weight[t] += g.vw[v]
+			side[v] = t
+		}
+		cut = bestCut
+		if best == 0 {
+			break
+		}
+	}
+	return cut
+}
+
+type gainEntry struct {
+	v    int
+	gain int64
+}
+
+// gainHeap is a max-heap of vertices ordered by gain,
+// with ties broken by vertex number.
+type gainHeap []gainEntry
+
+func (h gainHeap) Len() int { return len(h) }
+func (h gainHeap) Less(i, j int) bool {
+	if h[i].gain != h[j].gain {
+		return h[i].gain > h[j].gain
+	}
+	return h[i].v < h[j].v
+}
+func (h gainHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *gainHeap) Push(x interface{}) { *h = append(*h, x.(gainEntry)) }
+func (h *gainHeap) Pop() interface{} {
+	old := *h
+	n := len(old) - 1
+	x := old[n]
+	*h = old[:n]
+	return x
+}
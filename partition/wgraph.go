@@ -0,0 +1,171 @@
+package partition
+
+import (
+	"github.com/yourbasic/graph"
+	"sort"
+)
+
+// wgraph is an undirected graph with weighted vertices and edges,
+// without self-loops or parallel edges. The neighbors of v are adj[v],
+// in increasing order, and the edge to adj[v][i] has the weight ew[v][i].
+type wgraph struct {
+	vw  []int64
+	adj [][]int
+	ew  [][]int64
+}
+
+// newWGraph returns g as a wgraph, where each vertex has weight 1,
+// and the weight of an edge {v, w} is the sum of the weights of
+// the directed edges (v, w) and (w, v) in g. For an undirected graph,
+// this is twice the weight of the edge, which scales all cuts equally.
+// The neighbors are sorted, so that the result doesn't depend on
+// the order in which g visits them.
+func newWGraph(g graph.Iterator, weighted bool) *wgraph {
+	n := g.Order()
+	type half struct {
+		w int
+		x int64
+	}
+	both := make([][]half, n)
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if v == w {
+				return
+			}
+			x := int64(1)
+			if weighted {
+				x = c
+			}
+			both[v] = append(both[v], half{w, x})
+			both[w] = append(both[w], half{v, x})
+			return
+		})
+	}
+	h := &wgraph{
+		vw:  make([]int64, n),
+		adj: make([][]int, n),
+		ew:  make([][]int64, n),
+	}
+	pos := make([]int, n) // pos[w] is the index of w in h.adj[v] plus 1
+	for v, list := range both {
+		h.vw[v] = 1
+		sort.Slice(list, func(i, j int) bool { return list[i].w < list[j].w })
+		for _, e := range list {
+			if pos[e.w] == 0 {
+				h.adj[v] = append(h.adj[v], e.w)
+				h.ew[v] = append(h.ew[v], 0)
+				pos[e.w] = len(h.adj[v])
+			}
+			h.ew[v][pos[e.w]-1] += e.x
+		}
+		for _, w := range h.adj[v] {
+			pos[w] = 0
+		}
+	}
+	return h
+}
+
+func (g *wgraph) order() int {
+	return len(g.vw)
+}
+
+func (g *wgraph) totalWeight() int64 {
+	var sum int64
+	for _, x := range g.vw {
+		sum += x
+	}
+	return sum
+}
+
+// induced returns the subgraph induced by the vertices for which
+// keep is true, and the index of each vertex of g in the subgraph,
+// or -1 if it isn't part of it.
+func (g *wgraph) induced(keep func(v int) bool) (*wgraph, []int) {
+	index := make([]int, g.order())
+	m := 0
+	for v := range index {
+		index[v] = -1
+		if keep(v) {
+			index[v] = m
+			m++
+		}
+	}
+	h := &wgraph{
+		vw:  make([]int64, m),
+		adj: make([][]int, m),
+		ew:  make([][]int64, m),
+	}
+	for v, i := range index {
+		if i == -1 {
+			continue
+		}
+		h.vw[i] = g.vw[v]
+		for j, w := range g.adj[v] {
+			if k := index[w]; k != -1 {
+				h.adj[i] = append(h.adj[i], k)
+				h.ew[i] = append(h.ew[i], g.ew[v][j])
+			}
+		}
+	}
+	return h, index
+}
+
+// coarsen contracts a heavy-edge matching of g, visiting the vertices
+// in random order and matching each unmatched vertex to the unmatched
+// neighbor with the heaviest edge, unless their combined weight is more
+// than maxWeight. It returns the coarse graph and the coarse vertex
+// of each vertex in g.
+func (p *partitioner) coarsen(g *wgraph, maxWeight int64) (*wgraph, []int) {
+	n := g.order()
+	cmap := make([]int, n)
+	for v := range cmap {
+		cmap[v] = -1
+	}
+	var members [][2]int // the one or two vertices of each coarse vertex
+	for _, v := range p.r.Perm(n) {
+		if cmap[v] != -1 {
+			continue
+		}
+		match := v
+		var heaviest int64 = -1
+		for i, w := range g.adj[v] {
+			if cmap[w] == -1 && g.ew[v][i] > heaviest && g.vw[v]+g.vw[w] <= maxWeight {
+				match, heaviest = w, g.ew[v][i]
+			}
+		}
+		cmap[v], cmap[match] = len(members), len(members)
+		members = append(members, [2]int{v, match})
+	}
+
+	m := len(members)
+	c := &wgraph{
+		vw:  make([]int64, m),
+		adj: make([][]int, m),
+		ew:  make([][]int64, m),
+	}
+	pos := make([]int, m) // pos[d] is the index of d in c.adj[cv] plus 1
+	for cv, pair := range members {
+		for i, v := range pair {
+			if i == 1 && v == pair[0] {
+				break
+			}
+			c.vw[cv] += g.vw[v]
+			for j, w := range g.adj[v] {
+				d := cmap[w]
+				if d == cv {
+					continue
+				}
+				if pos[d] == 0 {
+					c.adj[cv] = append(c.adj[cv], d)
+					c.ew[cv] = append(c.ew[cv], 0)
+					pos[d] = len(c.adj[cv])
+				}
+				c.ew[cv][pos[d]-1] += g.ew[v][j]
+			}
+		}
+		for _, d := range c.adj[cv] {
+			pos[d] = 0
+		}
+	}
+	return c, cmap
+}
@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+)
+
+// Patch describes the changes that turn one graph into another,
+// as computed by Diff. The edge lists are sorted by V, then by W.
+type Patch struct {
+	Order   int          // the order of the new graph
+	Added   []Edge       // edges of the new graph only, with their new costs
+	Removed []Edge       // edges of the old graph only, with their old costs
+	Changed []CostChange // edges of both graphs with different costs
+}
+
+// CostChange is a change of the cost of the edge from V to W.
+type CostChange struct {
+	V, W     int
+	Old, New int64
+}
+
+// ErrConflict is returned by ApplyPatch when the graph doesn't
+// match the old graph of the patch.
+var ErrConflict = errors.New("graph: patch conflicts with graph")
+
+// Diff returns the changes that turn old into new.
+// The new graph may have more vertices than the old one, but not fewer.
+// If old or new is a multigraph, any duplicate edges will be lost.
+//
+// The time complexity is O(|E|⋅log|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the two graphs.
+func Diff(old, new Iterator) Patch {
+	m, n := old.Order(), new.Order()
+	if m > n {
+		panic("order mismatch: " + strconv.Itoa(m) + " > " + strconv.Itoa(n))
+	}
+	p := Patch{Order: n, Added: []Edge{}, Removed: []Edge{}, Changed: []CostChange{}}
+	cost := make(map[int]int64)
+	for v := 0; v < n; v++ {
+		clear(cost)
+		if v < m {
+			old.Visit(v, func(w int, c int64) (skip bool) {
+				cost[w] = c
+				return
+			})
+		}
+		new.Visit(v, func(w int, c int64) (skip bool) {
+			if prev, ok := cost[w]; !ok {
+				p.Added = append(p.Added, Edge{v, w, c})
+			} else if c != prev {
+				p.Changed = append(p.Changed, CostChange{v, w, prev, c})
+			}
+			delete(cost, w)
+			return
+		})
+		for w, c := range cost {
+			p.Removed = append(p.Removed, Edge{v, w, c})
+		}
+	}
+	sortEdges(p.Added)
+	sortEdges(p.Removed)
+	sort.Slice(p.Changed, func(i, j int) bool {
+		a, b := p.Changed[i], p.Changed[j]
+		return a.V < b.V || a.V == b.V && a.W < b.W
+	})
+	return p
+}
+
+// ApplyPatch applies the changes in p to g, adding vertices if g has
+// fewer than p.Order. If g doesn't match the old graph of the patch,
+// it returns ErrConflict and leaves g unchanged: each removed or changed
+// edge must exist in g with its old cost, and no added edge may exist.
+//
+// The time complexity is O(|P|), where |P| is the number of changes in the patch.
+func ApplyPatch(g *Mutable, p Patch) error {
+	n := g.Order()
+	has := func(v, w int) bool { return v < n && w < n && g.Edge(v, w) }
+	for _, e := range p.Added {
+		if e.V < 0 || e.W < 0 || e.V >= p.Order || e.W >= p.Order || has(e.V, e.W) {
+			return ErrConflict
+		}
+	}
+	for _, e := range p.Removed {
+		if e.V < 0 || e.W < 0 || !has(e.V, e.W) || g.Cost(e.V, e.W) != e.Cost {
+			return ErrConflict
+		}
+	}
+	for _, c := range p.Changed {
+		if c.V < 0 || c.W < 0 || !has(c.V, c.W) || g.Cost(c.V, c.W) != c.Old {
+			return ErrConflict
+		}
+	}
+	// Grow the graph without reusing removed vertices.
+	for len(g.edges) < p.Order {
+		g.edges = append(g.edges, nil)
+		if g.removed != nil {
+			g.removed = append(g.removed, false)
+		}
+		if g.sorted != nil {
+			g.sorted = append(g.sorted, nil)
+		}
+	}
+	for _, e := range p.Removed {
+		g.Delete(e.V, e.W)
+	}
+	for _, c := range p.Changed {
+		g.AddCost(c.V, c.W, c.New)
+	}
+	for _, e := range p.Added {
+		g.AddCost(e.V, e.W, e.Cost)
+	}
+	return nil
+}
+
+// sortEdges sorts a list of edges by V, then by W.
+func sortEdges(edges []Edge) {
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		return a.V < b.V || a.V == b.V && a.W < b.W
+	})
+}
@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	g, h := setupSetOps()
+	h.AddCost(0, 1, 1)
+	p := Diff(g, h)
+	exp := Patch{
+		Order:   4,
+		Added:   []Edge{{3, 0, 40}},
+		Removed: []Edge{},
+		Changed: []CostChange{{1, 2, 2, 20}, {2, 3, 3, 30}},
+	}
+	if mess, diff := diff(p, exp); diff {
+		t.Errorf("Diff %s", mess)
+	}
+	if err := ApplyPatch(g, p); err != nil {
+		t.Errorf("ApplyPatch: %v", err)
+	}
+	if !Equal(g, h) {
+		t.Errorf("ApplyPatch: %v; want %v", g, h)
+	}
+	if err := ApplyPatch(g, p); err != ErrConflict {
+		t.Errorf("ApplyPatch twice: %v; want %v", err, ErrConflict)
+	}
+
+	g = New(2)
+	h = New(3)
+	g.Add(0, 1)
+	h.AddCost(2, 0, 5)
+	p = Diff(g, h)
+	exp = Patch{
+		Order:   3,
+		Added:   []Edge{{2, 0, 5}},
+		Removed: []Edge{{0, 1, 0}},
+		Changed: []CostChange{},
+	}
+	if mess, diff := diff(p, exp); diff {
+		t.Errorf("Diff %s", mess)
+	}
+	g.RemoveVertex(1)
+	g.Add(0, 1)
+	if err := ApplyPatch(g, p); err != nil || g.Order() != 3 || !g.Edge(2, 0) || g.Edge(0, 1) {
+		t.Errorf("ApplyPatch with removed vertex: %v, %v", err, g)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Diff: no panic for shrinking graph")
+		}
+	}()
+	Diff(h, New(2))
+}
+
+func TestDiffRandom(t *testing.T) {
+	for n := 1; n < 30; n++ {
+		g, h := New(n), New(n+rand.Intn(3))
+		for m := rand.Intn(3*n + 1); m > 0; m-- {
+			g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(3))
+			h.AddCost(rand.Intn(h.Order()), rand.Intn(h.Order()), rand.Int63n(3))
+		}
+		p := Diff(g, h)
+		if err := ApplyPatch(g, p); err != nil {
+			t.Errorf("ApplyPatch: %v", err)
+		}
+		if !Equal(g, h) {
+			t.Errorf("ApplyPatch(Diff(g, h)): %v; want %v", g, h)
+		}
+		Consistent("ApplyPatch", t, g)
+	}
+}
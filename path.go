@@ -7,18 +7,21 @@ package graph
 // The time complexity is O((|E| + |V|)⋅log|V|), where |E| is the number of edges
 // and |V| the number of vertices in the graph.
 func ShortestPath(g Iterator, v, w int) (path []int, dist int64) {
-	parent, distances := ShortestPaths(g, v)
-	path, dist = []int{}, distances[w]
-	if dist == -1 {
-		return
-	}
-	for v := w; v != -1; v = parent[v] {
-		path = append(path, v)
-	}
-	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
-		path[i], path[j] = path[j], path[i]
-	}
-	return
+	t := ShortestPathTree(g, v)
+	return t.PathTo(w), t.DistTo(w)
+}
+
+// ShortestPathFiltered computes a shortest path from v to w,
+// using only edges (x, y) of cost c for which keep(x, y, c) is true.
+// The filter is applied during the search; no copy of g is made.
+// Only edges with non-negative costs are included.
+// The number dist is the length of the path, or -1 if w cannot be reached.
+//
+// The time complexity is O((|E| + |V|)⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func ShortestPathFiltered(g Iterator, v, w int, keep func(v, w int, c int64) bool) (path []int, dist int64) {
+	t := NewPathTree(ShortestPathsFiltered(g, v, keep))
+	return t.PathTo(w), t.DistTo(w)
 }
 
 // ShortestPaths computes the shortest paths from v to all other vertices.
@@ -31,17 +34,107 @@ func ShortestPath(g Iterator, v, w int) (path []int, dist int64) {
 // The time complexity is O((|E| + |V|)⋅log|V|), where |E| is the number of edges
 // and |V| the number of vertices in the graph.
 func ShortestPaths(g Iterator, v int) (parent []int, dist []int64) {
+	return ShortestPathsFiltered(g, v, nil)
+}
+
+// ShortestPathsFiltered computes the shortest paths from v to all other
+// vertices, using only edges (x, y) of cost c for which keep(x, y, c) is true.
+// A nil keep function includes all edges. The results are the same as
+// for ShortestPaths applied to the filtered graph.
+//
+// The time complexity is O((|E| + |V|)⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func ShortestPathsFiltered(g Iterator, v int, keep func(v, w int, c int64) bool) (parent []int, dist []int64) {
+	return shortestPaths(g, v, keep, Max)
+}
+
+// ShortestPathsWithin computes the shortest paths from v to all vertices
+// at distance at most maxDist from v. Only edges with non-negative costs
+// are included. The search stops as soon as all remaining vertices are
+// farther away than maxDist; these vertices are reported as unreachable.
+// The numbers parent[w] and dist[w] are the same as for ShortestPaths.
+//
+// The time complexity is O((|E'| + |V'|)⋅log|V'|), where |V'| is the number
+// of vertices within distance maxDist and |E'| the number of edges leaving them.
+// Only the allocation of the result slices depends on the size of the graph.
+func ShortestPathsWithin(g Iterator, v int, maxDist int64) (parent []int, dist []int64) {
+	return shortestPaths(g, v, nil, maxDist)
+}
+
+// shortestPaths runs Dijkstra's algorithm from v, including only edges
+// accepted by keep, or all edges if keep is nil, and only paths of length
+// at most maxDist.
+func shortestPaths(g Iterator, v int, keep func(v, w int, c int64) bool, maxDist int64) (parent []int, dist []int64) {
 	n := g.Order()
 	dist = make([]int64, n)
 	parent = make([]int, n)
+	dijkstra(g, v, keep, maxDist, parent, dist, emptyPrioQueue(dist))
+	return
+}
+
+// dijkstra is like shortestPaths, but writes its results into parent
+// and dist, which must have length g.Order(), and uses Q as its queue.
+// The queue must be empty, and its costs must be dist.
+func dijkstra(g Iterator, v int, keep func(v, w int, c int64) bool, maxDist int64, parent []int, dist []int64, Q *prioQueue) {
 	for i := range dist {
 		dist[i], parent[i] = -1, -1
 	}
 	dist[v] = 0
 
 	// Dijkstra's algorithm
-	Q := emptyPrioQueue(dist)
+	// The visit function is created once, since it escapes to the heap.
+	visit := func(w int, d int64) (skip bool) {
+		if d < 0 || keep != nil && !keep(v, w, d) {
+			return
+		}
+		alt := dist[v] + d
+		if alt > maxDist {
+			return
+		}
+		switch {
+		case dist[w] == -1:
+			dist[w], parent[w] = alt, v
+			Q.Push(w)
+		case alt < dist[w]:
+			dist[w], parent[w] = alt, v
+			Q.Fix(w)
+		}
+		return
+	}
 	Q.Push(v)
+	for Q.Len() > 0 {
+		v = Q.Pop()
+		g.Visit(v, visit)
+	}
+}
+
+// ShortestPathsMulti computes the shortest paths from a set of sources
+// to all other vertices. Only edges with non-negative costs are included.
+// The number nearest[w] is a source closest to w, or -1 if no source
+// can reach w. The number dist[w] equals the length of a shortest path
+// from nearest[w] to w, or is -1 if w cannot be reached.
+// The number parent[w] is the predecessor of w on such a path,
+// or -1 if none exists.
+//
+// The time complexity is O((|E| + |V|)⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func ShortestPathsMulti(g Iterator, sources ...int) (parent, nearest []int, dist []int64) {
+	n := g.Order()
+	dist = make([]int64, n)
+	parent = make([]int, n)
+	nearest = make([]int, n)
+	for i := range dist {
+		dist[i], parent[i], nearest[i] = -1, -1, -1
+	}
+
+	// Dijkstra's algorithm with all sources at distance 0
+	Q := emptyPrioQueue(dist)
+	for _, s := range sources {
+		if dist[s] == -1 {
+			dist[s], nearest[s] = 0, s
+			Q.Push(s)
+		}
+	}
 	for Q.Len() > 0 {
 		v := Q.Pop()
 		g.Visit(v, func(w int, d int64) (skip bool) {
@@ -51,10 +144,10 @@ func ShortestPaths(g Iterator, v int) (parent []int, dist []int64) {
 			alt := dist[v] + d
 			switch {
 			case dist[w] == -1:
-				dist[w], parent[w] = alt, v
+				dist[w], parent[w], nearest[w] = alt, v, nearest[v]
 				Q.Push(w)
 			case alt < dist[w]:
-				dist[w], parent[w] = alt, v
+				dist[w], parent[w], nearest[w] = alt, v, nearest[v]
 				Q.Fix(w)
 			}
 			return
@@ -62,3 +155,45 @@ func ShortestPaths(g Iterator, v int) (parent []int, dist []int64) {
 	}
 	return
 }
+
+// ShortestPathWithQueue computes a shortest path from v to w,
+// using q as the priority queue of Dijkstra's algorithm.
+// The queue must be empty. It returns the same values as ShortestPath;
+// the time complexity depends on the implementation of the queue.
+func ShortestPathWithQueue(g Iterator, v, w int, q DistQueue) (path []int, dist int64) {
+	t := NewPathTree(ShortestPathsWithQueue(g, v, q))
+	return t.PathTo(w), t.DistTo(w)
+}
+
+// ShortestPathsWithQueue computes the shortest paths from v to all other
+// vertices, using q as the priority queue of Dijkstra's algorithm.
+// The queue must be empty. It returns the same values as ShortestPaths;
+// the time complexity depends on the implementation of the queue.
+func ShortestPathsWithQueue(g Iterator, v int, q DistQueue) (parent []int, dist []int64) {
+	n := g.Order()
+	dist = make([]int64, n)
+	parent = make([]int, n)
+	for i := range dist {
+		dist[i], parent[i] = -1, -1
+	}
+	dist[v] = 0
+
+	q.Push(v, 0)
+	for q.Len() > 0 {
+		v, d := q.Pop()
+		if d > dist[v] {
+			continue // An outdated entry.
+		}
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if c < 0 {
+				return
+			}
+			if alt := d + c; dist[w] == -1 || alt < dist[w] {
+				dist[w], parent[w] = alt, v
+				q.Push(w, alt)
+			}
+			return
+		})
+	}
+	return
+}
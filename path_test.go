@@ -50,6 +50,126 @@ func TestShortestPath(t *testing.T) {
 	}
 }
 
+func TestShortestPathFiltered(t *testing.T) {
+	g := New(5)
+	g.AddBothCost(0, 1, 1) //  0 -- 1 -- 2
+	g.AddBothCost(1, 2, 1) //  |         |
+	g.AddBothCost(0, 3, 2) //  3 ------- 4
+	g.AddBothCost(3, 4, 2)
+	g.AddBothCost(2, 4, 1)
+
+	all := func(v, w int, c int64) bool { return true }
+	path, dist := ShortestPathFiltered(g, 0, 4, all)
+	if mess, diff := diff(path, []int{0, 1, 2, 4}); diff {
+		t.Errorf("ShortestPathFiltered->path %s", mess)
+	}
+	if mess, diff := diff(dist, int64(3)); diff {
+		t.Errorf("ShortestPathFiltered->dist %s", mess)
+	}
+
+	// Close the road between 1 and 2, in one direction.
+	closed := func(v, w int, c int64) bool { return !(v == 1 && w == 2) }
+	path, dist = ShortestPathFiltered(g, 0, 4, closed)
+	if mess, diff := diff(path, []int{0, 3, 4}); diff {
+		t.Errorf("ShortestPathFiltered->path %s", mess)
+	}
+	if mess, diff := diff(dist, int64(4)); diff {
+		t.Errorf("ShortestPathFiltered->dist %s", mess)
+	}
+	path, dist = ShortestPathFiltered(g, 4, 0, closed)
+	if mess, diff := diff(path, []int{4, 2, 1, 0}); diff {
+		t.Errorf("ShortestPathFiltered->path %s", mess)
+	}
+	if mess, diff := diff(dist, int64(3)); diff {
+		t.Errorf("ShortestPathFiltered->dist %s", mess)
+	}
+
+	cheap := func(v, w int, c int64) bool { return c < 2 }
+	parent, distances := ShortestPathsFiltered(g, 0, cheap)
+	if mess, diff := diff(parent, []int{-1, 0, 1, -1, 2}); diff {
+		t.Errorf("ShortestPathsFiltered->parent %s", mess)
+	}
+	if mess, diff := diff(distances, []int64{0, 1, 2, -1, 3}); diff {
+		t.Errorf("ShortestPathsFiltered->dist %s", mess)
+	}
+	parent, distances = ShortestPathsFiltered(g, 0, nil)
+	if mess, diff := diff(parent, []int{-1, 0, 1, 0, 2}); diff {
+		t.Errorf("ShortestPathsFiltered->parent %s", mess)
+	}
+	if mess, diff := diff(distances, []int64{0, 1, 2, 2, 3}); diff {
+		t.Errorf("ShortestPathsFiltered->dist %s", mess)
+	}
+}
+
+func TestShortestPathsMulti(t *testing.T) {
+	g := New(7)
+	g.AddBothCost(0, 1, 2) //  0 -- 1 -- 2 -- 3 -- 4    5 --> 6
+	g.AddBothCost(1, 2, 2)
+	g.AddBothCost(2, 3, 1)
+	g.AddBothCost(3, 4, 1)
+	g.AddCost(5, 6, 1)
+
+	parent, nearest, dist := ShortestPathsMulti(g, 0, 4, 4)
+	if mess, diff := diff(parent, []int{-1, 0, 3, 4, -1, -1, -1}); diff {
+		t.Errorf("ShortestPathsMulti->parent %s", mess)
+	}
+	if mess, diff := diff(nearest, []int{0, 0, 4, 4, 4, -1, -1}); diff {
+		t.Errorf("ShortestPathsMulti->nearest %s", mess)
+	}
+	if mess, diff := diff(dist, []int64{0, 2, 2, 1, 0, -1, -1}); diff {
+		t.Errorf("ShortestPathsMulti->dist %s", mess)
+	}
+
+	parent, nearest, dist = ShortestPathsMulti(g)
+	if mess, diff := diff(parent, []int{-1, -1, -1, -1, -1, -1, -1}); diff {
+		t.Errorf("ShortestPathsMulti->parent %s", mess)
+	}
+	if mess, diff := diff(nearest, []int{-1, -1, -1, -1, -1, -1, -1}); diff {
+		t.Errorf("ShortestPathsMulti->nearest %s", mess)
+	}
+	if mess, diff := diff(dist, []int64{-1, -1, -1, -1, -1, -1, -1}); diff {
+		t.Errorf("ShortestPathsMulti->dist %s", mess)
+	}
+
+	_, nearest, dist = ShortestPathsMulti(g, 5)
+	if mess, diff := diff(nearest, []int{-1, -1, -1, -1, -1, 5, 5}); diff {
+		t.Errorf("ShortestPathsMulti->nearest %s", mess)
+	}
+	if mess, diff := diff(dist, []int64{-1, -1, -1, -1, -1, 0, 1}); diff {
+		t.Errorf("ShortestPathsMulti->dist %s", mess)
+	}
+}
+
+func TestShortestPathsWithin(t *testing.T) {
+	g := New(6)
+	g.AddBothCost(0, 1, 1) //  0 -- 1 -- 2 -- 3 -- 4    5
+	g.AddBothCost(1, 2, 2)
+	g.AddBothCost(2, 3, 3)
+	g.AddBothCost(3, 4, 4)
+	g.AddBothCost(0, 4, 20)
+
+	for _, e := range []struct {
+		maxDist int64
+		parent  []int
+		dist    []int64
+	}{
+		{0, []int{-1, -1, -1, -1, -1, -1}, []int64{0, -1, -1, -1, -1, -1}},
+		{2, []int{-1, 0, -1, -1, -1, -1}, []int64{0, 1, -1, -1, -1, -1}},
+		{3, []int{-1, 0, 1, -1, -1, -1}, []int64{0, 1, 3, -1, -1, -1}},
+		{9, []int{-1, 0, 1, 2, -1, -1}, []int64{0, 1, 3, 6, -1, -1}},
+		{10, []int{-1, 0, 1, 2, 3, -1}, []int64{0, 1, 3, 6, 10, -1}},
+		{Max, []int{-1, 0, 1, 2, 3, -1}, []int64{0, 1, 3, 6, 10, -1}},
+	} {
+		parent, dist := ShortestPathsWithin(g, 0, e.maxDist)
+		if mess, diff := diff(parent, e.parent); diff {
+			t.Errorf("ShortestPathsWithin(%d)->parent %s", e.maxDist, mess)
+		}
+		if mess, diff := diff(dist, e.dist); diff {
+			t.Errorf("ShortestPathsWithin(%d)->dist %s", e.maxDist, mess)
+		}
+	}
+}
+
 func BenchmarkShortestPaths(b *testing.B) {
 	n := 1000
 	b.StopTimer()
@@ -0,0 +1,98 @@
+package graph
+
+import "sync"
+
+// PathTree represents a forest of shortest paths, such as the one
+// computed by ShortestPaths. Each reachable vertex has a link to its
+// predecessor on a shortest path, and a root of the forest is
+// a vertex at distance zero with no predecessor.
+type PathTree struct {
+	parent []int
+	dist   []int64
+
+	once sync.Once
+	lca  *LCA // computed on first use
+}
+
+// NewPathTree returns a path tree with parent pointers parent
+// and distances dist in the format returned by ShortestPaths:
+// parent[w] is the predecessor of w, or -1 if none exists, and
+// dist[w] is the distance to w, or -1 if w cannot be reached.
+// The slices are not copied.
+func NewPathTree(parent []int, dist []int64) *PathTree {
+	return &PathTree{parent: parent, dist: dist}
+}
+
+// ShortestPathTree computes a tree of shortest paths from v
+// to all other vertices. Only edges with non-negative costs are included.
+//
+// The time complexity is O((|E| + |V|)⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func ShortestPathTree(g Iterator, v int) *PathTree {
+	return NewPathTree(ShortestPaths(g, v))
+}
+
+// Reaches tells if there is a path to w in the tree.
+func (t *PathTree) Reaches(w int) bool {
+	return t.dist[w] != -1
+}
+
+// DistTo returns the length of the path to w, or -1 if w cannot be reached.
+func (t *PathTree) DistTo(w int) int64 {
+	return t.dist[w]
+}
+
+// Parent returns the predecessor of w on the path to w,
+// or -1 if w is a root or cannot be reached.
+func (t *PathTree) Parent(w int) int {
+	return t.parent[w]
+}
+
+// PathTo returns the path from a root to w,
+// or an empty path if w cannot be reached.
+func (t *PathTree) PathTo(w int) []int {
+	if t.dist[w] == -1 {
+		return []int{}
+	}
+	return followParents(t.parent, w)
+}
+
+// Ancestor returns the lowest common ancestor of v and w in the tree,
+// or -1 if they have no common ancestor.
+// The first call takes O(|V|⋅log|V|) time to build an LCA;
+// subsequent calls take constant time.
+func (t *PathTree) Ancestor(v, w int) int {
+	if t.dist[v] == -1 || t.dist[w] == -1 {
+		return -1
+	}
+	t.once.Do(func() { t.lca = NewLCA(t.parent) })
+	return t.lca.Ancestor(v, w)
+}
+
+// DistBetween returns the length of the path between v and w in the tree,
+// through their lowest common ancestor, or -1 if there is no such path.
+// In a tree of shortest paths from a single source, this is an upper
+// bound on the distance between v and w in the undirected graph.
+// The time complexity is the same as for Ancestor.
+func (t *PathTree) DistBetween(v, w int) int64 {
+	a := t.Ancestor(v, w)
+	if a == -1 {
+		return -1
+	}
+	return t.dist[v] + t.dist[w] - 2*t.dist[a]
+}
+
+// followParents returns the path from a root to w,
+// following the parent pointers starting at w.
+func followParents(parent []int, w int) []int {
+	length := 0
+	for v := w; v != -1; v = parent[v] {
+		length++
+	}
+	path := make([]int, length)
+	for v := w; v != -1; v = parent[v] {
+		length--
+		path[length] = v
+	}
+	return path
+}
@@ -0,0 +1,90 @@
+package graph
+
+import "testing"
+
+func TestPathTree(t *testing.T) {
+	g := New(5)
+	g.AddCost(0, 1, 2) //  0 --> 1 --> 2     4
+	g.AddCost(1, 2, 3) //  |           ^
+	g.AddCost(0, 3, 1) //  --> 3 -------
+	g.AddCost(3, 2, 1)
+
+	tree := ShortestPathTree(g, 0)
+	for _, e := range []struct {
+		w       int
+		path    []int
+		dist    int64
+		parent  int
+		reaches bool
+	}{
+		{0, []int{0}, 0, -1, true},
+		{1, []int{0, 1}, 2, 0, true},
+		{2, []int{0, 3, 2}, 2, 3, true},
+		{3, []int{0, 3}, 1, 0, true},
+		{4, []int{}, -1, -1, false},
+	} {
+		if mess, diff := diff(tree.PathTo(e.w), e.path); diff {
+			t.Errorf("PathTo(%d) %s", e.w, mess)
+		}
+		if mess, diff := diff(tree.DistTo(e.w), e.dist); diff {
+			t.Errorf("DistTo(%d) %s", e.w, mess)
+		}
+		if mess, diff := diff(tree.Parent(e.w), e.parent); diff {
+			t.Errorf("Parent(%d) %s", e.w, mess)
+		}
+		if mess, diff := diff(tree.Reaches(e.w), e.reaches); diff {
+			t.Errorf("Reaches(%d) %s", e.w, mess)
+		}
+	}
+
+	// A forest with two roots.
+	parent, _, dist := ShortestPathsMulti(g, 1, 3)
+	tree = NewPathTree(parent, dist)
+	if mess, diff := diff(tree.PathTo(2), []int{3, 2}); diff {
+		t.Errorf("PathTo(2) %s", mess)
+	}
+	if mess, diff := diff(tree.PathTo(1), []int{1}); diff {
+		t.Errorf("PathTo(1) %s", mess)
+	}
+	if mess, diff := diff(tree.Reaches(0), false); diff {
+		t.Errorf("Reaches(0) %s", mess)
+	}
+}
+
+func TestPathTreeAncestor(t *testing.T) {
+	g := New(6)
+	g.AddCost(0, 1, 2) //  0 --> 1 --> 2     5
+	g.AddCost(1, 2, 3) //  |
+	g.AddCost(0, 3, 1) //  --> 3 --> 4
+	g.AddCost(3, 4, 4)
+
+	tree := ShortestPathTree(g, 0)
+	for _, q := range []struct {
+		v, w int
+		a    int
+		dist int64
+	}{
+		{2, 4, 0, 10},
+		{1, 2, 1, 3},
+		{2, 2, 2, 0},
+		{4, 5, -1, -1},
+		{5, 5, -1, -1},
+	} {
+		if mess, diff := diff(tree.Ancestor(q.v, q.w), q.a); diff {
+			t.Errorf("Ancestor(%d, %d) %s", q.v, q.w, mess)
+		}
+		if mess, diff := diff(tree.DistBetween(q.v, q.w), q.dist); diff {
+			t.Errorf("DistBetween(%d, %d) %s", q.v, q.w, mess)
+		}
+	}
+
+	// A forest with two roots.
+	parent, _, dist := ShortestPathsMulti(g, 1, 3)
+	tree = NewPathTree(parent, dist)
+	if mess, diff := diff(tree.Ancestor(2, 4), -1); diff {
+		t.Errorf("Ancestor(2, 4) %s", mess)
+	}
+	if mess, diff := diff(tree.DistBetween(1, 2), int64(3)); diff {
+		t.Errorf("DistBetween(1, 2) %s", mess)
+	}
+}
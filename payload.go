@@ -0,0 +1,163 @@
+package graph
+
+import "strconv"
+
+// Graph is a directed graph with weighted edges, like Mutable,
+// where each vertex carries a value of type V.
+// The values are kept in a slice indexed by vertex, next to
+// the graph, so that they stay in sync when vertices are added.
+//
+// Graph implements the Iterator interface, so any algorithm in this
+// package can be run on it; Values maps the vertices in a result,
+// for instance a path, to their values.
+type Graph[V any] struct {
+	g      *Mutable
+	values []V
+}
+
+// NewGraph returns a graph with one vertex for each of the given values,
+// numbered from 0 to n-1, and no edges.
+func NewGraph[V any](values ...V) *Graph[V] {
+	return &Graph[V]{
+		g:      New(len(values)),
+		values: append([]V(nil), values...),
+	}
+}
+
+// String returns a string representation of the graph.
+func (g *Graph[V]) String() string {
+	return String(g.g)
+}
+
+// Mutable returns the graph without its values. The graph is shared,
+// so changes to its edges are seen by both; vertices should only be
+// added and removed through g.
+func (g *Graph[V]) Mutable() *Mutable {
+	return g.g
+}
+
+// Order returns the number of vertices in the graph.
+func (g *Graph[V]) Order() int {
+	return len(g.values)
+}
+
+// Visit calls the do function for each neighbor w of v,
+// with c equal to the cost of the edge from v to w.
+// It behaves like the Visit method of Mutable.
+func (g *Graph[V]) Visit(v int, do func(w int, c int64) bool) bool {
+	return g.g.Visit(v, do)
+}
+
+// VisitValues calls the do function for each neighbor w of v,
+// with c equal to the cost of the edge from v to w,
+// and x equal to the value of w.
+// If do returns true, VisitValues returns immediately,
+// skipping any remaining neighbors, and returns true.
+func (g *Graph[V]) VisitValues(v int, do func(w int, c int64, x V) bool) bool {
+	return g.g.Visit(v, func(w int, c int64) bool {
+		return do(w, c, g.values[w])
+	})
+}
+
+// Value returns the value of v.
+func (g *Graph[V]) Value(v int) V {
+	g.check(v)
+	return g.values[v]
+}
+
+// SetValue sets the value of v to x.
+func (g *Graph[V]) SetValue(v int, x V) {
+	g.check(v)
+	g.values[v] = x
+}
+
+// Values returns the values of the given vertices, for instance a path.
+func (g *Graph[V]) Values(vertices []int) []V {
+	res := make([]V, len(vertices))
+	for i, v := range vertices {
+		res[i] = g.Value(v)
+	}
+	return res
+}
+
+// Degree returns the number of outward directed edges from v.
+func (g *Graph[V]) Degree(v int) int {
+	return g.g.Degree(v)
+}
+
+// Edge tells if there is an edge from v to w.
+func (g *Graph[V]) Edge(v, w int) bool {
+	return g.g.Edge(v, w)
+}
+
+// Cost returns the cost of an edge from v to w, or 0 if no such edge exists.
+func (g *Graph[V]) Cost(v, w int) int64 {
+	return g.g.Cost(v, w)
+}
+
+// Add inserts a directed edge from v to w with zero cost.
+// It removes the previous cost if this edge already exists.
+func (g *Graph[V]) Add(v, w int) {
+	g.g.AddCost(v, w, 0)
+}
+
+// AddCost inserts a directed edge from v to w with cost c.
+// It overwrites the previous cost if this edge already exists.
+func (g *Graph[V]) AddCost(v, w int, c int64) {
+	g.g.AddCost(v, w, c)
+}
+
+// AddBoth inserts edges with zero cost between v and w.
+// It removes the previous costs if these edges already exist.
+func (g *Graph[V]) AddBoth(v, w int) {
+	g.g.AddBoth(v, w)
+}
+
+// AddBothCost inserts edges with cost c between v and w.
+// It overwrites the previous costs if these edges already exist.
+func (g *Graph[V]) AddBothCost(v, w int, c int64) {
+	g.g.AddBothCost(v, w, c)
+}
+
+// Delete removes an edge from v to w.
+func (g *Graph[V]) Delete(v, w int) {
+	g.g.Delete(v, w)
+}
+
+// DeleteBoth removes all edges between v and w.
+func (g *Graph[V]) DeleteBoth(v, w int) {
+	g.g.DeleteBoth(v, w)
+}
+
+// AddVertex adds a vertex with value x and without edges to the graph,
+// and returns it. A previously removed vertex is reused, if there is one.
+func (g *Graph[V]) AddVertex(x V) int {
+	v := g.g.AddVertex()
+	if v == len(g.values) {
+		g.values = append(g.values, x)
+	} else {
+		g.values[v] = x
+	}
+	return v
+}
+
+// RemoveVertex removes all edges to and from v, sets its value to
+// the zero value, and makes v available for reuse by AddVertex.
+//
+// The time complexity is O(|V|), where |V| is the number of vertices in the graph.
+func (g *Graph[V]) RemoveVertex(v int) {
+	g.g.RemoveVertex(v)
+	var zero V
+	g.values[v] = zero
+}
+
+// Removed tells if v has been removed by RemoveVertex and not reused.
+func (g *Graph[V]) Removed(v int) bool {
+	return g.g.Removed(v)
+}
+
+func (g *Graph[V]) check(v int) {
+	if v < 0 || v >= len(g.values) {
+		panic("vertex out of range: " + strconv.Itoa(v))
+	}
+}
@@ -0,0 +1,81 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGraph(t *testing.T) {
+	type city struct {
+		name string
+		pop  int
+	}
+	g := NewGraph(city{"a", 10}, city{"b", 20}, city{"c", 30})
+	g.AddCost(0, 1, 4)
+	g.AddBothCost(1, 2, 1)
+	g.Add(0, 2)
+	if mess, diff := diff(g.String(), "3 [(0 1):4 (0 2) {1 2}:1]"); diff {
+		t.Errorf("NewGraph %s", mess)
+	}
+	Consistent("Graph", t, g)
+
+	sum := 0
+	g.VisitValues(0, func(w int, c int64, x city) (skip bool) {
+		sum += x.pop
+		return
+	})
+	if mess, diff := diff(sum, 50); diff {
+		t.Errorf("VisitValues %s", mess)
+	}
+
+	v := g.AddVertex(city{"d", 40})
+	if mess, diff := diff(v, 3); diff {
+		t.Errorf("AddVertex %s", mess)
+	}
+	g.AddCost(2, 3, 2)
+	path, _ := ShortestPath(g, 0, 3)
+	names := []string{}
+	for _, x := range g.Values(path) {
+		names = append(names, x.name)
+	}
+	if mess, diff := diff(names, []string{"a", "c", "d"}); diff {
+		t.Errorf("Values %s", mess)
+	}
+
+	g.RemoveVertex(1)
+	if mess, diff := diff(g.Value(1), city{}); diff {
+		t.Errorf("RemoveVertex %s", mess)
+	}
+	if !g.Removed(1) || g.Edge(0, 1) {
+		t.Errorf("RemoveVertex: vertex 1 not removed")
+	}
+	if mess, diff := diff(g.AddVertex(city{"e", 50}), 1); diff {
+		t.Errorf("AddVertex %s", mess)
+	}
+	g.SetValue(1, city{"f", 60})
+	if mess, diff := diff(g.Value(1).name, "f"); diff {
+		t.Errorf("SetValue %s", mess)
+	}
+	if mess, diff := diff(g.Order(), 4); diff {
+		t.Errorf("Order %s", mess)
+	}
+	if mess, diff := diff(g.Mutable().Order(), 4); diff {
+		t.Errorf("Mutable %s", mess)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Value(4): no panic")
+		}
+	}()
+	g.Value(4)
+}
+
+func ExampleGraph() {
+	g := NewGraph("start", "middle", "end")
+	g.AddCost(0, 1, 1)
+	g.AddCost(1, 2, 1)
+	path, _ := ShortestPath(g, 0, 2)
+	fmt.Println(g.Values(path))
+	// Output: [start middle end]
+}
@@ -0,0 +1,505 @@
+package graph
+
+import "sort"
+
+// IsPlanar tells if an undirected graph can be drawn in the plane
+// without crossing edges. If it can, embedding[v] lists the neighbors
+// of v in clockwise order in such a drawing: a combinatorial embedding
+// of g. Self-loops and parallel edges are ignored, and an edge in
+// either direction counts as an undirected edge.
+//
+// The implementation uses the left-right planarity test of de Fraysseix
+// and Rosenstiehl, as described by Brandes. The time complexity is
+// O(|E| + |V|), where |E| is the number of edges and |V| the number
+// of vertices in the graph.
+func IsPlanar(g Iterator) (embedding [][]int, ok bool) {
+	s := newPlanarity(undirected(g))
+	if !s.test() {
+		return nil, false
+	}
+	return s.embed(), true
+}
+
+// KuratowskiSubgraph returns the edges of a subgraph of g that is a
+// subdivision of K₅ or K₃,₃, which proves that g isn't planar.
+// Each edge is given once, with V < W and the cost of an edge between
+// V and W in g, and the edges are sorted. If g is planar, ok is false.
+//
+// The implementation removes edges one at a time, keeping those that
+// are needed for the graph to stay non-planar. The time complexity is
+// O(|V|²), where |V| is the number of vertices in the graph.
+func KuratowskiSubgraph(g Iterator) (edges []Edge, ok bool) {
+	adj := undirected(g)
+	if newPlanarity(adj).test() {
+		return []Edge{}, false
+	}
+	// Start with the shortest non-planar prefix of the edges,
+	// and then remove the edges that aren't needed.
+	var all []Edge
+	for v, list := range adj {
+		for _, w := range list {
+			if v < w {
+				all = append(all, Edge{v, w, 0})
+			}
+		}
+	}
+	n := len(adj)
+	planar := func(edges []Edge) bool {
+		sub := make([][]int, n)
+		for _, e := range edges {
+			sub[e.V] = append(sub[e.V], e.W)
+			sub[e.W] = append(sub[e.W], e.V)
+		}
+		return newPlanarity(sub).test()
+	}
+	i, j := 0, len(all)
+	for i < j {
+		m := int(uint(i+j) >> 1)
+		if planar(all[:m+1]) {
+			i = m + 1
+		} else {
+			j = m
+		}
+	}
+	edges = append([]Edge{}, all[:i+1]...)
+	for k := 0; k < len(edges); {
+		rest := append(append([]Edge{}, edges[:k]...), edges[k+1:]...)
+		if planar(rest) {
+			k++
+		} else {
+			edges = rest
+		}
+	}
+	for k, e := range edges {
+		c := edgeCost(g, e.V, e.W)
+		if c == -1 {
+			c = edgeCost(g, e.W, e.V)
+		}
+		if c == -1 {
+			c = 0
+		}
+		edges[k].Cost = c
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		return a.V < b.V || a.V == b.V && a.W < b.W
+	})
+	return edges, true
+}
+
+// planarity holds the state of the left-right planarity test.
+// The edges of the undirected graph are numbered, and each edge is
+// oriented by a depth-first search; -1 means no edge.
+type planarity struct {
+	adj      [][]int
+	edgeOf   [][]int // edgeOf[v][i] is the edge between v and adj[v][i].
+	from, to []int
+	oriented []bool
+
+	height     []int
+	parentEdge []int
+	roots      []int
+	out        [][]int // The oriented edges leaving each vertex.
+
+	lowpt, lowpt2, nesting []int
+	ref, side, lowptEdge   []int
+	stackBottom            []*conflictPair
+	stack                  []*conflictPair
+
+	// The embedding: the clockwise and counterclockwise neighbors
+	// of w around each vertex v in cw[v][w] and ccw[v][w].
+	cw, ccw           []map[int]int
+	first             []int
+	leftRef, rightRef []int
+}
+
+// edgeInterval is a sequence of return edges from low to high.
+type edgeInterval struct {
+	low, high int
+}
+
+func (i edgeInterval) empty() bool {
+	return i.low == -1 && i.high == -1
+}
+
+type conflictPair struct {
+	left, right edgeInterval
+}
+
+func (p *conflictPair) swap() {
+	p.left, p.right = p.right, p.left
+}
+
+func newPlanarity(adj [][]int) *planarity {
+	n := len(adj)
+	s := &planarity{
+		adj:        adj,
+		edgeOf:     make([][]int, n),
+		height:     make([]int, n),
+		parentEdge: make([]int, n),
+		out:        make([][]int, n),
+	}
+	index := make([]map[int]int, n)
+	for v, list := range adj {
+		s.edgeOf[v] = make([]int, len(list))
+		for i, w := range list {
+			if e, ok := index[w][v]; ok {
+				s.edgeOf[v][i] = e
+				continue
+			}
+			if index[v] == nil {
+				index[v] = make(map[int]int)
+			}
+			e := len(s.from)
+			index[v][w] = e
+			s.edgeOf[v][i] = e
+			s.from = append(s.from, v)
+			s.to = append(s.to, w)
+		}
+	}
+	m := len(s.from)
+	s.oriented = make([]bool, m)
+	s.lowpt = make([]int, m)
+	s.lowpt2 = make([]int, m)
+	s.nesting = make([]int, m)
+	s.ref = make([]int, m)
+	s.side = make([]int, m)
+	s.lowptEdge = make([]int, m)
+	s.stackBottom = make([]*conflictPair, m)
+	for e := range s.ref {
+		s.ref[e], s.side[e], s.lowptEdge[e] = -1, 1, -1
+	}
+	for v := range s.height {
+		s.height[v], s.parentEdge[v] = -1, -1
+	}
+	return s
+}
+
+// test tells if the graph is planar.
+func (s *planarity) test() bool {
+	n, m := len(s.adj), len(s.from)
+	if n > 2 && m > 3*n-6 {
+		return false
+	}
+	for v := range s.adj {
+		if s.height[v] == -1 {
+			s.height[v] = 0
+			s.roots = append(s.roots, v)
+			s.orient(v)
+		}
+	}
+	s.sortOut()
+	for _, v := range s.roots {
+		if !s.testFrom(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortOut sorts the oriented edges leaving each vertex by nesting depth.
+func (s *planarity) sortOut() {
+	for _, list := range s.out {
+		sort.SliceStable(list, func(i, j int) bool {
+			return s.nesting[list[i]] < s.nesting[list[j]]
+		})
+	}
+}
+
+// orient orients the edges by a depth-first search from v,
+// and computes the lowpoints and nesting depths.
+func (s *planarity) orient(v int) {
+	e := s.parentEdge[v]
+	for i, w := range s.adj[v] {
+		vw := s.edgeOf[v][i]
+		if s.oriented[vw] {
+			continue
+		}
+		s.oriented[vw] = true
+		s.from[vw], s.to[vw] = v, w
+		s.out[v] = append(s.out[v], vw)
+		s.lowpt[vw], s.lowpt2[vw] = s.height[v], s.height[v]
+		if s.height[w] == -1 { // A tree edge.
+			s.parentEdge[w] = vw
+			s.height[w] = s.height[v] + 1
+			s.orient(w)
+		} else { // A back edge.
+			s.lowpt[vw] = s.height[w]
+		}
+		s.nesting[vw] = 2 * s.lowpt[vw]
+		if s.lowpt2[vw] < s.height[v] { // A chordal edge.
+			s.nesting[vw]++
+		}
+		if e == -1 {
+			continue
+		}
+		switch {
+		case s.lowpt[vw] < s.lowpt[e]:
+			s.lowpt2[e] = min(s.lowpt[e], s.lowpt2[vw])
+			s.lowpt[e] = s.lowpt[vw]
+		case s.lowpt[vw] > s.lowpt[e]:
+			s.lowpt2[e] = min(s.lowpt2[e], s.lowpt[vw])
+		default:
+			s.lowpt2[e] = min(s.lowpt2[e], s.lowpt2[vw])
+		}
+	}
+}
+
+func (s *planarity) top() *conflictPair {
+	if len(s.stack) == 0 {
+		return nil
+	}
+	return s.stack[len(s.stack)-1]
+}
+
+func (s *planarity) pop() *conflictPair {
+	p := s.top()
+	s.stack = s.stack[:len(s.stack)-1]
+	return p
+}
+
+// conflicting tells if the edgeInterval i has a return edge
+// higher than the lowpoint of the edge b.
+func (s *planarity) conflicting(i edgeInterval, b int) bool {
+	return !i.empty() && s.lowpt[i.high] > s.lowpt[b]
+}
+
+func (s *planarity) lowest(p *conflictPair) int {
+	switch {
+	case p.left.empty():
+		return s.lowpt[p.right.low]
+	case p.right.empty():
+		return s.lowpt[p.left.low]
+	}
+	return min(s.lowpt[p.left.low], s.lowpt[p.right.low])
+}
+
+// testFrom tests the constraints of the edges in the subtree of v.
+func (s *planarity) testFrom(v int) bool {
+	e := s.parentEdge[v]
+	for i, ei := range s.out[v] {
+		w := s.to[ei]
+		s.stackBottom[ei] = s.top()
+		if ei == s.parentEdge[w] { // A tree edge.
+			if !s.testFrom(w) {
+				return false
+			}
+		} else { // A back edge.
+			s.lowptEdge[ei] = ei
+			s.stack = append(s.stack, &conflictPair{
+				left:  edgeInterval{-1, -1},
+				right: edgeInterval{ei, ei},
+			})
+		}
+		// Integrate the new return edges.
+		if s.lowpt[ei] < s.height[v] {
+			if i == 0 {
+				s.lowptEdge[e] = s.lowptEdge[ei]
+			} else if !s.addConstraints(ei, e) {
+				return false
+			}
+		}
+	}
+	if e != -1 {
+		s.removeBackEdges(e)
+	}
+	return true
+}
+
+func (s *planarity) addConstraints(ei, e int) bool {
+	p := &conflictPair{left: edgeInterval{-1, -1}, right: edgeInterval{-1, -1}}
+	// Merge the return edges of ei into p.right.
+	for {
+		q := s.pop()
+		if !q.left.empty() {
+			q.swap()
+		}
+		if !q.left.empty() {
+			return false
+		}
+		if s.lowpt[q.right.low] > s.lowpt[e] { // Merge intervals.
+			if p.right.empty() {
+				p.right = q.right
+			} else {
+				s.ref[p.right.low] = q.right.high
+			}
+			p.right.low = q.right.low
+		} else { // Align.
+			s.ref[q.right.low] = s.lowptEdge[e]
+		}
+		if s.top() == s.stackBottom[ei] {
+			break
+		}
+	}
+	// Merge the conflicting return edges of the earlier edges into p.left.
+	for t := s.top(); t != nil && (s.conflicting(t.left, ei) || s.conflicting(t.right, ei)); t = s.top() {
+		q := s.pop()
+		if s.conflicting(q.right, ei) {
+			q.swap()
+		}
+		if s.conflicting(q.right, ei) {
+			return false
+		}
+		// Merge the edgeInterval below lowpt(ei) into p.right.
+		s.ref[p.right.low] = q.right.high
+		if q.right.low != -1 {
+			p.right.low = q.right.low
+		}
+		if p.left.empty() {
+			p.left = q.left
+		} else {
+			s.ref[p.left.low] = q.left.high
+		}
+		p.left.low = q.left.low
+	}
+	if !p.left.empty() || !p.right.empty() {
+		s.stack = append(s.stack, p)
+	}
+	return true
+}
+
+// removeBackEdges trims the back edges ending at the parent of e.
+func (s *planarity) removeBackEdges(e int) {
+	u := s.from[e]
+	// Drop entire conflict pairs.
+	for len(s.stack) > 0 && s.lowest(s.top()) == s.height[u] {
+		if p := s.pop(); p.left.low != -1 {
+			s.side[p.left.low] = -1
+		}
+	}
+	// One more conflict pair to consider.
+	if len(s.stack) > 0 {
+		p := s.pop()
+		for p.left.high != -1 && s.to[p.left.high] == u {
+			p.left.high = s.ref[p.left.high]
+		}
+		if p.left.high == -1 && p.left.low != -1 { // Just emptied.
+			s.ref[p.left.low] = p.right.low
+			s.side[p.left.low] = -1
+			p.left.low = -1
+		}
+		for p.right.high != -1 && s.to[p.right.high] == u {
+			p.right.high = s.ref[p.right.high]
+		}
+		if p.right.high == -1 && p.right.low != -1 { // Just emptied.
+			s.ref[p.right.low] = p.left.low
+			s.side[p.right.low] = -1
+			p.right.low = -1
+		}
+		s.stack = append(s.stack, p)
+	}
+	// The side of e is the side of a highest return edge.
+	if s.lowpt[e] < s.height[u] {
+		hl, hr := s.top().left.high, s.top().right.high
+		if hl != -1 && (hr == -1 || s.lowpt[hl] > s.lowpt[hr]) {
+			s.ref[e] = hl
+		} else {
+			s.ref[e] = hr
+		}
+	}
+}
+
+// sign returns the final side of e, resolving the references.
+func (s *planarity) sign(e int) int {
+	var chain []int
+	for x := e; s.ref[x] != -1; x = s.ref[x] {
+		chain = append(chain, x)
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		x := chain[i]
+		s.side[x] *= s.side[s.ref[x]]
+		s.ref[x] = -1
+	}
+	return s.side[e]
+}
+
+// embed computes the embedding of a planar graph after test.
+func (s *planarity) embed() [][]int {
+	n := len(s.adj)
+	for e := range s.nesting {
+		s.nesting[e] *= s.sign(e)
+	}
+	s.sortOut()
+	s.cw, s.ccw = make([]map[int]int, n), make([]map[int]int, n)
+	s.first = make([]int, n)
+	s.leftRef, s.rightRef = make([]int, n), make([]int, n)
+	for v := range s.first {
+		s.cw[v], s.ccw[v] = make(map[int]int), make(map[int]int)
+		s.first[v] = -1
+		prev := -1
+		for _, e := range s.out[v] {
+			s.addCW(v, s.to[e], prev)
+			prev = s.to[e]
+		}
+	}
+	for _, v := range s.roots {
+		s.embedFrom(v)
+	}
+	embedding := make([][]int, n)
+	for v := range embedding {
+		embedding[v] = []int{}
+		if s.first[v] == -1 {
+			continue
+		}
+		w := s.first[v]
+		for {
+			embedding[v] = append(embedding[v], w)
+			if w = s.cw[v][w]; w == s.first[v] {
+				break
+			}
+		}
+	}
+	return embedding
+}
+
+func (s *planarity) embedFrom(v int) {
+	for _, ei := range s.out[v] {
+		w := s.to[ei]
+		if ei == s.parentEdge[w] { // A tree edge.
+			s.addFirst(w, v)
+			s.leftRef[v], s.rightRef[v] = w, w
+			s.embedFrom(w)
+		} else if s.side[ei] == 1 { // A back edge.
+			s.addCW(w, v, s.rightRef[w])
+		} else {
+			s.addCCW(w, v, s.leftRef[w])
+			s.leftRef[w] = v
+		}
+	}
+}
+
+// addCW adds w to the neighbors of v, clockwise after ref, or as the
+// only neighbor if ref is -1.
+func (s *planarity) addCW(v, w, ref int) {
+	if ref == -1 {
+		s.cw[v][w], s.ccw[v][w] = w, w
+		s.first[v] = w
+		return
+	}
+	next := s.cw[v][ref]
+	s.cw[v][ref] = w
+	s.cw[v][w], s.ccw[v][w] = next, ref
+	s.ccw[v][next] = w
+}
+
+// addCCW adds w to the neighbors of v, counterclockwise before ref.
+func (s *planarity) addCCW(v, w, ref int) {
+	if ref == -1 {
+		s.addCW(v, w, -1)
+		return
+	}
+	s.addCW(v, w, s.ccw[v][ref])
+	if ref == s.first[v] {
+		s.first[v] = w
+	}
+}
+
+// addFirst adds w as the first neighbor of v.
+func (s *planarity) addFirst(v, w int) {
+	if s.first[v] == -1 {
+		s.addCW(v, w, -1)
+	} else {
+		s.addCCW(v, w, s.first[v])
+	}
+	s.first[v] = w
+}
@@ -0,0 +1,262 @@
+package graph
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func complete(n int) *Mutable {
+	g := New(n)
+	for v := 0; v < n; v++ {
+		for w := v + 1; w < n; w++ {
+			g.AddBoth(v, w)
+		}
+	}
+	return g
+}
+
+func TestIsPlanar(t *testing.T) {
+	embedding, ok := IsPlanar(New(0))
+	if mess, diff := diff(embedding, [][]int{}); diff {
+		t.Errorf("IsPlanar->embedding %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("IsPlanar->ok %s", mess)
+	}
+
+	g := New(3)
+	g.Add(0, 1)
+	g.AddBoth(1, 2)
+	g.Add(2, 2)
+	embedding, ok = IsPlanar(g)
+	if mess, diff := diff(len(embedding[1]), 2); diff {
+		t.Errorf("IsPlanar->embedding %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("IsPlanar->ok %s", mess)
+	}
+	checkEmbedding(t, g, embedding)
+
+	for _, n := range []int{1, 2, 3, 4} {
+		g := complete(n)
+		embedding, ok := IsPlanar(g)
+		if !ok {
+			t.Errorf("IsPlanar(K%d) false", n)
+		}
+		checkEmbedding(t, g, embedding)
+	}
+
+	k33 := New(6)
+	for v := 0; v < 3; v++ {
+		for w := 3; w < 6; w++ {
+			k33.AddBoth(v, w)
+		}
+	}
+	petersen := New(10)
+	for v := 0; v < 5; v++ {
+		petersen.AddBoth(v, (v+1)%5)
+		petersen.AddBoth(v, v+5)
+		petersen.AddBoth(v+5, (v+2)%5+5)
+	}
+	for _, g := range []*Mutable{complete(5), complete(6), k33, petersen} {
+		if _, ok := IsPlanar(g); ok {
+			t.Errorf("IsPlanar(%v) true", g)
+		}
+		edges, ok := KuratowskiSubgraph(g)
+		if !ok {
+			t.Errorf("KuratowskiSubgraph(%v)->ok false", g)
+		}
+		checkKuratowski(t, g, edges)
+	}
+
+	// A large grid.
+	m := 100
+	g = New(m * m)
+	for r := 0; r < m; r++ {
+		for c := 0; c < m; c++ {
+			if c+1 < m {
+				g.AddBoth(r*m+c, r*m+c+1)
+			}
+			if r+1 < m {
+				g.AddBoth(r*m+c, (r+1)*m+c)
+			}
+		}
+	}
+	embedding, ok = IsPlanar(g)
+	if !ok {
+		t.Errorf("IsPlanar(grid) false")
+	}
+	checkEmbedding(t, g, embedding)
+	edges, ok := KuratowskiSubgraph(g)
+	if mess, diff := diff(edges, []Edge{}); diff {
+		t.Errorf("KuratowskiSubgraph->edges %s", mess)
+	}
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("KuratowskiSubgraph->ok %s", mess)
+	}
+}
+
+func TestIsPlanarRandom(t *testing.T) {
+	for i := 0; i < 500; i++ {
+		n := 1 + rand.Intn(12)
+		g := New(n)
+		for m := rand.Intn(2*n*n/3 + 1); m > 0; m-- {
+			g.AddBoth(rand.Intn(n), rand.Intn(n))
+		}
+		if embedding, ok := IsPlanar(g); ok {
+			checkEmbedding(t, g, embedding)
+		} else {
+			edges, ok := KuratowskiSubgraph(g)
+			if !ok {
+				t.Errorf("KuratowskiSubgraph(%v)->ok false", g)
+			}
+			checkKuratowski(t, g, edges)
+		}
+	}
+}
+
+// checkEmbedding checks that embedding is a planar embedding of g:
+// a rotation system of g whose faces satisfy Euler's formula.
+func checkEmbedding(t *testing.T, g Iterator, embedding [][]int) {
+	adj := undirected(g)
+	if len(embedding) != len(adj) {
+		t.Errorf("embedding %v of %v", embedding, g)
+		return
+	}
+	// pos[v][w] is the position of w in the rotation of v.
+	pos := make([]map[int]int, len(adj))
+	edges := 0
+	for v, list := range embedding {
+		sorted := append([]int{}, list...)
+		sort.Ints(sorted)
+		if mess, diff := diff(sorted, adj[v]); diff && len(adj[v]) > 0 {
+			t.Errorf("embedding: neighbors of %d %s", v, mess)
+			return
+		}
+		pos[v] = make(map[int]int)
+		for i, w := range list {
+			pos[v][w] = i
+		}
+		edges += len(list)
+	}
+	// Trace the faces: after the half-edge (v, w) comes (w, u),
+	// where u follows v in the rotation of w.
+	seen := make(map[[2]int]bool)
+	faces := 0
+	for v, list := range embedding {
+		for _, w := range list {
+			if seen[[2]int{v, w}] {
+				continue
+			}
+			faces++
+			for x, y := v, w; !seen[[2]int{x, y}]; {
+				seen[[2]int{x, y}] = true
+				rot := embedding[y]
+				x, y = y, rot[(pos[y][x]+1)%len(rot)]
+			}
+		}
+	}
+	// Each connected component with edges satisfies V - E + F = 2.
+	vertices, components := 0, 0
+	for _, comp := range Components(Copy(g)) {
+		if len(comp) > 1 {
+			vertices += len(comp)
+			components++
+		}
+	}
+	if vertices-edges/2+faces != 2*components {
+		t.Errorf("embedding %v of %v has %d faces", embedding, g, faces)
+	}
+}
+
+// checkKuratowski checks that edges form a subgraph of g that is
+// a subdivision of K₅ or K₃,₃.
+func checkKuratowski(t *testing.T, g *Mutable, edges []Edge) {
+	adj := make(map[int][]int)
+	for _, e := range edges {
+		if e.V >= e.W || !g.Edge(e.V, e.W) && !g.Edge(e.W, e.V) {
+			t.Errorf("KuratowskiSubgraph: bad edge %v", e)
+			return
+		}
+		adj[e.V] = append(adj[e.V], e.W)
+		adj[e.W] = append(adj[e.W], e.V)
+	}
+	// Replace paths through vertices of degree 2 by single edges.
+	branch := make(map[int]bool)
+	for v, list := range adj {
+		if len(list) > 2 {
+			branch[v] = true
+		} else if len(list) < 2 {
+			t.Errorf("KuratowskiSubgraph: vertex %d has degree %d", v, len(list))
+			return
+		}
+	}
+	contracted := make(map[[2]int]int)
+	for v := range branch {
+		for _, w := range adj[v] {
+			prev := v
+			for !branch[w] {
+				next := adj[w][0]
+				if next == prev {
+					next = adj[w][1]
+				}
+				prev, w = w, next
+			}
+			if v < w {
+				contracted[[2]int{v, w}]++
+			}
+		}
+	}
+	degrees := make(map[int]int)
+	for e, k := range contracted {
+		if k != 1 || e[0] == e[1] {
+			t.Errorf("KuratowskiSubgraph: not a subdivision of K5 or K3,3: %v", edges)
+			return
+		}
+		degrees[e[0]]++
+		degrees[e[1]]++
+	}
+	_, bipartite := Bipartition(kuratowskiGraph(contracted))
+	switch {
+	case len(branch) == 5 && len(contracted) == 10:
+		return // K5
+	case len(branch) == 6 && len(contracted) == 9 && bipartite:
+		return // K3,3
+	}
+	t.Errorf("KuratowskiSubgraph: not a subdivision of K5 or K3,3: %v", edges)
+}
+
+func kuratowskiGraph(edges map[[2]int]int) *Mutable {
+	max := 0
+	for e := range edges {
+		if e[1] > max {
+			max = e[1]
+		}
+	}
+	g := New(max + 1)
+	for e := range edges {
+		g.AddBoth(e[0], e[1])
+	}
+	return g
+}
+
+func BenchmarkIsPlanar(b *testing.B) {
+	m := 100
+	b.StopTimer()
+	g := New(m * m)
+	for r := 0; r < m; r++ {
+		for c := 0; c < m; c++ {
+			if c+1 < m {
+				g.AddBoth(r*m+c, r*m+c+1)
+			}
+			if r+1 < m {
+				g.AddBoth(r*m+c, (r+1)*m+c)
+			}
+		}
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = IsPlanar(g)
+	}
+}
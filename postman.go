@@ -0,0 +1,124 @@
+package graph
+
+// ChinesePostman solves the route inspection problem for an undirected
+// graph with non-negative edge costs: it returns a closed walk of
+// minimum total cost that uses each edge at least once. If the edges
+// aren't all in one connected component, it sets ok to false.
+//
+// The walk starts and ends at the smallest vertex with an edge.
+// The graph is undirected if each edge from v to w is matched by an
+// edge from w to v; each undirected edge must be used once, and the
+// walk may traverse it in either direction.
+//
+// The implementation pairs up the vertices of odd degree with a minimum
+// cost perfect matching of their shortest path distances, and finds an
+// Euler circuit in the graph with the shortest paths added. The time
+// complexity is O(k⋅|E|⋅log|V| + k³), where k is the number of vertices
+// of odd degree, |E| is the number of edges and |V| the number of
+// vertices in the graph.
+func ChinesePostman(g Iterator) (walk []int, cost int64, ok bool) {
+	n := g.Order()
+	var edges []Edge
+	degree := make([]int, n)
+	sets, count := makeSingletons(n), n
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if v <= w {
+				edges = append(edges, Edge{v, w, c})
+				cost += c
+				degree[v]++
+				degree[w]++
+				if x, y := sets.find(v), sets.find(w); x != y {
+					sets.union(x, y)
+					count--
+				}
+			}
+			return
+		})
+	}
+	if len(edges) == 0 {
+		return []int{}, 0, true
+	}
+	// The vertices without edges are components of their own.
+	for _, d := range degree {
+		if d == 0 {
+			count--
+		}
+	}
+	if count != 1 {
+		return []int{}, 0, false
+	}
+
+	var odd []int
+	for v, d := range degree {
+		if d&1 == 1 {
+			odd = append(odd, v)
+		}
+	}
+	parent := make([][]int, len(odd))
+	dist := make([][]int64, len(odd))
+	total := int64(1)
+	for i, v := range odd {
+		parent[i], dist[i] = ShortestPaths(g, v)
+		for _, w := range odd {
+			if dist[i][w] == -1 {
+				return []int{}, 0, false // Not an undirected graph.
+			}
+			total += dist[i][w]
+		}
+	}
+	// A minimum cost perfect matching is a maximum weight matching
+	// with weights total - dist; total is larger than the cost of any
+	// matching, so all perfect matchings weigh more than other matchings.
+	pairs := New(len(odd))
+	for i := range odd {
+		for j, w := range odd {
+			if i != j {
+				pairs.AddCost(i, j, total-dist[i][w])
+			}
+		}
+	}
+	matching, _ := MaxWeightMatching(pairs)
+	for _, e := range matching {
+		v, w := odd[e.V], odd[e.W]
+		cost += dist[e.V][w]
+		for x := w; x != v; x = parent[e.V][x] {
+			edges = append(edges, Edge{parent[e.V][x], x, 0})
+		}
+	}
+	return eulerCircuit(n, edges), cost, true
+}
+
+// eulerCircuit returns an Euler circuit of the undirected multigraph
+// with the given edges, starting at the smallest vertex with an edge.
+// All vertices must have even degree.
+func eulerCircuit(n int, edges []Edge) (walk []int) {
+	adj := make([][]int, n) // The edges of each vertex.
+	start := n
+	for i, e := range edges {
+		adj[e.V] = append(adj[e.V], i)
+		adj[e.W] = append(adj[e.W], i)
+		start = min(start, min(e.V, e.W))
+	}
+	used := make([]bool, len(edges))
+	next := make([]int, n) // The next edge of each vertex to try.
+	for stack := []int{start}; len(stack) > 0; {
+		v := stack[len(stack)-1]
+		for next[v] < len(adj[v]) && used[adj[v][next[v]]] {
+			next[v]++
+		}
+		if next[v] == len(adj[v]) {
+			walk = append(walk, v)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		i := adj[v][next[v]]
+		used[i] = true
+		w := edges[i].V
+		if w == v {
+			w = edges[i].W
+		}
+		stack = append(stack, w)
+	}
+	return
+}
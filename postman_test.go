@@ -0,0 +1,152 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkPostman checks that walk is a closed walk in g of the given cost
+// that uses each undirected edge at least once.
+func checkPostman(t *testing.T, g *Mutable, walk []int, cost int64) {
+	if len(walk) == 0 || walk[0] != walk[len(walk)-1] {
+		t.Errorf("ChinesePostman: %v isn't a closed walk", walk)
+		return
+	}
+	used := make(map[[2]int]bool)
+	total := int64(0)
+	for i := 1; i < len(walk); i++ {
+		v, w := walk[i-1], walk[i]
+		if !g.Edge(v, w) {
+			t.Errorf("ChinesePostman: no edge (%d, %d) in %v", v, w, walk)
+			return
+		}
+		total += g.Cost(v, w)
+		used[[2]int{min(v, w), max(v, w)}] = true
+	}
+	if total != cost {
+		t.Errorf("ChinesePostman: cost of %v is %d; want %d", walk, total, cost)
+	}
+	for v := 0; v < g.Order(); v++ {
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			if !used[[2]int{min(v, w), max(v, w)}] {
+				t.Errorf("ChinesePostman: %v doesn't use (%d, %d)", walk, v, w)
+			}
+			return
+		})
+	}
+}
+
+func TestChinesePostman(t *testing.T) {
+	walk, cost, ok := ChinesePostman(New(2))
+	if mess, diff := diff(walk, []int{}); diff {
+		t.Errorf("ChinesePostman->walk %s", mess)
+	}
+	if mess, diff := diff(cost, int64(0)); diff {
+		t.Errorf("ChinesePostman->cost %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("ChinesePostman->ok %s", mess)
+	}
+
+	// A path is walked twice.
+	g := New(4)
+	g.AddBothCost(1, 2, 3)
+	g.AddBothCost(2, 3, 4)
+	walk, cost, ok = ChinesePostman(g)
+	if mess, diff := diff(walk, []int{1, 2, 3, 2, 1}); diff {
+		t.Errorf("ChinesePostman->walk %s", mess)
+	}
+	if mess, diff := diff(cost, int64(14)); diff {
+		t.Errorf("ChinesePostman->cost %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("ChinesePostman->ok %s", mess)
+	}
+
+	// K4 with unit costs needs two extra edges.
+	walk, cost, ok = ChinesePostman(complete4())
+	checkPostman(t, complete4(), walk, cost)
+	if mess, diff := diff(cost, int64(8)); diff {
+		t.Errorf("ChinesePostman->cost %s", mess)
+	}
+
+	g.AddBothCost(0, 0, 1)
+	_, _, ok = ChinesePostman(g)
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("ChinesePostman->ok %s", mess)
+	}
+}
+
+func complete4() *Mutable {
+	g := New(4)
+	for v := 0; v < 4; v++ {
+		for w := v + 1; w < 4; w++ {
+			g.AddBothCost(v, w, 1)
+		}
+	}
+	return g
+}
+
+func TestChinesePostmanRandom(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		n := 1 + rand.Intn(8)
+		g := New(n)
+		for v := 1; v < n; v++ {
+			g.AddBothCost(rand.Intn(v), v, rand.Int63n(10))
+		}
+		for m := rand.Intn(2 * n); m > 0; m-- {
+			g.AddBothCost(rand.Intn(n), rand.Intn(n), rand.Int63n(10))
+		}
+		walk, cost, ok := ChinesePostman(g)
+		if !ok {
+			t.Errorf("ChinesePostman(%v)->ok false", g)
+			continue
+		}
+		if n == 1 && !g.Edge(0, 0) {
+			continue
+		}
+		checkPostman(t, g, walk, cost)
+		if exp := brutePostman(g); cost != exp {
+			t.Errorf("ChinesePostman(%v)->cost %d; want %d", g, cost, exp)
+		}
+	}
+}
+
+// brutePostman returns the cost of an optimal route
+// by trying all pairings of the odd vertices.
+func brutePostman(g *Mutable) int64 {
+	_, dist, _ := AllPairsShortestPaths(g)
+	cost := int64(0)
+	var odd []int
+	for v := 0; v < g.Order(); v++ {
+		degree := 0
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if v <= w {
+				cost += c
+			}
+			if v == w {
+				degree++
+			}
+			degree++
+			return
+		})
+		if degree&1 == 1 {
+			odd = append(odd, v)
+		}
+	}
+	var pair func(odd []int) int64
+	pair = func(odd []int) int64 {
+		if len(odd) == 0 {
+			return 0
+		}
+		best := int64(-1)
+		for i := 1; i < len(odd); i++ {
+			rest := append(append([]int{}, odd[1:i]...), odd[i+1:]...)
+			if c := dist[odd[0]][odd[i]] + pair(rest); best == -1 || c < best {
+				best = c
+			}
+		}
+		return best
+	}
+	return cost + pair(odd)
+}
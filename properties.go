@@ -0,0 +1,136 @@
+package graph
+
+// IsBipartite tells if g is bipartite, and returns a 2-coloring of its
+// vertices: every edge of g connects a vertex with color 0 to a vertex
+// with color 1. The vertices with color 0 are those in the set returned
+// by Bipartition, which includes the first vertex of each connected
+// component. If g isn't bipartite, it returns an empty slice and sets ok to false.
+//
+// The graph should be undirected: each edge from v to w should be
+// matched by an edge from w to v.
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func IsBipartite(g Iterator) (color []int, ok bool) {
+	part, ok := Bipartition(g)
+	if !ok {
+		return []int{}, false
+	}
+	// The part holds the first vertex of each component.
+	color = make([]int, g.Order())
+	for v := range color {
+		color[v] = 1
+	}
+	for _, v := range part {
+		color[v] = 0
+	}
+	return color, true
+}
+
+// IsForest tells if g is a forest: an undirected graph without cycles.
+// Self-loops and multiple edges count as cycles.
+//
+// The graph should be undirected: each edge from v to w should be
+// matched by an edge from w to v.
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func IsForest(g Iterator) bool {
+	_, ok := forest(g)
+	return ok
+}
+
+// IsTree tells if g is a tree: a connected undirected graph without cycles.
+// A tree has at least one vertex, and self-loops and multiple edges
+// count as cycles.
+//
+// The graph should be undirected: each edge from v to w should be
+// matched by an edge from w to v.
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func IsTree(g Iterator) bool {
+	count, ok := forest(g)
+	return ok && count == 1
+}
+
+// forest makes a breadth-first search of g, and tells if it found
+// no cycles. It returns the number of connected components searched.
+func forest(g Iterator) (count int, ok bool) {
+	n := g.Order()
+	parent := make([]int, n)
+	for v := range parent {
+		parent[v] = -2 // not seen
+	}
+	for v := range parent {
+		if parent[v] != -2 {
+			continue
+		}
+		count++
+		parent[v] = -1
+		for queue := []int{v}; len(queue) > 0; queue = queue[1:] {
+			v := queue[0]
+			skipped := false // the edge back to the parent has been skipped
+			if g.Visit(v, func(w int, _ int64) (skip bool) {
+				switch {
+				case w == v:
+					return true
+				case w == parent[v] && !skipped:
+					skipped = true
+				case parent[w] != -2:
+					return true
+				default:
+					parent[w] = v
+					queue = append(queue, w)
+				}
+				return
+			}) {
+				return count, false
+			}
+		}
+	}
+	return count, true
+}
+
+// IsRegular tells if g is regular: if every vertex has the same
+// indegree and outdegree, which is then returned by the function.
+// A graph without vertices is regular of degree 0.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func IsRegular(g Iterator) (degree int, ok bool) {
+	in, out := Degrees(g)
+	if len(out) == 0 {
+		return 0, true
+	}
+	degree = out[0]
+	for v := range out {
+		if in[v] != degree || out[v] != degree {
+			return 0, false
+		}
+	}
+	return degree, true
+}
+
+// IsComplete tells if g is complete: if there is an edge from each
+// vertex to every other vertex. Self-loops and multiple edges are ignored.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func IsComplete(g Iterator) bool {
+	n := g.Order()
+	// last[w] is the vertex, plus one, whose neighbors were last visited
+	// when w was found.
+	last := make([]int, n)
+	for v := 0; v < n; v++ {
+		count := 0
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			if w != v && last[w] != v+1 {
+				last[w] = v + 1
+				count++
+			}
+			return
+		})
+		if count != n-1 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,182 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestIsBipartite(t *testing.T) {
+	g := New(5)
+	g.AddBoth(0, 1)
+	g.AddBoth(1, 2)
+	g.AddBoth(3, 4)
+	color, ok := IsBipartite(g)
+	if mess, diff := diff(color, []int{0, 1, 0, 0, 1}); diff {
+		t.Errorf("IsBipartite->color %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("IsBipartite->ok %s", mess)
+	}
+
+	g.AddBoth(0, 2)
+	color, ok = IsBipartite(g)
+	if mess, diff := diff(color, []int{}); diff {
+		t.Errorf("IsBipartite->color %s", mess)
+	}
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("IsBipartite->ok %s", mess)
+	}
+
+	g = New(1)
+	g.Add(0, 0)
+	if _, ok := IsBipartite(g); ok {
+		t.Errorf("IsBipartite(self-loop) true")
+	}
+
+	for i := 0; i < 100; i++ {
+		n := 1 + rand.Intn(12)
+		g := New(n)
+		for m := rand.Intn(n + 1); m > 0; m-- {
+			g.AddBoth(rand.Intn(n), rand.Intn(n))
+		}
+		color, ok := IsBipartite(g)
+		_, exp := Bipartition(g)
+		if mess, diff := diff(ok, exp); diff {
+			t.Errorf("IsBipartite(%v)->ok %s", g, mess)
+		}
+		if !ok {
+			continue
+		}
+		for v := 0; v < n; v++ {
+			g.Visit(v, func(w int, _ int64) (skip bool) {
+				if color[v] == color[w] {
+					t.Errorf("IsBipartite(%v): edge (%d, %d) has one color", g, v, w)
+				}
+				return
+			})
+		}
+	}
+}
+
+func TestIsForest(t *testing.T) {
+	if !IsForest(New(0)) || IsTree(New(0)) {
+		t.Errorf("IsForest/IsTree(empty) wrong")
+	}
+	if !IsForest(New(1)) || !IsTree(New(1)) {
+		t.Errorf("IsForest/IsTree(singleton) wrong")
+	}
+
+	g := New(4)
+	g.AddBoth(0, 1)
+	g.AddBoth(0, 2)
+	if mess, diff := diff(IsForest(g), true); diff {
+		t.Errorf("IsForest %s", mess)
+	}
+	if mess, diff := diff(IsTree(g), false); diff {
+		t.Errorf("IsTree %s", mess)
+	}
+	g.AddBoth(2, 3)
+	if mess, diff := diff(IsTree(g), true); diff {
+		t.Errorf("IsTree %s", mess)
+	}
+	g.AddBoth(1, 3)
+	if mess, diff := diff(IsForest(g), false); diff {
+		t.Errorf("IsForest %s", mess)
+	}
+
+	g = New(2)
+	g.Add(1, 1)
+	if mess, diff := diff(IsForest(g), false); diff {
+		t.Errorf("IsForest(self-loop) %s", mess)
+	}
+
+	// Multiple edges form a cycle.
+	h := FromEdges(2, []Edge{{0, 1, 0}, {1, 0, 0}, {0, 1, 1}, {1, 0, 1}})
+	if mess, diff := diff(IsForest(h), false); diff {
+		t.Errorf("IsForest(multigraph) %s", mess)
+	}
+
+	for i := 0; i < 100; i++ {
+		n := 1 + rand.Intn(12)
+		g := New(n)
+		for m := rand.Intn(n + 1); m > 0; m-- {
+			if v, w := rand.Intn(n), rand.Intn(n); v != w {
+				g.AddBoth(v, w)
+			}
+		}
+		count := len(Components(g))
+		exp := Check(g).Size/2 == n-count
+		if mess, diff := diff(IsForest(g), exp); diff {
+			t.Errorf("IsForest(%v) %s", g, mess)
+		}
+		if mess, diff := diff(IsTree(g), exp && count == 1); diff {
+			t.Errorf("IsTree(%v) %s", g, mess)
+		}
+	}
+}
+
+func TestIsRegular(t *testing.T) {
+	degree, ok := IsRegular(New(0))
+	if mess, diff := diff(degree, 0); diff || !ok {
+		t.Errorf("IsRegular(empty)->degree %s, ok %v", mess, ok)
+	}
+	g := New(4)
+	for v := 0; v < 4; v++ {
+		g.AddBoth(v, (v+1)%4)
+	}
+	degree, ok = IsRegular(g)
+	if mess, diff := diff(degree, 2); diff || !ok {
+		t.Errorf("IsRegular(cycle)->degree %s, ok %v", mess, ok)
+	}
+	g.AddBoth(0, 2)
+	if _, ok := IsRegular(g); ok {
+		t.Errorf("IsRegular(%v) true", g)
+	}
+
+	// A directed cycle is 1-regular, but a directed path is not.
+	g = New(3)
+	g.Add(0, 1)
+	g.Add(1, 2)
+	if _, ok := IsRegular(g); ok {
+		t.Errorf("IsRegular(%v) true", g)
+	}
+	g.Add(2, 0)
+	degree, ok = IsRegular(g)
+	if mess, diff := diff(degree, 1); diff || !ok {
+		t.Errorf("IsRegular(directed cycle)->degree %s, ok %v", mess, ok)
+	}
+	// Indegrees must match too.
+	g = New(2)
+	g.Add(0, 0)
+	g.Add(1, 0)
+	if _, ok := IsRegular(g); ok {
+		t.Errorf("IsRegular(%v) true", g)
+	}
+}
+
+func TestIsComplete(t *testing.T) {
+	if !IsComplete(New(0)) || !IsComplete(New(1)) {
+		t.Errorf("IsComplete(trivial) false")
+	}
+	g := New(3)
+	g.AddBoth(0, 1)
+	g.AddBoth(1, 2)
+	g.Add(0, 0)
+	if mess, diff := diff(IsComplete(g), false); diff {
+		t.Errorf("IsComplete %s", mess)
+	}
+	g.Add(0, 2)
+	if mess, diff := diff(IsComplete(g), false); diff {
+		t.Errorf("IsComplete %s", mess)
+	}
+	g.Add(2, 0)
+	if mess, diff := diff(IsComplete(g), true); diff {
+		t.Errorf("IsComplete %s", mess)
+	}
+
+	// Multiple edges are counted once.
+	h := FromEdges(3, []Edge{{0, 1, 0}, {0, 1, 1}, {1, 0, 0}, {2, 0, 0}, {2, 1, 0}})
+	if mess, diff := diff(IsComplete(h), false); diff {
+		t.Errorf("IsComplete(multigraph) %s", mess)
+	}
+}
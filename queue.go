@@ -0,0 +1,294 @@
+package graph
+
+import (
+	"math/bits"
+	"strconv"
+)
+
+// DistQueue is a priority queue of vertices ordered by distance,
+// used by ShortestPathsWithQueue and AStarWithQueue.
+//
+// A vertex may be pushed several times, each time with a smaller
+// distance than before. An implementation may either update the
+// position of the vertex in the queue, or keep all entries;
+// entries with outdated distances are ignored when popped.
+// The distances popped from the queue never decrease.
+type DistQueue interface {
+	// Push adds vertex v with distance d to the queue.
+	Push(v int, d int64)
+
+	// Pop removes and returns a vertex v with minimum distance d.
+	Pop() (v int, d int64)
+
+	// Len returns the number of entries in the queue.
+	Len() int
+}
+
+// NewDistQueue returns a DistQueue suitable for running
+// ShortestPathsWithQueue on g, chosen by the size of the graph
+// and the range of its edge costs. Dial's algorithm, implemented by
+// NewBucketQueue, takes O(|E| + maxCost⋅|V|) time and is picked if
+// this is at most the O(|E|⋅log|V|) time of Dijkstra's algorithm with
+// a heap; graphs with costs 0 and 1 only get a NewZeroOneQueue.
+// Otherwise the 4-ary heap of NewQuadHeapQueue is used, which is faster
+// than the binary heap of NewHeapQueue for graphs of all sizes.
+// Negative costs are ignored, like in ShortestPathsWithQueue.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func NewDistQueue(g Iterator) DistQueue {
+	n := g.Order()
+	var maxCost, edges int64
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			edges++
+			if c > maxCost {
+				maxCost = c
+			}
+			return
+		})
+	}
+	switch {
+	case maxCost <= 1:
+		return NewZeroOneQueue()
+	case maxCost <= edges*int64(bits.Len(uint(n)))/int64(n):
+		return NewBucketQueue(maxCost)
+	}
+	return NewQuadHeapQueue(n)
+}
+
+// NewHeapQueue returns a DistQueue for the vertices 0..n-1
+// implemented as a binary heap.
+// Push and Pop have time complexity O(log n).
+func NewHeapQueue(n int) DistQueue {
+	q := emptyPrioQueue(make([]int64, n))
+	for v := range q.index {
+		q.index[v] = -1
+	}
+	return heapQueue{q}
+}
+
+type heapQueue struct {
+	q *prioQueue
+}
+
+func (h heapQueue) Push(v int, d int64) {
+	h.q.cost[v] = d
+	if h.q.Contains(v) {
+		h.q.Fix(v)
+	} else {
+		h.q.Push(v)
+	}
+}
+
+func (h heapQueue) Pop() (v int, d int64) {
+	v = h.q.Pop()
+	return v, h.q.cost[v]
+}
+
+func (h heapQueue) Len() int {
+	return h.q.Len()
+}
+
+type distEntry struct {
+	v int
+	d int64
+}
+
+// NewQuadHeapQueue returns a DistQueue for the vertices 0..n-1
+// implemented as a 4-ary heap. The entries are stored in the heap
+// together with their distances, and the position of each vertex
+// is kept in an index array, so that a repeated Push updates the
+// entry in place. The heap is shallower than a binary heap, and
+// the children of a node are adjacent in memory, which makes it
+// faster for large graphs.
+// Push and Pop have time complexity O(log n).
+func NewQuadHeapQueue(n int) DistQueue {
+	q := &quadHeapQueue{index: make([]int, n)}
+	for v := range q.index {
+		q.index[v] = -1
+	}
+	return q
+}
+
+type quadHeapQueue struct {
+	heap  []distEntry
+	index []int // the position of each vertex in the heap, or -1
+}
+
+func (q *quadHeapQueue) Push(v int, d int64) {
+	i := q.index[v]
+	if i == -1 {
+		i = len(q.heap)
+		q.heap = append(q.heap, distEntry{v, d})
+		q.index[v] = i
+		q.up(i)
+		return
+	}
+	old := q.heap[i].d
+	q.heap[i].d = d
+	if d < old {
+		q.up(i)
+	} else {
+		q.down(i)
+	}
+}
+
+func (q *quadHeapQueue) Pop() (v int, d int64) {
+	top := q.heap[0]
+	n := len(q.heap) - 1
+	last := q.heap[n]
+	q.heap = q.heap[:n]
+	q.index[top.v] = -1
+	if n > 0 {
+		q.heap[0] = last
+		q.index[last.v] = 0
+		q.down(0)
+	}
+	return top.v, top.d
+}
+
+func (q *quadHeapQueue) Len() int {
+	return len(q.heap)
+}
+
+func (q *quadHeapQueue) up(i int) {
+	e := q.heap[i]
+	for i > 0 {
+		p := (i - 1) / 4
+		if q.heap[p].d <= e.d {
+			break
+		}
+		q.heap[i] = q.heap[p]
+		q.index[q.heap[i].v] = i
+		i = p
+	}
+	q.heap[i] = e
+	q.index[e.v] = i
+}
+
+func (q *quadHeapQueue) down(i int) {
+	e := q.heap[i]
+	n := len(q.heap)
+	for {
+		c := 4*i + 1
+		if c >= n {
+			break
+		}
+		// Find the child with the smallest distance.
+		min := c
+		for j := c + 1; j < c+4 && j < n; j++ {
+			if q.heap[j].d < q.heap[min].d {
+				min = j
+			}
+		}
+		if e.d <= q.heap[min].d {
+			break
+		}
+		q.heap[i] = q.heap[min]
+		q.index[q.heap[i].v] = i
+		i = min
+	}
+	q.heap[i] = e
+	q.index[e.v] = i
+}
+
+// NewZeroOneQueue returns a DistQueue for graphs where all edges
+// have cost 0 or 1. Using this queue, ShortestPathsWithQueue
+// performs a 0-1 breadth-first search.
+// Push and Pop have amortized time complexity O(1).
+// Push panics if d isn't equal to, or one more than,
+// the most recently popped distance.
+func NewZeroOneQueue() DistQueue {
+	return &zeroOneQueue{}
+}
+
+// A deque with the invariant that all entries in front have
+// distance cur, and the entries in back, in increasing order,
+// have distance cur or cur+1.
+type zeroOneQueue struct {
+	front []distEntry // a stack
+	back  []distEntry // a queue, starting at index head
+	head  int
+	cur   int64
+}
+
+func (q *zeroOneQueue) Push(v int, d int64) {
+	switch d {
+	case q.cur:
+		q.front = append(q.front, distEntry{v, d})
+	case q.cur + 1:
+		q.back = append(q.back, distEntry{v, d})
+	default:
+		panic("distance out of range: " + strconv.FormatInt(d, 10))
+	}
+}
+
+func (q *zeroOneQueue) Pop() (v int, d int64) {
+	var e distEntry
+	if n := len(q.front) - 1; n >= 0 {
+		e, q.front = q.front[n], q.front[:n]
+	} else {
+		e = q.back[q.head]
+		q.head++
+		if q.head == len(q.back) {
+			q.back, q.head = q.back[:0], 0
+		}
+	}
+	q.cur = e.d
+	return e.v, e.d
+}
+
+func (q *zeroOneQueue) Len() int {
+	return len(q.front) + len(q.back) - q.head
+}
+
+// NewBucketQueue returns a DistQueue for graphs with edge costs
+// in the range 0..maxCost. Using this queue, ShortestPathsWithQueue
+// implements Dial's algorithm, with time complexity O(|E| + maxCost⋅|V|).
+// The queue uses maxCost + 1 buckets in a circular array.
+// Push panics if d is smaller than the most recently popped distance,
+// or if it exceeds this distance by more than maxCost.
+func NewBucketQueue(maxCost int64) DistQueue {
+	if maxCost < 0 {
+		panic("negative max cost: " + strconv.FormatInt(maxCost, 10))
+	}
+	return &bucketQueue{buckets: make([][]int, maxCost+1)}
+}
+
+// All entries in buckets[d % len(buckets)] have distance d,
+// with cur ≤ d ≤ cur + maxCost.
+type bucketQueue struct {
+	buckets [][]int
+	cur     int64
+	size    int
+}
+
+func (q *bucketQueue) Push(v int, d int64) {
+	m := int64(len(q.buckets))
+	if d < q.cur || d-q.cur >= m {
+		panic("distance out of range: " + strconv.FormatInt(d, 10))
+	}
+	i := d % m
+	q.buckets[i] = append(q.buckets[i], v)
+	q.size++
+}
+
+func (q *bucketQueue) Pop() (v int, d int64) {
+	if q.size == 0 {
+		panic("pop from empty queue")
+	}
+	m := int64(len(q.buckets))
+	for len(q.buckets[q.cur%m]) == 0 {
+		q.cur++
+	}
+	b := q.buckets[q.cur%m]
+	n := len(b) - 1
+	v, q.buckets[q.cur%m] = b[n], b[:n]
+	q.size--
+	return v, q.cur
+}
+
+func (q *bucketQueue) Len() int {
+	return q.size
+}
@@ -0,0 +1,227 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestShortestPathsWithQueue(t *testing.T) {
+	n := 200
+	for _, maxCost := range []int64{1, 5, 20} {
+		g := New(n)
+		for i := 0; i < 5*n; i++ {
+			g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(maxCost+1))
+		}
+		expParent, expDist := ShortestPaths(g, 0)
+		queues := map[string]DistQueue{
+			"heap":     NewHeapQueue(n),
+			"quadheap": NewQuadHeapQueue(n),
+			"bucket":   NewBucketQueue(maxCost),
+			"auto":     NewDistQueue(g),
+		}
+		if maxCost == 1 {
+			queues["0-1"] = NewZeroOneQueue()
+		}
+		for name, q := range queues {
+			parent, dist := ShortestPathsWithQueue(g, 0, q)
+			if mess, diff := diff(dist, expDist); diff {
+				t.Errorf("ShortestPathsWithQueue(%s)->dist %s", name, mess)
+			}
+			if q.Len() != 0 {
+				t.Errorf("ShortestPathsWithQueue(%s): %d entries left in queue", name, q.Len())
+			}
+			// The parents may differ, but must give paths of the same length.
+			for w := range parent {
+				if parent[w] == -1 {
+					if expParent[w] != -1 {
+						t.Errorf("ShortestPathsWithQueue(%s)->parent[%d] = -1", name, w)
+					}
+					continue
+				}
+				if d := dist[parent[w]] + g.Cost(parent[w], w); d != dist[w] {
+					t.Errorf("ShortestPathsWithQueue(%s)->parent[%d] gives distance %d; want %d", name, w, d, dist[w])
+				}
+			}
+		}
+	}
+
+	g := New(4)
+	g.AddCost(0, 1, 1)
+	g.AddCost(1, 3, 0)
+	g.AddCost(0, 2, 1)
+	g.AddCost(2, 3, 1)
+	g.AddCost(3, 2, -1)
+	path, dist := ShortestPathWithQueue(g, 0, 3, NewZeroOneQueue())
+	if mess, diff := diff(path, []int{0, 1, 3}); diff {
+		t.Errorf("ShortestPathWithQueue->path %s", mess)
+	}
+	if mess, diff := diff(dist, int64(1)); diff {
+		t.Errorf("ShortestPathWithQueue->dist %s", mess)
+	}
+}
+
+func TestQuadHeapQueue(t *testing.T) {
+	n := 100
+	q := NewQuadHeapQueue(n)
+	dist := make([]int64, n)
+	for v := range dist {
+		dist[v] = rand.Int63n(1000)
+		q.Push(v, dist[v])
+	}
+	// Decrease some keys, and increase others.
+	for i := 0; i < n; i++ {
+		v := rand.Intn(n)
+		dist[v] = rand.Int63n(1000)
+		q.Push(v, dist[v])
+	}
+	if q.Len() != n {
+		t.Errorf("QuadHeapQueue.Len() = %d; want %d", q.Len(), n)
+	}
+	prev := int64(-1)
+	seen := make([]bool, n)
+	for q.Len() > 0 {
+		v, d := q.Pop()
+		if d < prev || d != dist[v] || seen[v] {
+			t.Errorf("QuadHeapQueue.Pop() = %d, %d after %d", v, d, prev)
+		}
+		prev, seen[v] = d, true
+	}
+
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddBothCost(rand.Intn(n), rand.Intn(n), rand.Int63n(10))
+	}
+	_, expTotal := Prim(g, NewHeapQueue(n))
+	if _, total := Prim(g, NewQuadHeapQueue(n)); total != expTotal {
+		t.Errorf("Prim(QuadHeapQueue)->total %d; want %d", total, expTotal)
+	}
+}
+
+func TestNewDistQueue(t *testing.T) {
+	g := New(100)
+	for v := 0; v < 99; v++ {
+		g.AddCost(v, v+1, 1)
+	}
+	if _, ok := NewDistQueue(g).(*zeroOneQueue); !ok {
+		t.Errorf("NewDistQueue(0-1 costs) = %T", NewDistQueue(g))
+	}
+	g.AddCost(0, 2, 5)
+	if _, ok := NewDistQueue(g).(*bucketQueue); !ok {
+		t.Errorf("NewDistQueue(small costs) = %T", NewDistQueue(g))
+	}
+	g.AddCost(0, 3, 1e6)
+	if _, ok := NewDistQueue(g).(*quadHeapQueue); !ok {
+		t.Errorf("NewDistQueue(large costs) = %T", NewDistQueue(g))
+	}
+}
+
+func TestZeroOneQueue(t *testing.T) {
+	q := NewZeroOneQueue()
+	q.Push(0, 0)
+	q.Push(1, 1)
+	q.Push(2, 0)
+	q.Push(3, 1)
+	var res []int
+	for q.Len() > 0 {
+		v, d := q.Pop()
+		res = append(res, v, int(d))
+		if v == 2 {
+			q.Push(4, 0)
+		}
+		if v == 1 {
+			q.Push(5, 2)
+		}
+	}
+	if mess, diff := diff(res, []int{2, 0, 4, 0, 0, 0, 1, 1, 3, 1, 5, 2}); diff {
+		t.Errorf("ZeroOneQueue %s", mess)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("ZeroOneQueue: Push(0, 4) didn't panic")
+		}
+	}()
+	q.Push(0, 4)
+}
+
+func TestBucketQueue(t *testing.T) {
+	q := NewBucketQueue(3)
+	q.Push(0, 3)
+	q.Push(1, 0)
+	q.Push(2, 2)
+	var res []int
+	for q.Len() > 0 {
+		v, d := q.Pop()
+		res = append(res, v, int(d))
+		if v == 2 {
+			q.Push(3, 5)
+			q.Push(4, 3)
+		}
+	}
+	if mess, diff := diff(res, []int{1, 0, 2, 2, 4, 3, 0, 3, 3, 5}); diff {
+		t.Errorf("BucketQueue %s", mess)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("BucketQueue: Push(0, 9) didn't panic")
+		}
+	}()
+	q.Push(0, 9)
+}
+
+func BenchmarkHeapQueue(b *testing.B) {
+	benchmarkQueue(b, func() DistQueue { return NewHeapQueue(1000) })
+}
+
+func BenchmarkBucketQueue(b *testing.B) {
+	benchmarkQueue(b, func() DistQueue { return NewBucketQueue(1) })
+}
+
+func BenchmarkZeroOneQueue(b *testing.B) {
+	benchmarkQueue(b, NewZeroOneQueue)
+}
+
+func benchmarkQueue(b *testing.B, newQueue func() DistQueue) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(2))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ShortestPathsWithQueue(g, 0, newQueue())
+	}
+}
+
+func BenchmarkQuadHeapQueue(b *testing.B) {
+	benchmarkQueue(b, func() DistQueue { return NewQuadHeapQueue(1000) })
+}
+
+// BenchmarkQueues compares the queues on random graphs of different sizes.
+func BenchmarkQueues(b *testing.B) {
+	for _, n := range []int{1e3, 1e4, 1e5, 1e6} {
+		g := New(n)
+		for i := 0; i < 4*n; i++ {
+			g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(100))
+		}
+		h := Sort(g)
+		queues := []struct {
+			name     string
+			newQueue func() DistQueue
+		}{
+			{"heap", func() DistQueue { return NewHeapQueue(n) }},
+			{"quadheap", func() DistQueue { return NewQuadHeapQueue(n) }},
+			{"bucket", func() DistQueue { return NewBucketQueue(99) }},
+		}
+		for _, q := range queues {
+			b.Run(fmt.Sprintf("%s/%d", q.name, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					_, _ = ShortestPathsWithQueue(h, i%n, q.newQueue())
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Package random generates random graphs.
+//
+// Every generator takes a rand.Source, and produces the same graph
+// when given sources seeded with the same value. All edges have cost zero.
+//
+// GNP and GNM build Erdős–Rényi random graphs, BarabasiAlbert builds
+// scale-free graphs by preferential attachment, WattsStrogatz builds
+// small-world graphs, and Regular builds random regular graphs.
+// These graphs are undirected, without self-loops.
+// Configuration builds a random multigraph with a given degree sequence.
+// DAG builds random directed acyclic graphs.
+//
+// Walk takes random walks in a graph, and SpanningTree picks
+// uniformly random spanning trees using Wilson's algorithm.
+// VertexSample, EdgeSample, Snowball and ForestFire draw smaller
+// samples of a large graph.
+package random
+
+import (
+	"github.com/yourbasic/graph"
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+// GNP returns an Erdős–Rényi random graph with n vertices, in which
+// each of the n(n-1)/2 possible undirected edges is present,
+// independently of the others, with probability p.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func GNP(n int, p float64, src rand.Source) *graph.Mutable {
+	g := graph.New(n)
+	pairs(n, p, rand.New(src), func(v, w int) {
+		g.AddBoth(v, w)
+	})
+	return g
+}
+
+// GNM returns an Erdős–Rényi random graph with n vertices and m edges,
+// chosen uniformly at random from all such undirected graphs.
+// It panics if m is negative or larger than n(n-1)/2.
+//
+// The time complexity is O(m + |V|), where |V| is the number of vertices.
+func GNM(n, m int, src rand.Source) *graph.Mutable {
+	total := int64(n) * int64(n-1) / 2
+	if m < 0 || int64(m) > total {
+		panic("edge count out of range: " + strconv.Itoa(m))
+	}
+	r := rand.New(src)
+	g := graph.New(n)
+	// Floyd's algorithm picks m distinct pairs from 0..total-1.
+	chosen := make(map[int64]bool, m)
+	for j := total - int64(m); j < total; j++ {
+		k := r.Int63n(j + 1)
+		if chosen[k] {
+			k = j
+		}
+		chosen[k] = true
+		v, w := unrank(k)
+		g.AddBoth(v, w)
+	}
+	return g
+}
+
+// DAG returns a random directed acyclic graph with n vertices,
+// in which each of the n(n-1)/2 possible edges (v, w) with v < w
+// is present, independently of the others, with probability p.
+// Hence 0, 1,… , n-1 is a topological ordering of the graph.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func DAG(n int, p float64, src rand.Source) *graph.Mutable {
+	g := graph.New(n)
+	pairs(n, p, rand.New(src), func(v, w int) {
+		g.Add(w, v)
+	})
+	return g
+}
+
+// pairs calls do(v, w) for each pair w < v < n with probability p.
+// It uses the geometric skipping method of Batagelj and Brandes
+// to jump directly from one chosen pair to the next.
+func pairs(n int, p float64, r *rand.Rand, do func(v, w int)) {
+	switch {
+	case p <= 0:
+		return
+	case p >= 1:
+		for v := 1; v < n; v++ {
+			for w := 0; w < v; w++ {
+				do(v, w)
+			}
+		}
+		return
+	}
+	lp := math.Log1p(-p)
+	for v, w := 1, -1; v < n; {
+		skip := math.Log1p(-r.Float64()) / lp
+		if skip >= float64(n)*float64(n) {
+			return
+		}
+		w += 1 + int(skip)
+		for w >= v && v < n {
+			w -= v
+			v++
+		}
+		if v < n {
+			do(v, w)
+		}
+	}
+}
+
+// unrank returns the k:th pair v < w in the order
+// (0, 1), (0, 2), (1, 2), (0, 3), (1, 3), (2, 3),…
+func unrank(k int64) (v, w int) {
+	x := int64((1 + math.Sqrt(1+8*float64(k))) / 2)
+	for x*(x-1)/2 > k {
+		x--
+	}
+	for (x+1)*x/2 <= k {
+		x++
+	}
+	return int(k - x*(x-1)/2), int(x)
+}
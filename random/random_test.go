@@ -0,0 +1,146 @@
+package random
+
+import (
+	"fmt"
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func diff(res, exp interface{}) (message string, diff bool) {
+	if !reflect.DeepEqual(res, exp) {
+		message = fmt.Sprintf("%v; want %v", res, exp)
+		diff = true
+	}
+	return
+}
+
+// checkSimple checks that g is undirected, without self-loops,
+// and that all edges have cost zero.
+func checkSimple(t *testing.T, name string, g *graph.Mutable) {
+	t.Helper()
+	for v := 0; v < g.Order(); v++ {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			switch {
+			case v == w:
+				t.Errorf("%s: self-loop at %d", name, v)
+			case !g.Edge(w, v):
+				t.Errorf("%s: edge (%d, %d) but not (%d, %d)", name, v, w, w, v)
+			case c != 0:
+				t.Errorf("%s: edge (%d, %d) has cost %d", name, v, w, c)
+			}
+			return
+		})
+	}
+}
+
+// size returns the number of undirected edges of g.
+func size(g *graph.Mutable) int {
+	return graph.Check(g).Size / 2
+}
+
+func TestGNP(t *testing.T) {
+	g := GNP(10, 0, rand.NewSource(1))
+	if mess, diff := diff(size(g), 0); diff {
+		t.Errorf("GNP(p=0)->size %s", mess)
+	}
+	g = GNP(10, 1, rand.NewSource(1))
+	if mess, diff := diff(size(g), 45); diff {
+		t.Errorf("GNP(p=1)->size %s", mess)
+	}
+	for _, n := range []int{0, 1} {
+		if mess, diff := diff(size(GNP(n, 1, rand.NewSource(1))), 0); diff {
+			t.Errorf("GNP(n=%d)->size %s", n, mess)
+		}
+	}
+
+	n, p := 400, 0.05
+	g = GNP(n, p, rand.NewSource(2))
+	checkSimple(t, "GNP", g)
+	exp := p * float64(n*(n-1)/2)
+	if m := float64(size(g)); m < 0.9*exp || m > 1.1*exp {
+		t.Errorf("GNP->size %v; want about %v", m, exp)
+	}
+	h := GNP(n, p, rand.NewSource(2))
+	if !graph.Equal(g, h) {
+		t.Errorf("GNP not reproducible")
+	}
+}
+
+func TestGNM(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 5, 30} {
+		max := n * (n - 1) / 2
+		for _, m := range []int{0, max / 3, max} {
+			g := GNM(n, m, rand.NewSource(int64(m)))
+			checkSimple(t, "GNM", g)
+			if mess, diff := diff(size(g), m); diff {
+				t.Errorf("GNM(%d, %d)->size %s", n, m, mess)
+			}
+		}
+	}
+	g, h := GNM(100, 500, rand.NewSource(3)), GNM(100, 500, rand.NewSource(3))
+	if !graph.Equal(g, h) {
+		t.Errorf("GNM not reproducible")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("GNM(3, 4) should panic")
+		}
+	}()
+	GNM(3, 4, rand.NewSource(1))
+}
+
+func TestUnrank(t *testing.T) {
+	k := int64(0)
+	for w := 1; w < 50; w++ {
+		for v := 0; v < w; v++ {
+			x, y := unrank(k)
+			if x != v || y != w {
+				t.Errorf("unrank(%d) = (%d, %d); want (%d, %d)", k, x, y, v, w)
+			}
+			k++
+		}
+	}
+}
+
+func TestDAG(t *testing.T) {
+	n, p := 300, 0.1
+	g := DAG(n, p, rand.NewSource(4))
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if w <= v {
+				t.Errorf("DAG: edge (%d, %d)", v, w)
+			}
+			return
+		})
+	}
+	if !graph.Acyclic(g) {
+		t.Errorf("DAG: not acyclic")
+	}
+	exp := p * float64(n*(n-1)/2)
+	if m := float64(graph.Check(g).Size); m < 0.9*exp || m > 1.1*exp {
+		t.Errorf("DAG->size %v; want about %v", m, exp)
+	}
+	if !graph.Equal(g, DAG(n, p, rand.NewSource(4))) {
+		t.Errorf("DAG not reproducible")
+	}
+	if mess, diff := diff(graph.Check(DAG(5, 1, rand.NewSource(4))).Size, 10); diff {
+		t.Errorf("DAG(p=1)->size %s", mess)
+	}
+}
+
+func BenchmarkGNP(b *testing.B) {
+	src := rand.NewSource(1)
+	for i := 0; i < b.N; i++ {
+		_ = GNP(1000, 0.01, src)
+	}
+}
+
+func BenchmarkGNM(b *testing.B) {
+	src := rand.NewSource(1)
+	for i := 0; i < b.N; i++ {
+		_ = GNM(1000, 5000, src)
+	}
+}
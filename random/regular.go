@@ -0,0 +1,108 @@
+package random
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"strconv"
+)
+
+// Regular returns a random d-regular graph with n vertices, in which
+// every vertex has exactly d neighbors. It panics if nd is odd, or if
+// d is negative or not less than n.
+//
+// The graph is built by randomly pairing the d stubs of each vertex,
+// discarding pairs that would form self-loops or multiple edges,
+// and pairing the remaining stubs again. The process starts over
+// if it gets stuck. This gives an approximately uniform distribution,
+// and the expected time complexity is O(nd²) for small d.
+func Regular(n, d int, src rand.Source) *graph.Mutable {
+	if d < 0 || d >= n && d > 0 || n*d%2 != 0 {
+		panic("invalid degree: " + strconv.Itoa(d))
+	}
+	r := rand.New(src)
+	for {
+		if g := tryRegular(n, d, r); g != nil {
+			return g
+		}
+	}
+}
+
+// tryRegular makes one attempt to build a random d-regular graph.
+// It returns nil if it gets stuck.
+func tryRegular(n, d int, r *rand.Rand) *graph.Mutable {
+	g := graph.New(n)
+	stubs := make([]int, 0, n*d)
+	for v := 0; v < n; v++ {
+		for i := 0; i < d; i++ {
+			stubs = append(stubs, v)
+		}
+	}
+	for len(stubs) > 0 {
+		r.Shuffle(len(stubs), func(i, j int) {
+			stubs[i], stubs[j] = stubs[j], stubs[i]
+		})
+		var left []int
+		for i := 0; i < len(stubs); i += 2 {
+			v, w := stubs[i], stubs[i+1]
+			if v == w || g.Edge(v, w) {
+				left = append(left, v, w)
+				continue
+			}
+			g.AddBoth(v, w)
+		}
+		if !suitable(g, left) {
+			return nil
+		}
+		stubs = left
+	}
+	return g
+}
+
+// suitable tells if some pair of the remaining stubs can be joined.
+func suitable(g *graph.Mutable, stubs []int) bool {
+	if len(stubs) == 0 {
+		return true
+	}
+	for i, v := range stubs {
+		for _, w := range stubs[i+1:] {
+			if v != w && !g.Edge(v, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Configuration returns a random multigraph, built by the configuration
+// model, in which vertex v has degree seq[v]. Each vertex gets seq[v]
+// stubs, and the stubs are then joined in random pairs. Each pair
+// becomes an undirected edge, stored as two directed edges of cost zero;
+// hence the graph may have multiple edges and self-loops.
+// It panics if an element of seq is negative or if their sum is odd.
+//
+// The time complexity is O(n + m), where n is the length of seq and m its sum.
+func Configuration(seq []int, src rand.Source) *graph.Multigraph {
+	var stubs []int
+	for v, d := range seq {
+		if d < 0 {
+			panic("negative degree: " + strconv.Itoa(d))
+		}
+		for i := 0; i < d; i++ {
+			stubs = append(stubs, v)
+		}
+	}
+	if len(stubs)%2 != 0 {
+		panic("odd degree sum: " + strconv.Itoa(len(stubs)))
+	}
+	r := rand.New(src)
+	r.Shuffle(len(stubs), func(i, j int) {
+		stubs[i], stubs[j] = stubs[j], stubs[i]
+	})
+	g := graph.NewMultigraph(len(seq))
+	for i := 0; i < len(stubs); i += 2 {
+		v, w := stubs[i], stubs[i+1]
+		g.AddEdge(v, w, 0)
+		g.AddEdge(w, v, 0)
+	}
+	return g
+}
@@ -0,0 +1,72 @@
+package random
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"testing"
+)
+
+func TestRegular(t *testing.T) {
+	for _, c := range []struct{ n, d int }{
+		{0, 0}, {1, 0}, {2, 1}, {4, 3}, {5, 2}, {10, 3}, {50, 4}, {100, 7}, {9, 8},
+	} {
+		g := Regular(c.n, c.d, rand.NewSource(int64(c.n+c.d)))
+		checkSimple(t, "Regular", g)
+		for v := 0; v < c.n; v++ {
+			if g.Degree(v) != c.d {
+				t.Errorf("Regular(%d, %d)->Degree(%d) %d", c.n, c.d, v, g.Degree(v))
+			}
+		}
+	}
+	g, h := Regular(60, 4, rand.NewSource(5)), Regular(60, 4, rand.NewSource(5))
+	if !graph.Equal(g, h) {
+		t.Errorf("Regular not reproducible")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Regular(5, 3) should panic")
+		}
+	}()
+	Regular(5, 3, rand.NewSource(1))
+}
+
+func TestConfiguration(t *testing.T) {
+	seq := []int{3, 0, 1, 2, 4, 2}
+	g := Configuration(seq, rand.NewSource(1))
+	if mess, diff := diff(g.Order(), len(seq)); diff {
+		t.Errorf("Configuration->Order %s", mess)
+	}
+	for v, d := range seq {
+		if mess, diff := diff(g.Degree(v), d); diff {
+			t.Errorf("Configuration->Degree(%d) %s", v, mess)
+		}
+	}
+	if mess, diff := diff(g.Size(), 12); diff {
+		t.Errorf("Configuration->Size %s", mess)
+	}
+	in, _ := graph.Degrees(g)
+	if mess, diff := diff(in, seq); diff {
+		t.Errorf("Configuration->indegree %s", mess)
+	}
+	if !graph.Equal(g, Configuration(seq, rand.NewSource(1))) {
+		t.Errorf("Configuration not reproducible")
+	}
+	if mess, diff := diff(Configuration(nil, rand.NewSource(1)).Order(), 0); diff {
+		t.Errorf("Configuration(nil)->Order %s", mess)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Configuration({1, 2}) should panic")
+		}
+	}()
+	Configuration([]int{1, 2}, rand.NewSource(1))
+}
+
+func BenchmarkRegular(b *testing.B) {
+	src := rand.NewSource(1)
+	for i := 0; i < b.N; i++ {
+		_ = Regular(1000, 4, src)
+	}
+}
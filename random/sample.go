@@ -0,0 +1,230 @@
+package random
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"sort"
+	"strconv"
+)
+
+// A Sample is a smaller graph drawn from a larger one.
+// Vertex i of the sample corresponds to vertex Vertices[i]
+// of the original graph, and the vertices are sorted in increasing order.
+type Sample struct {
+	Graph    *graph.Mutable
+	Vertices []int
+}
+
+// Index returns the vertex of the sample that corresponds to vertex v
+// of the original graph, or -1 if v isn't part of the sample.
+//
+// The time complexity is O(log n), where n is the order of the sample.
+func (s *Sample) Index(v int) int {
+	i := sort.SearchInts(s.Vertices, v)
+	if i < len(s.Vertices) && s.Vertices[i] == v {
+		return i
+	}
+	return -1
+}
+
+// induced returns the sample induced by the given vertices of g,
+// which are sorted in place.
+func induced(g graph.Iterator, vertices []int) *Sample {
+	sort.Ints(vertices)
+	return &Sample{graph.Copy(graph.Subgraph(g, vertices)), vertices}
+}
+
+// VertexSample returns the subgraph of g induced by k vertices chosen
+// uniformly at random; it consists of these vertices and all edges
+// of g between them. If k is at least the order of g, all vertices are used.
+// It panics if k is negative.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in g.
+func VertexSample(g graph.Iterator, k int, src rand.Source) *Sample {
+	if k < 0 {
+		panic("negative sample size: " + strconv.Itoa(k))
+	}
+	n := g.Order()
+	if k > n {
+		k = n
+	}
+	perm := rand.New(src).Perm(n)
+	return induced(g, perm[:k])
+}
+
+// EdgeSample returns a subgraph of g in which each edge of g is kept
+// with probability p. An edge and its reverse are kept or dropped
+// together, which means that the sample of an undirected graph is
+// undirected. The sample consists of the kept edges and their endpoints;
+// vertices without kept edges are dropped.
+//
+// The time complexity is O(|E|⋅log|E| + |V|), where |E| is the number
+// of edges and |V| the number of vertices in g.
+func EdgeSample(g graph.Iterator, p float64, src rand.Source) *Sample {
+	r := rand.New(src)
+	var edges []graph.Edge
+	graph.VisitAll(g, func(v, w int, c int64) (skip bool) {
+		edges = append(edges, graph.Edge{V: v, W: w, Cost: c})
+		return
+	})
+	// Sort the edges so that an edge and its reverse are next to each other,
+	// and the random choices don't depend on the order of Visit.
+	key := func(e graph.Edge) (int, int) {
+		if e.V < e.W {
+			return e.V, e.W
+		}
+		return e.W, e.V
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		ei, ej := edges[i], edges[j]
+		vi, wi := key(ei)
+		vj, wj := key(ej)
+		switch {
+		case vi != vj:
+			return vi < vj
+		case wi != wj:
+			return wi < wj
+		case ei.V != ej.V:
+			return ei.V < ej.V
+		}
+		return ei.Cost < ej.Cost
+	})
+	n := g.Order()
+	index := make([]int, n)
+	var vertices []int
+	kept := edges[:0]
+	for i := 0; i < len(edges); {
+		v, w := key(edges[i])
+		j := i + 1
+		for j < len(edges) {
+			if x, y := key(edges[j]); x != v || y != w {
+				break
+			}
+			j++
+		}
+		if r.Float64() < p {
+			kept = append(kept, edges[i:j]...)
+			for _, u := range [2]int{v, w} {
+				if index[u] == 0 {
+					index[u] = 1
+					vertices = append(vertices, u)
+				}
+			}
+		}
+		i = j
+	}
+	sort.Ints(vertices)
+	for i, v := range vertices {
+		index[v] = i
+	}
+	h := graph.New(len(vertices))
+	for _, e := range kept {
+		h.AddCost(index[e.V], index[e.W], e.Cost)
+	}
+	return &Sample{h, vertices}
+}
+
+// Snowball returns the subgraph of g induced by k vertices found by
+// snowball sampling: starting at a random vertex, it repeatedly adds
+// at most fanout randomly chosen new neighbors of each vertex in the sample,
+// in breadth-first order, until there are k vertices. If the search gets
+// stuck before that, it starts again at a new random vertex.
+// If fanout ≤ 0, all neighbors are added.
+// If k is at least the order of g, all vertices are used.
+// It panics if k is negative.
+//
+// The time complexity is O(|E|⋅log|V| + |V|), where |E| is the number
+// of edges and |V| the number of vertices in g.
+func Snowball(g graph.Iterator, k, fanout int, src rand.Source) *Sample {
+	return spread(g, k, src, func(r *rand.Rand, candidates int) int {
+		if fanout <= 0 {
+			return candidates
+		}
+		return fanout
+	})
+}
+
+// ForestFire returns the subgraph of g induced by k vertices found by
+// forest-fire sampling: starting at a random vertex, the fire burns
+// a random number x of the unburned neighbors of each burning vertex,
+// where x is geometrically distributed with mean p/(1-p), and these
+// vertices then start burning in turn. If the fire dies out before
+// k vertices have burned, it starts again at a new random vertex.
+// If k is at least the order of g, all vertices are used.
+// It panics if k is negative or p isn't in the range 0 ≤ p ≤ 1.
+//
+// The time complexity is O(|E|⋅log|V| + |V|), where |E| is the number
+// of edges and |V| the number of vertices in g.
+func ForestFire(g graph.Iterator, k int, p float64, src rand.Source) *Sample {
+	if !(p >= 0 && p <= 1) {
+		panic("invalid probability: " + strconv.FormatFloat(p, 'g', -1, 64))
+	}
+	return spread(g, k, src, func(r *rand.Rand, candidates int) int {
+		x := 0
+		for x < candidates && r.Float64() < p {
+			x++
+		}
+		return x
+	})
+}
+
+// spread grows a sample from random start vertices in breadth-first order.
+// For each vertex in the sample, count returns how many of its new
+// neighbors to add; the neighbors are chosen uniformly at random.
+func spread(g graph.Iterator, k int, src rand.Source, count func(r *rand.Rand, candidates int) int) *Sample {
+	if k < 0 {
+		panic("negative sample size: " + strconv.Itoa(k))
+	}
+	n := g.Order()
+	if k > n {
+		k = n
+	}
+	r := rand.New(src)
+	starts := r.Perm(n)
+	seen := make([]bool, n)
+	vertices := make([]int, 0, k)
+	var candidates []int
+	for next, i := 0, 0; len(vertices) < k; i++ {
+		if i == len(vertices) {
+			// The search is stuck; start again at a new vertex.
+			for seen[starts[next]] {
+				next++
+			}
+			seen[starts[next]] = true
+			vertices = append(vertices, starts[next])
+		}
+		candidates = candidates[:0]
+		g.Visit(vertices[i], func(w int, _ int64) (skip bool) {
+			if !seen[w] {
+				candidates = append(candidates, w)
+			}
+			return
+		})
+		// Sort and remove duplicates, so that the choice
+		// doesn't depend on the order of Visit.
+		sort.Ints(candidates)
+		m := 0
+		for j, w := range candidates {
+			if j == 0 || w != candidates[j-1] {
+				candidates[m] = w
+				m++
+			}
+		}
+		candidates = candidates[:m]
+		x := count(r, m)
+		if x > m {
+			x = m
+		}
+		if x > k-len(vertices) {
+			x = k - len(vertices)
+		}
+		for j := 0; j < x; j++ {
+			l := j + r.Intn(m-j)
+			candidates[j], candidates[l] = candidates[l], candidates[j]
+			seen[candidates[j]] = true
+			vertices = append(vertices, candidates[j])
+		}
+	}
+	return induced(g, vertices)
+}
@@ -0,0 +1,147 @@
+package random
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// checkSample checks that s is a valid sample of g: the vertices are
+// sorted and distinct, and each edge of the sample is an edge of g.
+// If induced is true, all edges of g between sampled vertices must be present.
+func checkSample(t *testing.T, name string, g *graph.Mutable, s *Sample, induced bool) {
+	t.Helper()
+	if s.Graph.Order() != len(s.Vertices) {
+		t.Fatalf("%s: order %d, %d vertices", name, s.Graph.Order(), len(s.Vertices))
+	}
+	for i, v := range s.Vertices {
+		if i > 0 && v <= s.Vertices[i-1] {
+			t.Fatalf("%s: vertices %v not sorted", name, s.Vertices)
+		}
+		if s.Index(v) != i {
+			t.Errorf("%s: Index(%d) = %d, want %d", name, v, s.Index(v), i)
+		}
+	}
+	for i, v := range s.Vertices {
+		s.Graph.Visit(i, func(j int, c int64) (skip bool) {
+			if w := s.Vertices[j]; !g.Edge(v, w) || g.Cost(v, w) != c {
+				t.Errorf("%s: edge (%d, %d) not in graph", name, v, w)
+			}
+			return
+		})
+		if !induced {
+			continue
+		}
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			if j := s.Index(w); j != -1 && !s.Graph.Edge(i, j) {
+				t.Errorf("%s: edge (%d, %d) missing", name, v, w)
+			}
+			return
+		})
+	}
+}
+
+func TestVertexSample(t *testing.T) {
+	g := GNP(50, 0.2, rand.NewSource(1))
+	s := VertexSample(g, 20, rand.NewSource(2))
+	if mess, diff := diff(len(s.Vertices), 20); diff {
+		t.Errorf("VertexSample %s", mess)
+	}
+	checkSample(t, "VertexSample", g, s, true)
+	if mess, diff := diff(VertexSample(g, 20, rand.NewSource(2)).Vertices, s.Vertices); diff {
+		t.Errorf("VertexSample: not reproducible %s", mess)
+	}
+	if mess, diff := diff(len(VertexSample(g, 100, rand.NewSource(2)).Vertices), 50); diff {
+		t.Errorf("VertexSample(k > n) %s", mess)
+	}
+	if mess, diff := diff(VertexSample(g, 0, rand.NewSource(2)).Graph.Order(), 0); diff {
+		t.Errorf("VertexSample(k = 0) %s", mess)
+	}
+}
+
+func TestEdgeSample(t *testing.T) {
+	g := GNP(100, 0.1, rand.NewSource(1))
+	for v := 0; v < 100; v++ {
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			g.AddBothCost(v, w, int64(v+w))
+			return
+		})
+	}
+	s := EdgeSample(g, 0.3, rand.NewSource(2))
+	checkSample(t, "EdgeSample", g, s, false)
+	checkSimple := func(h *graph.Mutable) {
+		for v := 0; v < h.Order(); v++ {
+			if h.Degree(v) == 0 {
+				t.Errorf("EdgeSample: isolated vertex %d", v)
+			}
+			h.Visit(v, func(w int, _ int64) (skip bool) {
+				if !h.Edge(w, v) {
+					t.Errorf("EdgeSample: edge (%d, %d) but not (%d, %d)", v, w, w, v)
+				}
+				return
+			})
+		}
+	}
+	checkSimple(s.Graph)
+	if m, exp := size(s.Graph), 0.3*float64(size(g)); float64(m) < 0.8*exp || float64(m) > 1.2*exp {
+		t.Errorf("EdgeSample: %d edges, want about %.0f", m, exp)
+	}
+	if mess, diff := diff(EdgeSample(graph.Sort(g), 0.3, rand.NewSource(2)).Vertices, s.Vertices); diff {
+		t.Errorf("EdgeSample: depends on Visit order %s", mess)
+	}
+	if mess, diff := diff(EdgeSample(g, 1, rand.NewSource(2)).Graph.String(), g.String()); diff {
+		t.Errorf("EdgeSample(p = 1) %s", mess)
+	}
+}
+
+func TestSnowball(t *testing.T) {
+	// Two disjoint paths 0-1-…-9 and 10-…-19.
+	g := graph.New(20)
+	for v := 0; v < 19; v++ {
+		if v != 9 {
+			g.AddBoth(v, v+1)
+		}
+	}
+	s := Snowball(g, 15, 1, rand.NewSource(3))
+	if mess, diff := diff(len(s.Vertices), 15); diff {
+		t.Errorf("Snowball %s", mess)
+	}
+	checkSample(t, "Snowball", g, s, true)
+	// With no fanout limit, a whole component is added before a restart.
+	for seed := int64(0); seed < 10; seed++ {
+		s := Snowball(g, 10, 0, rand.NewSource(seed))
+		if v := s.Vertices[0]; v != 0 && v != 10 || s.Vertices[9] != v+9 {
+			t.Errorf("Snowball(fanout=0) %v; want a single path", s.Vertices)
+		}
+	}
+
+	h := GNP(100, 0.1, rand.NewSource(1))
+	s = Snowball(h, 30, 3, rand.NewSource(2))
+	checkSample(t, "Snowball", h, s, true)
+	if mess, diff := diff(Snowball(graph.Sort(h), 30, 3, rand.NewSource(2)).Vertices, s.Vertices); diff {
+		t.Errorf("Snowball: depends on Visit order %s", mess)
+	}
+}
+
+func TestForestFire(t *testing.T) {
+	g := GNP(100, 0.1, rand.NewSource(1))
+	for _, p := range []float64{0, 0.5, 1} {
+		s := ForestFire(g, 40, p, rand.NewSource(2))
+		if mess, diff := diff(len(s.Vertices), 40); diff {
+			t.Errorf("ForestFire(p=%v) %s", p, mess)
+		}
+		checkSample(t, "ForestFire", g, s, true)
+	}
+	// With p = 0 the fire never spreads, and the sample is uniform.
+	s := ForestFire(g, 40, 0, rand.NewSource(2))
+	exp := rand.New(rand.NewSource(2)).Perm(100)[:40]
+	sort.Ints(exp)
+	if mess, diff := diff(s.Vertices, exp); diff {
+		t.Errorf("ForestFire(p=0) %s", mess)
+	}
+	if mess, diff := diff(ForestFire(graph.Sort(g), 40, 0.5, rand.NewSource(3)).Vertices,
+		ForestFire(g, 40, 0.5, rand.NewSource(3)).Vertices); diff {
+		t.Errorf("ForestFire: depends on Visit order %s", mess)
+	}
+}
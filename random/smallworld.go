@@ -0,0 +1,84 @@
+package random
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"strconv"
+)
+
+// WattsStrogatz returns a Watts–Strogatz small-world graph with n vertices.
+// It starts with a ring in which each vertex is connected to its k nearest
+// neighbors, k/2 on each side, and then replaces each edge {v, w},
+// with probability beta, by an edge {v, x} to a random vertex x.
+// An edge is kept if v is already connected to all other vertices.
+// It panics if k is odd, negative or not less than n.
+//
+// The time complexity is O(nk), if beta is not close to 1.
+func WattsStrogatz(n, k int, beta float64, src rand.Source) *graph.Mutable {
+	if k < 0 || k%2 != 0 || k >= n && k > 0 {
+		panic("invalid neighbor count: " + strconv.Itoa(k))
+	}
+	r := rand.New(src)
+	g := graph.New(n)
+	for j := 1; j <= k/2; j++ {
+		for v := 0; v < n; v++ {
+			g.AddBoth(v, (v+j)%n)
+		}
+	}
+	for j := 1; j <= k/2; j++ {
+		for v := 0; v < n; v++ {
+			if r.Float64() >= beta || g.Degree(v) >= n-1 {
+				continue
+			}
+			x := r.Intn(n)
+			for x == v || g.Edge(v, x) {
+				x = r.Intn(n)
+			}
+			g.DeleteBoth(v, (v+j)%n)
+			g.AddBoth(v, x)
+		}
+	}
+	return g
+}
+
+// BarabasiAlbert returns a Barabási–Albert scale-free graph with n vertices,
+// grown by preferential attachment. It starts with m isolated vertices,
+// and each one of the vertices m, m+1,… , n-1 is then connected to m
+// distinct earlier vertices, chosen with probability proportional
+// to their degree. The first of these is connected to all of 0..m-1.
+// It panics unless 1 ≤ m < n.
+//
+// The time complexity is O(nm).
+func BarabasiAlbert(n, m int, src rand.Source) *graph.Mutable {
+	if m < 1 || m >= n {
+		panic("invalid edge count: " + strconv.Itoa(m))
+	}
+	r := rand.New(src)
+	g := graph.New(n)
+	// Each vertex occurs in repeated once for each of its edges.
+	repeated := make([]int, 0, 2*m*(n-m))
+	targets := make([]int, m)
+	for i := range targets {
+		targets[i] = i
+	}
+	chosen := make(map[int]bool, m)
+	for v := m; v < n; v++ {
+		for _, w := range targets {
+			g.AddBoth(v, w)
+			repeated = append(repeated, v, w)
+		}
+		if v == n-1 {
+			break
+		}
+		clear(chosen)
+		for i := range targets {
+			w := repeated[r.Intn(len(repeated))]
+			for chosen[w] {
+				w = repeated[r.Intn(len(repeated))]
+			}
+			chosen[w] = true
+			targets[i] = w
+		}
+	}
+	return g
+}
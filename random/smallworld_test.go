@@ -0,0 +1,90 @@
+package random
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"testing"
+)
+
+func TestWattsStrogatz(t *testing.T) {
+	n, k := 20, 4
+	g := WattsStrogatz(n, k, 0, rand.NewSource(1))
+	checkSimple(t, "WattsStrogatz", g)
+	for v := 0; v < n; v++ {
+		for j := 1; j <= k/2; j++ {
+			if !g.Edge(v, (v+j)%n) {
+				t.Errorf("WattsStrogatz(beta=0): missing edge (%d, %d)", v, (v+j)%n)
+			}
+		}
+	}
+	if mess, diff := diff(size(g), n*k/2); diff {
+		t.Errorf("WattsStrogatz(beta=0)->size %s", mess)
+	}
+
+	for _, beta := range []float64{0.1, 0.5, 1} {
+		g := WattsStrogatz(100, 6, beta, rand.NewSource(2))
+		checkSimple(t, "WattsStrogatz", g)
+		if mess, diff := diff(size(g), 300); diff {
+			t.Errorf("WattsStrogatz(beta=%v)->size %s", beta, mess)
+		}
+		if !graph.Equal(g, WattsStrogatz(100, 6, beta, rand.NewSource(2))) {
+			t.Errorf("WattsStrogatz not reproducible")
+		}
+	}
+
+	// A complete graph can't be rewired.
+	g = WattsStrogatz(5, 4, 1, rand.NewSource(3))
+	if mess, diff := diff(size(g), 10); diff {
+		t.Errorf("WattsStrogatz(5, 4)->size %s", mess)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("WattsStrogatz(10, 3) should panic")
+		}
+	}()
+	WattsStrogatz(10, 3, 0, rand.NewSource(1))
+}
+
+func TestBarabasiAlbert(t *testing.T) {
+	for _, m := range []int{1, 2, 5} {
+		n := 200
+		g := BarabasiAlbert(n, m, rand.NewSource(int64(m)))
+		checkSimple(t, "BarabasiAlbert", g)
+		if mess, diff := diff(size(g), m*(n-m)); diff {
+			t.Errorf("BarabasiAlbert(%d, %d)->size %s", n, m, mess)
+		}
+		for v := m; v < n; v++ {
+			if g.Degree(v) < m {
+				t.Errorf("BarabasiAlbert(%d, %d)->Degree(%d) %d; want ≥ %d", n, m, v, g.Degree(v), m)
+			}
+		}
+		if !graph.Connected(g) {
+			t.Errorf("BarabasiAlbert(%d, %d) not connected", n, m)
+		}
+		if !graph.Equal(g, BarabasiAlbert(n, m, rand.NewSource(int64(m)))) {
+			t.Errorf("BarabasiAlbert not reproducible")
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("BarabasiAlbert(3, 3) should panic")
+		}
+	}()
+	BarabasiAlbert(3, 3, rand.NewSource(1))
+}
+
+func BenchmarkWattsStrogatz(b *testing.B) {
+	src := rand.NewSource(1)
+	for i := 0; i < b.N; i++ {
+		_ = WattsStrogatz(1000, 10, 0.1, src)
+	}
+}
+
+func BenchmarkBarabasiAlbert(b *testing.B) {
+	src := rand.NewSource(1)
+	for i := 0; i < b.N; i++ {
+		_ = BarabasiAlbert(1000, 5, src)
+	}
+}
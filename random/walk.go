@@ -0,0 +1,123 @@
+package random
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"strconv"
+)
+
+// Walk returns a random walk in g that starts at start and takes at most
+// steps steps. In each step, the walk follows an edge out of the current
+// vertex, chosen with probability proportional to its cost; if the total
+// cost of the edges out of the vertex is zero, all edges are equally likely.
+// Negative costs count as zero. The walk ends early at a vertex without
+// outgoing edges. The returned path begins with start and holds
+// one more vertex than the number of steps taken.
+// The same source gives the same walk if the Visit method of g produces
+// the neighbors in a fixed order, as for an Immutable graph or
+// a Mutable graph in sorted mode.
+//
+// The time complexity is O(steps⋅d), where d is the largest outdegree
+// of a vertex on the walk.
+func Walk(g graph.Iterator, start, steps int, src rand.Source) []int {
+	if start < 0 || start >= g.Order() {
+		panic("vertex out of range: " + strconv.Itoa(start))
+	}
+	r := rand.New(src)
+	path := []int{start}
+	for v := start; steps > 0; steps-- {
+		if v = step(g, v, r, true); v == -1 {
+			break
+		}
+		path = append(path, v)
+	}
+	return path
+}
+
+// step returns a random neighbor of v, or -1 if v has no neighbors.
+// If weighted is true, the neighbors are chosen with probability
+// proportional to the edge costs, as in Walk; otherwise uniformly.
+func step(g graph.Iterator, v int, r *rand.Rand, weighted bool) int {
+	var total int64
+	deg := 0
+	g.Visit(v, func(_ int, c int64) (skip bool) {
+		deg++
+		if weighted && c > 0 {
+			total += c
+		}
+		return
+	})
+	if deg == 0 {
+		return -1
+	}
+	next := -1
+	if total == 0 {
+		k := r.Intn(deg)
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			if k == 0 {
+				next = w
+				return true
+			}
+			k--
+			return
+		})
+		return next
+	}
+	x := r.Int63n(total)
+	g.Visit(v, func(w int, c int64) (skip bool) {
+		if c <= 0 {
+			return
+		}
+		if x < c {
+			next = w
+			return true
+		}
+		x -= c
+		return
+	})
+	return next
+}
+
+// SpanningTree returns a spanning tree for each connected component of
+// an undirected graph, chosen uniformly at random among all spanning
+// trees of the component; the edge costs are ignored.
+// The forest is returned as a slice of parent pointers: parent[v] is
+// either the parent of v in a tree, or -1 if v is the root of a tree.
+// Each tree is rooted at the smallest vertex of its component.
+// As for Walk, the same source gives the same trees if the Visit method
+// of g produces the neighbors in a fixed order.
+//
+// The implementation uses Wilson's algorithm, which adds loop-erased
+// random walks to the tree until all vertices are covered.
+// The expected time complexity is O(τ), where τ is the mean hitting time
+// of the root, which is O(|E|⋅|V|) in the worst case; |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func SpanningTree(g graph.Iterator, src rand.Source) (parent []int) {
+	n := g.Order()
+	r := rand.New(src)
+	parent = make([]int, n)
+	inTree := make([]bool, n)
+	for _, comp := range graph.Components(g) {
+		root := comp[0]
+		for _, v := range comp {
+			if v < root {
+				root = v
+			}
+		}
+		inTree[root] = true
+		parent[root] = -1
+	}
+	// next[v] is the vertex after v on the latest walk from the start;
+	// overwriting it when the walk returns to v erases the loop.
+	next := make([]int, n)
+	for start := 0; start < n; start++ {
+		for v := start; !inTree[v]; v = next[v] {
+			next[v] = step(g, v, r, false)
+		}
+		for v := start; !inTree[v]; v = next[v] {
+			inTree[v] = true
+			parent[v] = next[v]
+		}
+	}
+	return
+}
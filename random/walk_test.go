@@ -0,0 +1,101 @@
+package random
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	g := graph.Sort(GNP(30, 0.2, rand.NewSource(1)))
+	path := Walk(g, 0, 100, rand.NewSource(2))
+	if mess, diff := diff(path, Walk(g, 0, 100, rand.NewSource(2))); diff {
+		t.Errorf("Walk: not reproducible %s", mess)
+	}
+	if path[0] != 0 || len(path) != 101 && g.Degree(0) > 0 {
+		t.Errorf("Walk: start %d, length %d", path[0], len(path))
+	}
+	for i := 1; i < len(path); i++ {
+		if !g.Edge(path[i-1], path[i]) {
+			t.Errorf("Walk: no edge (%d, %d)", path[i-1], path[i])
+		}
+	}
+
+	// 0 → 1 has cost 0, 0 → 2 has cost 1, 0 → 3 has cost 3.
+	h := graph.New(4)
+	h.AddCost(0, 1, 0)
+	h.AddCost(0, 2, 1)
+	h.AddCost(0, 3, 3)
+	h.Add(2, 0)
+	h.Add(3, 0)
+	count := make([]int, 4)
+	for _, v := range Walk(h, 0, 8000, rand.NewSource(3)) {
+		count[v]++
+	}
+	if count[1] != 0 {
+		t.Errorf("Walk: edge of cost 0 taken %d times", count[1])
+	}
+	if r := float64(count[3]) / float64(count[2]); r < 2.5 || r > 3.5 {
+		t.Errorf("Walk: ratio %.2f between costs 3 and 1; want about 3", r)
+	}
+
+	if mess, diff := diff(Walk(graph.New(1), 0, 10, rand.NewSource(1)), []int{0}); diff {
+		t.Errorf("Walk %s", mess)
+	}
+	path = Walk(h, 1, 10, rand.NewSource(1))
+	if mess, diff := diff(path, []int{1}); diff {
+		t.Errorf("Walk %s", mess)
+	}
+}
+
+func TestSpanningTree(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		g := GNP(40, 0.08, rand.NewSource(int64(i)))
+		parent := SpanningTree(g, rand.NewSource(int64(i)))
+		comps := graph.Components(g)
+		roots := 0
+		for v, p := range parent {
+			switch {
+			case p == -1:
+				roots++
+			case !g.Edge(v, p):
+				t.Errorf("SpanningTree: no edge (%d, %d)", v, p)
+			}
+		}
+		if roots != len(comps) {
+			t.Errorf("SpanningTree: %d roots; want %d", roots, len(comps))
+		}
+		// Following the parents from any vertex leads to a root.
+		for v := range parent {
+			w := v
+			for k := 0; k < len(parent) && parent[w] != -1; k++ {
+				w = parent[w]
+			}
+			if parent[w] != -1 {
+				t.Errorf("SpanningTree: cycle through %d", v)
+			}
+		}
+	}
+
+	// A 4-cycle has 4 spanning trees, which should be equally likely.
+	g := graph.New(4)
+	for v := 0; v < 4; v++ {
+		g.AddBoth(v, (v+1)%4)
+	}
+	src := rand.NewSource(1)
+	count := make(map[[4]int]int)
+	samples := 4000
+	for i := 0; i < samples; i++ {
+		var key [4]int
+		copy(key[:], SpanningTree(g, src))
+		count[key]++
+	}
+	if len(count) != 4 {
+		t.Errorf("SpanningTree: %d different trees; want 4", len(count))
+	}
+	for tree, k := range count {
+		if k < samples/4*8/10 || k > samples/4*12/10 {
+			t.Errorf("SpanningTree: tree %v chosen %d of %d times", tree, k, samples)
+		}
+	}
+}
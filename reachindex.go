@@ -0,0 +1,209 @@
+package graph
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// reachLabels is the number of random interval labelings in a ReachIndex.
+const reachLabels = 2
+
+// ReachIndex is an index that answers reachability queries in large graphs.
+// Unlike TransitiveClosure, which may fall back to a bit matrix,
+// it uses O(|V|) space, and most queries take constant time.
+//
+// The index is built on the condensation of the graph, where it stores
+// for each component:
+//
+//   - its level, the number of edges on a longest path to a sink;
+//
+//   - interval labels from randomized depth-first searches, as in GRAIL
+//     by Yildirim, Chaoji and Zaki: if d is reachable from c, the interval
+//     of d is contained in the interval of c;
+//
+//   - the sets of 64 landmark components that it reaches and is reached
+//     from, stored as bitsets and computed for all landmarks in one pass.
+//
+// A query that is not decided by these labels falls back to
+// a depth-first search in the condensation, pruned by the same labels.
+// The methods of a ReachIndex are safe for concurrent use.
+type ReachIndex struct {
+	comp  []int // comp[v] is the strongly connected component of v
+	dag   *Immutable
+	level []int
+
+	labels [reachLabels][]interval // hi is the postorder number
+	low    []int                   // low[c] is the smallest postorder number in the first DFS subtree of c
+
+	out []uint64 // out[c] is the set of landmarks reachable from c
+	in  []uint64 // in[c] is the set of landmarks that reach c
+}
+
+// BuildReachIndex returns a reachability index for g.
+//
+// The time complexity is O(|E| + |V|⋅log|V|), where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func BuildReachIndex(g Iterator) *ReachIndex {
+	dag, comp := Condensation(g)
+	m := dag.Order()
+	r := &ReachIndex{
+		comp:  comp,
+		dag:   dag,
+		level: make([]int, m),
+		out:   make([]uint64, m),
+		in:    make([]uint64, m),
+	}
+
+	// All edges go from a component to one with a smaller number.
+	for c := 0; c < m; c++ {
+		for _, e := range dag.edges[dag.offset[c]:dag.offset[c+1]] {
+			if l := r.level[e.vertex] + 1; l > r.level[c] {
+				r.level[c] = l
+			}
+		}
+	}
+
+	// Use the components with most paths through them as landmarks.
+	indegree := make([]int, m)
+	for _, e := range dag.edges {
+		indegree[e.vertex]++
+	}
+	order := make([]int, m)
+	for c := range order {
+		order[c] = c
+	}
+	score := func(c int) int { return (indegree[c] + 1) * (dag.Degree(c) + 1) }
+	sort.SliceStable(order, func(i, j int) bool { return score(order[i]) > score(order[j]) })
+	for i, c := range order {
+		if i == 64 {
+			break
+		}
+		r.out[c] = 1 << uint(i)
+		r.in[c] = 1 << uint(i)
+	}
+	for c := 0; c < m; c++ {
+		for _, e := range dag.edges[dag.offset[c]:dag.offset[c+1]] {
+			r.out[c] |= r.out[e.vertex]
+		}
+	}
+	for c := m - 1; c >= 0; c-- {
+		for _, e := range dag.edges[dag.offset[c]:dag.offset[c+1]] {
+			r.in[e.vertex] |= r.in[c]
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := range r.labels {
+		r.labels[i] = make([]interval, m)
+		if i == 0 {
+			r.low = make([]int, m)
+		}
+		r.label(i, rnd)
+	}
+	return r
+}
+
+// label computes the i:th interval labeling with a depth-first search
+// that visits the roots and the successors of each vertex in random order.
+func (r *ReachIndex) label(i int, rnd *rand.Rand) {
+	dag, m := r.dag, r.dag.Order()
+	label := r.labels[i]
+	visited := make([]bool, m)
+	type frame struct {
+		c, start, next int // visit the successors from start, cyclically
+	}
+	var stack []frame
+	post := 0
+	push := func(c int) {
+		visited[c] = true
+		if i == 0 {
+			// The subtree of c is numbered from here up to c.
+			r.low[c] = post
+		}
+		stack = append(stack, frame{c, rnd.Intn(dag.Degree(c) + 1), 0})
+	}
+	for _, root := range rnd.Perm(m) {
+		if visited[root] {
+			continue
+		}
+		push(root)
+		for len(stack) > 0 {
+			f := &stack[len(stack)-1]
+			if deg := dag.Degree(f.c); f.next < deg {
+				d := dag.edges[dag.offset[f.c]+(f.start+f.next)%deg].vertex
+				f.next++
+				if !visited[d] {
+					push(d)
+				}
+				continue
+			}
+			c := f.c
+			stack = stack[:len(stack)-1]
+			// The lower bound includes all successors, not only the children.
+			lo := post
+			for _, e := range dag.edges[dag.offset[c]:dag.offset[c+1]] {
+				if l := label[e.vertex].lo; l < lo {
+					lo = l
+				}
+			}
+			label[c] = interval{lo, post}
+			post++
+		}
+	}
+}
+
+// Reachable tells if there is a path from v to w.
+// A vertex is always reachable from itself.
+func (r *ReachIndex) Reachable(v, w int) bool {
+	c, d := r.comp[v], r.comp[w]
+	if c == d {
+		return true
+	}
+	if !r.mayReach(c, d) {
+		return false
+	}
+	if r.out[c]&r.in[d] != 0 || r.inSubtree(c, d) {
+		return true
+	}
+
+	// Search the successors of c that may reach d.
+	visited := map[int]bool{c: true}
+	stack := []int{c}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, x := range r.dag.edges[r.dag.offset[e]:r.dag.offset[e+1]] {
+			f := x.vertex
+			if f == d || r.inSubtree(f, d) {
+				return true
+			}
+			if !visited[f] && r.mayReach(f, d) {
+				visited[f] = true
+				stack = append(stack, f)
+			}
+		}
+	}
+	return false
+}
+
+// mayReach returns false if the labels show that d isn't reachable from c.
+func (r *ReachIndex) mayReach(c, d int) bool {
+	if c < d || r.level[c] <= r.level[d] {
+		return false
+	}
+	if r.out[d]&^r.out[c] != 0 || r.in[c]&^r.in[d] != 0 {
+		return false
+	}
+	for _, label := range r.labels {
+		if a, b := label[c], label[d]; b.lo < a.lo || b.hi > a.hi {
+			return false
+		}
+	}
+	return true
+}
+
+// inSubtree tells if d belongs to the subtree of c in the first DFS.
+func (r *ReachIndex) inSubtree(c, d int) bool {
+	p := r.labels[0][d].hi
+	return r.low[c] <= p && p <= r.labels[0][c].hi
+}
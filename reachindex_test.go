@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestReachIndex(t *testing.T) {
+	g := New(6)
+	g.Add(0, 1) // 0 -> 1 <-> 2 -> 3
+	g.Add(1, 2) //           |
+	g.Add(2, 1) //           v
+	g.Add(2, 3) //      4 -> 5
+	g.Add(2, 5)
+	g.Add(4, 5)
+	r := BuildReachIndex(g)
+	exp := TransitiveClosure(g)
+	for v := 0; v < 6; v++ {
+		for w := 0; w < 6; w++ {
+			if r.Reachable(v, w) != exp.Reachable(v, w) {
+				t.Errorf("Reachable(%d, %d) %t; want %t", v, w, !exp.Reachable(v, w), exp.Reachable(v, w))
+			}
+		}
+	}
+
+	BuildReachIndex(New(0))
+	for n := 1; n < 120; n += 1 + n/8 {
+		for _, m := range []int{n / 2, n, 2 * n, 5 * n} {
+			g := New(n)
+			for i := 0; i < m; i++ {
+				v, w := rand.Intn(n), rand.Intn(n)
+				if rand.Intn(4) > 0 && v > w {
+					v, w = w, v // mostly acyclic
+				}
+				g.Add(v, w)
+			}
+			r := BuildReachIndex(g)
+			exp := TransitiveClosure(g)
+			for v := 0; v < n; v++ {
+				for w := 0; w < n; w++ {
+					if r.Reachable(v, w) != exp.Reachable(v, w) {
+						t.Fatalf("Reachable(%d, %d) %t in %v", v, w, !exp.Reachable(v, w), g)
+					}
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkReachIndex(b *testing.B) {
+	b.StopTimer()
+	n := 100000
+	g := New(n)
+	for i := 0; i < 3*n; i++ {
+		v, w := rand.Intn(n), rand.Intn(n)
+		if v > w {
+			v, w = w, v
+		}
+		g.Add(v, w)
+	}
+	r := BuildReachIndex(g)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = r.Reachable(rand.Intn(n), rand.Intn(n))
+	}
+}
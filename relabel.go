@@ -0,0 +1,172 @@
+package graph
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Relabel returns an immutable copy of g where each vertex v
+// is renumbered to perm[v]. The slice must be a permutation of
+// the numbers 0 to n-1, where n is the order of g.
+// Any duplicate edges in g are kept.
+//
+// Renumbering a graph with one of the orders computed by BFSOrdering,
+// DegreeOrdering, DegeneracyOrdering or RCMOrdering, converted to
+// a permutation by Permutation, places vertices that are used together close to each other in memory,
+// which can make algorithms on large graphs considerably faster.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func Relabel(g Iterator, perm []int) *Immutable {
+	n := g.Order()
+	if len(perm) != n {
+		panic("slice length " + strconv.Itoa(len(perm)) + " doesn't match graph order " + strconv.Itoa(n))
+	}
+	seen := make([]bool, n)
+	for _, p := range perm {
+		if p < 0 || p >= n || seen[p] {
+			panic("not a permutation: " + strconv.Itoa(p))
+		}
+		seen[p] = true
+	}
+	edges := make([]Edge, 0, Size(g))
+	VisitAll(g, func(v, w int, c int64) (skip bool) {
+		edges = append(edges, Edge{perm[v], perm[w], c})
+		return
+	})
+	return FromEdges(n, edges)
+}
+
+// Permutation returns the permutation that renumbers the vertices
+// so that order[i] gets the number i, as expected by Relabel.
+// It is the inverse of order, and applying it twice gives back order.
+func Permutation(order []int) []int {
+	perm := make([]int, len(order))
+	for i, v := range order {
+		perm[v] = i
+	}
+	return perm
+}
+
+// Bandwidth returns the largest difference |v - w| between the endpoints
+// of an edge in g, or 0 if g has no edges.
+func Bandwidth(g Iterator) int {
+	b := 0
+	VisitAll(g, func(v, w int, _ int64) (skip bool) {
+		if d := v - w; d > b {
+			b = d
+		} else if -d > b {
+			b = -d
+		}
+		return
+	})
+	return b
+}
+
+// BFSOrdering returns the vertices of g in breadth-first order,
+// starting from each vertex that hasn't been reached yet,
+// in increasing numerical order.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func BFSOrdering(g Iterator) []int {
+	n := g.Order()
+	visited := make([]bool, n)
+	order := make([]int, 0, n)
+	for s := 0; s < n; s++ {
+		if visited[s] {
+			continue
+		}
+		visited[s] = true
+		order = append(order, s)
+		for i := len(order) - 1; i < len(order); i++ {
+			g.Visit(order[i], func(w int, _ int64) (skip bool) {
+				if !visited[w] {
+					visited[w] = true
+					order = append(order, w)
+				}
+				return
+			})
+		}
+	}
+	return order
+}
+
+// DegreeOrdering returns the vertices of g in order of decreasing
+// out-degree, with ties broken by vertex number.
+//
+// The time complexity is O(|E| + |V|⋅log|V|), where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func DegreeOrdering(g Iterator) []int {
+	_, out := Degrees(g)
+	order := make([]int, len(out))
+	for v := range order {
+		order[v] = v
+	}
+	sort.SliceStable(order, func(i, j int) bool { return out[order[i]] > out[order[j]] })
+	return order
+}
+
+// DegeneracyOrdering returns the vertices of g in an order where each vertex
+// has as few neighbors as possible later in the order, found by repeatedly
+// removing a vertex of smallest degree. The graph is treated as undirected.
+// The largest number of later neighbors of a vertex is the degeneracy of g.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func DegeneracyOrdering(g Iterator) []int {
+	order, _ := degeneracyOrder(undirected(g))
+	return order
+}
+
+// RCMOrdering returns the vertices of g in reverse Cuthill–McKee order,
+// which tends to give a small bandwidth: neighbors get numbers that
+// are close to each other. The graph is treated as undirected.
+//
+// Each connected component is traversed in breadth-first order,
+// starting at a vertex of smallest degree and visiting the neighbors
+// of each vertex in order of increasing degree; the resulting order
+// is then reversed.
+//
+// The time complexity is O(|E|⋅log|V| + |V|), where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func RCMOrdering(g Iterator) []int {
+	adj := undirected(g)
+	n := len(adj)
+	byDegree := make([]int, n)
+	for v := range byDegree {
+		byDegree[v] = v
+	}
+	less := func(v, w int) bool {
+		if len(adj[v]) != len(adj[w]) {
+			return len(adj[v]) < len(adj[w])
+		}
+		return v < w
+	}
+	sort.Slice(byDegree, func(i, j int) bool { return less(byDegree[i], byDegree[j]) })
+
+	visited := make([]bool, n)
+	order := make([]int, 0, n)
+	for _, s := range byDegree {
+		if visited[s] {
+			continue
+		}
+		visited[s] = true
+		order = append(order, s)
+		for i := len(order) - 1; i < len(order); i++ {
+			start := len(order)
+			for _, w := range adj[order[i]] {
+				if !visited[w] {
+					visited[w] = true
+					order = append(order, w)
+				}
+			}
+			next := order[start:]
+			sort.Slice(next, func(i, j int) bool { return less(next[i], next[j]) })
+		}
+	}
+	for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order
+}
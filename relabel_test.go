@@ -0,0 +1,110 @@
+package graph
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestRelabel(t *testing.T) {
+	g := New(4)
+	g.AddCost(0, 1, 5)
+	g.Add(1, 2)
+	g.AddBoth(2, 3)
+	h := Relabel(g, []int{3, 2, 1, 0})
+	if mess, diff := diff(h.String(), "4 [{0 1} (2 1) (3 2):5]"); diff {
+		t.Errorf("Relabel %s", mess)
+	}
+	if mess, diff := diff(Permutation([]int{2, 0, 1}), []int{1, 2, 0}); diff {
+		t.Errorf("Permutation %s", mess)
+	}
+	if mess, diff := diff(Bandwidth(g), 1); diff {
+		t.Errorf("Bandwidth %s", mess)
+	}
+	if mess, diff := diff(Bandwidth(New(3)), 0); diff {
+		t.Errorf("Bandwidth %s", mess)
+	}
+
+	for _, perm := range [][]int{{0, 1, 2}, {0, 1, 2, 2}, {0, 1, 4, 2}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Relabel(%v): no panic", perm)
+				}
+			}()
+			Relabel(g, perm)
+		}()
+	}
+}
+
+func TestOrderings(t *testing.T) {
+	// 0 → 1, 0 → 2, 1 → 3, 4 isolated, 5 → 0
+	g := New(6)
+	g.Add(0, 2)
+	g.Add(0, 1)
+	g.Add(1, 3)
+	g.Add(5, 0)
+	g.SetSorted(true)
+	if mess, diff := diff(BFSOrdering(g), []int{0, 1, 2, 3, 4, 5}); diff {
+		t.Errorf("BFSOrdering %s", mess)
+	}
+	if mess, diff := diff(DegreeOrdering(g), []int{0, 1, 5, 2, 3, 4}); diff {
+		t.Errorf("DegreeOrdering %s", mess)
+	}
+
+	isPerm := func(name string, order []int, n int) {
+		s := append([]int{}, order...)
+		sort.Ints(s)
+		for i := range s {
+			if s[i] != i || len(s) != n {
+				t.Errorf("%s %v: not a permutation of 0..%d", name, order, n-1)
+				return
+			}
+		}
+	}
+	n := 100
+	r := New(n)
+	for m := 3 * n; m > 0; m-- {
+		r.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	isPerm("BFSOrdering", BFSOrdering(r), n)
+	isPerm("DegreeOrdering", DegreeOrdering(r), n)
+	isPerm("DegeneracyOrdering", DegeneracyOrdering(r), n)
+	isPerm("RCMOrdering", RCMOrdering(r), n)
+
+	// A grid with shuffled vertex numbers has a large bandwidth,
+	// which RCM brings down to about the width of the grid.
+	w := 10
+	grid := New(w * w)
+	shuffle := rand.Perm(w * w)
+	for i := 0; i < w; i++ {
+		for j := 0; j < w; j++ {
+			if i+1 < w {
+				grid.AddBoth(shuffle[i*w+j], shuffle[(i+1)*w+j])
+			}
+			if j+1 < w {
+				grid.AddBoth(shuffle[i*w+j], shuffle[i*w+j+1])
+			}
+		}
+	}
+	h := Relabel(grid, Permutation(RCMOrdering(grid)))
+	if b := Bandwidth(h); b > 2*w {
+		t.Errorf("RCMOrdering: bandwidth %d; want at most %d", b, 2*w)
+	}
+	if mess, diff := diff(Size(h), Size(grid)); diff {
+		t.Errorf("Relabel->Size %s", mess)
+	}
+}
+
+func BenchmarkRCMOrdering(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddBoth(rand.Intn(n), rand.Intn(n))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = RCMOrdering(g)
+	}
+}
@@ -0,0 +1,131 @@
+package graph
+
+import "iter"
+
+// Neighbors returns an iterator over the neighbors w of v in g,
+// together with the cost c of the edge (v, w). The neighbors are
+// produced in the same order as by the Visit method of g.
+func Neighbors(g Iterator, v int) iter.Seq2[int, int64] {
+	return func(yield func(w int, c int64) bool) {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			return !yield(w, c)
+		})
+	}
+}
+
+// Edges returns an iterator over all edges of g, ordered by their
+// first vertex. An undirected edge is produced twice,
+// once in each direction.
+func Edges(g Iterator) iter.Seq[Edge] {
+	return func(yield func(Edge) bool) {
+		for v := 0; v < g.Order(); v++ {
+			if g.Visit(v, func(w int, c int64) (skip bool) {
+				return !yield(Edge{v, w, c})
+			}) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors returns an iterator over the neighbors w of v,
+// together with the cost c of the edge (v, w).
+func (g *Mutable) Neighbors(v int) iter.Seq2[int, int64] {
+	return Neighbors(g, v)
+}
+
+// Edges returns an iterator over all edges of g, ordered by their first vertex.
+func (g *Mutable) Edges() iter.Seq[Edge] {
+	return Edges(g)
+}
+
+// Neighbors returns an iterator over the neighbors w of v in increasing
+// numerical order, together with the cost c of the edge (v, w).
+func (g *Immutable) Neighbors(v int) iter.Seq2[int, int64] {
+	return func(yield func(w int, c int64) bool) {
+		for _, e := range g.edges[g.offset[v]:g.offset[v+1]] {
+			if !yield(e.vertex, e.cost) {
+				return
+			}
+		}
+	}
+}
+
+// Edges returns an iterator over all edges of g, ordered by their
+// first vertex and then by their second vertex.
+func (g *Immutable) Edges() iter.Seq[Edge] {
+	return func(yield func(Edge) bool) {
+		for v := 0; v < g.Order(); v++ {
+			for _, e := range g.edges[g.offset[v]:g.offset[v+1]] {
+				if !yield(Edge{v, e.vertex, e.cost}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// BFSOrder returns an iterator over the vertices reachable from v
+// in breadth-first order, starting with v.
+func BFSOrder(g Iterator, v int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		BFSLayers(g, v, func(layer []int) (skip bool) {
+			for _, w := range layer {
+				if !yield(w) {
+					return true
+				}
+			}
+			return
+		})
+	}
+}
+
+// DFSOrder returns an iterator over the vertices reachable from v
+// in depth-first preorder, starting with v.
+func DFSOrder(g Iterator, v int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		var d *dfs
+		d = newDFS(g, DFSEvents{
+			Discover: func(w int) {
+				d.stop = !yield(w)
+			},
+		})
+		d.search(v)
+	}
+}
+
+// TopOrder returns an iterator over the vertices of g in topological order.
+// The vertices are produced in the same order as by TopSort.
+// If g has a cycle, the sequence ends early, leaving out the vertices
+// that are on a cycle or can be reached from one; use Acyclic or TopSort
+// to tell if the order is complete.
+func TopOrder(g Iterator) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		indegree := make([]int, g.Order())
+		for v := range indegree {
+			g.Visit(v, func(w int, _ int64) (skip bool) {
+				indegree[w]++
+				return
+			})
+		}
+		var queue []int
+		for v, degree := range indegree {
+			if degree == 0 {
+				queue = append(queue, v)
+			}
+		}
+		for i := 0; i < len(queue); i++ {
+			v := queue[i]
+			if !yield(v) {
+				return
+			}
+			g.Visit(v, func(w int, _ int64) (skip bool) {
+				indegree[w]--
+				if indegree[w] == 0 {
+					queue = append(queue, w)
+				}
+				return
+			})
+		}
+	}
+}
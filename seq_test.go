@@ -0,0 +1,137 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNeighbors(t *testing.T) {
+	g := New(4)
+	g.AddCost(0, 1, 5)
+	g.AddCost(0, 2, 6)
+	g.AddCost(0, 3, 7)
+	h := Sort(g)
+	var res []int64
+	for w, c := range h.Neighbors(0) {
+		res = append(res, int64(w), c)
+	}
+	if mess, diff := diff(res, []int64{1, 5, 2, 6, 3, 7}); diff {
+		t.Errorf("Neighbors %s", mess)
+	}
+
+	res = nil
+	for w, c := range Neighbors(h, 0) {
+		if w == 3 {
+			break
+		}
+		res = append(res, int64(w), c)
+	}
+	if mess, diff := diff(res, []int64{1, 5, 2, 6}); diff {
+		t.Errorf("Neighbors %s", mess)
+	}
+
+	count := 0
+	for range g.Neighbors(0) {
+		count++
+	}
+	if mess, diff := diff(count, 3); diff {
+		t.Errorf("Neighbors %s", mess)
+	}
+}
+
+func TestEdges(t *testing.T) {
+	g := New(4)
+	g.AddCost(0, 1, 5)
+	g.AddBothCost(2, 3, 6)
+	h := Sort(g)
+	var res []Edge
+	for e := range h.Edges() {
+		res = append(res, e)
+	}
+	exp := []Edge{{0, 1, 5}, {2, 3, 6}, {3, 2, 6}}
+	if mess, diff := diff(res, exp); diff {
+		t.Errorf("Edges %s", mess)
+	}
+
+	res = nil
+	for e := range Edges(h) {
+		if e.V == 3 {
+			break
+		}
+		res = append(res, e)
+	}
+	if mess, diff := diff(res, exp[:2]); diff {
+		t.Errorf("Edges %s", mess)
+	}
+
+	n := 20
+	g = New(n)
+	for i := 0; i < 3*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(10))
+	}
+	res = nil
+	for e := range g.Edges() {
+		if g.Cost(e.V, e.W) != e.Cost {
+			t.Errorf("Edges: %v has cost %d", e, g.Cost(e.V, e.W))
+		}
+		res = append(res, e)
+	}
+	if mess, diff := diff(len(res), Sort(g).stats.Size); diff {
+		t.Errorf("Edges %s", mess)
+	}
+}
+
+func TestOrders(t *testing.T) {
+	g := New(6)
+	g.Add(0, 1)
+	g.Add(0, 2)
+	g.Add(1, 3)
+	g.Add(2, 4)
+	g.Add(3, 2)
+	h := Sort(g)
+
+	var res []int
+	for v := range BFSOrder(h, 0) {
+		res = append(res, v)
+	}
+	if mess, diff := diff(res, []int{0, 1, 2, 3, 4}); diff {
+		t.Errorf("BFSOrder %s", mess)
+	}
+
+	res = nil
+	for v := range DFSOrder(h, 0) {
+		res = append(res, v)
+	}
+	if mess, diff := diff(res, []int{0, 1, 3, 2, 4}); diff {
+		t.Errorf("DFSOrder %s", mess)
+	}
+
+	res = nil
+	for v := range DFSOrder(h, 0) {
+		if v == 2 {
+			break
+		}
+		res = append(res, v)
+	}
+	if mess, diff := diff(res, []int{0, 1, 3}); diff {
+		t.Errorf("DFSOrder %s", mess)
+	}
+
+	res = nil
+	for v := range TopOrder(h) {
+		res = append(res, v)
+	}
+	exp, _ := TopSort(h)
+	if mess, diff := diff(res, exp); diff {
+		t.Errorf("TopOrder %s", mess)
+	}
+
+	g.Add(4, 3)
+	res = nil
+	for v := range TopOrder(g) {
+		res = append(res, v)
+	}
+	if mess, diff := diff(res, []int{0, 5, 1}); diff {
+		t.Errorf("TopOrder %s", mess)
+	}
+}
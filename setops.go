@@ -0,0 +1,73 @@
+package graph
+
+import "strconv"
+
+// Union returns a graph with the edges that are in g or in h.
+// For edges that are in both graphs, the cost is combine(c1, c2),
+// where c1 is the cost in g and c2 the cost in h.
+// If combine is nil, the cost in g is used.
+// The graphs must have the same order.
+// If g or h is a multigraph, any duplicate edges will be lost.
+func Union(g, h Iterator, combine func(c1, c2 int64) int64) *Mutable {
+	checkOrder(g, h)
+	res := Copy(g)
+	for v := 0; v < res.Order(); v++ {
+		h.Visit(v, func(w int, c int64) (skip bool) {
+			if c1, ok := res.edges[v][w]; ok {
+				if combine != nil {
+					res.edges[v][w] = combine(c1, c)
+				}
+				return
+			}
+			res.AddCost(v, w, c)
+			return
+		})
+	}
+	return res
+}
+
+// Intersection returns a graph with the edges that are in both g and h.
+// The cost of an edge is combine(c1, c2), where c1 is the cost in g
+// and c2 the cost in h. If combine is nil, the cost in g is used.
+// The graphs must have the same order.
+// If g or h is a multigraph, any duplicate edges will be lost.
+func Intersection(g, h Iterator, combine func(c1, c2 int64) int64) *Mutable {
+	checkOrder(g, h)
+	in := Copy(h)
+	res := New(g.Order())
+	for v := 0; v < res.Order(); v++ {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			c2, ok := in.edges[v][w]
+			if !ok {
+				return
+			}
+			if combine != nil {
+				c = combine(c, c2)
+			}
+			res.AddCost(v, w, c)
+			return
+		})
+	}
+	return res
+}
+
+// Difference returns a graph with the edges of g that aren't in h,
+// with their costs in g. The graphs must have the same order.
+// If g is a multigraph, any duplicate edges will be lost.
+func Difference(g, h Iterator) *Mutable {
+	checkOrder(g, h)
+	res := Copy(g)
+	for v := 0; v < res.Order(); v++ {
+		h.Visit(v, func(w int, _ int64) (skip bool) {
+			res.Delete(v, w)
+			return
+		})
+	}
+	return res
+}
+
+func checkOrder(g, h Iterator) {
+	if m, n := g.Order(), h.Order(); m != n {
+		panic("order mismatch: " + strconv.Itoa(m) + " != " + strconv.Itoa(n))
+	}
+}
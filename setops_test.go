@@ -0,0 +1,64 @@
+package graph
+
+import "testing"
+
+func setupSetOps() (g, h *Mutable) {
+	g = New(4)
+	g.AddCost(0, 1, 1)
+	g.AddCost(1, 2, 2)
+	g.AddCost(2, 3, 3)
+	h = New(4)
+	h.AddCost(1, 2, 20)
+	h.AddCost(2, 3, 30)
+	h.AddCost(3, 0, 40)
+	return
+}
+
+func TestUnion(t *testing.T) {
+	g, h := setupSetOps()
+	res := Union(g, h, nil)
+	if mess, diff := diff(res.String(), "4 [(0 1):1 (1 2):2 (2 3):3 (3 0):40]"); diff {
+		t.Errorf("Union %s", mess)
+	}
+	res = Union(g, h, func(c1, c2 int64) int64 { return c1 + c2 })
+	if mess, diff := diff(res.String(), "4 [(0 1):1 (1 2):22 (2 3):33 (3 0):40]"); diff {
+		t.Errorf("Union %s", mess)
+	}
+	Consistent("Union", t, res)
+	if mess, diff := diff(g.String(), "4 [(0 1):1 (1 2):2 (2 3):3]"); diff {
+		t.Errorf("Union: g modified %s", mess)
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	g, h := setupSetOps()
+	res := Intersection(g, h, nil)
+	if mess, diff := diff(res.String(), "4 [(1 2):2 (2 3):3]"); diff {
+		t.Errorf("Intersection %s", mess)
+	}
+	res = Intersection(g, h, func(c1, c2 int64) int64 { return c2 - c1 })
+	if mess, diff := diff(res.String(), "4 [(1 2):18 (2 3):27]"); diff {
+		t.Errorf("Intersection %s", mess)
+	}
+	Consistent("Intersection", t, res)
+}
+
+func TestDifference(t *testing.T) {
+	g, h := setupSetOps()
+	res := Difference(g, h)
+	if mess, diff := diff(res.String(), "4 [(0 1):1]"); diff {
+		t.Errorf("Difference %s", mess)
+	}
+	res = Difference(h, g)
+	if mess, diff := diff(res.String(), "4 [(3 0):40]"); diff {
+		t.Errorf("Difference %s", mess)
+	}
+	Consistent("Difference", t, res)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Difference: no panic for order mismatch")
+		}
+	}()
+	Difference(g, New(3))
+}
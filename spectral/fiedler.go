@@ -0,0 +1,275 @@
+package spectral
+
+import (
+	"github.com/yourbasic/graph"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// The Lanczos basis holds at most krylov vectors,
+// and a restart keeps the kept best Ritz vectors.
+const (
+	krylov = 64
+	kept   = 16
+)
+
+// Fiedler computes the Fiedler vector of g: the unit eigenvector x that
+// belongs to the second smallest eigenvalue λ of the Laplacian matrix of g,
+// or of the normalized Laplacian if opts.Normalized is set. The smallest
+// eigenvalue is 0, with the eigenvector of all ones, or D^(1/2) times
+// that vector for the normalized Laplacian. If g is connected, λ > 0
+// is its algebraic connectivity, and the signs of the entries of x
+// split the graph into two parts with few edges between them.
+// If the iteration doesn't converge within opts.MaxIter iterations,
+// ok is set to false. If opts is nil, the default options are used.
+//
+// The implementation uses the thick-restart Lanczos method with full
+// reorthogonalization: the basis grows to 64 vectors, and is then
+// restarted with the 16 Ritz vectors of the smallest Ritz values.
+// Each iteration takes O(|E| + k⋅|V|) time, where |E| is the number
+// of edges and |V| the number of vertices in the graph, and k ≤ 64
+// is the size of the basis.
+func Fiedler(g graph.Iterator, opts *Options) (x []float64, lambda float64, ok bool) {
+	if opts == nil {
+		opts = new(Options)
+	}
+	m, deg := laplacian(g, opts, opts.Normalized)
+	n := m.Order()
+	if n < 2 {
+		return make([]float64, n), 0, true
+	}
+	// The eigenvector u of the smallest eigenvalue, and the largest
+	// diagonal entry, which bounds the eigenvalues.
+	u := make([]float64, n)
+	scale := 1.0
+	for v := range u {
+		u[v] = 1
+		if opts.Normalized {
+			u[v] = math.Sqrt(deg[v])
+		}
+		scale = math.Max(scale, m.At(v, v))
+	}
+	normalize(u)
+	tol, maxIter := opts.Tol, opts.MaxIter
+	if tol <= 0 {
+		tol = 1e-9
+	}
+	if maxIter <= 0 {
+		maxIter = 1000
+	}
+	size := min(krylov, n-1)
+
+	// The basis q is orthonormal and orthogonal to u, and mq[i] = M⋅q[i].
+	var q, mq [][]float64
+	iter := 0
+	add := func(w []float64) bool {
+		for pass := 0; pass < 2; pass++ {
+			orthogonalize(w, [][]float64{u})
+			orthogonalize(w, q)
+		}
+		if normalize(w) < 1e-10 {
+			return false
+		}
+		mw := make([]float64, n)
+		m.Mul(w, mw)
+		q, mq = append(q, w), append(mq, mw)
+		iter++
+		return true
+	}
+	r := rand.New(rand.NewSource(opts.Seed))
+	start := make([]float64, n)
+	for v := range start {
+		start[v] = r.Float64() - 0.5
+	}
+	added := add(start)
+	for {
+		// Extend the Krylov subspace.
+		for len(q) < size && iter < maxIter {
+			if !add(append([]float64(nil), mq[len(mq)-1]...)) {
+				break
+			}
+			added = true
+		}
+
+		// Find the Ritz vectors of the smallest Ritz values.
+		k := len(q)
+		h := make([][]float64, k)
+		for i := range h {
+			h[i] = make([]float64, k)
+			for j := 0; j <= i; j++ {
+				h[i][j] = (dot(q[i], mq[j]) + dot(q[j], mq[i])) / 2
+				h[j][i] = h[i][j]
+			}
+		}
+		vals, vecs := jacobi(h)
+		order := make([]int, k)
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(i, j int) bool { return vals[order[i]] < vals[order[j]] })
+		ritz := func(i int) (y, my []float64) {
+			y, my = make([]float64, n), make([]float64, n)
+			for j := range q {
+				a := vecs[j][i]
+				for v := range y {
+					y[v] += a * q[j][v]
+					my[v] += a * mq[j][v]
+				}
+			}
+			return
+		}
+		x, mx := ritz(order[0])
+		lambda = vals[order[0]]
+		res := make([]float64, n)
+		for v := range res {
+			res[v] = mx[v] - lambda*x[v]
+		}
+		switch {
+		case math.Sqrt(dot(res, res)) <= tol*scale:
+			return x, lambda, true
+		case iter >= maxIter || !added:
+			return x, lambda, false
+		}
+
+		// Restart with the best Ritz vectors; their residuals are all
+		// parallel to res, which continues the Krylov subspace.
+		p := min(kept, k-1)
+		nq, nmq := [][]float64{x}, [][]float64{mx}
+		for _, i := range order[1:p] {
+			y, my := ritz(i)
+			nq, nmq = append(nq, y), append(nmq, my)
+		}
+		q, mq = nq, nmq
+		added = add(res)
+	}
+}
+
+// jacobi computes the eigenvalues and eigenvectors of the symmetric
+// matrix a, which is overwritten, with the cyclic Jacobi method.
+// The eigenvector of vals[i] is the column i of vecs.
+func jacobi(a [][]float64) (vals []float64, vecs [][]float64) {
+	n := len(a)
+	vecs = make([][]float64, n)
+	for i := range vecs {
+		vecs[i] = make([]float64, n)
+		vecs[i][i] = 1
+	}
+	for sweep := 0; sweep < 100; sweep++ {
+		off, total := 0.0, 0.0
+		for i := range a {
+			for j := range a {
+				if i != j {
+					off += a[i][j] * a[i][j]
+				}
+				total += a[i][j] * a[i][j]
+			}
+		}
+		if off <= 1e-30*total {
+			break
+		}
+		for p := 0; p < n; p++ {
+			for r := p + 1; r < n; r++ {
+				if a[p][r] == 0 {
+					continue
+				}
+				theta := (a[r][r] - a[p][p]) / (2 * a[p][r])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				for k := range a {
+					akp, akr := a[k][p], a[k][r]
+					a[k][p], a[k][r] = c*akp-s*akr, s*akp+c*akr
+				}
+				for k := range a {
+					apk, ark := a[p][k], a[r][k]
+					a[p][k], a[r][k] = c*apk-s*ark, s*apk+c*ark
+				}
+				for k := range vecs {
+					vkp, vkr := vecs[k][p], vecs[k][r]
+					vecs[k][p], vecs[k][r] = c*vkp-s*vkr, s*vkp+c*vkr
+				}
+			}
+		}
+	}
+	vals = make([]float64, n)
+	for i := range vals {
+		vals[i] = a[i][i]
+	}
+	return
+}
+
+// orthogonalize subtracts the projections of w onto the unit vectors in q.
+func orthogonalize(w []float64, q [][]float64) {
+	for _, v := range q {
+		a := dot(v, w)
+		for i := range w {
+			w[i] -= a * v[i]
+		}
+	}
+}
+
+// normalize scales x to unit length, unless it's zero,
+// and returns its original length.
+func normalize(x []float64) float64 {
+	l := math.Sqrt(dot(x, x))
+	if l == 0 {
+		return 0
+	}
+	for i := range x {
+		x[i] /= l
+	}
+	return l
+}
+
+func dot(x, y []float64) float64 {
+	sum := 0.0
+	for i := range x {
+		sum += x[i] * y[i]
+	}
+	return sum
+}
+
+// Bisection splits the vertices of g into two halves with few edges
+// between them, using the Fiedler vector x computed as in Fiedler:
+// part[v] is 0 for the ⌊n/2⌋ vertices with the smallest values of x[v],
+// with ties broken by vertex number, and 1 for the other vertices.
+// The cut is the total weight of the edges between the two halves,
+// counted in the direction from part 0 to part 1.
+// The result ok is the same as for Fiedler.
+//
+// The time complexity is that of Fiedler plus O(|V|⋅log|V|),
+// where |V| is the number of vertices in the graph.
+func Bisection(g graph.Iterator, opts *Options) (part []int, cut int64, ok bool) {
+	x, _, ok := Fiedler(g, opts)
+	n := len(x)
+	order := make([]int, n)
+	for v := range order {
+		order[v] = v
+	}
+	sort.SliceStable(order, func(i, j int) bool { return x[order[i]] < x[order[j]] })
+	part = make([]int, n)
+	for _, v := range order[n/2:] {
+		part[v] = 1
+	}
+	weighted := opts != nil && opts.Weighted
+	for v := 0; v < n; v++ {
+		if part[v] != 0 {
+			continue
+		}
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if part[w] == 1 {
+				if weighted {
+					cut += c
+				} else {
+					cut++
+				}
+			}
+			return
+		})
+	}
+	return part, cut, ok
+}
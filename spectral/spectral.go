@@ -0,0 +1,212 @@
+// Package spectral implements spectral graph theory: matrices derived
+// from a graph, and algorithms based on their eigenvectors.
+//
+// Adjacency, Laplacian and NormalizedLaplacian return sparse matrices
+// that can be used with other numerical software. Fiedler computes
+// the eigenvector of the second smallest eigenvalue of the Laplacian
+// with the Lanczos method, and Bisection uses it to split the graph
+// into two halves with few edges between them.
+//
+// The graphs are undirected: an undirected edge {v, w} is represented by
+// the two directed edges (v, w) and (w, v), as in the graph package.
+package spectral
+
+import (
+	"github.com/yourbasic/graph"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Options configure the matrices and the eigenvector computations.
+// A nil *Options is the same as the zero value.
+type Options struct {
+	// Weighted tells if the cost of an edge is its weight;
+	// otherwise each edge has weight 1. The costs must be non-negative.
+	Weighted bool
+
+	// Normalized tells Fiedler and Bisection to use the normalized
+	// Laplacian instead of the Laplacian.
+	Normalized bool
+
+	// Tol is the tolerance of the eigenvector computations, relative
+	// to the largest degree. If Tol is 0, 1e-9 is used.
+	Tol float64
+
+	// MaxIter is the maximum number of Lanczos iterations.
+	// If MaxIter is 0, 1000 is used.
+	MaxIter int
+
+	// Seed initializes the random starting vector of the iteration.
+	Seed int64
+}
+
+// Matrix is a square sparse matrix in compressed sparse row format.
+// The nonzero entries of row i are Val[k] in column Col[k], for k from
+// RowPtr[i] to RowPtr[i+1]-1, in order of increasing column.
+type Matrix struct {
+	RowPtr []int
+	Col    []int
+	Val    []float64
+}
+
+// Order returns the number of rows and columns of the matrix.
+func (m *Matrix) Order() int {
+	return len(m.RowPtr) - 1
+}
+
+// At returns the entry in row i and column j.
+//
+// The time complexity is O(log k), where k is the number
+// of nonzero entries in the row.
+func (m *Matrix) At(i, j int) float64 {
+	cols := m.Col[m.RowPtr[i]:m.RowPtr[i+1]]
+	k := sort.SearchInts(cols, j)
+	if k < len(cols) && cols[k] == j {
+		return m.Val[m.RowPtr[i]+k]
+	}
+	return 0
+}
+
+// Mul computes the matrix-vector product y = Mx.
+// The slices must have the same length as the order of m.
+func (m *Matrix) Mul(x, y []float64) {
+	n := m.Order()
+	if len(x) != n || len(y) != n {
+		panic("vector length " + strconv.Itoa(len(x)) + " doesn't match matrix order " + strconv.Itoa(n))
+	}
+	for i := range y {
+		sum := 0.0
+		for k := m.RowPtr[i]; k < m.RowPtr[i+1]; k++ {
+			sum += m.Val[k] * x[m.Col[k]]
+		}
+		y[i] = sum
+	}
+}
+
+// Dense returns the matrix as a slice of rows.
+func (m *Matrix) Dense() [][]float64 {
+	n := m.Order()
+	res := make([][]float64, n)
+	for i := range res {
+		res[i] = make([]float64, n)
+		for k := m.RowPtr[i]; k < m.RowPtr[i+1]; k++ {
+			res[i][m.Col[k]] = m.Val[k]
+		}
+	}
+	return res
+}
+
+// Adjacency returns the weighted adjacency matrix of g, where the entry
+// in row v and column w is the sum of the weights of the edges from v to w.
+//
+// The time complexity is O(|E|⋅log|V| + |V|), where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func Adjacency(g graph.Iterator, opts *Options) *Matrix {
+	if opts == nil {
+		opts = new(Options)
+	}
+	return build(g, opts, true, nil)
+}
+
+// Laplacian returns the Laplacian matrix L = D - A of g, where A is
+// the adjacency matrix without self-loops, and D is the diagonal
+// matrix of the weighted degrees of the vertices in A.
+//
+// The time complexity is O(|E|⋅log|V| + |V|), where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func Laplacian(g graph.Iterator, opts *Options) *Matrix {
+	if opts == nil {
+		opts = new(Options)
+	}
+	m, _ := laplacian(g, opts, false)
+	return m
+}
+
+// NormalizedLaplacian returns the normalized Laplacian matrix
+// I - D^(-1/2)⋅A⋅D^(-1/2) of g, with A and D as in Laplacian.
+// The row and column of an isolated vertex are zero.
+//
+// The time complexity is O(|E|⋅log|V| + |V|), where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func NormalizedLaplacian(g graph.Iterator, opts *Options) *Matrix {
+	if opts == nil {
+		opts = new(Options)
+	}
+	m, _ := laplacian(g, opts, true)
+	return m
+}
+
+// laplacian returns the Laplacian or normalized Laplacian of g,
+// and the weighted degrees of the vertices.
+func laplacian(g graph.Iterator, opts *Options, normalized bool) (*Matrix, []float64) {
+	n := g.Order()
+	deg := make([]float64, n)
+	a := build(g, opts, false, deg)
+	diag := func(v int) float64 {
+		switch {
+		case !normalized:
+			return deg[v]
+		case deg[v] > 0:
+			return 1
+		}
+		return 0
+	}
+	m := &Matrix{RowPtr: make([]int, n+1)}
+	for v := 0; v < n; v++ {
+		done := deg[v] == 0
+		for k := a.RowPtr[v]; k < a.RowPtr[v+1]; k++ {
+			w := a.Col[k]
+			if !done && w > v {
+				m.Col = append(m.Col, v)
+				m.Val = append(m.Val, diag(v))
+				done = true
+			}
+			x := -a.Val[k]
+			if d := deg[v] * deg[w]; normalized && d > 0 {
+				x /= math.Sqrt(d)
+			} else if normalized {
+				x = 0
+			}
+			m.Col = append(m.Col, w)
+			m.Val = append(m.Val, x)
+		}
+		if !done {
+			m.Col = append(m.Col, v)
+			m.Val = append(m.Val, diag(v))
+		}
+		m.RowPtr[v+1] = len(m.Col)
+	}
+	return m, deg
+}
+
+// build returns the adjacency matrix of g, with or without self-loops,
+// and adds the sum of each row to deg, if deg is not nil.
+func build(g graph.Iterator, opts *Options, loops bool, deg []float64) *Matrix {
+	h := graph.Sort(g)
+	n := h.Order()
+	m := &Matrix{RowPtr: make([]int, n+1)}
+	for v := 0; v < n; v++ {
+		h.Visit(v, func(w int, c int64) (skip bool) {
+			if v == w && !loops {
+				return
+			}
+			x := 1.0
+			if opts.Weighted {
+				x = float64(c)
+			}
+			if deg != nil {
+				deg[v] += x
+			}
+			if l := len(m.Col); l > m.RowPtr[v] && m.Col[l-1] == w {
+				m.Val[l-1] += x
+				return
+			}
+			m.Col = append(m.Col, w)
+			m.Val = append(m.Val, x)
+			return
+		})
+		m.RowPtr[v+1] = len(m.Col)
+	}
+	return m
+}
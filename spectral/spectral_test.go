@@ -0,0 +1,193 @@
+package spectral
+
+import (
+	"fmt"
+	"github.com/yourbasic/graph"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func diff(res, exp interface{}) (message string, diff bool) {
+	if !reflect.DeepEqual(res, exp) {
+		message = fmt.Sprintf("%v; want %v", res, exp)
+		diff = true
+	}
+	return
+}
+
+// path returns an undirected path with n vertices.
+func path(n int) *graph.Mutable {
+	g := graph.New(n)
+	for v := 0; v+1 < n; v++ {
+		g.AddBoth(v, v+1)
+	}
+	return g
+}
+
+func TestMatrices(t *testing.T) {
+	g := graph.New(4)
+	g.AddBothCost(0, 1, 2)
+	g.AddBothCost(1, 2, 3)
+	g.AddCost(2, 2, 5)
+	a := Adjacency(g, &Options{Weighted: true})
+	exp := [][]float64{
+		{0, 2, 0, 0},
+		{2, 0, 3, 0},
+		{0, 3, 5, 0},
+		{0, 0, 0, 0},
+	}
+	if mess, diff := diff(a.Dense(), exp); diff {
+		t.Errorf("Adjacency %s", mess)
+	}
+	if mess, diff := diff(a.At(2, 1), 3.0); diff {
+		t.Errorf("At %s", mess)
+	}
+	if mess, diff := diff(Adjacency(g, nil).At(1, 2), 1.0); diff {
+		t.Errorf("Adjacency %s", mess)
+	}
+
+	l := Laplacian(g, &Options{Weighted: true})
+	exp = [][]float64{
+		{2, -2, 0, 0},
+		{-2, 5, -3, 0},
+		{0, -3, 3, 0},
+		{0, 0, 0, 0},
+	}
+	if mess, diff := diff(l.Dense(), exp); diff {
+		t.Errorf("Laplacian %s", mess)
+	}
+	if mess, diff := diff(l.Col, []int{0, 1, 0, 1, 2, 1, 2}); diff {
+		t.Errorf("Laplacian.Col %s", mess)
+	}
+	y := make([]float64, 4)
+	l.Mul([]float64{1, 1, 1, 1}, y)
+	if mess, diff := diff(y, []float64{0, 0, 0, 0}); diff {
+		t.Errorf("Mul %s", mess)
+	}
+
+	nl := NormalizedLaplacian(path(3), nil).Dense()
+	s := -1 / math.Sqrt(2)
+	exp = [][]float64{
+		{1, s, 0},
+		{s, 1, s},
+		{0, s, 1},
+	}
+	for i := range exp {
+		for j := range exp {
+			if math.Abs(nl[i][j]-exp[i][j]) > 1e-12 {
+				t.Fatalf("NormalizedLaplacian %v; want %v", nl, exp)
+			}
+		}
+	}
+}
+
+// checkEigen checks that x is a unit eigenvector of m with eigenvalue lambda,
+// orthogonal to u.
+func checkEigen(t *testing.T, name string, m *Matrix, u, x []float64, lambda float64) {
+	t.Helper()
+	y := make([]float64, len(x))
+	m.Mul(x, y)
+	for i := range y {
+		if math.Abs(y[i]-lambda*x[i]) > 1e-6 {
+			t.Errorf("%s: not an eigenvector with eigenvalue %v: %v", name, lambda, x)
+			return
+		}
+	}
+	if d := dot(x, x); math.Abs(d-1) > 1e-9 {
+		t.Errorf("%s: length² %v", name, d)
+	}
+	if d := dot(x, u); math.Abs(d) > 1e-9 {
+		t.Errorf("%s: x⋅u = %v", name, d)
+	}
+}
+
+func TestFiedler(t *testing.T) {
+	for _, n := range []int{2, 3, 10, 100, 300} {
+		g := path(n)
+		x, lambda, ok := Fiedler(g, nil)
+		if !ok {
+			t.Errorf("Fiedler(path %d): no convergence", n)
+		}
+		if exp := 2 - 2*math.Cos(math.Pi/float64(n)); math.Abs(lambda-exp) > 1e-8 {
+			t.Errorf("Fiedler(path %d): λ = %v; want %v", n, lambda, exp)
+		}
+		ones := make([]float64, n)
+		for i := range ones {
+			ones[i] = 1
+		}
+		checkEigen(t, "Fiedler", Laplacian(g, nil), ones, x, lambda)
+		// The vector is monotone along the path.
+		for v := 1; v < n; v++ {
+			if (x[v]-x[v-1])*(x[n-1]-x[0]) <= 0 {
+				t.Errorf("Fiedler(path %d): not monotone: %v", n, x)
+				break
+			}
+		}
+
+		x, lambda, ok = Fiedler(g, &Options{Normalized: true})
+		if !ok {
+			t.Errorf("Fiedler(path %d, normalized): no convergence", n)
+		}
+		u := make([]float64, n)
+		for v := range u {
+			u[v] = math.Sqrt(float64(g.Degree(v)))
+		}
+		normalize(u)
+		checkEigen(t, "Fiedler(normalized)", NormalizedLaplacian(g, nil), u, x, lambda)
+	}
+	if x, lambda, ok := Fiedler(graph.New(1), nil); !ok || lambda != 0 || len(x) != 1 {
+		t.Errorf("Fiedler(1 vertex) %v %v %v", x, lambda, ok)
+	}
+	if _, _, ok := Fiedler(path(300), &Options{MaxIter: 5}); ok {
+		t.Errorf("Fiedler(MaxIter: 5): converged")
+	}
+	// A disconnected graph has λ = 0.
+	g := graph.New(6)
+	for _, e := range [][2]int{{0, 1}, {1, 2}, {2, 0}, {3, 4}, {4, 5}, {5, 3}} {
+		g.AddBoth(e[0], e[1])
+	}
+	if _, lambda, ok := Fiedler(g, nil); !ok || math.Abs(lambda) > 1e-8 {
+		t.Errorf("Fiedler(disconnected) λ = %v, ok = %v", lambda, ok)
+	}
+}
+
+func TestBisection(t *testing.T) {
+	// Two cliques of size 5, joined by the edge {4, 5}.
+	g := graph.New(10)
+	for _, offset := range []int{0, 5} {
+		for v := 0; v < 5; v++ {
+			for w := v + 1; w < 5; w++ {
+				g.AddBoth(offset+v, offset+w)
+			}
+		}
+	}
+	g.AddBothCost(4, 5, 7)
+	part, cut, ok := Bisection(g, nil)
+	if !ok {
+		t.Errorf("Bisection: no convergence")
+	}
+	if mess, diff := diff(cut, int64(1)); diff {
+		t.Errorf("Bisection: cut %s", mess)
+	}
+	for v := 1; v < 10; v++ {
+		if (part[v] == part[0]) != (v < 5) {
+			t.Errorf("Bisection %v", part)
+			break
+		}
+	}
+	if _, cut, _ := Bisection(g, &Options{Weighted: true, Normalized: true}); cut != 7 {
+		t.Errorf("Bisection(weighted): cut %d; want 7", cut)
+	}
+	part, cut, _ = Bisection(graph.New(0), nil)
+	if len(part) != 0 || cut != 0 {
+		t.Errorf("Bisection(empty) %v %d", part, cut)
+	}
+}
+
+func BenchmarkFiedler(b *testing.B) {
+	g := path(1000)
+	for i := 0; i < b.N; i++ {
+		Fiedler(g, nil)
+	}
+}
@@ -0,0 +1,150 @@
+package graph
+
+import (
+	"sort"
+	"strconv"
+)
+
+// SteinerTree returns a tree in the undirected graph g that connects all
+// the terminal vertices, and the total cost of its edges. The tree may
+// include other vertices. Each edge is given once, with V < W,
+// and the edges are sorted. Only edges with non-negative costs are used.
+// If some terminals can't be connected, it returns ok = false.
+//
+// Finding a minimum Steiner tree is NP-hard. This implementation uses
+// Mehlhorn's version of the shortest path heuristic, which finds
+// a tree of cost at most 2 - 2/t times the cost of a minimum tree,
+// where t is the number of terminals.
+//
+// The graph is undirected if each edge from v to w is matched by
+// an edge from w to v.
+// The time complexity is O((|E| + |V|)⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func SteinerTree(g Iterator, terminals []int) (edges []Edge, cost int64, ok bool) {
+	n := g.Order()
+	terminal := make([]bool, n)
+	for _, v := range terminals {
+		if v < 0 || v >= n {
+			panic("vertex out of range: " + strconv.Itoa(v))
+		}
+		terminal[v] = true
+	}
+
+	// Find the terminal nearest to each vertex, and connect two terminals
+	// s and t by the shortest path through an edge (v, w) with v close
+	// to s and w close to t.
+	parent, nearest, dist := ShortestPathsMulti(g, terminals...)
+	type bridge struct {
+		v, w int
+		cost int64 // the length of the path through (v, w)
+		c    int64 // the cost of (v, w)
+	}
+	best := make(map[[2]int]bridge)
+	for v := 0; v < n; v++ {
+		if nearest[v] == -1 {
+			continue
+		}
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			s, t := nearest[v], nearest[w]
+			if c < 0 || t == -1 || s >= t {
+				return
+			}
+			b := bridge{v, w, dist[v] + c + dist[w], c}
+			if old, ok := best[[2]int{s, t}]; !ok || b.cost < old.cost {
+				best[[2]int{s, t}] = b
+			}
+			return
+		})
+	}
+	distance := New(n)
+	for st, b := range best {
+		distance.AddBothCost(st[0], st[1], b.cost)
+	}
+	mst, _ := Kruskal(distance)
+	roots := 0
+	for v, p := range mst {
+		if terminal[v] && p == -1 {
+			roots++
+		}
+	}
+	if roots > 1 {
+		return []Edge{}, 0, false
+	}
+
+	// Replace each edge of the spanning tree of the terminals
+	// with the corresponding path in g.
+	h := New(n)
+	add := func(v, w int, c int64) {
+		if !h.Edge(v, w) || c < h.Cost(v, w) {
+			h.AddBothCost(v, w, c)
+		}
+	}
+	for s, t := range mst {
+		if t == -1 || !terminal[s] {
+			continue
+		}
+		st := [2]int{s, t}
+		if s > t {
+			st = [2]int{t, s}
+		}
+		b := best[st]
+		add(b.v, b.w, b.c)
+		for _, x := range []int{b.v, b.w} {
+			for ; parent[x] != -1; x = parent[x] {
+				add(x, parent[x], dist[x]-dist[parent[x]])
+			}
+		}
+	}
+
+	// Take a minimum spanning tree of the union of the paths,
+	// and remove leaves that aren't terminals.
+	tree, _ := Kruskal(h)
+	degree := make([]int, n)
+	adj := make([][]int, n)
+	for v, p := range tree {
+		if p != -1 {
+			degree[v]++
+			degree[p]++
+			adj[v] = append(adj[v], p)
+			adj[p] = append(adj[p], v)
+		}
+	}
+	removed := make([]bool, n)
+	var leaves []int
+	for v := 0; v < n; v++ {
+		if degree[v] == 1 && !terminal[v] {
+			leaves = append(leaves, v)
+		}
+	}
+	for len(leaves) > 0 {
+		v := leaves[len(leaves)-1]
+		leaves = leaves[:len(leaves)-1]
+		removed[v] = true
+		for _, w := range adj[v] {
+			if removed[w] {
+				continue
+			}
+			degree[w]--
+			if degree[w] == 1 && !terminal[w] {
+				leaves = append(leaves, w)
+			}
+		}
+	}
+	edges = []Edge{}
+	for v, p := range tree {
+		if p == -1 || removed[v] || removed[p] {
+			continue
+		}
+		e := Edge{v, p, h.Cost(v, p)}
+		if e.V > e.W {
+			e.V, e.W = e.W, e.V
+		}
+		edges = append(edges, e)
+		cost += e.Cost
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		return a.V < b.V || a.V == b.V && a.W < b.W
+	})
+	return edges, cost, true
+}
@@ -0,0 +1,153 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// bruteSteiner returns the cost of a minimum Steiner tree, found by
+// computing a minimum spanning tree for each set of vertices that
+// includes the terminals, or -1 if there is no such tree.
+func bruteSteiner(g Iterator, terminals []int) int64 {
+	n := g.Order()
+	var required uint
+	for _, v := range terminals {
+		required |= 1 << uint(v)
+	}
+	best := int64(-1)
+	for set := uint(0); set < 1<<uint(n); set++ {
+		if set&required != required {
+			continue
+		}
+		var vertices []int
+		for v := 0; v < n; v++ {
+			if set&(1<<uint(v)) != 0 {
+				vertices = append(vertices, v)
+			}
+		}
+		sub := Subgraph(g, vertices)
+		if len(vertices) > 0 && !Connected(sub) {
+			continue
+		}
+		_, total := Kruskal(sub)
+		if best == -1 || total < best {
+			best = total
+		}
+	}
+	return best
+}
+
+// checkSteiner checks that edges form a tree in g that connects
+// the terminals, of the given cost, with terminals as leaves.
+func checkSteiner(t *testing.T, g *Mutable, terminals []int, edges []Edge, cost int64) {
+	t.Helper()
+	n := g.Order()
+	h := New(n)
+	var sum int64
+	for _, e := range edges {
+		if e.V >= e.W || !g.Edge(e.V, e.W) || g.Cost(e.V, e.W) != e.Cost {
+			t.Errorf("SteinerTree(%v, %v): bad edge %v", g, terminals, e)
+		}
+		if h.Edge(e.V, e.W) {
+			t.Errorf("SteinerTree(%v, %v): duplicate edge %v", g, terminals, e)
+		}
+		h.AddBothCost(e.V, e.W, e.Cost)
+		sum += e.Cost
+	}
+	if sum != cost {
+		t.Errorf("SteinerTree(%v, %v)->cost %d; edges add up to %d", g, terminals, cost, sum)
+	}
+	terminal := make([]bool, n)
+	for _, v := range terminals {
+		terminal[v] = true
+	}
+	var vertices []int
+	for v := 0; v < n; v++ {
+		if h.Degree(v) > 0 || terminal[v] {
+			vertices = append(vertices, v)
+		}
+		if h.Degree(v) == 1 && !terminal[v] {
+			t.Errorf("SteinerTree(%v, %v): leaf %d isn't a terminal", g, terminals, v)
+		}
+	}
+	if len(vertices) > 0 && !IsTree(Subgraph(h, vertices)) {
+		t.Errorf("SteinerTree(%v, %v)->edges %v: not a tree", g, terminals, edges)
+	}
+}
+
+func TestSteinerTree(t *testing.T) {
+	// A star with center 0, where the terminals are the leaves.
+	g := New(4)
+	g.AddBothCost(0, 1, 1)
+	g.AddBothCost(0, 2, 1)
+	g.AddBothCost(0, 3, 1)
+	g.AddBothCost(1, 2, 3)
+	g.AddBothCost(2, 3, 3)
+	edges, cost, ok := SteinerTree(g, []int{1, 2, 3})
+	if mess, diff := diff(edges, []Edge{{0, 1, 1}, {0, 2, 1}, {0, 3, 1}}); diff {
+		t.Errorf("SteinerTree->edges %s", mess)
+	}
+	if mess, diff := diff(cost, int64(3)); diff {
+		t.Errorf("SteinerTree->cost %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("SteinerTree->ok %s", mess)
+	}
+
+	edges, cost, ok = SteinerTree(g, []int{2})
+	if mess, diff := diff(edges, []Edge{}); diff || cost != 0 || !ok {
+		t.Errorf("SteinerTree(one terminal)->edges %s, cost %d, ok %v", mess, cost, ok)
+	}
+	edges, cost, ok = SteinerTree(g, nil)
+	if mess, diff := diff(edges, []Edge{}); diff || cost != 0 || !ok {
+		t.Errorf("SteinerTree(no terminals)->edges %s, cost %d, ok %v", mess, cost, ok)
+	}
+
+	g = New(4)
+	g.AddBothCost(0, 1, 1)
+	g.AddBothCost(2, 3, 1)
+	edges, _, ok = SteinerTree(g, []int{0, 3})
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("SteinerTree(disconnected)->ok %s", mess)
+	}
+	if mess, diff := diff(edges, []Edge{}); diff {
+		t.Errorf("SteinerTree(disconnected)->edges %s", mess)
+	}
+
+	for i := 0; i < 100; i++ {
+		n := 1 + rand.Intn(10)
+		g := New(n)
+		for m := rand.Intn(2*n + 1); m > 0; m-- {
+			if v, w := rand.Intn(n), rand.Intn(n); v != w {
+				g.AddBothCost(v, w, rand.Int63n(10))
+			}
+		}
+		terminals := rand.Perm(n)[:1+rand.Intn(n)]
+		edges, cost, ok := SteinerTree(g, terminals)
+		opt := bruteSteiner(g, terminals)
+		if mess, diff := diff(ok, opt != -1); diff {
+			t.Errorf("SteinerTree(%v, %v)->ok %s", g, terminals, mess)
+		}
+		if !ok {
+			continue
+		}
+		checkSteiner(t, g, terminals, edges, cost)
+		if k := int64(len(terminals)); cost < opt || cost*k > (2*k-2)*opt {
+			t.Errorf("SteinerTree(%v, %v)->cost %d; optimum %d", g, terminals, cost, opt)
+		}
+	}
+}
+
+func BenchmarkSteinerTree(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 5*n; i++ {
+		g.AddBothCost(rand.Intn(n), rand.Intn(n), rand.Int63n(100))
+	}
+	terminals := rand.Perm(n)[:50]
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = SteinerTree(g, terminals)
+	}
+}
@@ -8,6 +8,15 @@ package graph
 // Each vertex of the graph appears in exactly one of the strongly
 // connected components, and any vertex that is not on a directed cycle
 // forms a strongly connected component all by itself.
+//
+// The components are listed in reverse topological order: if there is
+// an edge from a vertex in component i to a vertex in component j,
+// then i ≥ j.
+//
+// The implementation is an iterative version of Tarjan's algorithm,
+// so it works for graphs with very long paths.
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
 func StrongComponents(g Iterator) [][]int {
 	n := g.Order()
 	s := &scc{
@@ -24,6 +33,65 @@ func StrongComponents(g Iterator) [][]int {
 	return components
 }
 
+// StrongComponentLabels labels the strongly connected components of g.
+// The number label[v] is the component of vertex v, and size[c]
+// is the number of vertices in component c. The components are
+// numbered as in StrongComponents.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func StrongComponentLabels(g Iterator) (label []int, size []int) {
+	components := StrongComponents(g)
+	label, size = make([]int, g.Order()), make([]int, len(components))
+	for c, vertices := range components {
+		size[c] = len(vertices)
+		for _, v := range vertices {
+			label[v] = c
+		}
+	}
+	return
+}
+
+// Condensation returns the condensation of g: the acyclic graph
+// with one vertex for each strongly connected component of g,
+// numbered as in StrongComponents, and an edge from component i to j
+// if g has an edge from a vertex in i to a vertex in j, with i ≠ j.
+// The cost of the edge is the smallest cost of such an edge in g.
+// The number comp[v] is the component of vertex v.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func Condensation(g Iterator) (dag *Immutable, comp []int) {
+	components := StrongComponents(g)
+	comp = make([]int, g.Order())
+	for c, vertices := range components {
+		for _, v := range vertices {
+			comp[v] = c
+		}
+	}
+	var edges []Edge
+	for c, vertices := range components {
+		// index[d] is the position of the edge to d in edges.
+		index := make(map[int]int)
+		for _, v := range vertices {
+			g.Visit(v, func(w int, cost int64) (skip bool) {
+				d := comp[w]
+				if d == c {
+					return
+				}
+				if i, ok := index[d]; !ok {
+					index[d] = len(edges)
+					edges = append(edges, Edge{c, d, cost})
+				} else if cost < edges[i].Cost {
+					edges[i].Cost = cost
+				}
+				return
+			})
+		}
+	}
+	return FromEdges(len(components), edges), comp
+}
+
 // Tarjan's algorithm
 type scc struct {
 	graph   Iterator
@@ -36,38 +104,68 @@ type scc struct {
 // Make a depth-first search starting at v and append all strongly
 // connected components of the visited subgraph to comps.
 func (s *scc) append(components [][]int, v int) [][]int {
-	// A vertex remains on this stack after it has been visited iff
-	// there is a path from it to some vertex earlier on the stack.
-	s.stack = append(s.stack, v)
+	// The search path is kept in frames; the neighbors of the vertices
+	// on the path are stored consecutively in the neighbors slice.
+	type frame struct {
+		v            int
+		start, next  int // neighbors[start:] are the neighbors of v
+		newComponent bool
+	}
+	var path []frame
+	var neighbors []int
+	visit := func(v int) {
+		// A vertex remains on this stack after it has been visited iff
+		// there is a path from it to some vertex earlier on the stack.
+		s.stack = append(s.stack, v)
 
-	// lowLink[v] is the smallest vertex known to be reachable from v.
-	s.lowLink[v] = s.time
-	s.time++
+		// lowLink[v] is the smallest vertex known to be reachable from v.
+		s.lowLink[v] = s.time
+		s.time++
+		s.visited[v] = true
 
-	newComponent := true
-	s.visited[v] = true
-	s.graph.Visit(v, func(w int, _ int64) (skip bool) {
-		if !s.visited[w] {
-			components = s.append(components, w)
+		start := len(neighbors)
+		s.graph.Visit(v, func(w int, _ int64) (skip bool) {
+			neighbors = append(neighbors, w)
+			return
+		})
+		path = append(path, frame{v, start, start, true})
+	}
+	visit(v)
+	for len(path) > 0 {
+		f := &path[len(path)-1]
+		v := f.v
+		if f.next < len(neighbors) {
+			w := neighbors[f.next]
+			if !s.visited[w] {
+				visit(w)
+				continue
+			}
+			// Either w was visited before, or the search from w is done.
+			if s.lowLink[v] > s.lowLink[w] {
+				s.lowLink[v] = s.lowLink[w]
+				f.newComponent = false
+			}
+			f.next++
+			continue
 		}
-		if s.lowLink[v] > s.lowLink[w] {
-			s.lowLink[v] = s.lowLink[w]
-			newComponent = false
+		neighbors = neighbors[:f.start]
+		newComponent := f.newComponent
+		path = path[:len(path)-1]
+		if !newComponent {
+			continue
 		}
-		return
-	})
-	if !newComponent {
-		return components
-	}
-	var comp []int
-	for {
-		n := len(s.stack) - 1
-		w := s.stack[n]
-		s.stack = s.stack[:n]
-		s.lowLink[w] = int(^uint(0) >> 1) // maxint
-		comp = append(comp, w)
-		if v == w {
-			return append(components, comp)
+		var comp []int
+		for {
+			n := len(s.stack) - 1
+			w := s.stack[n]
+			s.stack = s.stack[:n]
+			s.lowLink[w] = int(^uint(0) >> 1) // maxint
+			comp = append(comp, w)
+			if v == w {
+				break
+			}
 		}
+		components = append(components, comp)
 	}
+	return components
 }
@@ -31,6 +31,14 @@ func TestStrongComponents(t *testing.T) {
 	if mess, diff := diff(StrongComponents(g), exp); diff {
 		t.Errorf("StronglyConnected %s", mess)
 	}
+
+	label, size := StrongComponentLabels(g)
+	if mess, diff := diff(label, []int{0, 0, 0, 2, 1, 2, 1, 3, 4, 5}); diff {
+		t.Errorf("StrongComponentLabels->label %s", mess)
+	}
+	if mess, diff := diff(size, []int{3, 2, 2, 1, 1, 1}); diff {
+		t.Errorf("StrongComponentLabels->size %s", mess)
+	}
 }
 
 func BenchmarkStrongComponents(b *testing.B) {
@@ -45,3 +53,58 @@ func BenchmarkStrongComponents(b *testing.B) {
 		_ = StrongComponents(g)
 	}
 }
+
+func TestStrongComponentsDeep(t *testing.T) {
+	// A long cycle gives a deep search.
+	n := 100000
+	g := New(n)
+	for v := 0; v < n; v++ {
+		g.Add(v, (v+1)%n)
+	}
+	if mess, diff := diff(len(StrongComponents(g)), 1); diff {
+		t.Errorf("StrongComponents %s", mess)
+	}
+}
+
+func TestCondensation(t *testing.T) {
+	g := New(6)
+	g.AddCost(0, 1, 1)
+	g.AddCost(1, 0, 1)
+	g.AddCost(1, 2, 5)
+	g.AddCost(0, 2, 3)
+	g.AddCost(2, 3, 2)
+	g.AddCost(3, 2, 2)
+	g.AddCost(3, 4, 4)
+	g.AddCost(5, 5, 1)
+	dag, comp := Condensation(g)
+	// Components: {4}, {3, 2}, {1, 0}, {5}.
+	if mess, diff := diff(comp, []int{2, 2, 1, 1, 0, 3}); diff {
+		t.Errorf("Condensation->comp %s", mess)
+	}
+	if mess, diff := diff(dag.String(), "4 [(1 0):4 (2 1):3]"); diff {
+		t.Errorf("Condensation->dag %s", mess)
+	}
+	if !Acyclic(dag) {
+		t.Errorf("Condensation: dag has a cycle")
+	}
+}
+
+func TestCondensationRandom(t *testing.T) {
+	n := 100
+	g := New(n)
+	for i := 0; i < 2*n; i++ {
+		g.Add(rand.Intn(n), rand.Intn(n))
+	}
+	dag, comp := Condensation(g)
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			if c, d := comp[v], comp[w]; c < d || c > d && !dag.Edge(c, d) {
+				t.Errorf("Condensation: edge (%d, %d) maps to (%d, %d)", v, w, c, d)
+			}
+			return
+		})
+	}
+	if !Acyclic(dag) {
+		t.Errorf("Condensation: dag has a cycle")
+	}
+}
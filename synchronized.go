@@ -0,0 +1,194 @@
+package graph
+
+import "sync"
+
+// SyncGraph is a wrapper that makes a Mutable graph safe for concurrent use.
+// All methods hold a read-write lock, so edges and vertices can be added
+// and removed by one goroutine while others read the graph.
+//
+// SyncGraph implements the Iterator interface, so any algorithm in this
+// package can run against it without data races, and several algorithms
+// can run at the same time. However, the lock is only held during each
+// call to Order or Visit. If the graph is modified while an algorithm
+// is running, the algorithm may see different versions of the graph
+// in different calls, and it may panic if vertices are added.
+// Only algorithms that make a single pass over the neighbor lists,
+// such as Degrees and Check, are still useful then: each list is read
+// in a consistent state. For all other algorithms, use Read to run the
+// algorithm under a read lock, or run it on a copy returned by Snapshot.
+type SyncGraph struct {
+	mu sync.RWMutex
+	g  *Mutable
+}
+
+// Synchronized returns a wrapper that makes g safe for concurrent use.
+// The graph g must not be accessed directly while the wrapper is in use.
+func Synchronized(g *Mutable) *SyncGraph {
+	return &SyncGraph{g: g}
+}
+
+// String returns a string representation of the graph.
+func (s *SyncGraph) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return String(s.g)
+}
+
+// Order returns the number of vertices in the graph.
+func (s *SyncGraph) Order() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.Order()
+}
+
+// Visit calls the do function for each neighbor w of v,
+// with c equal to the cost of the edge from v to w.
+// If do returns true, Visit returns immediately,
+// skipping any remaining neighbors, and returns true.
+//
+// The neighbors are copied under a read lock before do is called,
+// so do may call any method of the graph, including those
+// that modify it. The iteration order is the same as for
+// the Visit method of the underlying graph.
+func (s *SyncGraph) Visit(v int, do func(w int, c int64) bool) bool {
+	for _, e := range s.neighbors(v) {
+		if do(e.vertex, e.cost) {
+			return true
+		}
+	}
+	return false
+}
+
+// neighbors returns a copy of the neighbors of v.
+func (s *SyncGraph) neighbors(v int) []neighbor {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	neighbors := make([]neighbor, 0, len(s.g.edges[v]))
+	s.g.Visit(v, func(w int, c int64) (skip bool) {
+		neighbors = append(neighbors, neighbor{w, c})
+		return
+	})
+	return neighbors
+}
+
+// Degree returns the number of outward directed edges from v.
+func (s *SyncGraph) Degree(v int) int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.Degree(v)
+}
+
+// Edge tells if there is an edge from v to w.
+func (s *SyncGraph) Edge(v, w int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.Edge(v, w)
+}
+
+// Cost returns the cost of an edge from v to w, or 0 if no such edge exists.
+func (s *SyncGraph) Cost(v, w int) int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.Cost(v, w)
+}
+
+// Add inserts a directed edge from v to w with zero cost.
+// It removes the previous cost if this edge already exists.
+func (s *SyncGraph) Add(v, w int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.g.Add(v, w)
+}
+
+// AddCost inserts a directed edge from v to w with cost c.
+// It overwrites the previous cost if this edge already exists.
+func (s *SyncGraph) AddCost(v, w int, c int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.g.AddCost(v, w, c)
+}
+
+// AddBoth inserts edges with zero cost between v and w.
+// It removes the previous costs if these edges already exist.
+func (s *SyncGraph) AddBoth(v, w int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.g.AddBoth(v, w)
+}
+
+// AddBothCost inserts edges with cost c between v and w.
+// It overwrites the previous costs if these edges already exist.
+func (s *SyncGraph) AddBothCost(v, w int, c int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.g.AddBothCost(v, w, c)
+}
+
+// Delete removes an edge from v to w.
+func (s *SyncGraph) Delete(v, w int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.g.Delete(v, w)
+}
+
+// DeleteBoth removes all edges between v and w.
+func (s *SyncGraph) DeleteBoth(v, w int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.g.DeleteBoth(v, w)
+}
+
+// AddVertex adds a vertex without edges to the graph and returns it,
+// like Mutable.AddVertex.
+func (s *SyncGraph) AddVertex() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.g.AddVertex()
+}
+
+// RemoveVertex removes all edges to and from v, and makes v
+// available for reuse by AddVertex, like Mutable.RemoveVertex.
+func (s *SyncGraph) RemoveVertex(v int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.g.RemoveVertex(v)
+}
+
+// Removed tells if v has been removed by RemoveVertex and not reused.
+func (s *SyncGraph) Removed(v int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.Removed(v)
+}
+
+// Read calls f with the underlying graph while holding a read lock.
+// Any algorithm can be run by f, and it sees a consistent graph;
+// other readers may run at the same time, but writers are blocked.
+// The function f must not modify the graph, call methods of s,
+// or retain g.
+func (s *SyncGraph) Read(f func(g *Mutable)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f(s.g)
+}
+
+// Write calls f with the underlying graph while holding a write lock,
+// so that several changes can be made atomically.
+// The function f must not call methods of s, or retain g.
+func (s *SyncGraph) Write(f func(g *Mutable)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(s.g)
+}
+
+// Snapshot returns an immutable copy of the current graph,
+// with neighbors sorted as by Sort. Algorithms that run on the copy
+// don't block writers.
+//
+// The time complexity is O(|E|⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func (s *SyncGraph) Snapshot() *Immutable {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return Sort(s.g)
+}
@@ -0,0 +1,108 @@
+package graph
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSynchronized(t *testing.T) {
+	s := Synchronized(New(3))
+	s.Add(0, 1)
+	s.AddCost(1, 2, 5)
+	s.AddBoth(0, 2)
+	s.AddBothCost(1, 1, 3)
+	if mess, diff := diff(s.String(), "3 [(0 1) {0 2} (1 1):3 (1 2):5]"); diff {
+		t.Errorf("Synchronized %s", mess)
+	}
+	if mess, diff := diff(s.Order(), 3); diff {
+		t.Errorf("Order %s", mess)
+	}
+	if mess, diff := diff(s.Degree(1), 2); diff {
+		t.Errorf("Degree %s", mess)
+	}
+	if mess, diff := diff(s.Edge(1, 2), true); diff {
+		t.Errorf("Edge %s", mess)
+	}
+	if mess, diff := diff(s.Cost(1, 2), int64(5)); diff {
+		t.Errorf("Cost %s", mess)
+	}
+	s.Delete(1, 1)
+	s.DeleteBoth(0, 2)
+	if mess, diff := diff(s.Snapshot().String(), "3 [(0 1) (1 2):5]"); diff {
+		t.Errorf("Snapshot %s", mess)
+	}
+
+	v := s.AddVertex()
+	s.Add(v, 0)
+	s.RemoveVertex(0)
+	if mess, diff := diff(s.Removed(0), true); diff {
+		t.Errorf("Removed %s", mess)
+	}
+	s.Write(func(g *Mutable) {
+		g.Add(1, 3)
+		g.Add(3, 1)
+	})
+	s.Read(func(g *Mutable) {
+		if mess, diff := diff(g.String(), "4 [(1 2):5 {1 3}]"); diff {
+			t.Errorf("Read %s", mess)
+		}
+	})
+
+	// Visit may modify the graph.
+	s.Visit(1, func(w int, _ int64) (skip bool) {
+		s.Delete(1, w)
+		return
+	})
+	if mess, diff := diff(s.Degree(1), 0); diff {
+		t.Errorf("Degree after Visit %s", mess)
+	}
+
+	// A panic in Visit releases the lock.
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Visit(-1): no panic")
+			}
+		}()
+		s.Visit(-1, func(w int, _ int64) (skip bool) { return })
+	}()
+	s.Add(1, 2)
+	if !s.Edge(1, 2) {
+		t.Errorf("Add after panic in Visit: no edge")
+	}
+}
+
+func TestSynchronizedConcurrent(t *testing.T) {
+	n := 100
+	s := Synchronized(New(n))
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for v := 0; v < n; v++ {
+				s.AddBoth(v, (v+i+1)%n)
+				s.DeleteBoth(v, (v+i+2)%n)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				Degrees(s)
+				// The graph is undirected between writes.
+				s.Read(func(g *Mutable) {
+					for v := 0; v < n; v++ {
+						g.Visit(v, func(w int, _ int64) (skip bool) {
+							if !g.Edge(w, v) {
+								t.Errorf("edge (%d, %d) without (%d, %d)", v, w, w, v)
+							}
+							return
+						})
+					}
+				})
+				_ = Components(s.Snapshot())
+			}
+		}()
+	}
+	wg.Wait()
+}
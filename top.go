@@ -1,15 +1,113 @@
 package graph
 
+import "container/heap"
+
 // TopSort returns a topological ordering of the vertices in
 // a directed acyclic graph; if the graph is not acyclic,
 // no such ordering exists and ok is set to false.
 //
 // In a topological order v comes before w for every directed edge from v to w.
+// Use FindCycle to find a cycle in a graph that isn't acyclic.
 func TopSort(g Iterator) (order []int, ok bool) {
 	order, ok = topsort(g, true)
 	return
 }
 
+// TopSortStable returns the topological ordering of the vertices in
+// a directed acyclic graph in which, among the vertices that may come
+// next, the smallest vertex always comes first. The order is hence the
+// same for all graphs with the same edges. If the graph is not acyclic,
+// no such ordering exists and ok is set to false.
+//
+// The time complexity is O(|E| + |V|⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func TopSortStable(g Iterator) (order []int, ok bool) {
+	return TopSortFunc(g, func(v, w int) bool { return v < w })
+}
+
+// TopSortFunc is like TopSortStable, but breaks ties using the less
+// function: among the vertices that may come next, a vertex v such that
+// less(v, w) for all other candidates w comes first.
+// The less function must describe a strict weak ordering.
+func TopSortFunc(g Iterator, less func(v, w int) bool) (order []int, ok bool) {
+	indegree := make([]int, g.Order())
+	for v := range indegree {
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			indegree[w]++
+			return
+		})
+	}
+	q := &vertexHeap{less: less}
+	for v, degree := range indegree {
+		if degree == 0 {
+			q.vertices = append(q.vertices, v)
+		}
+	}
+	heap.Init(q)
+	order = []int{}
+	for q.Len() > 0 {
+		v := heap.Pop(q).(int)
+		order = append(order, v)
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			indegree[w]--
+			if indegree[w] == 0 {
+				heap.Push(q, w)
+			}
+			return
+		})
+	}
+	if len(order) != g.Order() {
+		return []int{}, false
+	}
+	return order, true
+}
+
+type vertexHeap struct {
+	vertices []int
+	less     func(v, w int) bool
+}
+
+func (q *vertexHeap) Len() int           { return len(q.vertices) }
+func (q *vertexHeap) Less(i, j int) bool { return q.less(q.vertices[i], q.vertices[j]) }
+func (q *vertexHeap) Swap(i, j int)      { q.vertices[i], q.vertices[j] = q.vertices[j], q.vertices[i] }
+func (q *vertexHeap) Push(x interface{}) { q.vertices = append(q.vertices, x.(int)) }
+
+func (q *vertexHeap) Pop() interface{} {
+	n := len(q.vertices) - 1
+	v := q.vertices[n]
+	q.vertices = q.vertices[:n]
+	return v
+}
+
+// FindCycle returns a directed cycle in g, if there is one.
+// Otherwise, it returns an empty slice and sets ok to false.
+// The cycle is given as a list of vertices, with an edge from each vertex
+// to the next and from the last vertex back to the first.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func FindCycle(g Iterator) (cycle []int, ok bool) {
+	var d *dfs
+	d = newDFS(g, DFSEvents{
+		BackEdge: func(v, w int, _ int64) {
+			// The path from w to v, followed by the edge back to w.
+			i := len(d.path) - 1
+			for d.path[i].v != w {
+				i--
+			}
+			for _, f := range d.path[i:] {
+				cycle = append(cycle, f.v)
+			}
+			d.stop = true
+		},
+	})
+	d.searchAll()
+	if cycle == nil {
+		return []int{}, false
+	}
+	return cycle, true
+}
+
 // Acyclic tells if g has no cycles.
 func Acyclic(g Iterator) bool {
 	_, acyclic := topsort(g, false)
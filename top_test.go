@@ -113,3 +113,89 @@ func BenchmarkTopSort(b *testing.B) {
 		_, _ = TopSort(g)
 	}
 }
+
+func TestTopSortStable(t *testing.T) {
+	g := New(6)
+	g.Add(5, 2)
+	g.Add(5, 0)
+	g.Add(4, 0)
+	g.Add(4, 1)
+	g.Add(2, 3)
+	g.Add(3, 1)
+	order, ok := TopSortStable(g)
+	if mess, diff := diff(order, []int{4, 5, 0, 2, 3, 1}); diff {
+		t.Errorf("TopSortStable %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("TopSortStable->ok %s", mess)
+	}
+
+	order, ok = TopSortFunc(g, func(v, w int) bool { return v > w })
+	if mess, diff := diff(order, []int{5, 4, 2, 3, 1, 0}); diff {
+		t.Errorf("TopSortFunc %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("TopSortFunc->ok %s", mess)
+	}
+
+	g.Add(1, 5)
+	order, ok = TopSortStable(g)
+	if mess, diff := diff(order, []int{}); diff {
+		t.Errorf("TopSortStable %s", mess)
+	}
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("TopSortStable->ok %s", mess)
+	}
+}
+
+func TestFindCycle(t *testing.T) {
+	g := New(5)
+	g.Add(0, 1)
+	g.Add(1, 2)
+	g.Add(0, 2)
+	g.Add(3, 4)
+	cycle, ok := FindCycle(g)
+	if mess, diff := diff(cycle, []int{}); diff {
+		t.Errorf("FindCycle %s", mess)
+	}
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("FindCycle->ok %s", mess)
+	}
+
+	g.Add(4, 4)
+	cycle, ok = FindCycle(g)
+	if mess, diff := diff(cycle, []int{4}); diff {
+		t.Errorf("FindCycle %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("FindCycle->ok %s", mess)
+	}
+
+	g.Delete(4, 4)
+	g.Add(2, 3)
+	g.Add(4, 1)
+	cycle, ok = FindCycle(Sort(g))
+	if mess, diff := diff(cycle, []int{1, 2, 3, 4}); diff {
+		t.Errorf("FindCycle %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("FindCycle->ok %s", mess)
+	}
+}
+
+func TestFindCycleRandom(t *testing.T) {
+	n := 50
+	for i := 0; i < 20; i++ {
+		g := New(n)
+		for j := 0; j < n; j++ {
+			g.Add(rand.Intn(n), rand.Intn(n))
+		}
+		cycle, ok := FindCycle(g)
+		if ok == Acyclic(g) {
+			t.Errorf("FindCycle->ok %t; want %t", ok, !ok)
+		}
+		if ok && cycleCost(g, cycle) == Max {
+			t.Errorf("FindCycle: %v is not a cycle", cycle)
+		}
+	}
+}
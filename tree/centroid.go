@@ -0,0 +1,104 @@
+package tree
+
+import "github.com/yourbasic/graph"
+
+// Centroid returns the centroid of the tree g: the vertices whose removal
+// leaves no component with more than half of the vertices.
+// The centroid consists of one vertex or two adjacent vertices,
+// given in increasing order.
+//
+// The time complexity is O(|V|), where |V| is the number of vertices.
+func Centroid(g graph.Iterator) []int {
+	check(g)
+	n := g.Order()
+	parent, order := bfs(g, 0)
+	size := make([]int, n)
+	largest := make([]int, n) // the largest subtree of a child of v
+	for i := n - 1; i >= 0; i-- {
+		v := order[i]
+		size[v]++
+		if p := parent[v]; p != -1 {
+			size[p] += size[v]
+			largest[p] = max(largest[p], size[v])
+		}
+	}
+	res := []int{}
+	for v := 0; v < n; v++ {
+		if max(largest[v], n-size[v]) <= n/2 {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// CentroidDecomposition returns the centroid tree of g as a parent array.
+// The root of the centroid tree is the smallest centroid of g. Removing
+// it splits g into smaller trees, whose smallest centroids are the
+// children of the root, and so on recursively. The centroid tree has
+// depth at most log₂|V|, and the vertices of each subtree of the
+// centroid tree form a connected subtree of g.
+//
+// The time complexity is O(|V|⋅log|V|), where |V| is the number of vertices.
+func CentroidDecomposition(g graph.Iterator) (parent []int) {
+	check(g)
+	n := g.Order()
+	parent = make([]int, n)
+	removed := make([]bool, n)
+	size := make([]int, n)
+	up := make([]int, n) // parent pointers within the current component
+
+	type part struct{ v, parent int }
+	for parts := []part{{0, -1}}; len(parts) > 0; {
+		p := parts[len(parts)-1]
+		parts = parts[:len(parts)-1]
+
+		// Compute the subtree sizes of the component of p.v.
+		order := []int{p.v}
+		up[p.v] = -1
+		for i := 0; i < len(order); i++ {
+			v := order[i]
+			g.Visit(v, func(w int, _ int64) (skip bool) {
+				if w != up[v] && !removed[w] {
+					up[w] = v
+					order = append(order, w)
+				}
+				return
+			})
+		}
+		for i := len(order) - 1; i >= 0; i-- {
+			v := order[i]
+			size[v] = 1
+			g.Visit(v, func(w int, _ int64) (skip bool) {
+				if w != up[v] && !removed[w] {
+					size[v] += size[w]
+				}
+				return
+			})
+		}
+
+		// Find the smallest centroid.
+		m, c := len(order), -1
+		for _, v := range order {
+			largest := m - size[v]
+			g.Visit(v, func(w int, _ int64) (skip bool) {
+				if w != up[v] && !removed[w] {
+					largest = max(largest, size[w])
+				}
+				return
+			})
+			if largest <= m/2 && (c == -1 || v < c) {
+				c = v
+			}
+		}
+
+		parent[c] = p.parent
+		removed[c] = true
+		g.Visit(c, func(w int, _ int64) (skip bool) {
+			if !removed[w] {
+				parts = append(parts, part{w, c})
+			}
+			return
+		})
+	}
+	return
+}
@@ -0,0 +1,123 @@
+package tree
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"testing"
+)
+
+// largestPart returns the size of the largest component, and the number
+// of components, of g restricted to the vertices in keep, after removing v.
+// No vertex is removed if v is -1.
+func largestPart(g graph.Iterator, keep []bool, v int) (largest, count int) {
+	n := g.Order()
+	seen := make([]bool, n)
+	if v != -1 {
+		seen[v] = true
+	}
+	for s := 0; s < n; s++ {
+		if seen[s] || !keep[s] {
+			continue
+		}
+		size := 0
+		count++
+		seen[s] = true
+		for stack := []int{s}; len(stack) > 0; {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			size++
+			g.Visit(u, func(w int, _ int64) (skip bool) {
+				if !seen[w] && keep[w] {
+					seen[w] = true
+					stack = append(stack, w)
+				}
+				return
+			})
+		}
+		largest = max(largest, size)
+	}
+	return
+}
+
+func TestCentroid(t *testing.T) {
+	g := FromParents([]int{-1, 0, 0, 1, 1, 4})
+	if mess, diff := diff(Centroid(g), []int{1}); diff {
+		t.Errorf("Centroid %s", mess)
+	}
+	if mess, diff := diff(Centroid(FromParents([]int{-1, 0})), []int{0, 1}); diff {
+		t.Errorf("Centroid %s", mess)
+	}
+	if mess, diff := diff(Centroid(FromParents([]int{-1})), []int{0}); diff {
+		t.Errorf("Centroid %s", mess)
+	}
+
+	for i := 0; i < 100; i++ {
+		n := 1 + rand.Intn(30)
+		_, g := random(n, false)
+		all := make([]bool, n)
+		for v := range all {
+			all[v] = true
+		}
+		exp := []int{}
+		for v := 0; v < n; v++ {
+			if largest, _ := largestPart(g, all, v); largest <= n/2 {
+				exp = append(exp, v)
+			}
+		}
+		if mess, diff := diff(Centroid(g), exp); diff {
+			t.Errorf("Centroid(%v) %s", g, mess)
+		}
+	}
+}
+
+func TestCentroidDecomposition(t *testing.T) {
+	// A path 0-1-2-3-4-5-6.
+	g := FromParents([]int{-1, 0, 1, 2, 3, 4, 5})
+	if mess, diff := diff(CentroidDecomposition(g), []int{1, 3, 1, -1, 5, 3, 5}); diff {
+		t.Errorf("CentroidDecomposition %s", mess)
+	}
+	if mess, diff := diff(CentroidDecomposition(FromParents([]int{-1})), []int{-1}); diff {
+		t.Errorf("CentroidDecomposition %s", mess)
+	}
+
+	for i := 0; i < 100; i++ {
+		n := 1 + rand.Intn(40)
+		_, g := random(n, false)
+		parent := CentroidDecomposition(g)
+		l := graph.NewLCA(parent)
+		depth := 0
+		for v := 0; v < n; v++ {
+			depth = max(depth, l.Depth(v))
+		}
+		if 1<<uint(depth) > n {
+			t.Errorf("CentroidDecomposition(%v) = %v has depth %d", g, parent, depth)
+		}
+		// Each vertex c is a centroid of the vertices in its subtree
+		// of the centroid tree, which must be connected in g.
+		for c := 0; c < n; c++ {
+			keep := make([]bool, n)
+			size := 0
+			for v := 0; v < n; v++ {
+				if l.Ancestor(v, c) == c {
+					keep[v] = true
+					size++
+				}
+			}
+			if _, count := largestPart(g, keep, -1); count != 1 {
+				t.Errorf("CentroidDecomposition(%v) = %v: subtree of %d not connected", g, parent, c)
+			}
+			if largest, _ := largestPart(g, keep, c); largest > size/2 {
+				t.Errorf("CentroidDecomposition(%v) = %v: %d isn't a centroid", g, parent, c)
+			}
+		}
+	}
+}
+
+func BenchmarkCentroidDecomposition(b *testing.B) {
+	b.StopTimer()
+	_, g := random(10000, false)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = CentroidDecomposition(g)
+	}
+}
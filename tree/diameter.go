@@ -0,0 +1,83 @@
+package tree
+
+import "github.com/yourbasic/graph"
+
+// Diameter returns a longest path in the tree g, where the length
+// of a path is its number of edges. If there are several longest paths,
+// the path starts at the vertex farthest from 0 with the smallest number,
+// and ends at the vertex farthest from the start, again with the
+// smallest number.
+//
+// The time complexity is O(|V|), where |V| is the number of vertices.
+func Diameter(g graph.Iterator) (path []int) {
+	check(g)
+	path, _ = diameter(g, func(int64) int64 { return 1 })
+	return
+}
+
+// WeightedDiameter returns a path of maximum total cost in the tree g,
+// and its cost. The costs must be non-negative. Ties are broken
+// as in Diameter.
+//
+// The time complexity is O(|V|), where |V| is the number of vertices.
+func WeightedDiameter(g graph.Iterator) (path []int, dist int64) {
+	check(g)
+	return diameter(g, func(c int64) int64 { return c })
+}
+
+// Center returns the center of the tree g: the vertices that minimize
+// the largest number of edges on a path to another vertex.
+// The center consists of one vertex or two adjacent vertices,
+// given in increasing order.
+//
+// The time complexity is O(|V|), where |V| is the number of vertices.
+func Center(g graph.Iterator) []int {
+	path := Diameter(g)
+	k := len(path) - 1
+	if k%2 == 0 {
+		return []int{path[k/2]}
+	}
+	v, w := path[k/2], path[k/2+1]
+	if v > w {
+		v, w = w, v
+	}
+	return []int{v, w}
+}
+
+func diameter(g graph.Iterator, cost func(c int64) int64) (path []int, dist int64) {
+	a, _, _ := farthest(g, 0, cost)
+	b, parent, d := farthest(g, a, cost)
+	for v := b; v != -1; v = parent[v] {
+		path = append(path, v)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, d
+}
+
+// farthest returns the vertex farthest from v, with the smallest number,
+// the parent of each vertex in the tree rooted at v, and the distance.
+func farthest(g graph.Iterator, v int, cost func(c int64) int64) (w int, parent []int, d int64) {
+	n := g.Order()
+	dist := make([]int64, n)
+	parent = make([]int, n)
+	parent[v] = -1
+	w = v
+	for stack := []int{v}; len(stack) > 0; {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if dist[u] > d || dist[u] == d && u < w {
+			w, d = u, dist[u]
+		}
+		g.Visit(u, func(x int, c int64) (skip bool) {
+			if x != parent[u] {
+				parent[x] = u
+				dist[x] = dist[u] + cost(c)
+				stack = append(stack, x)
+			}
+			return
+		})
+	}
+	return
+}
@@ -0,0 +1,114 @@
+package tree
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"testing"
+)
+
+// eccentricities returns the largest number of edges, and the largest
+// cost, on a path from each vertex of the tree g to another vertex.
+func eccentricities(g graph.Iterator) (hops []int, dist []int64) {
+	n := g.Order()
+	hops, dist = make([]int, n), make([]int64, n)
+	for v := 0; v < n; v++ {
+		parent, d := graph.ShortestPaths(g, v)
+		for w := 0; w < n; w++ {
+			dist[v] = max(dist[v], d[w])
+			hops[v] = max(hops[v], len(graph.NewPathTree(parent, d).PathTo(w))-1)
+		}
+	}
+	return
+}
+
+// checkPath checks that path is a path in g with the given length and cost.
+func checkPath(t *testing.T, g *graph.Mutable, path []int, length int, cost int64) {
+	t.Helper()
+	if len(path)-1 != length {
+		t.Errorf("path %v in %v has length %d; want %d", path, g, len(path)-1, length)
+	}
+	var sum int64
+	for i := 1; i < len(path); i++ {
+		if !g.Edge(path[i-1], path[i]) {
+			t.Errorf("path %v isn't a path in %v", path, g)
+			return
+		}
+		sum += g.Cost(path[i-1], path[i])
+	}
+	if sum != cost {
+		t.Errorf("path %v in %v has cost %d; want %d", path, g, sum, cost)
+	}
+}
+
+func TestDiameter(t *testing.T) {
+	g := FromParents([]int{-1, 0, 1, 2, 1, 4, 5})
+	if mess, diff := diff(Diameter(g), []int{6, 5, 4, 1, 2, 3}); diff {
+		t.Errorf("Diameter %s", mess)
+	}
+	if mess, diff := diff(Center(g), []int{1, 4}); diff {
+		t.Errorf("Center %s", mess)
+	}
+	if mess, diff := diff(Diameter(FromParents([]int{-1})), []int{0}); diff {
+		t.Errorf("Diameter %s", mess)
+	}
+	if mess, diff := diff(Center(FromParents([]int{-1})), []int{0}); diff {
+		t.Errorf("Center %s", mess)
+	}
+	if mess, diff := diff(Center(FromParents([]int{1, -1, 1})), []int{1}); diff {
+		t.Errorf("Center %s", mess)
+	}
+
+	h := graph.New(4)
+	h.AddBothCost(0, 1, 5)
+	h.AddBothCost(0, 2, 1)
+	h.AddBothCost(0, 3, 2)
+	path, dist := WeightedDiameter(h)
+	if mess, diff := diff(path, []int{1, 0, 3}); diff {
+		t.Errorf("WeightedDiameter->path %s", mess)
+	}
+	if mess, diff := diff(dist, int64(7)); diff {
+		t.Errorf("WeightedDiameter->dist %s", mess)
+	}
+
+	for i := 0; i < 100; i++ {
+		n := 1 + rand.Intn(30)
+		_, g := random(n, true)
+		hops, dist := eccentricities(g)
+		maxHops, maxDist, radius := 0, int64(0), n
+		for v := 0; v < n; v++ {
+			maxHops, maxDist, radius = max(maxHops, hops[v]), max(maxDist, dist[v]), min(radius, hops[v])
+		}
+		path := Diameter(g)
+		checkPath(t, g, path, maxHops, costOf(g, path))
+		path, d := WeightedDiameter(g)
+		if mess, diff := diff(d, maxDist); diff {
+			t.Errorf("WeightedDiameter(%v)->dist %s", g, mess)
+		}
+		checkPath(t, g, path, len(path)-1, maxDist)
+		center := []int{}
+		for v := 0; v < n; v++ {
+			if hops[v] == radius {
+				center = append(center, v)
+			}
+		}
+		if mess, diff := diff(Center(g), center); diff {
+			t.Errorf("Center(%v) %s", g, mess)
+		}
+	}
+}
+
+func costOf(g *graph.Mutable, path []int) (sum int64) {
+	for i := 1; i < len(path); i++ {
+		sum += g.Cost(path[i-1], path[i])
+	}
+	return
+}
+
+func BenchmarkDiameter(b *testing.B) {
+	b.StopTimer()
+	_, g := random(10000, false)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Diameter(g)
+	}
+}
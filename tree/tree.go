@@ -0,0 +1,82 @@
+// Package tree implements algorithms for trees.
+//
+// A tree is a connected undirected graph without cycles, and with
+// at least one vertex: each edge from v to w is matched by an edge
+// from w to v. The graph.IsTree function tells if a graph is a tree;
+// the functions in this package panic if they are given a graph
+// that isn't. A rooted tree can also be given as a parent array,
+// in which parent[v] is the parent of v, or -1 if v is the root,
+// and converted to a graph by FromParents.
+//
+// Diameter finds a longest path, Center the vertices that minimize
+// the distance to the farthest vertex, and Centroid the vertices that
+// minimize the size of the largest subtree left when they are removed.
+// CentroidDecomposition builds the centroid tree, which is used by
+// divide-and-conquer algorithms on trees.
+package tree
+
+import (
+	"github.com/yourbasic/graph"
+	"strconv"
+)
+
+// FromParents returns the tree with an edge {v, parent[v]} of cost zero
+// for each vertex v that isn't the root. It panics if the parent pointers
+// don't form a tree.
+func FromParents(parent []int) *graph.Immutable {
+	n := len(parent)
+	edges := make([]graph.Edge, 0, 2*n)
+	for v, p := range parent {
+		if p < -1 || p >= n {
+			panic("vertex out of range: " + strconv.Itoa(p))
+		}
+		if p != -1 {
+			edges = append(edges, graph.Edge{V: v, W: p}, graph.Edge{V: p, W: v})
+		}
+	}
+	g := graph.FromEdges(n, edges)
+	check(g)
+	return g
+}
+
+// Parents returns the parent array of the tree g rooted at root,
+// with parent[root] = -1.
+//
+// The time complexity is O(|V|), where |V| is the number of vertices.
+func Parents(g graph.Iterator, root int) (parent []int) {
+	check(g)
+	parent, _ = bfs(g, root)
+	return
+}
+
+// check panics if g isn't a tree.
+func check(g graph.Iterator) {
+	if !graph.IsTree(g) {
+		panic("not a tree")
+	}
+}
+
+// bfs makes a breadth-first search of the tree g from root,
+// and returns the parent of each vertex and the vertices in the order
+// they were visited.
+func bfs(g graph.Iterator, root int) (parent, order []int) {
+	n := g.Order()
+	if root < 0 || root >= n {
+		panic("vertex out of range: " + strconv.Itoa(root))
+	}
+	parent = make([]int, n)
+	order = make([]int, 0, n)
+	parent[root] = -1
+	order = append(order, root)
+	for i := 0; i < len(order); i++ {
+		v := order[i]
+		g.Visit(v, func(w int, _ int64) (skip bool) {
+			if w != parent[v] {
+				parent[w] = v
+				order = append(order, w)
+			}
+			return
+		})
+	}
+	return
+}
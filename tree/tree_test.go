@@ -0,0 +1,85 @@
+package tree
+
+import (
+	"fmt"
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func diff(res, exp interface{}) (message string, diff bool) {
+	if !reflect.DeepEqual(res, exp) {
+		message = fmt.Sprintf("%v; want %v", res, exp)
+		diff = true
+	}
+	return
+}
+
+// random returns a random tree with n vertices, and optionally
+// random edge costs, as a parent array and as a graph.
+func random(n int, weighted bool) (parent []int, g *graph.Mutable) {
+	perm := rand.Perm(n)
+	parent = make([]int, n)
+	g = graph.New(n)
+	parent[perm[0]] = -1
+	for i := 1; i < n; i++ {
+		v, p := perm[i], perm[rand.Intn(i)]
+		parent[v] = p
+		var c int64
+		if weighted {
+			c = rand.Int63n(10)
+		}
+		g.AddBothCost(v, p, c)
+	}
+	return
+}
+
+func TestFromParents(t *testing.T) {
+	g := FromParents([]int{-1, 0, 0, 1})
+	if mess, diff := diff(g.String(), "4 [{0 1} {0 2} {1 3}]"); diff {
+		t.Errorf("FromParents %s", mess)
+	}
+	if mess, diff := diff(FromParents([]int{-1}).String(), "1 []"); diff {
+		t.Errorf("FromParents %s", mess)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("FromParents with two roots should panic")
+		}
+	}()
+	FromParents([]int{-1, -1})
+}
+
+func TestParents(t *testing.T) {
+	g := FromParents([]int{-1, 0, 0, 1})
+	if mess, diff := diff(Parents(g, 3), []int{1, 3, 0, -1}); diff {
+		t.Errorf("Parents %s", mess)
+	}
+	for i := 0; i < 100; i++ {
+		n := 1 + rand.Intn(30)
+		parent, g := random(n, false)
+		root := 0
+		for parent[root] != -1 {
+			root++
+		}
+		if mess, diff := diff(Parents(g, root), parent); diff {
+			t.Errorf("Parents %s", mess)
+		}
+		if !graph.Equal(FromParents(parent), g) {
+			t.Errorf("FromParents(%v) = %v; want %v", parent, FromParents(parent), g)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Parents of a cycle should panic")
+		}
+	}()
+	h := graph.New(3)
+	h.AddBoth(0, 1)
+	h.AddBoth(1, 2)
+	h.AddBoth(2, 0)
+	Parents(h, 0)
+}
@@ -0,0 +1,129 @@
+package graph
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Triangles returns the number of triangles in g: sets of three vertices
+// that are pairwise adjacent. The graph is treated as undirected,
+// as in the coloring functions; self-loops and duplicate edges are ignored.
+// The work is split among the given number of worker goroutines,
+// or all available CPUs if workers ≤ 0.
+//
+// The implementation is the node iterator algorithm with degree ordering:
+// each edge is directed from the vertex of lower degree to the vertex of
+// higher degree, and each triangle is found once from its lowest vertex.
+// The time complexity is O(|E|⋅√|E| + |V|), where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func Triangles(g Iterator, workers int) int {
+	total := 0
+	for _, t := range TriangleCounts(g, workers) {
+		total += t
+	}
+	return total / 3
+}
+
+// TriangleCounts returns the number of triangles that each vertex of g
+// belongs to. As in Triangles, the graph is treated as undirected, and the
+// work is split among the given number of worker goroutines, or all
+// available CPUs if workers ≤ 0.
+//
+// The time complexity is O(|E|⋅√|E| + |V|), where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func TriangleCounts(g Iterator, workers int) []int {
+	count, _ := triangleCounts(g, workers)
+	return count
+}
+
+// LocalClustering returns the local clustering coefficient of each vertex
+// of g: the fraction of pairs of neighbors of v that are also neighbors of
+// each other, or 0 if v has fewer than two neighbors. The graph is treated
+// as undirected, and the work is split as in TriangleCounts.
+//
+// The time complexity is O(|E|⋅√|E| + |V|), where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func LocalClustering(g Iterator, workers int) []float64 {
+	count, adj := triangleCounts(g, workers)
+	res := make([]float64, len(count))
+	for v, t := range count {
+		if d := len(adj[v]); d >= 2 {
+			res[v] = 2 * float64(t) / float64(d*(d-1))
+		}
+	}
+	return res
+}
+
+// Transitivity returns the global clustering coefficient of g:
+// three times the number of triangles divided by the number of paths
+// of length two, or 0 if there are no such paths. The graph is treated
+// as undirected, and the work is split as in TriangleCounts.
+//
+// The time complexity is O(|E|⋅√|E| + |V|), where |E| is the number
+// of edges and |V| the number of vertices in the graph.
+func Transitivity(g Iterator, workers int) float64 {
+	count, adj := triangleCounts(g, workers)
+	triangles, paths := 0, 0
+	for v, t := range count {
+		d := len(adj[v])
+		triangles += t
+		paths += d * (d - 1) / 2
+	}
+	if paths == 0 {
+		return 0
+	}
+	return float64(triangles) / float64(paths)
+}
+
+// triangleCounts returns the number of triangles at each vertex,
+// and the undirected neighbor lists of g.
+func triangleCounts(g Iterator, workers int) (count []int, adj [][]int) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	adj = undirected(g)
+	n := len(adj)
+	// less tells if v comes before w in degree order.
+	less := func(v, w int) bool {
+		if len(adj[v]) != len(adj[w]) {
+			return len(adj[v]) < len(adj[w])
+		}
+		return v < w
+	}
+	// out[v] holds the neighbors of v that come after v.
+	out := make([][]int, n)
+	for v, list := range adj {
+		for _, w := range list {
+			if less(v, w) {
+				out[v] = append(out[v], w)
+			}
+		}
+	}
+	count = make([]int, n)
+	var mu sync.Mutex
+	parallel(n, workers, func(lo, hi int) int {
+		local := make([]int, n)
+		mark := make([]int, n) // mark[w] is v+1 if w is in out[v]
+		for v := lo; v < hi; v++ {
+			for _, w := range out[v] {
+				mark[w] = v + 1
+			}
+			for _, u := range out[v] {
+				for _, w := range out[u] {
+					if mark[w] == v+1 {
+						local[v]++
+						local[u]++
+						local[w]++
+					}
+				}
+			}
+		}
+		mu.Lock()
+		for v, t := range local {
+			count[v] += t
+		}
+		mu.Unlock()
+		return -1
+	})
+	return count, adj
+}
@@ -0,0 +1,83 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTriangles(t *testing.T) {
+	// Two triangles 0-1-2 and 1-2-3 sharing an edge, a pendant vertex 4,
+	// plus a self-loop and a duplicate directed edge that are ignored.
+	g := New(6)
+	g.AddBoth(0, 1)
+	g.AddBoth(0, 2)
+	g.AddBoth(1, 2)
+	g.AddBoth(1, 3)
+	g.Add(2, 3)
+	g.AddBoth(3, 4)
+	g.Add(4, 4)
+	for _, workers := range []int{1, 2, 0} {
+		if mess, diff := diff(Triangles(g, workers), 2); diff {
+			t.Errorf("Triangles %s", mess)
+		}
+		if mess, diff := diff(TriangleCounts(g, workers), []int{1, 2, 2, 1, 0, 0}); diff {
+			t.Errorf("TriangleCounts %s", mess)
+		}
+	}
+	local := LocalClustering(g, 1)
+	exp := []float64{1, 2.0 / 3, 2.0 / 3, 1.0 / 3, 0, 0}
+	for v := range exp {
+		if math.Abs(local[v]-exp[v]) > 1e-12 {
+			t.Errorf("LocalClustering %v; want %v", local, exp)
+			break
+		}
+	}
+	// 6 triangle corners and 1+3+3+3+0 paths of length 2.
+	if res := Transitivity(g, 1); math.Abs(res-6.0/10) > 1e-12 {
+		t.Errorf("Transitivity %v; want %v", res, 0.6)
+	}
+	if mess, diff := diff(Transitivity(New(3), 1), 0.0); diff {
+		t.Errorf("Transitivity %s", mess)
+	}
+}
+
+func TestTrianglesRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		n := 1 + r.Intn(40)
+		g := New(n)
+		for j := r.Intn(5 * n); j > 0; j-- {
+			g.AddBoth(r.Intn(n), r.Intn(n))
+		}
+		exp := make([]int, n)
+		for u := 0; u < n; u++ {
+			for v := u + 1; v < n; v++ {
+				for w := v + 1; w < n; w++ {
+					if g.Edge(u, v) && g.Edge(v, w) && g.Edge(u, w) {
+						exp[u]++
+						exp[v]++
+						exp[w]++
+					}
+				}
+			}
+		}
+		if mess, diff := diff(TriangleCounts(g, 4), exp); diff {
+			t.Errorf("TriangleCounts %s", mess)
+		}
+	}
+}
+
+func BenchmarkTriangles(b *testing.B) {
+	n := 10000
+	g := New(n)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10*n; i++ {
+		g.AddBoth(r.Intn(n), r.Intn(n))
+	}
+	h := Sort(g)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Triangles(h, 0)
+	}
+}
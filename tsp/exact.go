@@ -0,0 +1,135 @@
+package tsp
+
+import (
+	"github.com/yourbasic/graph"
+	"strconv"
+)
+
+// HeldKarp computes an optimal tour in g, starting at vertex 0.
+// If g has no tour, it returns an empty tour and sets ok to false.
+// A graph with one vertex has the tour [0] of cost 0.
+//
+// The implementation uses the dynamic programming algorithm of Held and
+// Karp. It panics if g has more than MaxExact vertices. The time
+// complexity is O(2^|V|⋅|V|²), where |V| is the number of vertices.
+func HeldKarp(g graph.Iterator) (tour []int, cost int64, ok bool) {
+	m := newExactMatrix(g)
+	n := m.n
+	if n == 0 {
+		return []int{}, 0, true
+	}
+	if n == 1 {
+		return []int{0}, 0, true
+	}
+	// dist[S<<k+j] is the cost of a shortest path from 0 that visits
+	// the vertices in S and ends at j+1, where S is a subset of the
+	// vertices 1..n-1 with bit j for vertex j+1.
+	k := n - 1
+	dist, parent := m.paths(k, func(j int) int64 { return m.at(0, j+1) }, 1)
+	end, full := -1, 1<<k-1
+	cost = graph.Max
+	for j := 0; j < k; j++ {
+		d, c := dist[full*k+j], m.at(j+1, 0)
+		if d != graph.Max && c != graph.Max && d+c < cost {
+			end, cost = j, d+c
+		}
+	}
+	if end == -1 {
+		return []int{}, 0, false
+	}
+	tour = append(walkBack(parent, k, full, end, 1), 0)
+	reverse(tour)
+	return tour, cost, true
+}
+
+// HamiltonianPath computes a path of minimum total cost in g that visits
+// each vertex exactly once, with any start and end. If there is no such
+// path, it returns an empty path and sets ok to false.
+//
+// It panics if g has more than MaxExact vertices. The time complexity
+// is O(2^|V|⋅|V|²), where |V| is the number of vertices.
+func HamiltonianPath(g graph.Iterator) (path []int, cost int64, ok bool) {
+	m := newExactMatrix(g)
+	n := m.n
+	if n == 0 {
+		return []int{}, 0, true
+	}
+	dist, parent := m.paths(n, func(int) int64 { return 0 }, 0)
+	end, full := -1, 1<<n-1
+	cost = graph.Max
+	for j := 0; j < n; j++ {
+		if d := dist[full*n+j]; d < cost {
+			end, cost = j, d
+		}
+	}
+	if cost == graph.Max {
+		return []int{}, 0, false
+	}
+	path = walkBack(parent, n, full, end, 0)
+	reverse(path)
+	return path, cost, true
+}
+
+func newExactMatrix(g graph.Iterator) *matrix {
+	if n := g.Order(); n > MaxExact {
+		panic("too many vertices: " + strconv.Itoa(n))
+	}
+	return newMatrix(g)
+}
+
+// paths computes the shortest paths through all subsets of k vertices,
+// numbered j+offset for j in 0..k-1, starting with the cost first(j)
+// for the subset {j}. The value graph.Max means no path.
+func (m *matrix) paths(k int, first func(j int) int64, offset int) (dist []int64, parent []int8) {
+	dist = make([]int64, k<<k)
+	parent = make([]int8, k<<k)
+	for i := range dist {
+		dist[i] = graph.Max
+	}
+	for j := 0; j < k; j++ {
+		dist[(1<<j)*k+j] = first(j)
+		parent[(1<<j)*k+j] = -1
+	}
+	for set := 1; set < 1<<k; set++ {
+		for j := 0; j < k; j++ {
+			d := dist[set*k+j]
+			if d == graph.Max || set&(1<<j) == 0 {
+				continue
+			}
+			for i := 0; i < k; i++ {
+				if set&(1<<i) != 0 {
+					continue
+				}
+				c := m.at(j+offset, i+offset)
+				if c == graph.Max {
+					continue
+				}
+				next := (set | 1<<i) * k
+				if d+c < dist[next+i] {
+					dist[next+i] = d + c
+					parent[next+i] = int8(j)
+				}
+			}
+		}
+	}
+	return
+}
+
+// walkBack returns the vertices of the path ending at j+offset
+// through the subset set, from the end to the start.
+func walkBack(parent []int8, k, set, j, offset int) []int {
+	var path []int
+	for j != -1 {
+		path = append(path, j+offset)
+		p := int(parent[set*k+j])
+		set &^= 1 << j
+		j = p
+	}
+	return path
+}
+
+func reverse(a []int) {
+	for i, j := 0, len(a)-1; i < j; i, j = i+1, j-1 {
+		a[i], a[j] = a[j], a[i]
+	}
+}
@@ -0,0 +1,100 @@
+package tsp
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"testing"
+)
+
+func TestHeldKarp(t *testing.T) {
+	for n, exp := range [][]int{{}, {0}} {
+		tour, cost, ok := HeldKarp(graph.New(n))
+		if mess, diff := diff(tour, exp); diff {
+			t.Errorf("HeldKarp->tour %s", mess)
+		}
+		if mess, diff := diff(cost, int64(0)); diff {
+			t.Errorf("HeldKarp->cost %s", mess)
+		}
+		if mess, diff := diff(ok, true); diff {
+			t.Errorf("HeldKarp->ok %s", mess)
+		}
+	}
+
+	// A directed graph with a single tour.
+	g := graph.New(4)
+	g.AddCost(0, 2, 1)
+	g.AddCost(2, 1, 2)
+	g.AddCost(1, 3, 3)
+	g.AddCost(3, 0, 4)
+	g.AddCost(0, 1, 1)
+	tour, cost, ok := HeldKarp(g)
+	if mess, diff := diff(tour, []int{0, 2, 1, 3}); diff {
+		t.Errorf("HeldKarp->tour %s", mess)
+	}
+	if mess, diff := diff(cost, int64(10)); diff {
+		t.Errorf("HeldKarp->cost %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("HeldKarp->ok %s", mess)
+	}
+
+	g.Delete(3, 0)
+	tour, cost, ok = HeldKarp(g)
+	if mess, diff := diff(tour, []int{}); diff {
+		t.Errorf("HeldKarp->tour %s", mess)
+	}
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("HeldKarp->ok %s", mess)
+	}
+
+	path, cost, ok := HamiltonianPath(g)
+	if mess, diff := diff(path, []int{0, 2, 1, 3}); diff {
+		t.Errorf("HamiltonianPath->path %s", mess)
+	}
+	if mess, diff := diff(cost, int64(6)); diff {
+		t.Errorf("HamiltonianPath->cost %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("HamiltonianPath->ok %s", mess)
+	}
+	g.Delete(1, 3)
+	path, _, ok = HamiltonianPath(g)
+	if mess, diff := diff(path, []int{}); diff {
+		t.Errorf("HamiltonianPath->path %s", mess)
+	}
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("HamiltonianPath->ok %s", mess)
+	}
+}
+
+func TestHeldKarpRandom(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		n := 1 + rand.Intn(7)
+		g := graph.New(n)
+		for m := rand.Intn(n*n + 1); m > 0; m-- {
+			g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(100))
+		}
+		tour, cost, ok := HeldKarp(g)
+		exp := bruteTour(g)
+		if ok != (exp != graph.Max) {
+			t.Errorf("HeldKarp->ok %t for %v", ok, g)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		checkTour(t, "HeldKarp", g, tour, cost)
+		if cost != exp {
+			t.Errorf("HeldKarp->cost %d; want %d", cost, exp)
+		}
+	}
+}
+
+func BenchmarkHeldKarp(b *testing.B) {
+	b.StopTimer()
+	g := points(15)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = HeldKarp(g)
+	}
+}
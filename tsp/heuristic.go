@@ -0,0 +1,174 @@
+package tsp
+
+import (
+	"github.com/yourbasic/graph"
+	"strconv"
+)
+
+// NearestNeighbor constructs a tour in g that starts at v and
+// repeatedly moves to the closest vertex not yet visited. If this gets
+// stuck, it returns an empty tour and sets ok to false.
+//
+// The tour is often about 25% longer than an optimal tour.
+// The time complexity is O(|V|²), where |V| is the number of vertices.
+func NearestNeighbor(g graph.Iterator, v int) (tour []int, cost int64, ok bool) {
+	m := newMatrix(g)
+	if v < 0 || v >= m.n {
+		panic("vertex out of range: " + strconv.Itoa(v))
+	}
+	visited := make([]bool, m.n)
+	visited[v] = true
+	tour = []int{v}
+	for len(tour) < m.n {
+		next, best := -1, graph.Max
+		for w := 0; w < m.n; w++ {
+			if c := m.at(v, w); !visited[w] && c < best {
+				next, best = w, c
+			}
+		}
+		if next == -1 {
+			return []int{}, 0, false
+		}
+		visited[next] = true
+		tour = append(tour, next)
+		v = next
+	}
+	if cost, ok = m.tourCost(tour); !ok {
+		return []int{}, 0, false
+	}
+	return tour, cost, true
+}
+
+// Christofides constructs a tour in a complete undirected graph whose
+// costs satisfy the triangle inequality. The tour costs at most 3/2
+// times the cost of an optimal tour, and starts at vertex 0.
+// If g isn't complete and undirected, it returns an empty tour
+// and sets ok to false.
+//
+// The algorithm combines a minimum spanning tree with a minimum cost
+// perfect matching of its vertices of odd degree, and shortcuts an Euler
+// circuit of the result. The time complexity is O(|V|³), where |V| is
+// the number of vertices.
+func Christofides(g graph.Iterator) (tour []int, cost int64, ok bool) {
+	m := newMatrix(g)
+	n := m.n
+	if n <= 1 {
+		return identity(n), 0, true
+	}
+	if !m.symmetric() {
+		return []int{}, 0, false
+	}
+	for i, c := range m.cost {
+		if c == graph.Max && i/n != i%n {
+			return []int{}, 0, false
+		}
+	}
+
+	// A minimum spanning tree by Prim's algorithm.
+	var edges []graph.Edge
+	degree := make([]int, n)
+	parent := make([]int, n)
+	dist := make([]int64, n)
+	inTree := make([]bool, n)
+	for v := range dist {
+		dist[v], parent[v] = m.at(0, v), 0
+	}
+	inTree[0] = true
+	for k := 1; k < n; k++ {
+		v := -1
+		for w := range dist {
+			if !inTree[w] && (v == -1 || dist[w] < dist[v]) {
+				v = w
+			}
+		}
+		inTree[v] = true
+		edges = append(edges, graph.Edge{V: parent[v], W: v})
+		degree[v]++
+		degree[parent[v]]++
+		for w := range dist {
+			if c := m.at(v, w); !inTree[w] && c < dist[w] {
+				dist[w], parent[w] = c, v
+			}
+		}
+	}
+
+	// A minimum cost perfect matching of the odd vertices is a maximum
+	// weight matching with weights total - cost, where total is larger
+	// than the cost of any matching.
+	var odd []int
+	for v, d := range degree {
+		if d&1 == 1 {
+			odd = append(odd, v)
+		}
+	}
+	total := int64(1)
+	for _, v := range odd {
+		for _, w := range odd {
+			total += m.at(v, w)
+		}
+	}
+	pairs := graph.New(len(odd))
+	for i, v := range odd {
+		for j, w := range odd {
+			if i != j {
+				pairs.AddCost(i, j, total-m.at(v, w))
+			}
+		}
+	}
+	matching, _ := graph.MaxWeightMatching(pairs)
+	for _, e := range matching {
+		edges = append(edges, graph.Edge{V: odd[e.V], W: odd[e.W]})
+	}
+
+	// Shortcut an Euler circuit.
+	visited := make([]bool, n)
+	tour = []int{}
+	for _, v := range eulerCircuit(n, edges) {
+		if !visited[v] {
+			visited[v] = true
+			tour = append(tour, v)
+		}
+	}
+	cost, _ = m.tourCost(tour)
+	return tour, cost, true
+}
+
+// eulerCircuit returns an Euler circuit of the connected undirected
+// multigraph with the given edges, starting at vertex 0.
+// All vertices must have even degree.
+func eulerCircuit(n int, edges []graph.Edge) (walk []int) {
+	adj := make([][]int, n) // The edges of each vertex.
+	for i, e := range edges {
+		adj[e.V] = append(adj[e.V], i)
+		adj[e.W] = append(adj[e.W], i)
+	}
+	used := make([]bool, len(edges))
+	next := make([]int, n) // The next edge of each vertex to try.
+	for stack := []int{0}; len(stack) > 0; {
+		v := stack[len(stack)-1]
+		for next[v] < len(adj[v]) && used[adj[v][next[v]]] {
+			next[v]++
+		}
+		if next[v] == len(adj[v]) {
+			walk = append(walk, v)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		i := adj[v][next[v]]
+		used[i] = true
+		w := edges[i].V
+		if w == v {
+			w = edges[i].W
+		}
+		stack = append(stack, w)
+	}
+	return
+}
+
+func identity(n int) []int {
+	a := make([]int, n)
+	for i := range a {
+		a[i] = i
+	}
+	return a
+}
@@ -0,0 +1,94 @@
+package tsp
+
+import (
+	"github.com/yourbasic/graph"
+	"testing"
+)
+
+func TestNearestNeighbor(t *testing.T) {
+	g := graph.New(4)
+	g.AddBothCost(0, 1, 1)
+	g.AddBothCost(1, 2, 1)
+	g.AddBothCost(2, 3, 1)
+	g.AddBothCost(0, 2, 5)
+	tour, _, ok := NearestNeighbor(g, 0)
+	if mess, diff := diff(tour, []int{}); diff {
+		t.Errorf("NearestNeighbor->tour %s", mess)
+	}
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("NearestNeighbor->ok %s", mess)
+	}
+	g.AddBothCost(3, 0, 1)
+	tour, cost, ok := NearestNeighbor(g, 1)
+	if mess, diff := diff(tour, []int{1, 0, 3, 2}); diff {
+		t.Errorf("NearestNeighbor->tour %s", mess)
+	}
+	if mess, diff := diff(cost, int64(4)); diff {
+		t.Errorf("NearestNeighbor->cost %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("NearestNeighbor->ok %s", mess)
+	}
+}
+
+func TestChristofides(t *testing.T) {
+	for n, exp := range [][]int{{}, {0}} {
+		tour, cost, ok := Christofides(graph.New(n))
+		if mess, diff := diff(tour, exp); diff {
+			t.Errorf("Christofides->tour %s", mess)
+		}
+		if mess, diff := diff(cost, int64(0)); diff {
+			t.Errorf("Christofides->cost %s", mess)
+		}
+		if mess, diff := diff(ok, true); diff {
+			t.Errorf("Christofides->ok %s", mess)
+		}
+	}
+	g := graph.New(3)
+	g.AddBothCost(0, 1, 1)
+	g.AddBothCost(1, 2, 1)
+	_, _, ok := Christofides(g)
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("Christofides->ok %s", mess)
+	}
+	g.AddCost(0, 2, 1)
+	_, _, ok = Christofides(g)
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("Christofides->ok %s", mess)
+	}
+}
+
+func TestHeuristicsRandom(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		n := 2 + i%7
+		g := points(n)
+		opt := bruteTour(g)
+		tour, cost, ok := Christofides(g)
+		if !ok {
+			t.Errorf("Christofides->ok false")
+			continue
+		}
+		checkTour(t, "Christofides", g, tour, cost)
+		if 2*cost > 3*opt {
+			t.Errorf("Christofides->cost %d; optimal %d", cost, opt)
+		}
+		tour, cost, ok = NearestNeighbor(g, n-1)
+		if !ok {
+			t.Errorf("NearestNeighbor->ok false")
+			continue
+		}
+		checkTour(t, "NearestNeighbor", g, tour, cost)
+		if cost < opt {
+			t.Errorf("NearestNeighbor->cost %d; optimal %d", cost, opt)
+		}
+	}
+}
+
+func BenchmarkChristofides(b *testing.B) {
+	b.StopTimer()
+	g := points(200)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = Christofides(g)
+	}
+}
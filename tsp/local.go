@@ -0,0 +1,142 @@
+package tsp
+
+import "github.com/yourbasic/graph"
+
+// TwoOpt improves a tour by 2-opt local search: it repeatedly replaces
+// two edges of the tour by two cheaper edges, reversing the path between
+// them, until no such move remains. If the costs of g are asymmetric,
+// the cost of the reversed path is included in the comparison.
+// It returns the improved tour, a new slice, and its cost.
+// The tour must be a permutation of g's vertices; a missing edge
+// is worse than any edge, and the cost is graph.Max if the improved
+// tour still uses a missing edge. The tour may start at a different vertex.
+//
+// Each round of the search takes O(|V|²) time, or O(|V|³) time if the
+// costs are asymmetric, where |V| is the number of vertices.
+func TwoOpt(g graph.Iterator, tour []int) (improved []int, cost int64) {
+	m := newMatrix(g)
+	sym := m.symmetric()
+	t := append([]int{}, tour...)
+	n := len(t)
+	for improving := n >= 4; improving; {
+		improving = false
+		for i := 0; i < n-1; i++ {
+			for j := i + 2; j < n; j++ {
+				if i == 0 && j == n-1 {
+					continue
+				}
+				// Replace (a, b) and (c, d) by (a, c) and (b, d).
+				a, b, c, d := t[i], t[i+1], t[j], t[(j+1)%n]
+				old := []int64{m.at(a, b), m.at(c, d)}
+				alt := []int64{m.at(a, c), m.at(b, d)}
+				if !sym {
+					for k := i + 1; k < j; k++ {
+						old = append(old, m.at(t[k], t[k+1]))
+						alt = append(alt, m.at(t[k+1], t[k]))
+					}
+				}
+				if better(old, alt) {
+					reverse(t[i+1 : j+1])
+					improving = true
+				}
+			}
+		}
+	}
+	return t, m.costOrMax(t)
+}
+
+// OrOpt improves a tour by Or-opt local search: it repeatedly moves
+// a segment of one to three consecutive vertices, possibly reversed,
+// to a cheaper position in the tour, until no such move remains.
+// If the costs of g are asymmetric, segments are not reversed. It returns the improved tour, a new slice, and its cost,
+// which are as in TwoOpt.
+//
+// Each round of the search takes O(|V|²) time,
+// where |V| is the number of vertices.
+func OrOpt(g graph.Iterator, tour []int) (improved []int, cost int64) {
+	m := newMatrix(g)
+	revs := []bool{false}
+	if m.symmetric() {
+		revs = append(revs, true)
+	}
+	t := append([]int{}, tour...)
+	n := len(t)
+	for improving := true; improving; {
+		improving = false
+		for length := 1; length <= 3 && length+2 <= n; length++ {
+			for i := 0; i < n; i++ {
+				if orMove(m, t, i, length, revs) {
+					improving = true
+				}
+			}
+		}
+	}
+	return t, m.costOrMax(t)
+}
+
+// orMove tries to move the segment of the given length starting at
+// position i of t, reversed or not as listed in revs.
+// It returns true if it made an improving move.
+func orMove(m *matrix, t []int, i, length int, revs []bool) bool {
+	n := len(t)
+	at := func(k int) int { return t[(i+k+n)%n] }
+	// The segment s..e lies between p and q.
+	p, s, e, q := at(-1), at(0), at(length-1), at(length)
+	for k := length; k < n-1; k++ {
+		// Insert the segment between a and b.
+		a, b := at(k), at(k+1)
+		for _, rev := range revs {
+			x, y := s, e
+			if rev {
+				x, y = e, s
+			}
+			old := []int64{m.at(p, s), m.at(e, q), m.at(a, b)}
+			if !better(old, []int64{m.at(p, q), m.at(a, x), m.at(y, b)}) {
+				continue
+			}
+			seg := make([]int, length)
+			rest := make([]int, 0, n-length)
+			for j := 0; j < length; j++ {
+				seg[j] = at(j)
+			}
+			if rev {
+				reverse(seg)
+			}
+			for j := length; j < n; j++ {
+				rest = append(rest, at(j))
+				if j == k {
+					rest = append(rest, seg...)
+				}
+			}
+			copy(t, rest)
+			return true
+		}
+	}
+	return false
+}
+
+func (m *matrix) costOrMax(tour []int) int64 {
+	if cost, ok := m.tourCost(tour); ok {
+		return cost
+	}
+	return graph.Max
+}
+
+// better tells if the edges with the costs alt are cheaper than the
+// edges with the costs old, where graph.Max stands for a missing edge.
+func better(old, alt []int64) bool {
+	var a, b int64
+	for _, c := range alt {
+		if c == graph.Max {
+			return false
+		}
+		b += c
+	}
+	for _, c := range old {
+		if c == graph.Max {
+			return true
+		}
+		a += c
+	}
+	return b < a
+}
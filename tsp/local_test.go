@@ -0,0 +1,142 @@
+package tsp
+
+import (
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"testing"
+)
+
+var searches = []struct {
+	name string
+	f    func(graph.Iterator, []int) ([]int, int64)
+}{
+	{"TwoOpt", TwoOpt},
+	{"OrOpt", OrOpt},
+}
+
+func TestLocalSearch(t *testing.T) {
+	// A square with crossing diagonals in the tour.
+	g := graph.New(4)
+	g.AddBothCost(0, 1, 1)
+	g.AddBothCost(1, 2, 1)
+	g.AddBothCost(2, 3, 1)
+	g.AddBothCost(3, 0, 1)
+	g.AddBothCost(0, 2, 2)
+	g.AddBothCost(1, 3, 2)
+	for _, s := range searches {
+		for _, tour := range [][]int{{}, {0}, {0, 1}} {
+			res, cost := s.f(graph.New(len(tour)), tour)
+			if mess, diff := diff(res, tour); diff {
+				t.Errorf("%s->tour %s", s.name, mess)
+			}
+			if mess, diff := diff(cost, int64(0)); diff && len(tour) < 2 {
+				t.Errorf("%s->cost %s", s.name, mess)
+			}
+		}
+		tour := []int{0, 2, 1, 3}
+		res, cost := s.f(g, tour)
+		checkTour(t, s.name, g, res, cost)
+		if mess, diff := diff(cost, int64(4)); diff {
+			t.Errorf("%s->cost %s", s.name, mess)
+		}
+		if mess, diff := diff(tour, []int{0, 2, 1, 3}); diff {
+			t.Errorf("%s modified its input %s", s.name, mess)
+		}
+	}
+
+	// A missing edge.
+	g.Delete(0, 2)
+	g.Delete(2, 0)
+	res, cost := TwoOpt(g, []int{0, 2, 1, 3})
+	checkTour(t, "TwoOpt", g, res, cost)
+	g.Delete(0, 1)
+	g.Delete(1, 0)
+	_, cost = TwoOpt(g, []int{0, 2, 1, 3})
+	if mess, diff := diff(cost, graph.Max); diff {
+		t.Errorf("TwoOpt->cost %s", mess)
+	}
+
+	// Asymmetric costs, for which the 2-opt gain of the
+	// boundary edges alone can make the search cycle.
+	cost6 := [][]int64{
+		{0, 7, 5, 8, 3, 4},
+		{0, 4, 6, 8, 7, 1},
+		{8, 2, 6, 0, 0, 2},
+		{5, 2, 9, 6, 0, 0},
+		{5, 0, 5, 6, 0, 3},
+		{5, 7, 3, 1, 0, 0},
+	}
+	g = graph.New(6)
+	for v, row := range cost6 {
+		for w, c := range row {
+			if v != w {
+				g.AddCost(v, w, c)
+			}
+		}
+	}
+	for _, s := range searches {
+		tour := []int{0, 1, 3, 2, 4, 5}
+		start, _ := Cost(g, tour)
+		res, cost := s.f(g, tour)
+		checkTour(t, s.name, g, res, cost)
+		if cost > start {
+			t.Errorf("%s->cost %d; start %d", s.name, cost, start)
+		}
+	}
+}
+
+func TestLocalSearchRandom(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		n := 1 + rand.Intn(40)
+		g := points(n)
+		if i%2 == 1 {
+			g = asymmetric(n)
+		}
+		tour := rand.Perm(n)
+		start, _ := Cost(g, tour)
+		for _, s := range searches {
+			res, cost := s.f(g, tour)
+			checkTour(t, s.name, g, res, cost)
+			if cost > start {
+				t.Errorf("%s->cost %d; start %d", s.name, cost, start)
+			}
+			// The result is a local optimum.
+			if _, again := s.f(g, res); again != cost {
+				t.Errorf("%s: %d after another search; want %d", s.name, again, cost)
+			}
+		}
+	}
+}
+
+// asymmetric returns a complete directed graph with random costs.
+func asymmetric(n int) *graph.Mutable {
+	g := graph.New(n)
+	for v := 0; v < n; v++ {
+		for w := 0; w < n; w++ {
+			if v != w {
+				g.AddCost(v, w, rand.Int63n(100))
+			}
+		}
+	}
+	return g
+}
+
+func BenchmarkTwoOpt(b *testing.B) {
+	b.StopTimer()
+	g := points(200)
+	tour := rand.Perm(200)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = TwoOpt(g, tour)
+	}
+}
+
+func BenchmarkOrOpt(b *testing.B) {
+	b.StopTimer()
+	g := points(200)
+	tour := rand.Perm(200)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = OrOpt(g, tour)
+	}
+}
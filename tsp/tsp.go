@@ -0,0 +1,86 @@
+// Package tsp solves the traveling salesman problem: finding a cycle
+// of minimum total cost that visits each vertex of a graph exactly once.
+//
+// A tour is given as a list of vertices, with an edge from each vertex
+// to the next and from the last vertex back to the first. The cost of
+// a tour is the sum of the costs of these edges. If there are several
+// edges between two vertices, the one with the smallest cost is used.
+//
+// HeldKarp finds an optimal tour in exponential time, and works for
+// graphs with at most MaxExact vertices. For larger graphs,
+// NearestNeighbor and Christofides construct a tour, which TwoOpt
+// and OrOpt can improve by local search.
+package tsp
+
+import (
+	"github.com/yourbasic/graph"
+	"strconv"
+)
+
+// MaxExact is the largest number of vertices accepted by HeldKarp
+// and HamiltonianPath.
+const MaxExact = 20
+
+// Cost returns the cost of a tour in g. If the tour uses a missing edge,
+// it returns 0 and sets ok to false.
+func Cost(g graph.Iterator, tour []int) (cost int64, ok bool) {
+	return newMatrix(g).tourCost(tour)
+}
+
+// matrix holds the smallest cost of an edge between each pair of vertices,
+// or graph.Max if there is no edge.
+type matrix struct {
+	n    int
+	cost []int64
+}
+
+func newMatrix(g graph.Iterator) *matrix {
+	n := g.Order()
+	m := &matrix{n: n, cost: make([]int64, n*n)}
+	for i := range m.cost {
+		m.cost[i] = graph.Max
+	}
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if w < 0 || w >= n {
+				panic("vertex out of range: " + strconv.Itoa(w))
+			}
+			if c < m.cost[v*n+w] {
+				m.cost[v*n+w] = c
+			}
+			return
+		})
+	}
+	return m
+}
+
+func (m *matrix) at(v, w int) int64 {
+	return m.cost[v*m.n+w]
+}
+
+func (m *matrix) tourCost(tour []int) (cost int64, ok bool) {
+	if len(tour) == 1 {
+		return 0, true
+	}
+	for i, v := range tour {
+		c := m.at(v, tour[(i+1)%len(tour)])
+		if c == graph.Max {
+			return 0, false
+		}
+		cost += c
+	}
+	return cost, true
+}
+
+// symmetric tells if the cost of (v, w) equals the cost of (w, v)
+// for all vertices v and w.
+func (m *matrix) symmetric() bool {
+	for v := 0; v < m.n; v++ {
+		for w := v + 1; w < m.n; w++ {
+			if m.at(v, w) != m.at(w, v) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package tsp
+
+import (
+	"fmt"
+	"github.com/yourbasic/graph"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func diff(res, exp interface{}) (message string, diff bool) {
+	if !reflect.DeepEqual(res, exp) {
+		message = fmt.Sprintf("%v; want %v", res, exp)
+		diff = true
+	}
+	return
+}
+
+// points returns a complete undirected graph of n random points
+// in the plane, with Manhattan distances as costs.
+func points(n int) *graph.Mutable {
+	x, y := make([]int64, n), make([]int64, n)
+	for v := range x {
+		x[v], y[v] = rand.Int63n(1000), rand.Int63n(1000)
+	}
+	abs := func(a int64) int64 {
+		if a < 0 {
+			return -a
+		}
+		return a
+	}
+	g := graph.New(n)
+	for v := 0; v < n; v++ {
+		for w := v + 1; w < n; w++ {
+			g.AddBothCost(v, w, abs(x[v]-x[w])+abs(y[v]-y[w]))
+		}
+	}
+	return g
+}
+
+// checkTour checks that tour is a tour in g of the given cost.
+func checkTour(t *testing.T, name string, g graph.Iterator, tour []int, cost int64) {
+	seen := make([]bool, g.Order())
+	for _, v := range tour {
+		if v < 0 || v >= len(seen) || seen[v] {
+			t.Errorf("%s: %v isn't a permutation", name, tour)
+			return
+		}
+		seen[v] = true
+	}
+	if len(tour) != g.Order() {
+		t.Errorf("%s: %v isn't a permutation", name, tour)
+		return
+	}
+	if c, ok := Cost(g, tour); !ok || c != cost {
+		t.Errorf("%s: cost of %v is %d, %t; want %d", name, tour, c, ok, cost)
+	}
+}
+
+// bruteTour returns the cost of an optimal tour in g,
+// or graph.Max if there is none.
+func bruteTour(g graph.Iterator) int64 {
+	n := g.Order()
+	best := graph.Max
+	tour := identity(n)
+	var permute func(k int)
+	permute = func(k int) {
+		if k == n {
+			if c, ok := Cost(g, tour); ok && c < best {
+				best = c
+			}
+			return
+		}
+		for i := k; i < n; i++ {
+			tour[k], tour[i] = tour[i], tour[k]
+			permute(k + 1)
+			tour[k], tour[i] = tour[i], tour[k]
+		}
+	}
+	if n > 0 {
+		permute(1)
+	}
+	return best
+}
+
+func TestCost(t *testing.T) {
+	g := graph.New(3)
+	g.AddCost(0, 1, 1)
+	g.AddCost(1, 2, 2)
+	cost, ok := Cost(g, []int{0, 1, 2})
+	if mess, diff := diff(cost, int64(0)); diff {
+		t.Errorf("Cost->cost %s", mess)
+	}
+	if mess, diff := diff(ok, false); diff {
+		t.Errorf("Cost->ok %s", mess)
+	}
+	g.AddCost(2, 0, 3)
+	cost, ok = Cost(g, []int{0, 1, 2})
+	if mess, diff := diff(cost, int64(6)); diff {
+		t.Errorf("Cost->cost %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("Cost->ok %s", mess)
+	}
+	cost, ok = Cost(g, []int{1})
+	if mess, diff := diff(cost, int64(0)); diff {
+		t.Errorf("Cost->cost %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("Cost->ok %s", mess)
+	}
+}
@@ -0,0 +1,180 @@
+package graph
+
+import "strconv"
+
+// Undirected represents an undirected graph with weighted edges
+// that can be added or removed. The vertices are numbered from 0 to n-1.
+//
+// Unlike a Mutable graph with edges added by AddBoth, each edge is stored
+// only once, so the costs in the two directions can't get out of sync,
+// and Visit reports the edge from both of its endpoints.
+// A self-loop is visited once. An immutable copy of the graph,
+// with the same edges in both directions, can be made with Sort.
+type Undirected struct {
+	// adj[v] lists the IDs of the edges at v.
+	adj   [][]int
+	edges []undirectedEdge
+	ids   map[[2]int]int // ids[undirectedKey(v, w)] is the ID of the edge {v, w}
+	free  []int          // the IDs of deleted edges, available for reuse
+}
+
+type undirectedEdge struct {
+	ends [2]int
+	cost int64
+	pos  [2]int // the position of the edge in adj[ends[0]] and adj[ends[1]]
+}
+
+// NewUndirected constructs a new undirected graph with n vertices,
+// numbered from 0 to n-1, and no edges.
+func NewUndirected(n int) *Undirected {
+	return &Undirected{adj: make([][]int, n), ids: make(map[[2]int]int)}
+}
+
+// String returns a string representation of the graph.
+func (g *Undirected) String() string {
+	return String(g)
+}
+
+// Order returns the number of vertices in the graph.
+func (g *Undirected) Order() int {
+	return len(g.adj)
+}
+
+// Size returns the number of edges in the graph, each counted once.
+func (g *Undirected) Size() int {
+	return len(g.ids)
+}
+
+// Visit calls the do function for each neighbor w of v,
+// with c equal to the cost of the edge between v and w.
+// If do returns true, Visit returns immediately,
+// skipping any remaining neighbors, and returns true.
+//
+// The iteration order is not specified. It is not safe to add
+// or delete edges during a call to this method.
+func (g *Undirected) Visit(v int, do func(w int, c int64) bool) bool {
+	for _, id := range g.adj[v] {
+		e := &g.edges[id]
+		w := e.ends[0]
+		if w == v {
+			w = e.ends[1]
+		}
+		if do(w, e.cost) {
+			return true
+		}
+	}
+	return false
+}
+
+// VisitEdges calls the do function once for each edge {v, w}
+// of cost c, where v ≤ w, in order of increasing v.
+// If do returns true, VisitEdges returns immediately,
+// skipping any remaining edges, and returns true.
+func (g *Undirected) VisitEdges(do func(v, w int, c int64) bool) bool {
+	for v, ids := range g.adj {
+		for _, id := range ids {
+			e := &g.edges[id]
+			if e.ends[0] == v && do(v, e.ends[1], e.cost) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Degree returns the number of edges at v. A self-loop is counted once.
+func (g *Undirected) Degree(v int) int {
+	return len(g.adj[v])
+}
+
+// Edge tells if there is an edge between v and w.
+func (g *Undirected) Edge(v, w int) bool {
+	_, ok := g.ids[undirectedKey(v, w)]
+	return ok
+}
+
+// Cost returns the cost of the edge between v and w,
+// or 0 if no such edge exists.
+func (g *Undirected) Cost(v, w int) int64 {
+	if id, ok := g.ids[undirectedKey(v, w)]; ok {
+		return g.edges[id].cost
+	}
+	return 0
+}
+
+// Add inserts an edge with zero cost between v and w.
+// It removes the previous cost if this edge already exists.
+func (g *Undirected) Add(v, w int) {
+	g.AddCost(v, w, 0)
+}
+
+// AddCost inserts an edge with cost c between v and w.
+// It overwrites the previous cost if this edge already exists.
+func (g *Undirected) AddCost(v, w int, c int64) {
+	n := len(g.adj)
+	if v < 0 || v >= n {
+		panic("vertex out of range: " + strconv.Itoa(v))
+	}
+	if w < 0 || w >= n {
+		panic("vertex out of range: " + strconv.Itoa(w))
+	}
+	key := undirectedKey(v, w)
+	if id, ok := g.ids[key]; ok {
+		g.edges[id].cost = c
+		return
+	}
+	e := undirectedEdge{ends: key, cost: c}
+	var id int
+	if k := len(g.free); k > 0 {
+		id = g.free[k-1]
+		g.free = g.free[:k-1]
+	} else {
+		id = len(g.edges)
+		g.edges = append(g.edges, e)
+	}
+	e.pos[0] = len(g.adj[key[0]])
+	g.adj[key[0]] = append(g.adj[key[0]], id)
+	if key[1] != key[0] {
+		e.pos[1] = len(g.adj[key[1]])
+		g.adj[key[1]] = append(g.adj[key[1]], id)
+	} else {
+		e.pos[1] = e.pos[0] // A self-loop is listed once.
+	}
+	g.edges[id] = e
+	g.ids[key] = id
+}
+
+// Delete removes the edge between v and w.
+func (g *Undirected) Delete(v, w int) {
+	key := undirectedKey(v, w)
+	id, ok := g.ids[key]
+	if !ok {
+		return
+	}
+	delete(g.ids, key)
+	e := g.edges[id]
+	for i, x := range key {
+		if i == 1 && key[1] == key[0] {
+			break
+		}
+		// Move the last edge at x into the free position.
+		list := g.adj[x]
+		last := list[len(list)-1]
+		list[e.pos[i]] = last
+		for j, y := range g.edges[last].ends {
+			if y == x {
+				g.edges[last].pos[j] = e.pos[i]
+			}
+		}
+		g.adj[x] = list[:len(list)-1]
+	}
+	g.edges[id] = undirectedEdge{}
+	g.free = append(g.free, id)
+}
+
+// AddVertex adds a vertex without edges to the graph and returns it.
+// The new vertex is n, and the order of the graph grows to n+1.
+func (g *Undirected) AddVertex() int {
+	g.adj = append(g.adj, nil)
+	return len(g.adj) - 1
+}
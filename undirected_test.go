@@ -0,0 +1,86 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestUndirected(t *testing.T) {
+	g := NewUndirected(4)
+	g.Add(0, 1)
+	g.AddCost(2, 1, 3)
+	g.AddCost(3, 3, 5)
+	if mess, diff := diff(g.String(), "4 [{0 1} {1 2}:3 (3 3):5]"); diff {
+		t.Errorf("NewUndirected %s", mess)
+	}
+	Consistent("Undirected", t, g)
+	if mess, diff := diff(g.Size(), 3); diff {
+		t.Errorf("Size %s", mess)
+	}
+	if mess, diff := diff([]int{g.Degree(0), g.Degree(1), g.Degree(3)}, []int{1, 2, 1}); diff {
+		t.Errorf("Degree %s", mess)
+	}
+	if !g.Edge(1, 2) || !g.Edge(2, 1) || g.Edge(0, 2) {
+		t.Errorf("Edge: wrong result")
+	}
+	g.AddCost(1, 2, 4)
+	if mess, diff := diff([]int64{g.Cost(1, 2), g.Cost(2, 1), g.Cost(0, 3)}, []int64{4, 4, 0}); diff {
+		t.Errorf("Cost %s", mess)
+	}
+
+	var edges []Edge
+	g.VisitEdges(func(v, w int, c int64) (skip bool) {
+		edges = append(edges, Edge{v, w, c})
+		return
+	})
+	if mess, diff := diff(edges, []Edge{{0, 1, 0}, {1, 2, 4}, {3, 3, 5}}); diff {
+		t.Errorf("VisitEdges %s", mess)
+	}
+
+	g.Delete(1, 0)
+	g.Delete(3, 3)
+	g.Delete(0, 3)
+	if mess, diff := diff(g.String(), "4 [{1 2}:4]"); diff {
+		t.Errorf("Delete %s", mess)
+	}
+	if mess, diff := diff(g.AddVertex(), 4); diff {
+		t.Errorf("AddVertex %s", mess)
+	}
+	g.Add(4, 1)
+	if mess, diff := diff(g.String(), "5 [{1 2}:4 {1 4}]"); diff {
+		t.Errorf("AddVertex %s", mess)
+	}
+	Consistent("Undirected", t, g)
+}
+
+func TestUndirectedRandom(t *testing.T) {
+	n := 10
+	g := NewUndirected(n)
+	exp := New(n) // the same graph with edges in both directions
+	for i := 0; i < 2000; i++ {
+		v, w := rand.Intn(n), rand.Intn(n)
+		if rand.Intn(3) == 0 {
+			g.Delete(v, w)
+			exp.DeleteBoth(v, w)
+		} else {
+			c := rand.Int63n(10)
+			g.AddCost(v, w, c)
+			exp.AddBothCost(v, w, c)
+		}
+	}
+	if mess, diff := diff(g.String(), exp.String()); diff {
+		t.Errorf("Undirected %s", mess)
+	}
+	Consistent("Undirected", t, g)
+	size := 0
+	for v := 0; v < n; v++ {
+		for w := v; w < n; w++ {
+			if exp.Edge(v, w) {
+				size++
+			}
+		}
+	}
+	if mess, diff := diff(g.Size(), size); diff {
+		t.Errorf("Size %s", mess)
+	}
+}
@@ -0,0 +1,91 @@
+package graph
+
+// UnionFind is a disjoint-set data structure for the elements 0..n-1,
+// using path compression and union by rank. Any sequence of m operations
+// takes O(m⋅α(n)) time, where α is the very slowly growing inverse
+// of the Ackermann function.
+type UnionFind struct {
+	parent []int
+	rank   []uint8
+	count  int
+}
+
+// NewUnionFind returns a UnionFind with n singleton sets {0}, {1}, …, {n-1}.
+func NewUnionFind(n int) *UnionFind {
+	p := make([]int, n)
+	for v := range p {
+		p[v] = v
+	}
+	return &UnionFind{
+		parent: p,
+		rank:   make([]uint8, n),
+		count:  n,
+	}
+}
+
+// Len returns the number of elements.
+func (u *UnionFind) Len() int {
+	return len(u.parent)
+}
+
+// Count returns the number of disjoint sets.
+func (u *UnionFind) Count() int {
+	return u.count
+}
+
+// Find returns the representative of the set containing x.
+func (u *UnionFind) Find(x int) int {
+	p := u.parent
+	root := x
+	for root != p[root] {
+		root = p[root]
+	}
+	for p[x] != root {
+		p[x], x = root, p[x]
+	}
+	return root
+}
+
+// Same tells if x and y belong to the same set.
+func (u *UnionFind) Same(x, y int) bool {
+	return u.Find(x) == u.Find(y)
+}
+
+// Union merges the sets containing x and y.
+// It returns false if x and y already belong to the same set.
+func (u *UnionFind) Union(x, y int) bool {
+	x, y = u.Find(x), u.Find(y)
+	if x == y {
+		return false
+	}
+	switch {
+	case u.rank[x] < u.rank[y]:
+		x, y = y, x
+	case u.rank[x] == u.rank[y]:
+		u.rank[x]++
+	}
+	u.parent[y] = x
+	u.count--
+	return true
+}
+
+// Components returns the disjoint sets. Each set is sorted in increasing
+// order, and the sets are ordered by their smallest element.
+//
+// The time complexity is O(n⋅α(n)), where n is the number of elements.
+func (u *UnionFind) Components() [][]int {
+	index := make([]int, len(u.parent))
+	for i := range index {
+		index[i] = -1
+	}
+	sets := make([][]int, 0, u.count)
+	for v := range u.parent {
+		x := u.Find(v)
+		if index[x] == -1 {
+			index[x] = len(sets)
+			sets = append(sets, nil)
+		}
+		sets[index[x]] = append(sets[index[x]], v)
+	}
+	return sets
+}
@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestUnionFind(t *testing.T) {
+	u := NewUnionFind(0)
+	if mess, diff := diff(u.Components(), [][]int{}); diff {
+		t.Errorf("Components %s", mess)
+	}
+
+	u = NewUnionFind(5)
+	if mess, diff := diff(u.Union(0, 1), true); diff {
+		t.Errorf("Union %s", mess)
+	}
+	if mess, diff := diff(u.Union(3, 1), true); diff {
+		t.Errorf("Union %s", mess)
+	}
+	if mess, diff := diff(u.Union(0, 3), false); diff {
+		t.Errorf("Union %s", mess)
+	}
+	if u.Find(0) != u.Find(3) || u.Find(0) == u.Find(2) {
+		t.Errorf("Find: %d %d %d", u.Find(0), u.Find(2), u.Find(3))
+	}
+	if mess, diff := diff(u.Same(1, 3), true); diff {
+		t.Errorf("Same %s", mess)
+	}
+	if mess, diff := diff(u.Same(2, 4), false); diff {
+		t.Errorf("Same %s", mess)
+	}
+	u.Union(4, 2)
+	if mess, diff := diff(u.Count(), 2); diff {
+		t.Errorf("Count %s", mess)
+	}
+	if mess, diff := diff(u.Len(), 5); diff {
+		t.Errorf("Len %s", mess)
+	}
+	if mess, diff := diff(u.Components(), [][]int{{0, 1, 3}, {2, 4}}); diff {
+		t.Errorf("Components %s", mess)
+	}
+}
+
+func TestUnionFindRandom(t *testing.T) {
+	n := 100
+	g := New(n)
+	u := NewUnionFind(n)
+	for i := 0; i < n; i++ {
+		v, w := rand.Intn(n), rand.Intn(n)
+		g.AddBoth(v, w)
+		u.Union(v, w)
+	}
+	exp := Components(Sort(g))
+	if mess, diff := diff(u.Count(), len(exp)); diff {
+		t.Errorf("Count %s", mess)
+	}
+	sets := u.Components()
+	for _, set := range sets {
+		for _, v := range set {
+			if !u.Same(v, set[0]) {
+				t.Errorf("Components: %d and %d in different sets", v, set[0])
+			}
+		}
+	}
+	if mess, diff := diff(len(sets), len(exp)); diff {
+		t.Errorf("Components %s", mess)
+	}
+}
+
+func BenchmarkUnionFind(b *testing.B) {
+	n := 1000
+	for i := 0; i < b.N; i++ {
+		u := NewUnionFind(n)
+		for j := 0; j < n; j++ {
+			u.Union(rand.Intn(n), rand.Intn(n))
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package graph
+
+// ValidateOptions tell Validate which properties to check.
+// A nil *ValidateOptions is the same as the zero value.
+type ValidateOptions struct {
+	// AllowLoops accepts self-loops, edges from a vertex to itself.
+	AllowLoops bool
+
+	// AllowMulti accepts several edges from v to w.
+	AllowMulti bool
+
+	// Undirected requires each edge from v to w of cost c to have
+	// a matching reverse edge from w to v of cost c.
+	Undirected bool
+
+	// NonNegative requires all costs to be non-negative.
+	NonNegative bool
+}
+
+// Validate checks that all edges of g have endpoints in the range 0 to n-1,
+// where n is the order of g, and that g has the properties required by opts.
+// It returns nil if the graph is valid, and otherwise an *EdgeError
+// for the first invalid edge, in order of its first vertex, which wraps
+// ErrVertexOutOfRange, ErrSelfLoop, ErrDuplicateEdge, ErrAsymmetric
+// or ErrNegativeCost.
+// If opts is nil, self-loops and duplicate edges are reported,
+// but the graph may be directed and have negative costs.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph, plus the time for
+// a hash map of the edges if opts.Undirected is set.
+func Validate(g Iterator, opts *ValidateOptions) error {
+	if opts == nil {
+		opts = new(ValidateOptions)
+	}
+	n := g.Order()
+	// seen[w] is v+1 if an edge from v to w has been seen.
+	var seen []int
+	if !opts.AllowMulti {
+		seen = make([]int, n)
+	}
+	type edge struct {
+		v, w int
+		c    int64
+	}
+	var count map[edge]int
+	if opts.Undirected {
+		count = make(map[edge]int)
+	}
+	var err error
+	for v := 0; v < n && err == nil; v++ {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			switch {
+			case w < 0 || w >= n:
+				err = ErrVertexOutOfRange
+			case v == w && !opts.AllowLoops:
+				err = ErrSelfLoop
+			case seen != nil && seen[w] == v+1:
+				err = ErrDuplicateEdge
+			case c < 0 && opts.NonNegative:
+				err = ErrNegativeCost
+			}
+			if err != nil {
+				err = &EdgeError{v, w, c, err}
+				return true
+			}
+			if seen != nil {
+				seen[w] = v + 1
+			}
+			if count != nil {
+				count[edge{v, w, c}]++
+			}
+			return
+		})
+	}
+	if err != nil || count == nil {
+		return err
+	}
+	VisitAll(g, func(v, w int, c int64) (skip bool) {
+		if count[edge{v, w, c}] != count[edge{w, v, c}] {
+			err = &EdgeError{v, w, c, ErrAsymmetric}
+			return true
+		}
+		return
+	})
+	return err
+}
@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+)
+
+// badGraph is a graph with edges given by a list,
+// which may have vertices out of range.
+type badGraph struct {
+	n     int
+	edges []Edge
+}
+
+func (g badGraph) Order() int { return g.n }
+
+func (g badGraph) Visit(v int, do func(w int, c int64) bool) bool {
+	for _, e := range g.edges {
+		if e.V == v && do(e.W, e.Cost) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidate(t *testing.T) {
+	g := New(3)
+	g.AddBothCost(0, 1, 2)
+	g.AddBothCost(1, 2, -1)
+	if err := Validate(g, nil); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	if err := Validate(g, &ValidateOptions{Undirected: true}); err != nil {
+		t.Errorf("Validate undirected: %v", err)
+	}
+
+	for _, e := range []struct {
+		g    Iterator
+		opts *ValidateOptions
+		err  error
+		exp  *EdgeError
+	}{
+		{badGraph{2, []Edge{{0, 1, 0}, {1, 2, 5}}}, nil, ErrVertexOutOfRange, &EdgeError{1, 2, 5, ErrVertexOutOfRange}},
+		{badGraph{2, []Edge{{0, -1, 0}}}, nil, ErrVertexOutOfRange, &EdgeError{0, -1, 0, ErrVertexOutOfRange}},
+		{badGraph{2, []Edge{{1, 1, 0}}}, nil, ErrSelfLoop, &EdgeError{1, 1, 0, ErrSelfLoop}},
+		{badGraph{2, []Edge{{0, 1, 1}, {0, 1, 2}}}, nil, ErrDuplicateEdge, &EdgeError{0, 1, 2, ErrDuplicateEdge}},
+		{g, &ValidateOptions{NonNegative: true}, ErrNegativeCost, &EdgeError{1, 2, -1, ErrNegativeCost}},
+		{badGraph{2, []Edge{{0, 1, 1}, {1, 0, 2}}}, &ValidateOptions{Undirected: true}, ErrAsymmetric, &EdgeError{0, 1, 1, ErrAsymmetric}},
+		{badGraph{2, []Edge{{0, 1, 1}, {0, 1, 1}, {1, 0, 1}}}, &ValidateOptions{Undirected: true, AllowMulti: true}, ErrAsymmetric, &EdgeError{0, 1, 1, ErrAsymmetric}},
+	} {
+		err := Validate(e.g, e.opts)
+		if !errors.Is(err, e.err) {
+			t.Errorf("Validate(%v): %v; want %v", e.g, err, e.err)
+			continue
+		}
+		if mess, diff := diff(err, e.exp); diff {
+			t.Errorf("Validate(%v) %s", e.g, mess)
+		}
+	}
+
+	ok := badGraph{2, []Edge{{0, 0, 1}, {0, 1, 1}, {0, 1, 1}, {1, 0, 1}, {1, 0, 1}}}
+	if err := Validate(ok, &ValidateOptions{AllowLoops: true, AllowMulti: true, Undirected: true}); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+
+	err := Validate(badGraph{3, []Edge{{2, 2, -4}}}, nil)
+	if mess, diff := diff(err.Error(), "graph: self-loop: edge (2 2):-4"); diff {
+		t.Errorf("EdgeError.Error %s", mess)
+	}
+}
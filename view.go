@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Subgraph returns a view of the subgraph of g induced by the given vertices.
+// Vertex i of the subgraph corresponds to vertex vertices[i] of g,
+// and there is an edge from i to j if there is such an edge
+// from vertices[i] to vertices[j] in g.
+// No edges are copied; the view reads from g, which should not be
+// modified while the view is in use.
+func Subgraph(g Iterator, vertices []int) Iterator {
+	n := g.Order()
+	index := make([]int, n)
+	for i := range index {
+		index[i] = -1
+	}
+	for i, v := range vertices {
+		if v < 0 || v >= n {
+			panic("vertex out of range: " + strconv.Itoa(v))
+		}
+		if index[v] != -1 {
+			panic("duplicate vertex: " + strconv.Itoa(v))
+		}
+		index[v] = i
+	}
+	return &subgraph{g, vertices, index}
+}
+
+type subgraph struct {
+	g        Iterator
+	vertices []int // vertices[i] is a vertex in g
+	index    []int // index[v] is the subgraph vertex of v, or -1
+}
+
+func (s *subgraph) Order() int { return len(s.vertices) }
+
+func (s *subgraph) Visit(v int, do func(w int, c int64) bool) bool {
+	return s.g.Visit(s.vertices[v], func(w int, c int64) bool {
+		if i := s.index[w]; i != -1 {
+			return do(i, c)
+		}
+		return false
+	})
+}
+
+// FilterEdges returns a view of g that only includes the edges (v, w)
+// of cost c for which keep(v, w, c) is true.
+// No edges are copied; the view reads from g.
+func FilterEdges(g Iterator, keep func(v, w int, c int64) bool) Iterator {
+	return &filtered{g, keep}
+}
+
+type filtered struct {
+	g    Iterator
+	keep func(v, w int, c int64) bool
+}
+
+func (f *filtered) Order() int { return f.g.Order() }
+
+func (f *filtered) Visit(v int, do func(w int, c int64) bool) bool {
+	return f.g.Visit(v, func(w int, c int64) bool {
+		return f.keep(v, w, c) && do(w, c)
+	})
+}
+
+// TransposeView returns a view of the transpose graph of g,
+// in which all edges are reversed. Unlike Transpose, it doesn't
+// copy the graph, and changes to g are visible through the view.
+//
+// Since g only describes outgoing edges, the Visit method of the view
+// must search the whole graph. It takes O(|V|) time for Mutable graphs,
+// O(|V|⋅log|V|) time for Immutable graphs, and O(|E|) time otherwise,
+// where |E| is the number of edges and |V| the number of vertices in g.
+// Use Transpose to visit the reversed edges many times.
+func TransposeView(g Iterator) Iterator {
+	return &transposed{g}
+}
+
+type transposed struct {
+	g Iterator
+}
+
+func (t *transposed) Order() int { return t.g.Order() }
+
+func (t *transposed) Visit(v int, do func(w int, c int64) bool) bool {
+	switch g := t.g.(type) {
+	case *Mutable:
+		for u, neighbors := range g.edges {
+			if c, ok := neighbors[v]; ok && do(u, c) {
+				return true
+			}
+		}
+		return false
+	case *Immutable:
+		for u := 0; u < g.Order(); u++ {
+			edges := g.edges[g.offset[u]:g.offset[u+1]]
+			i := sort.Search(len(edges), func(i int) bool { return v <= edges[i].vertex })
+			for ; i < len(edges) && edges[i].vertex == v; i++ {
+				if do(u, edges[i].cost) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for u := 0; u < t.g.Order(); u++ {
+		if t.g.Visit(u, func(w int, c int64) bool {
+			return w == v && do(u, c)
+		}) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSubgraph(t *testing.T) {
+	g := New(5)
+	g.AddCost(0, 1, 1)
+	g.AddCost(1, 2, 2)
+	g.AddCost(2, 0, 3)
+	g.AddCost(3, 4, 4)
+	g.AddCost(2, 4, 5)
+	h := Subgraph(g, []int{4, 2, 1})
+	if mess, diff := diff(String(h), "3 [(1 0):5 (2 1):2]"); diff {
+		t.Errorf("Subgraph %s", mess)
+	}
+	if mess, diff := diff(String(Subgraph(g, nil)), "0 []"); diff {
+		t.Errorf("Subgraph %s", mess)
+	}
+	if mess, diff := diff(Subgraph(g, []int{0, 1, 2}).Visit(0, func(int, int64) bool { return true }), true); diff {
+		t.Errorf("Subgraph: abort %s", mess)
+	}
+	for _, vertices := range [][]int{{0, 5}, {1, 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Subgraph(%v): no panic", vertices)
+				}
+			}()
+			Subgraph(g, vertices)
+		}()
+	}
+}
+
+func TestFilterEdges(t *testing.T) {
+	g := New(3)
+	g.AddCost(0, 1, 1)
+	g.AddCost(1, 2, -2)
+	g.AddCost(2, 0, 3)
+	h := FilterEdges(g, func(v, w int, c int64) bool { return c > 0 })
+	if mess, diff := diff(String(h), "3 [(0 1):1 (2 0):3]"); diff {
+		t.Errorf("FilterEdges %s", mess)
+	}
+	g.AddCost(1, 2, 2)
+	if mess, diff := diff(String(h), "3 [(0 1):1 (1 2):2 (2 0):3]"); diff {
+		t.Errorf("FilterEdges after change %s", mess)
+	}
+}
+
+func TestTransposeView(t *testing.T) {
+	n := 10
+	g := New(n)
+	for i := 0; i < 3*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(5))
+	}
+	exp := String(Transpose(g))
+	if mess, diff := diff(String(TransposeView(g)), exp); diff {
+		t.Errorf("TransposeView(Mutable) %s", mess)
+	}
+	if mess, diff := diff(String(TransposeView(Sort(g))), exp); diff {
+		t.Errorf("TransposeView(Immutable) %s", mess)
+	}
+	f := FilterEdges(g, func(int, int, int64) bool { return true })
+	if mess, diff := diff(String(TransposeView(f)), exp); diff {
+		t.Errorf("TransposeView(Iterator) %s", mess)
+	}
+	if mess, diff := diff(String(TransposeView(TransposeView(g))), String(g)); diff {
+		t.Errorf("TransposeView(TransposeView) %s", mess)
+	}
+
+	m := FromEdges(2, []Edge{{0, 1, 1}, {0, 1, 1}})
+	if mess, diff := diff(String(TransposeView(m)), "2 [2×(1 0):1]"); diff {
+		t.Errorf("TransposeView(multigraph) %s", mess)
+	}
+}
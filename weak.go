@@ -26,6 +26,31 @@ func Components(g Iterator) [][]int {
 	return components
 }
 
+// ComponentLabels labels the (weakly) connected components of g.
+// The number label[v] is the component of vertex v, and size[c]
+// is the number of vertices in component c. The components are
+// numbered from 0 in order of their smallest vertex.
+//
+// The time complexity is O(|E|⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func ComponentLabels(g Iterator) (label []int, size []int) {
+	sets, count := components(g)
+	n := g.Order()
+	label, size = make([]int, n), make([]int, 0, count)
+	// index[x] is the label of the set with root x, plus one.
+	index := make([]int, n)
+	for v := range label {
+		x := sets.find(v)
+		if index[x] == 0 {
+			size = append(size, 0)
+			index[x] = len(size)
+		}
+		label[v] = index[x] - 1
+		size[label[v]]++
+	}
+	return
+}
+
 func components(g Iterator) (sets disjointSets, count int) {
 	n := g.Order()
 	sets, count = makeSingletons(n), n
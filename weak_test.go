@@ -52,6 +52,54 @@ func TestComponents(t *testing.T) {
 	}
 }
 
+func TestComponentLabels(t *testing.T) {
+	g := New(0)
+	label, size := ComponentLabels(g)
+	if mess, diff := diff(label, []int{}); diff {
+		t.Errorf("ComponentLabels->label %s", mess)
+	}
+	if mess, diff := diff(size, []int{}); diff {
+		t.Errorf("ComponentLabels->size %s", mess)
+	}
+
+	g = New(6)
+	g.Add(3, 0)
+	g.Add(4, 1)
+	g.Add(1, 3)
+	g.Add(5, 2)
+	label, size = ComponentLabels(g)
+	if mess, diff := diff(label, []int{0, 0, 1, 0, 0, 1}); diff {
+		t.Errorf("ComponentLabels->label %s", mess)
+	}
+	if mess, diff := diff(size, []int{4, 2}); diff {
+		t.Errorf("ComponentLabels->size %s", mess)
+	}
+}
+
+func TestComponentLabelsRandom(t *testing.T) {
+	n := 100
+	g := New(n)
+	for i := 0; i < n; i++ {
+		g.Add(rand.Intn(n), rand.Intn(n))
+	}
+	label, size := ComponentLabels(g)
+	components := Components(g)
+	if mess, diff := diff(len(size), len(components)); diff {
+		t.Errorf("ComponentLabels->size %s", mess)
+	}
+	for _, comp := range components {
+		c := label[comp[0]]
+		for _, v := range comp {
+			if label[v] != c {
+				t.Errorf("ComponentLabels: label[%d] %d; want %d", v, label[v], c)
+			}
+		}
+		if size[c] != len(comp) {
+			t.Errorf("ComponentLabels: size[%d] %d; want %d", c, size[c], len(comp))
+		}
+	}
+}
+
 func BenchmarkConnected(b *testing.B) {
 	n := 1000
 	b.StopTimer()
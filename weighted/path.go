@@ -0,0 +1,141 @@
+package weighted
+
+import "container/heap"
+
+// ShortestPath computes a shortest path from v to w.
+// Only edges with non-negative weights are included.
+// The number dist is the length of the path, or -1 if w cannot be reached.
+//
+// The time complexity is O((|E| + |V|)⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func ShortestPath[W Number](g Iterator[W], v, w int) (path []int, dist W) {
+	path, dist, ok := ShortestPathFunc(g, v, w, 0,
+		func(a, b W) W { return a + b },
+		func(a, b W) bool { return a < b })
+	if !ok {
+		dist--
+	}
+	return
+}
+
+// ShortestPaths computes the shortest paths from v to all other vertices.
+// Only edges with non-negative weights are included.
+// The number parent[w] is the predecessor of w on a shortest path from v to w,
+// or -1 if none exists.
+// The number dist[w] equals the length of a shortest path from v to w,
+// or is -1 if w cannot be reached. For unsigned types, -1 wraps around
+// to the largest value of the type.
+//
+// The time complexity is O((|E| + |V|)⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func ShortestPaths[W Number](g Iterator[W], v int) (parent []int, dist []W) {
+	parent, dist, reached := shortestPaths(g, v, 0,
+		func(a, b W) W { return a + b },
+		func(a, b W) bool { return a < b })
+	for w, ok := range reached {
+		if !ok {
+			dist[w]-- // The constant -1 would overflow unsigned types.
+		}
+	}
+	return
+}
+
+// ShortestPathFunc is like ShortestPath, but works for any weight type.
+// The weights are added with add and compared with less,
+// and zero is the length of an empty path; edges with weights
+// less than zero are not included.
+// If w cannot be reached, ok is false and dist is the zero value.
+//
+// For example, to use big.Rat weights:
+//
+//	add := func(a, b *big.Rat) *big.Rat { return new(big.Rat).Add(a, b) }
+//	less := func(a, b *big.Rat) bool { return a.Cmp(b) < 0 }
+//	path, dist, ok := weighted.ShortestPathFunc(g, v, w, new(big.Rat), add, less)
+func ShortestPathFunc[W any](g Iterator[W], v, w int, zero W, add func(a, b W) W, less func(a, b W) bool) (path []int, dist W, ok bool) {
+	parent, distances, reached := shortestPaths(g, v, zero, add, less)
+	if !reached[w] {
+		return []int{}, dist, false
+	}
+	return followParents(parent, w), distances[w], true
+}
+
+// ShortestPathsFunc is like ShortestPaths, but works for any weight type;
+// see ShortestPathFunc. The number reached[w] tells if w can be reached
+// from v; if not, dist[w] is the zero value.
+func ShortestPathsFunc[W any](g Iterator[W], v int, zero W, add func(a, b W) W, less func(a, b W) bool) (parent []int, dist []W, reached []bool) {
+	return shortestPaths(g, v, zero, add, less)
+}
+
+func shortestPaths[W any](g Iterator[W], v int, zero W, add func(a, b W) W, less func(a, b W) bool) (parent []int, dist []W, reached []bool) {
+	n := g.Order()
+	parent = make([]int, n)
+	dist = make([]W, n)
+	reached = make([]bool, n)
+	done := make([]bool, n)
+	for i := range parent {
+		parent[i] = -1
+	}
+	dist[v], reached[v] = zero, true
+	Q := &queue[W]{less: less}
+	heap.Push(Q, entry[W]{v, zero})
+	for Q.Len() > 0 {
+		e := heap.Pop(Q).(entry[W])
+		v := e.vertex
+		if done[v] {
+			continue // An outdated entry.
+		}
+		done[v] = true
+		g.Visit(v, func(w int, c W) (skip bool) {
+			if done[w] || less(c, zero) {
+				return
+			}
+			if alt := add(dist[v], c); !reached[w] || less(alt, dist[w]) {
+				dist[w], parent[w], reached[w] = alt, v, true
+				heap.Push(Q, entry[W]{w, alt})
+			}
+			return
+		})
+	}
+	return
+}
+
+// followParents returns the path from the root of the tree to w.
+func followParents(parent []int, w int) []int {
+	length := 1
+	for v := parent[w]; v != -1; v = parent[v] {
+		length++
+	}
+	path := make([]int, length)
+	for v := w; v != -1; v = parent[v] {
+		length--
+		path[length] = v
+	}
+	return path
+}
+
+type entry[W any] struct {
+	vertex int
+	dist   W
+}
+
+// queue is a priority queue of vertices ordered by tentative distance.
+// A vertex may be pushed more than once; outdated entries are skipped.
+type queue[W any] struct {
+	entries []entry[W]
+	less    func(a, b W) bool
+}
+
+func (q *queue[W]) Len() int { return len(q.entries) }
+
+func (q *queue[W]) Less(i, j int) bool { return q.less(q.entries[i].dist, q.entries[j].dist) }
+
+func (q *queue[W]) Swap(i, j int) { q.entries[i], q.entries[j] = q.entries[j], q.entries[i] }
+
+func (q *queue[W]) Push(x interface{}) { q.entries = append(q.entries, x.(entry[W])) }
+
+func (q *queue[W]) Pop() interface{} {
+	n := len(q.entries) - 1
+	x := q.entries[n]
+	q.entries = q.entries[:n]
+	return x
+}
@@ -0,0 +1,114 @@
+package weighted
+
+import (
+	"github.com/yourbasic/graph"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestShortestPath(t *testing.T) {
+	g := New[float64](6)
+	g.Add(0, 1, 0.5)
+	g.Add(0, 2, 0.25)
+	g.Add(2, 1, 0.125)
+	g.Add(1, 3, 1)
+	g.Add(3, 4, -1)
+	g.Add(2, 4, 4)
+	path, dist := ShortestPath[float64](g, 0, 3)
+	if mess, diff := diff(path, []int{0, 2, 1, 3}); diff {
+		t.Errorf("ShortestPath->path %s", mess)
+	}
+	if mess, diff := diff(dist, 1.375); diff {
+		t.Errorf("ShortestPath->dist %s", mess)
+	}
+	path, dist = ShortestPath[float64](g, 0, 5)
+	if mess, diff := diff(path, []int{}); diff {
+		t.Errorf("ShortestPath->path %s", mess)
+	}
+	if mess, diff := diff(dist, -1.0); diff {
+		t.Errorf("ShortestPath->dist %s", mess)
+	}
+
+	parent, distances := ShortestPaths[float64](g, 0)
+	if mess, diff := diff(parent, []int{-1, 2, 0, 1, 2, -1}); diff {
+		t.Errorf("ShortestPaths->parent %s", mess)
+	}
+	if mess, diff := diff(distances, []float64{0, 0.375, 0.25, 1.375, 4.25, -1}); diff {
+		t.Errorf("ShortestPaths->dist %s", mess)
+	}
+
+	u := New[uint8](2)
+	_, udist := ShortestPaths[uint8](u, 0)
+	if mess, diff := diff(udist, []uint8{0, 255}); diff {
+		t.Errorf("ShortestPaths->dist %s", mess)
+	}
+}
+
+func TestShortestPathFunc(t *testing.T) {
+	third := big.NewRat(1, 3)
+	g := New[*big.Rat](4)
+	g.Add(0, 1, third)
+	g.Add(1, 2, third)
+	g.Add(2, 3, third)
+	g.Add(0, 3, big.NewRat(1, 1))
+	g.Add(0, 2, big.NewRat(-1, 1))
+	add := func(a, b *big.Rat) *big.Rat { return new(big.Rat).Add(a, b) }
+	less := func(a, b *big.Rat) bool { return a.Cmp(b) < 0 }
+
+	// The edge with negative weight is ignored.
+	path, dist, ok := ShortestPathFunc[*big.Rat](g, 0, 2, new(big.Rat), add, less)
+	if mess, diff := diff(path, []int{0, 1, 2}); diff {
+		t.Errorf("ShortestPathFunc->path %s", mess)
+	}
+	if mess, diff := diff(dist.String(), "2/3"); diff {
+		t.Errorf("ShortestPathFunc->dist %s", mess)
+	}
+	if mess, diff := diff(ok, true); diff {
+		t.Errorf("ShortestPathFunc->ok %s", mess)
+	}
+	// The sum 1/3 + 1/3 + 1/3 is exactly 1, with no rounding errors.
+	_, dist, ok = ShortestPathFunc[*big.Rat](g, 0, 3, new(big.Rat), add, less)
+	if mess, diff := diff(dist.String(), "1/1"); diff {
+		t.Errorf("ShortestPathFunc->dist %s", mess)
+	}
+	path, dist, ok = ShortestPathFunc[*big.Rat](g, 3, 0, new(big.Rat), add, less)
+	if mess, diff := diff(path, []int{}); diff {
+		t.Errorf("ShortestPathFunc->path %s", mess)
+	}
+	if dist != nil || ok {
+		t.Errorf("ShortestPathFunc->(dist, ok) (%v, %t); want (<nil>, false)", dist, ok)
+	}
+
+	_, _, reached := ShortestPathsFunc[*big.Rat](g, 1, new(big.Rat), add, less)
+	if mess, diff := diff(reached, []bool{false, true, true, true}); diff {
+		t.Errorf("ShortestPathsFunc->reached %s", mess)
+	}
+}
+
+func TestShortestPathsRandom(t *testing.T) {
+	n := 100
+	g := graph.New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), int64(rand.Intn(10)))
+	}
+	_, exp := graph.ShortestPaths(g, 0)
+	_, dist := ShortestPaths(FromGraph(g), 0)
+	if mess, diff := diff(dist, exp); diff {
+		t.Errorf("ShortestPaths->dist %s", mess)
+	}
+}
+
+func BenchmarkShortestPaths(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New[float64](n)
+	for i := 0; i < n; i++ {
+		g.Add(0, rand.Intn(n), rand.Float64())
+		g.Add(rand.Intn(n), rand.Intn(n), rand.Float64())
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ShortestPaths[float64](g, 0)
+	}
+}
@@ -0,0 +1,148 @@
+// Package weighted provides graphs and shortest path algorithms
+// for edge weights of any type.
+//
+// The graph package uses int64 costs throughout. This package lets
+// float64, big.Rat and other weight types be used directly, without
+// scaling them to integers. Numeric weights satisfy the Number
+// constraint; other types are handled by the Func variants of
+// the algorithms, which take functions for adding and comparing weights.
+package weighted
+
+import (
+	"github.com/yourbasic/graph"
+	"strconv"
+)
+
+// Number is a constraint that permits any built-in numeric type
+// that can be used as an edge weight.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Iterator describes a weighted graph; an Iterator should describe
+// how to iterate over the edges of a graph, see graph.Iterator.
+type Iterator[W any] interface {
+	// Order returns the number of vertices in a graph.
+	Order() int
+
+	// Visit calls the do function for each neighbor w of vertex v,
+	// with c equal to the weight of the edge from v to w.
+	// If do returns true, Visit returns immediately, skipping any
+	// remaining neighbors, and returns true.
+	Visit(v int, do func(w int, c W) bool) bool
+}
+
+// Mutable represents a directed graph with a fixed number
+// of vertices and edges of weight W that can be added or removed.
+type Mutable[W any] struct {
+	// The map edges[v] contains the mapping {w:c} if there is an edge
+	// from v to w, and c is the weight assigned to this edge.
+	// The maps may be nil and are allocated as needed.
+	edges []map[int]W
+}
+
+// New constructs a new graph with n vertices, numbered from 0 to n-1, and no edges.
+func New[W any](n int) *Mutable[W] {
+	return &Mutable[W]{edges: make([]map[int]W, n)}
+}
+
+// Order returns the number of vertices in the graph.
+func (g *Mutable[W]) Order() int {
+	return len(g.edges)
+}
+
+// Visit calls the do function for each neighbor w of v,
+// with c equal to the weight of the edge from v to w.
+// If do returns true, Visit returns immediately,
+// skipping any remaining neighbors, and returns true.
+//
+// The iteration order is not specified and is not guaranteed
+// to be the same every time.
+func (g *Mutable[W]) Visit(v int, do func(w int, c W) bool) bool {
+	for w, c := range g.edges[v] {
+		if do(w, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// Edge tells if there is an edge from v to w.
+func (g *Mutable[W]) Edge(v, w int) bool {
+	if v < 0 || v >= g.Order() {
+		return false
+	}
+	_, ok := g.edges[v][w]
+	return ok
+}
+
+// Weight returns the weight of an edge from v to w,
+// or the zero value if no such edge exists.
+func (g *Mutable[W]) Weight(v, w int) W {
+	if v < 0 || v >= g.Order() {
+		var zero W
+		return zero
+	}
+	return g.edges[v][w]
+}
+
+// Add inserts a directed edge from v to w with weight c.
+// It overwrites the previous weight if this edge already exists.
+func (g *Mutable[W]) Add(v, w int, c W) {
+	// Make sure not to break internal state.
+	if w < 0 || w >= len(g.edges) {
+		panic("vertex out of range: " + strconv.Itoa(w))
+	}
+	if g.edges[v] == nil {
+		g.edges[v] = make(map[int]W)
+	}
+	g.edges[v][w] = c
+}
+
+// AddBoth inserts edges with weight c between v and w.
+// It overwrites the previous weights if these edges already exist.
+func (g *Mutable[W]) AddBoth(v, w int, c W) {
+	g.Add(v, w, c)
+	if v != w {
+		g.Add(w, v, c)
+	}
+}
+
+// Delete removes an edge from v to w.
+func (g *Mutable[W]) Delete(v, w int) {
+	delete(g.edges[v], w)
+}
+
+// DeleteBoth removes all edges between v and w.
+func (g *Mutable[W]) DeleteBoth(v, w int) {
+	g.Delete(v, w)
+	if v != w {
+		g.Delete(w, v)
+	}
+}
+
+// FromGraph returns a view of g with int64 weights.
+func FromGraph(g graph.Iterator) Iterator[int64] {
+	return g
+}
+
+// Convert returns a view of g in which the weight of each edge
+// is given by the function f applied to its int64 cost.
+func Convert[W any](g graph.Iterator, f func(c int64) W) Iterator[W] {
+	return converted[W]{g, f}
+}
+
+type converted[W any] struct {
+	g graph.Iterator
+	f func(c int64) W
+}
+
+func (g converted[W]) Order() int { return g.g.Order() }
+
+func (g converted[W]) Visit(v int, do func(w int, c W) bool) bool {
+	return g.g.Visit(v, func(w int, c int64) bool {
+		return do(w, g.f(c))
+	})
+}
@@ -0,0 +1,85 @@
+package weighted
+
+import (
+	"fmt"
+	"github.com/yourbasic/graph"
+	"reflect"
+	"testing"
+)
+
+func diff(res, exp interface{}) (message string, diff bool) {
+	if !reflect.DeepEqual(res, exp) {
+		message = fmt.Sprintf("%v; want %v", res, exp)
+		diff = true
+	}
+	return
+}
+
+func TestMutable(t *testing.T) {
+	g := New[float64](3)
+	if mess, diff := diff(g.Order(), 3); diff {
+		t.Errorf("Order %s", mess)
+	}
+	g.Add(0, 1, 0.5)
+	g.AddBoth(1, 2, 1.25)
+	g.Add(0, 1, 0.75)
+	for _, e := range []struct {
+		v, w int
+		edge bool
+		c    float64
+	}{
+		{0, 1, true, 0.75},
+		{1, 0, false, 0},
+		{1, 2, true, 1.25},
+		{2, 1, true, 1.25},
+		{-1, 0, false, 0},
+		{0, 3, false, 0},
+	} {
+		if mess, diff := diff(g.Edge(e.v, e.w), e.edge); diff {
+			t.Errorf("Edge(%d, %d) %s", e.v, e.w, mess)
+		}
+		if mess, diff := diff(g.Weight(e.v, e.w), e.c); diff {
+			t.Errorf("Weight(%d, %d) %s", e.v, e.w, mess)
+		}
+	}
+	g.DeleteBoth(2, 1)
+	g.Delete(0, 1)
+	for v := 0; v < g.Order(); v++ {
+		g.Visit(v, func(w int, c float64) (skip bool) {
+			t.Errorf("Visit(%d): unexpected edge to %d", v, w)
+			return
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Add(0, 3): no panic")
+		}
+	}()
+	g.Add(0, 3, 1)
+}
+
+func TestConvert(t *testing.T) {
+	g := graph.New(2)
+	g.AddCost(0, 1, 3)
+	var cost int64
+	FromGraph(g).Visit(0, func(w int, c int64) (skip bool) {
+		cost = c
+		return
+	})
+	if mess, diff := diff(cost, int64(3)); diff {
+		t.Errorf("FromGraph %s", mess)
+	}
+	h := Convert(g, func(c int64) float64 { return float64(c) / 2 })
+	if mess, diff := diff(h.Order(), 2); diff {
+		t.Errorf("Convert: Order %s", mess)
+	}
+	var weight float64
+	h.Visit(0, func(w int, c float64) (skip bool) {
+		weight = c
+		return
+	})
+	if mess, diff := diff(weight, 1.5); diff {
+		t.Errorf("Convert %s", mess)
+	}
+}
@@ -0,0 +1,59 @@
+package graph
+
+// WidestPath computes a widest path from v to w: a path that maximizes
+// the minimum cost of its edges. This is also known as a maximum capacity
+// or bottleneck shortest path. Only edges with non-negative costs are included.
+// The number width is the minimum edge cost of the path, or -1 if w cannot
+// be reached. The width of the path from v to v is Max.
+//
+// The time complexity is O((|E| + |V|)⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func WidestPath(g Iterator, v, w int) (path []int, width int64) {
+	parent, widths := WidestPaths(g, v)
+	return NewPathTree(parent, widths).PathTo(w), widths[w]
+}
+
+// WidestPaths computes the widest paths from v to all other vertices.
+// Only edges with non-negative costs are included.
+// The number parent[w] is the predecessor of w on a widest path from v to w,
+// or -1 if none exists.
+// The number width[w] equals the width of a widest path from v to w,
+// or is -1 if w cannot be reached.
+//
+// The time complexity is O((|E| + |V|)⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func WidestPaths(g Iterator, v int) (parent []int, width []int64) {
+	n := g.Order()
+	width = make([]int64, n)
+	parent = make([]int, n)
+	for i := range width {
+		width[i], parent[i] = -1, -1
+	}
+	width[v] = Max
+
+	// Dijkstra's algorithm with a max ordering: the queue
+	// holds vertices with their negated widths.
+	Q := NewHeapQueue(n)
+	Q.Push(v, -Max)
+	for Q.Len() > 0 {
+		v, d := Q.Pop()
+		if -d < width[v] {
+			continue // An outdated entry.
+		}
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if c < 0 {
+				return
+			}
+			alt := width[v]
+			if c < alt {
+				alt = c
+			}
+			if alt > width[w] {
+				width[w], parent[w] = alt, v
+				Q.Push(w, -alt)
+			}
+			return
+		})
+	}
+	return
+}
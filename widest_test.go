@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestWidestPath(t *testing.T) {
+	g := New(6)
+	g.AddBothCost(0, 1, 5) //  0 -5- 1 -3- 2
+	g.AddBothCost(1, 2, 3) //  |     |     |
+	g.AddBothCost(0, 3, 6) //  6     2     7
+	g.AddBothCost(1, 4, 2) //  |     |     |
+	g.AddBothCost(2, 5, 7) //  3 -4- 4 -8- 5
+	g.AddBothCost(3, 4, 4)
+	g.AddBothCost(4, 5, 8)
+
+	path, width := WidestPath(g, 0, 2)
+	if mess, diff := diff(path, []int{0, 3, 4, 5, 2}); diff {
+		t.Errorf("WidestPath->path %s", mess)
+	}
+	if mess, diff := diff(width, int64(4)); diff {
+		t.Errorf("WidestPath->width %s", mess)
+	}
+
+	parent, widths := WidestPaths(g, 1)
+	if mess, diff := diff(parent, []int{1, -1, 5, 0, 3, 4}); diff {
+		t.Errorf("WidestPaths->parent %s", mess)
+	}
+	if mess, diff := diff(widths, []int64{5, Max, 4, 5, 4, 4}); diff {
+		t.Errorf("WidestPaths->width %s", mess)
+	}
+
+	path, width = WidestPath(g, 0, 0)
+	if mess, diff := diff(path, []int{0}); diff {
+		t.Errorf("WidestPath->path %s", mess)
+	}
+	if mess, diff := diff(width, Max); diff {
+		t.Errorf("WidestPath->width %s", mess)
+	}
+
+	g = New(3)
+	g.AddCost(0, 1, 0)
+	g.AddCost(1, 2, -1)
+	_, widths = WidestPaths(g, 0)
+	if mess, diff := diff(widths, []int64{Max, 0, -1}); diff {
+		t.Errorf("WidestPaths->width %s", mess)
+	}
+}
+
+// Compare with a brute force computation: w can be reached using
+// only edges of cost at least c iff the widest path has width ≥ c.
+func TestWidestPathsRandom(t *testing.T) {
+	n := 50
+	g := New(n)
+	for i := 0; i < 3*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), int64(rand.Intn(10)))
+	}
+	_, width := WidestPaths(g, 0)
+	for c := int64(0); c <= 10; c++ {
+		_, dist := ShortestPathsFiltered(g, 0, func(_, _ int, d int64) bool { return d >= c })
+		for w := 1; w < n; w++ {
+			if reached := dist[w] != -1; reached != (width[w] >= c) {
+				t.Errorf("width[%d] = %d; reachable with costs ≥ %d: %t", w, width[w], c, reached)
+			}
+		}
+	}
+}
+
+func BenchmarkWidestPaths(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < n; i++ {
+		g.AddCost(0, rand.Intn(n), int64(rand.Intn(100)))
+		g.AddCost(rand.Intn(n), rand.Intn(n), int64(rand.Intn(100)))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = WidestPaths(g, 0)
+	}
+}
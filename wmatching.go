@@ -0,0 +1,602 @@
+package graph
+
+// MaxWeightMatching computes a matching of maximum total cost in
+// an undirected graph: a set of edges without common vertices with
+// the largest possible sum of edge costs. The edges are returned
+// with V < W, sorted by V, and weight is their total cost.
+// Edges of negative cost are never part of the matching.
+//
+// The implementation uses Edmonds' blossom algorithm with dual
+// variables, as described by Galil in “Efficient algorithms for finding
+// maximum matching in graphs”, ACM Computing Surveys, 1986.
+// The time complexity is O(|V|³), where |V| is the number of vertices
+// in the graph.
+func MaxWeightMatching(g Iterator) (matching []Edge, weight int64) {
+	n := g.Order()
+	var edges []Edge
+	for v := 0; v < n; v++ {
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if v < w {
+				edges = append(edges, Edge{v, w, c})
+			}
+			return
+		})
+	}
+	m := newWeightMatcher(n, edges)
+	m.run()
+	matching = []Edge{}
+	for v, p := range m.mate {
+		if p != -1 && v < m.endpoint[p] {
+			e := edges[p/2]
+			matching = append(matching, e)
+			weight += e.Cost
+		}
+	}
+	return
+}
+
+// weightMatcher holds the state of the weighted blossom algorithm.
+//
+// Vertices are numbered 0..n-1 and non-trivial blossoms n..2n-1.
+// Each edge k has two endpoints 2k and 2k+1, where endpoint p
+// is the vertex endpoint[p], and p^1 is the other endpoint of the edge.
+// A top-level blossom is either unlabeled, an S-blossom (label 1)
+// or a T-blossom (label 2).
+type weightMatcher struct {
+	n        int
+	edges    []Edge
+	endpoint []int
+	// neighbend[v] lists the remote endpoints of the edges incident to v.
+	neighbend [][]int
+	// mate[v] is the remote endpoint of the matched edge at v, or -1.
+	mate []int
+	// label[b] is the label of a top-level blossom or vertex b:
+	// 0 for unlabeled, 1 for S and 2 for T.
+	label []int
+	// labelend[b] is the remote endpoint of the edge through
+	// which b obtained its label, or -1.
+	labelend []int
+	// inblossom[v] is the top-level blossom containing vertex v.
+	inblossom []int
+	// blossomparent[b] is the immediate parent blossom of b, or -1.
+	blossomparent []int
+	// blossomchilds[b] lists the sub-blossoms of b, starting with its base,
+	// in the order of the cycle.
+	blossomchilds [][]int
+	// blossombase[b] is the base vertex of b, or -1 if b is unused.
+	blossombase []int
+	// blossomendps[b][i] is the endpoint of the edge connecting
+	// blossomchilds[b][i] to blossomchilds[b][i+1].
+	blossomendps [][]int
+	// bestedge[b] is the least-slack edge to a different S-blossom,
+	// or -1.
+	bestedge []int
+	// blossombestedges[b] lists the least-slack edges
+	// to neighboring S-blossoms of a non-trivial S-blossom b, or nil.
+	blossombestedges [][]int
+	unusedblossoms   []int
+	// dualvar[v] is twice the dual variable of vertex v, and
+	// dualvar[b] is the dual variable of blossom b, also doubled.
+	dualvar   []int64
+	allowedge []bool
+	queue     []int
+}
+
+func newWeightMatcher(n int, edges []Edge) *weightMatcher {
+	var maxweight int64
+	for _, e := range edges {
+		maxweight = max(maxweight, e.Cost)
+	}
+	m := &weightMatcher{
+		n:                n,
+		edges:            edges,
+		endpoint:         make([]int, 2*len(edges)),
+		neighbend:        make([][]int, n),
+		mate:             make([]int, n),
+		label:            make([]int, 2*n),
+		labelend:         make([]int, 2*n),
+		inblossom:        make([]int, n),
+		blossomparent:    make([]int, 2*n),
+		blossomchilds:    make([][]int, 2*n),
+		blossombase:      make([]int, 2*n),
+		blossomendps:     make([][]int, 2*n),
+		bestedge:         make([]int, 2*n),
+		blossombestedges: make([][]int, 2*n),
+		dualvar:          make([]int64, 2*n),
+		allowedge:        make([]bool, len(edges)),
+	}
+	for k, e := range edges {
+		m.endpoint[2*k], m.endpoint[2*k+1] = e.V, e.W
+		m.neighbend[e.V] = append(m.neighbend[e.V], 2*k+1)
+		m.neighbend[e.W] = append(m.neighbend[e.W], 2*k)
+	}
+	for v := 0; v < n; v++ {
+		m.mate[v] = -1
+		m.inblossom[v] = v
+		m.blossombase[v] = v
+		m.blossombase[n+v] = -1
+		m.dualvar[v] = maxweight
+		m.unusedblossoms = append(m.unusedblossoms, n+v)
+	}
+	for b := range m.labelend {
+		m.labelend[b] = -1
+		m.blossomparent[b] = -1
+		m.bestedge[b] = -1
+	}
+	return m
+}
+
+// slack returns twice the slack of edge k.
+func (m *weightMatcher) slack(k int) int64 {
+	e := m.edges[k]
+	return m.dualvar[e.V] + m.dualvar[e.W] - 2*e.Cost
+}
+
+// leaves calls do for each vertex in blossom b.
+func (m *weightMatcher) leaves(b int, do func(v int)) {
+	if b < m.n {
+		do(b)
+		return
+	}
+	for _, t := range m.blossomchilds[b] {
+		m.leaves(t, do)
+	}
+}
+
+// assignLabel labels the top-level blossom containing w with label t,
+// coming through endpoint p.
+func (m *weightMatcher) assignLabel(w, t, p int) {
+	for {
+		b := m.inblossom[w]
+		m.label[w], m.label[b] = t, t
+		m.labelend[w], m.labelend[b] = p, p
+		m.bestedge[w], m.bestedge[b] = -1, -1
+		if t == 1 {
+			m.leaves(b, func(v int) {
+				m.queue = append(m.queue, v)
+			})
+			return
+		}
+		// Label the mate of the base of a T-blossom with S.
+		base := m.blossombase[b]
+		w, t, p = m.endpoint[m.mate[base]], 1, m.mate[base]^1
+	}
+}
+
+// scanBlossom traces back from v and w to find a new blossom, or an
+// augmenting path. It returns the base vertex of the blossom, or -1.
+func (m *weightMatcher) scanBlossom(v, w int) (base int) {
+	var path []int
+	base = -1
+	for v != -1 || w != -1 {
+		b := m.inblossom[v]
+		if m.label[b]&4 != 0 {
+			base = m.blossombase[b]
+			break
+		}
+		path = append(path, b)
+		m.label[b] = 5
+		if m.labelend[b] == -1 {
+			v = -1
+		} else {
+			v = m.endpoint[m.labelend[b]]
+			b = m.inblossom[v]
+			v = m.endpoint[m.labelend[b]]
+		}
+		if w != -1 {
+			v, w = w, v
+		}
+	}
+	for _, b := range path {
+		m.label[b] = 1
+	}
+	return
+}
+
+// addBlossom constructs a new blossom with the given base, through
+// the edge k that connects two S-vertices.
+func (m *weightMatcher) addBlossom(base, k int) {
+	n := m.n
+	v, w := m.edges[k].V, m.edges[k].W
+	bb, bv, bw := m.inblossom[base], m.inblossom[v], m.inblossom[w]
+	b := m.unusedblossoms[len(m.unusedblossoms)-1]
+	m.unusedblossoms = m.unusedblossoms[:len(m.unusedblossoms)-1]
+	m.blossombase[b] = base
+	m.blossomparent[b] = -1
+	m.blossomparent[bb] = b
+
+	// Trace back from v to the base, and then from w to the base.
+	var path, endps []int
+	for bv != bb {
+		m.blossomparent[bv] = b
+		path = append(path, bv)
+		endps = append(endps, m.labelend[bv])
+		v = m.endpoint[m.labelend[bv]]
+		bv = m.inblossom[v]
+	}
+	path = append(path, bb)
+	reverse(path)
+	reverse(endps)
+	endps = append(endps, 2*k)
+	for bw != bb {
+		m.blossomparent[bw] = b
+		path = append(path, bw)
+		endps = append(endps, m.labelend[bw]^1)
+		w = m.endpoint[m.labelend[bw]]
+		bw = m.inblossom[w]
+	}
+	m.blossomchilds[b], m.blossomendps[b] = path, endps
+
+	m.label[b] = 1
+	m.labelend[b] = m.labelend[bb]
+	m.dualvar[b] = 0
+	m.leaves(b, func(v int) {
+		if m.label[m.inblossom[v]] == 2 {
+			// A former T-vertex becomes an S-vertex.
+			m.queue = append(m.queue, v)
+		}
+		m.inblossom[v] = b
+	})
+
+	// Compute the least-slack edges to neighboring S-blossoms.
+	bestedgeto := make([]int, 2*n)
+	for i := range bestedgeto {
+		bestedgeto[i] = -1
+	}
+	consider := func(k int) {
+		j := m.edges[k].W
+		if m.inblossom[j] == b {
+			j = m.edges[k].V
+		}
+		bj := m.inblossom[j]
+		if bj != b && m.label[bj] == 1 &&
+			(bestedgeto[bj] == -1 || m.slack(k) < m.slack(bestedgeto[bj])) {
+			bestedgeto[bj] = k
+		}
+	}
+	for _, bv := range path {
+		if m.blossombestedges[bv] == nil {
+			m.leaves(bv, func(v int) {
+				for _, p := range m.neighbend[v] {
+					consider(p / 2)
+				}
+			})
+		} else {
+			for _, k := range m.blossombestedges[bv] {
+				consider(k)
+			}
+		}
+		m.blossombestedges[bv] = nil
+		m.bestedge[bv] = -1
+	}
+	best := []int{}
+	m.bestedge[b] = -1
+	for _, k := range bestedgeto {
+		if k == -1 {
+			continue
+		}
+		best = append(best, k)
+		if m.bestedge[b] == -1 || m.slack(k) < m.slack(m.bestedge[b]) {
+			m.bestedge[b] = k
+		}
+	}
+	m.blossombestedges[b] = best
+}
+
+// expandBlossom expands the top-level blossom b. If endstage is true,
+// it also expands sub-blossoms with zero dual.
+func (m *weightMatcher) expandBlossom(b int, endstage bool) {
+	n := m.n
+	for _, s := range m.blossomchilds[b] {
+		m.blossomparent[s] = -1
+		switch {
+		case s < n:
+			m.inblossom[s] = s
+		case endstage && m.dualvar[s] == 0:
+			m.expandBlossom(s, endstage)
+		default:
+			m.leaves(s, func(v int) {
+				m.inblossom[v] = s
+			})
+		}
+	}
+
+	if !endstage && m.label[b] == 2 {
+		// Relabel the sub-blossoms on the even-length path from the entry
+		// child to the base, which keeps the alternating tree intact.
+		childs, endps := m.blossomchilds[b], m.blossomendps[b]
+		entrychild := m.inblossom[m.endpoint[m.labelend[b]^1]]
+		j := index(childs, entrychild)
+		jstep, endptrick := -1, 1
+		if j&1 != 0 {
+			j -= len(childs)
+			jstep, endptrick = 1, 0
+		}
+		p := m.labelend[b]
+		for j != 0 {
+			m.label[m.endpoint[p^1]] = 0
+			q := endps[mod(j-endptrick, len(endps))]
+			m.label[m.endpoint[q^endptrick^1]] = 0
+			m.assignLabel(m.endpoint[p^1], 2, p)
+			m.allowedge[q/2] = true
+			j += jstep
+			p = endps[mod(j-endptrick, len(endps))] ^ endptrick
+			m.allowedge[p/2] = true
+			j += jstep
+		}
+		bv := childs[mod(j, len(childs))]
+		m.label[m.endpoint[p^1]], m.label[bv] = 2, 2
+		m.labelend[m.endpoint[p^1]], m.labelend[bv] = p, p
+		m.bestedge[bv] = -1
+		j += jstep
+		for childs[mod(j, len(childs))] != entrychild {
+			bv := childs[mod(j, len(childs))]
+			if m.label[bv] == 1 {
+				// Already labeled through another edge.
+				j += jstep
+				continue
+			}
+			// Label the sub-blossom T if one of its vertices is reached.
+			reached := -1
+			m.leaves(bv, func(v int) {
+				if reached == -1 && m.label[v] != 0 {
+					reached = v
+				}
+			})
+			if reached != -1 {
+				m.label[reached] = 0
+				m.label[m.endpoint[m.mate[m.blossombase[bv]]]] = 0
+				m.assignLabel(reached, 2, m.labelend[reached])
+			}
+			j += jstep
+		}
+	}
+
+	m.label[b], m.labelend[b] = -1, -1
+	m.blossomchilds[b], m.blossomendps[b] = nil, nil
+	m.blossombase[b] = -1
+	m.blossombestedges[b] = nil
+	m.bestedge[b] = -1
+	m.unusedblossoms = append(m.unusedblossoms, b)
+}
+
+// augmentBlossom swaps matched and unmatched edges inside blossom b
+// along the path from vertex v to the base, making v the new base.
+func (m *weightMatcher) augmentBlossom(b, v int) {
+	n := m.n
+	t := v
+	for m.blossomparent[t] != b {
+		t = m.blossomparent[t]
+	}
+	if t >= n {
+		m.augmentBlossom(t, v)
+	}
+	childs, endps := m.blossomchilds[b], m.blossomendps[b]
+	i := index(childs, t)
+	j := i
+	jstep, endptrick := -1, 1
+	if i&1 != 0 {
+		j -= len(childs)
+		jstep, endptrick = 1, 0
+	}
+	for j != 0 {
+		j += jstep
+		t = childs[mod(j, len(childs))]
+		p := endps[mod(j-endptrick, len(endps))] ^ endptrick
+		if t >= n {
+			m.augmentBlossom(t, m.endpoint[p])
+		}
+		j += jstep
+		t = childs[mod(j, len(childs))]
+		if t >= n {
+			m.augmentBlossom(t, m.endpoint[p^1])
+		}
+		m.mate[m.endpoint[p]] = p ^ 1
+		m.mate[m.endpoint[p^1]] = p
+	}
+	// Rotate the lists so that the new base comes first.
+	m.blossomchilds[b] = append(append([]int{}, childs[i:]...), childs[:i]...)
+	m.blossomendps[b] = append(append([]int{}, endps[i:]...), endps[:i]...)
+	m.blossombase[b] = m.blossombase[m.blossomchilds[b][0]]
+}
+
+// augmentMatching augments the matching along the path through edge k,
+// which connects two S-vertices in different trees.
+func (m *weightMatcher) augmentMatching(k int) {
+	n := m.n
+	e := m.edges[k]
+	for _, sp := range [2][2]int{{e.V, 2*k + 1}, {e.W, 2 * k}} {
+		s, p := sp[0], sp[1]
+		for {
+			bs := m.inblossom[s]
+			if bs >= n {
+				m.augmentBlossom(bs, s)
+			}
+			m.mate[s] = p
+			if m.labelend[bs] == -1 {
+				break // Reached a single vertex.
+			}
+			t := m.endpoint[m.labelend[bs]]
+			bt := m.inblossom[t]
+			s = m.endpoint[m.labelend[bt]]
+			j := m.endpoint[m.labelend[bt]^1]
+			if bt >= n {
+				m.augmentBlossom(bt, j)
+			}
+			m.mate[j] = m.labelend[bt]
+			p = m.labelend[bt] ^ 1
+		}
+	}
+}
+
+func (m *weightMatcher) run() {
+	n := m.n
+	for stage := 0; stage < n; stage++ {
+		for b := range m.label {
+			m.label[b], m.bestedge[b] = 0, -1
+		}
+		for b := n; b < 2*n; b++ {
+			m.blossombestedges[b] = nil
+		}
+		for k := range m.allowedge {
+			m.allowedge[k] = false
+		}
+		m.queue = m.queue[:0]
+		for v := 0; v < n; v++ {
+			if m.mate[v] == -1 && m.label[m.inblossom[v]] == 0 {
+				m.assignLabel(v, 1, -1)
+			}
+		}
+
+		augmented := false
+		for {
+			for len(m.queue) > 0 && !augmented {
+				v := m.queue[len(m.queue)-1]
+				m.queue = m.queue[:len(m.queue)-1]
+				for _, p := range m.neighbend[v] {
+					k := p / 2
+					w := m.endpoint[p]
+					if m.inblossom[v] == m.inblossom[w] {
+						continue
+					}
+					var kslack int64
+					if !m.allowedge[k] {
+						kslack = m.slack(k)
+						if kslack <= 0 {
+							m.allowedge[k] = true
+						}
+					}
+					switch {
+					case m.allowedge[k] && m.label[m.inblossom[w]] == 0:
+						m.assignLabel(w, 2, p^1)
+					case m.allowedge[k] && m.label[m.inblossom[w]] == 1:
+						if base := m.scanBlossom(v, w); base >= 0 {
+							m.addBlossom(base, k)
+						} else {
+							m.augmentMatching(k)
+							augmented = true
+						}
+					case m.allowedge[k] && m.label[w] == 0:
+						// w is inside a T-blossom but hasn't been reached yet.
+						m.label[w] = 2
+						m.labelend[w] = p ^ 1
+					case m.allowedge[k]:
+					case m.label[m.inblossom[w]] == 1:
+						b := m.inblossom[v]
+						if m.bestedge[b] == -1 || kslack < m.slack(m.bestedge[b]) {
+							m.bestedge[b] = k
+						}
+					case m.label[w] == 0:
+						if m.bestedge[w] == -1 || kslack < m.slack(m.bestedge[w]) {
+							m.bestedge[w] = k
+						}
+					}
+					if augmented {
+						break
+					}
+				}
+			}
+			if augmented {
+				break
+			}
+
+			// Compute the dual update: the largest change that keeps
+			// all slacks non-negative and all blossom duals non-negative.
+			deltatype := 1
+			delta := m.dualvar[0]
+			for v := 1; v < n; v++ {
+				if m.dualvar[v] < delta {
+					delta = m.dualvar[v]
+				}
+			}
+			deltaedge, deltablossom := -1, -1
+			for v := 0; v < n; v++ {
+				if m.label[m.inblossom[v]] == 0 && m.bestedge[v] != -1 {
+					if d := m.slack(m.bestedge[v]); d < delta {
+						delta, deltatype, deltaedge = d, 2, m.bestedge[v]
+					}
+				}
+			}
+			for b := 0; b < 2*n; b++ {
+				if m.blossomparent[b] == -1 && m.label[b] == 1 && m.bestedge[b] != -1 {
+					if d := m.slack(m.bestedge[b]) / 2; d < delta {
+						delta, deltatype, deltaedge = d, 3, m.bestedge[b]
+					}
+				}
+			}
+			for b := n; b < 2*n; b++ {
+				if m.blossombase[b] >= 0 && m.blossomparent[b] == -1 &&
+					m.label[b] == 2 && m.dualvar[b] < delta {
+					delta, deltatype, deltablossom = m.dualvar[b], 4, b
+				}
+			}
+
+			for v := 0; v < n; v++ {
+				switch m.label[m.inblossom[v]] {
+				case 1:
+					m.dualvar[v] -= delta
+				case 2:
+					m.dualvar[v] += delta
+				}
+			}
+			for b := n; b < 2*n; b++ {
+				if m.blossombase[b] >= 0 && m.blossomparent[b] == -1 {
+					switch m.label[b] {
+					case 1:
+						m.dualvar[b] += delta
+					case 2:
+						m.dualvar[b] -= delta
+					}
+				}
+			}
+
+			switch deltatype {
+			case 2:
+				m.allowedge[deltaedge] = true
+				i, j := m.edges[deltaedge].V, m.edges[deltaedge].W
+				if m.label[m.inblossom[i]] == 0 {
+					i = j
+				}
+				m.queue = append(m.queue, i)
+			case 3:
+				m.allowedge[deltaedge] = true
+				m.queue = append(m.queue, m.edges[deltaedge].V)
+			case 4:
+				m.expandBlossom(deltablossom, false)
+			}
+			if deltatype == 1 {
+				break // No further improvement is possible.
+			}
+		}
+		if !augmented {
+			break
+		}
+		for b := n; b < 2*n; b++ {
+			if m.blossomparent[b] == -1 && m.blossombase[b] >= 0 &&
+				m.label[b] == 1 && m.dualvar[b] == 0 {
+				m.expandBlossom(b, true)
+			}
+		}
+	}
+}
+
+func reverse(a []int) {
+	for i, j := 0, len(a)-1; i < j; i, j = i+1, j-1 {
+		a[i], a[j] = a[j], a[i]
+	}
+}
+
+func index(a []int, x int) int {
+	for i, y := range a {
+		if y == x {
+			return i
+		}
+	}
+	return -1
+}
+
+// mod returns i modulo n in the range 0..n-1, which lets
+// negative indices count from the end of a list.
+func mod(i, n int) int {
+	return (i%n + n) % n
+}
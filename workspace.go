@@ -0,0 +1,107 @@
+package graph
+
+import "strconv"
+
+// Workspace holds scratch storage, such as priority queues, that can be
+// reused by many calls to the ...Into functions, so that a loop running
+// millions of queries on graphs of the same size doesn't allocate.
+// The zero value is an empty workspace ready to use; it grows as needed.
+// A Workspace must not be used by several goroutines at the same time.
+type Workspace struct {
+	q     *prioQueue
+	queue []int
+}
+
+// NewWorkspace returns an empty workspace.
+func NewWorkspace() *Workspace {
+	return new(Workspace)
+}
+
+// prioQueue returns an empty priority queue with the given costs.
+func (ws *Workspace) prioQueue(cost []int64) *prioQueue {
+	if ws.q == nil || len(ws.q.index) < len(cost) {
+		ws.q = emptyPrioQueue(cost)
+	}
+	ws.q.heap = ws.q.heap[:0]
+	ws.q.cost = cost
+	return ws.q
+}
+
+// intQueue returns an empty slice with capacity at least n.
+func (ws *Workspace) intQueue(n int) []int {
+	if cap(ws.queue) < n {
+		ws.queue = make([]int, 0, n)
+	}
+	return ws.queue[:0]
+}
+
+// ShortestPathsInto is like ShortestPaths, but writes the results into
+// parent and dist, which must have length g.Order(). It uses the scratch
+// storage in ws, which may be nil, and only allocates a constant amount of
+// memory if ws has been used before with a graph of the same size.
+//
+// The time complexity is O((|E| + |V|)⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func ShortestPathsInto(g Iterator, v int, parent []int, dist []int64, ws *Workspace) {
+	shortestPathsInto(g, v, nil, Max, parent, dist, ws)
+}
+
+// ShortestPathsWithinInto is like ShortestPathsWithin, but writes the results
+// into parent and dist, which must have length g.Order(). It uses the scratch
+// storage in ws, which may be nil.
+//
+// The time complexity is O(|V| + (|E'| + |V'|)⋅log|V'|), where |V| is the
+// number of vertices in the graph, |V'| the number of vertices within
+// distance maxDist and |E'| the number of edges leaving them.
+func ShortestPathsWithinInto(g Iterator, v int, maxDist int64, parent []int, dist []int64, ws *Workspace) {
+	shortestPathsInto(g, v, nil, maxDist, parent, dist, ws)
+}
+
+func shortestPathsInto(g Iterator, v int, keep func(v, w int, c int64) bool, maxDist int64, parent []int, dist []int64, ws *Workspace) {
+	n := g.Order()
+	checkLen(n, len(parent), len(dist))
+	if ws == nil {
+		ws = new(Workspace)
+	}
+	dijkstra(g, v, keep, maxDist, parent, dist, ws.prioQueue(dist))
+}
+
+// BFSTreeInto is like BFSTree, but writes the results into parent and dist,
+// which must have length g.Order(). It uses the scratch storage in ws,
+// which may be nil.
+//
+// The time complexity is O(|E| + |V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func BFSTreeInto(g Iterator, v int, parent []int, dist []int, ws *Workspace) {
+	n := g.Order()
+	checkLen(n, len(parent), len(dist))
+	if ws == nil {
+		ws = new(Workspace)
+	}
+	for i := range dist {
+		parent[i], dist[i] = -1, -1
+	}
+	dist[v] = 0
+	queue := append(ws.intQueue(n), v)
+	visit := func(w int, _ int64) (skip bool) {
+		if dist[w] == -1 {
+			parent[w], dist[w] = v, dist[v]+1
+			queue = append(queue, w)
+		}
+		return
+	}
+	for i := 0; i < len(queue); i++ {
+		v = queue[i]
+		g.Visit(v, visit)
+	}
+	ws.queue = queue
+}
+
+// checkLen panics unless the result slices have length n.
+func checkLen(n int, lens ...int) {
+	for _, l := range lens {
+		if l != n {
+			panic("slice length " + strconv.Itoa(l) + " doesn't match graph order " + strconv.Itoa(n))
+		}
+	}
+}
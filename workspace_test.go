@@ -0,0 +1,91 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestWorkspace(t *testing.T) {
+	ws := NewWorkspace()
+	for n := 1; n < 40; n++ {
+		g := New(n)
+		for m := rand.Intn(4*n + 1); m > 0; m-- {
+			g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(10)-1)
+		}
+		h := Sort(g)
+		parent, dist := make([]int, n), make([]int64, n)
+		bfsParent, bfsDist := make([]int, n), make([]int, n)
+		for i := 0; i < 3; i++ {
+			v := rand.Intn(n)
+			ShortestPathsInto(h, v, parent, dist, ws)
+			expParent, expDist := ShortestPaths(h, v)
+			if mess, diff := diff(parent, expParent); diff {
+				t.Errorf("ShortestPathsInto->parent %s", mess)
+			}
+			if mess, diff := diff(dist, expDist); diff {
+				t.Errorf("ShortestPathsInto->dist %s", mess)
+			}
+
+			ShortestPathsWithinInto(h, v, 5, parent, dist, ws)
+			expParent, expDist = ShortestPathsWithin(h, v, 5)
+			if mess, diff := diff(parent, expParent); diff {
+				t.Errorf("ShortestPathsWithinInto->parent %s", mess)
+			}
+			if mess, diff := diff(dist, expDist); diff {
+				t.Errorf("ShortestPathsWithinInto->dist %s", mess)
+			}
+
+			BFSTreeInto(h, v, bfsParent, bfsDist, ws)
+			expBFSParent, expBFSDist := BFSTree(h, v)
+			if mess, diff := diff(bfsParent, expBFSParent); diff {
+				t.Errorf("BFSTreeInto->parent %s", mess)
+			}
+			if mess, diff := diff(bfsDist, expBFSDist); diff {
+				t.Errorf("BFSTreeInto->dist %s", mess)
+			}
+		}
+	}
+	ShortestPathsInto(New(1), 0, []int{0}, []int64{0}, nil)
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("ShortestPathsInto with short slices should panic")
+		}
+	}()
+	ShortestPathsInto(New(2), 0, []int{0}, []int64{0}, ws)
+}
+
+func TestWorkspaceAllocs(t *testing.T) {
+	n := 1000
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(100))
+	}
+	h := Sort(g)
+	ws := new(Workspace)
+	parent, dist := make([]int, n), make([]int64, n)
+	bfsParent, bfsDist := make([]int, n), make([]int, n)
+	allocs := testing.AllocsPerRun(10, func() {
+		ShortestPathsInto(h, rand.Intn(n), parent, dist, ws)
+		BFSTreeInto(h, rand.Intn(n), bfsParent, bfsDist, ws)
+	})
+	if allocs > 10 {
+		t.Errorf("ShortestPathsInto and BFSTreeInto: %v allocs per run", allocs)
+	}
+}
+
+func BenchmarkShortestPathsInto(b *testing.B) {
+	b.StopTimer()
+	n := 1000
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), rand.Int63n(100))
+	}
+	h := Sort(g)
+	ws := new(Workspace)
+	parent, dist := make([]int, n), make([]int64, n)
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		ShortestPathsInto(h, i%n, parent, dist, ws)
+	}
+}
@@ -0,0 +1,147 @@
+package graph
+
+// KShortestPaths computes the k shortest loopless paths from v to w,
+// in order of increasing length. Only edges with non-negative costs are included.
+// The number dist[i] is the length of paths[i].
+// If there are fewer than k such paths, all of them are returned.
+//
+// The implementation uses Yen's algorithm, which makes O(k⋅|V|) calls
+// to a shortest path algorithm. The time complexity is
+// O(k⋅|V|⋅(|E| + |V|)⋅log|V|), where |E| is the number of edges
+// and |V| the number of vertices in the graph.
+func KShortestPaths(g Iterator, v, w, k int) (paths [][]int, dist []int64) {
+	paths, dist = [][]int{}, []int64{}
+	if k <= 0 {
+		return
+	}
+	path, d := ShortestPath(g, v, w)
+	if d == -1 {
+		return
+	}
+	paths, dist = append(paths, path), append(dist, d)
+
+	n := g.Order()
+	h := &yenGraph{
+		g:           g,
+		removedV:    make([]bool, n),
+		removedFrom: -1,
+		removedTo:   make(map[int]bool),
+	}
+	// Candidate paths, not necessarily in order.
+	var candidates [][]int
+	var candidateDist []int64
+	for len(paths) < k {
+		prev := paths[len(paths)-1]
+		rootDist := int64(0)
+		for i := 0; i < len(prev)-1; i++ {
+			spur, root := prev[i], prev[:i+1]
+
+			// Remove the edges leaving the spur vertex that are used by
+			// already found paths with the same root.
+			h.removedFrom = spur
+			for x := range h.removedTo {
+				delete(h.removedTo, x)
+			}
+			for _, p := range paths {
+				if len(p) > i+1 && equalPaths(p[:i+1], root) {
+					h.removedTo[p[i+1]] = true
+				}
+			}
+			// Remove the root vertices, except the spur vertex.
+			for _, x := range root[:i] {
+				h.removedV[x] = true
+			}
+
+			if spurPath, spurDist := ShortestPath(h, spur, w); spurDist != -1 {
+				p := make([]int, 0, i+len(spurPath))
+				p = append(append(p, root[:i]...), spurPath...)
+				if !containsPath(candidates, p) {
+					candidates = append(candidates, p)
+					candidateDist = append(candidateDist, rootDist+spurDist)
+				}
+			}
+
+			for _, x := range root[:i] {
+				h.removedV[x] = false
+			}
+			rootDist += edgeCost(g, spur, prev[i+1])
+		}
+		h.removedFrom = -1
+
+		if len(candidates) == 0 {
+			break
+		}
+		// Pick the shortest candidate, preferring paths with fewer edges.
+		best := 0
+		for j, d := range candidateDist {
+			if d < candidateDist[best] ||
+				d == candidateDist[best] && len(candidates[j]) < len(candidates[best]) {
+				best = j
+			}
+		}
+		paths = append(paths, candidates[best])
+		dist = append(dist, candidateDist[best])
+		last := len(candidates) - 1
+		candidates[best], candidateDist[best] = candidates[last], candidateDist[last]
+		candidates, candidateDist = candidates[:last], candidateDist[:last]
+	}
+	return
+}
+
+// yenGraph hides a set of vertices and a set of edges leaving
+// a single vertex from a graph.
+type yenGraph struct {
+	g           Iterator
+	removedV    []bool
+	removedFrom int
+	removedTo   map[int]bool
+}
+
+func (h *yenGraph) Order() int {
+	return h.g.Order()
+}
+
+func (h *yenGraph) Visit(v int, do func(w int, c int64) bool) bool {
+	if h.removedV[v] {
+		return false
+	}
+	return h.g.Visit(v, func(w int, c int64) bool {
+		if h.removedV[w] || v == h.removedFrom && h.removedTo[w] {
+			return false
+		}
+		return do(w, c)
+	})
+}
+
+// edgeCost returns the smallest non-negative cost of an edge from v to w.
+func edgeCost(g Iterator, v, w int) int64 {
+	cost := int64(-1)
+	g.Visit(v, func(x int, c int64) (skip bool) {
+		if x == w && c >= 0 && (cost == -1 || c < cost) {
+			cost = c
+		}
+		return
+	})
+	return cost
+}
+
+func equalPaths(p, q []int) bool {
+	if len(p) != len(q) {
+		return false
+	}
+	for i := range p {
+		if p[i] != q[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsPath(paths [][]int, p []int) bool {
+	for _, q := range paths {
+		if equalPaths(p, q) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestKShortestPaths(t *testing.T) {
+	g := New(6)
+	g.AddCost(0, 1, 3)
+	g.AddCost(0, 2, 2)
+	g.AddCost(1, 3, 4)
+	g.AddCost(2, 1, 1)
+	g.AddCost(2, 3, 2)
+	g.AddCost(2, 4, 3)
+	g.AddCost(3, 4, 2)
+	g.AddCost(3, 5, 1)
+	g.AddCost(4, 5, 2)
+
+	paths, dist := KShortestPaths(g, 0, 5, 3)
+	expPaths := [][]int{{0, 2, 3, 5}, {0, 2, 4, 5}, {0, 1, 3, 5}}
+	if mess, diff := diff(paths, expPaths); diff {
+		t.Errorf("KShortestPaths->paths %s", mess)
+	}
+	if mess, diff := diff(dist, []int64{5, 7, 8}); diff {
+		t.Errorf("KShortestPaths->dist %s", mess)
+	}
+
+	_, dist = KShortestPaths(g, 0, 5, 100)
+	if mess, diff := diff(dist, []int64{5, 7, 8, 8, 8, 11, 11}); diff {
+		t.Errorf("KShortestPaths->dist %s", mess)
+	}
+
+	paths, dist = KShortestPaths(g, 0, 0, 2)
+	if mess, diff := diff(paths, [][]int{{0}}); diff {
+		t.Errorf("KShortestPaths->paths %s", mess)
+	}
+	if mess, diff := diff(dist, []int64{0}); diff {
+		t.Errorf("KShortestPaths->dist %s", mess)
+	}
+
+	paths, dist = KShortestPaths(g, 5, 0, 2)
+	if mess, diff := diff(paths, [][]int{}); diff {
+		t.Errorf("KShortestPaths->paths %s", mess)
+	}
+	if mess, diff := diff(dist, []int64{}); diff {
+		t.Errorf("KShortestPaths->dist %s", mess)
+	}
+
+	paths, _ = KShortestPaths(g, 0, 5, 0)
+	if mess, diff := diff(paths, [][]int{}); diff {
+		t.Errorf("KShortestPaths->paths %s", mess)
+	}
+}
+
+// Compare with the lengths of all simple paths, found by brute force.
+func TestKShortestPathsRandom(t *testing.T) {
+	n := 8
+	g := New(n)
+	for i := 0; i < 3*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), int64(rand.Intn(10)))
+	}
+	var exp []int64
+	onPath := make([]bool, n)
+	var walk func(v int, d int64)
+	walk = func(v int, d int64) {
+		if v == n-1 {
+			exp = append(exp, d)
+			return
+		}
+		onPath[v] = true
+		g.Visit(v, func(w int, c int64) (skip bool) {
+			if !onPath[w] {
+				walk(w, d+c)
+			}
+			return
+		})
+		onPath[v] = false
+	}
+	walk(0, 0)
+	sort.Slice(exp, func(i, j int) bool { return exp[i] < exp[j] })
+
+	paths, dist := KShortestPaths(g, 0, n-1, len(exp)+1)
+	if len(exp) == 0 {
+		exp = []int64{}
+	}
+	if mess, diff := diff(dist, exp); diff {
+		t.Errorf("KShortestPaths->dist %s", mess)
+	}
+	for i, p := range paths {
+		length := int64(0)
+		for j := 1; j < len(p); j++ {
+			length += g.Cost(p[j-1], p[j])
+		}
+		if length != dist[i] {
+			t.Errorf("KShortestPaths: length of %v is %d; want %d", p, length, dist[i])
+		}
+	}
+}
+
+func BenchmarkKShortestPaths(b *testing.B) {
+	n := 1000
+	b.StopTimer()
+	g := New(n)
+	for i := 0; i < 4*n; i++ {
+		g.AddCost(rand.Intn(n), rand.Intn(n), int64(rand.Intn(10)))
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = KShortestPaths(g, 0, 1, 5)
+	}
+}